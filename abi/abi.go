@@ -17,6 +17,13 @@ func MakeTupleType(argumentTypes []Type) (Type, error) {
 	return avm_abi.MakeTupleType(argumentTypes)
 }
 
+// VerifyMethodSignature checks that a method signature string, such as
+// "add(uint64,uint64)uint128", is well-formed and that every argument and
+// return type in it can be parsed.
+func VerifyMethodSignature(methodSig string) error {
+	return avm_abi.VerifyMethodSignature(methodSig)
+}
+
 // AnyTransactionType is the ABI argument type string for a nonspecific transaction argument
 const AnyTransactionType = avm_abi.AnyTransactionType
 
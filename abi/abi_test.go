@@ -21,3 +21,46 @@ func TestImportWorks(t *testing.T) {
 
 	require.Equal(t, expected, actual)
 }
+
+func TestTupleRoundTrip(t *testing.T) {
+	abiType, err := TypeOf("(uint64,byte[4],bool[],string,ufixed64x2,address)")
+	require.NoError(t, err)
+	require.Equal(t, "(uint64,byte[4],bool[],string,ufixed64x2,address)", abiType.String())
+	require.True(t, abiType.IsDynamic())
+
+	var addr [32]byte
+	addr[31] = 1
+	value := []interface{}{
+		uint64(7),
+		[]byte{1, 2, 3, 4},
+		[]bool{true, false, true},
+		"hello",
+		uint64(31415),
+		addr[:],
+	}
+
+	encoded, err := abiType.Encode(value)
+	require.NoError(t, err)
+
+	decoded, err := abiType.Decode(encoded)
+	require.NoError(t, err)
+
+	decodedTuple, ok := decoded.([]interface{})
+	require.True(t, ok)
+	require.Len(t, decodedTuple, len(value))
+	require.Equal(t, uint64(7), decodedTuple[0])
+	require.Equal(t, []interface{}{true, false, true}, decodedTuple[2])
+	require.Equal(t, "hello", decodedTuple[3])
+	require.Equal(t, uint64(31415), decodedTuple[4])
+
+	reencoded, err := abiType.Encode(decoded)
+	require.NoError(t, err)
+	require.Equal(t, encoded, reencoded)
+}
+
+func TestVerifyMethodSignature(t *testing.T) {
+	require.NoError(t, VerifyMethodSignature("add(uint64,uint64)uint128"))
+	require.NoError(t, VerifyMethodSignature("swap(pay,axfer,account,asset)void"))
+	require.Error(t, VerifyMethodSignature("add(uint65)void"))
+	require.Error(t, VerifyMethodSignature("add(uint64"))
+}
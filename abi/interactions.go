@@ -1,6 +1,7 @@
 package abi
 
 import (
+	"bytes"
 	"crypto/sha512"
 	"fmt"
 	"strings"
@@ -195,6 +196,17 @@ func GetMethodByName(methods []Method, name string) (Method, error) {
 	return filteredMethods[0], nil
 }
 
+// GetMethodBySelector returns the method from the given list whose 4-byte
+// selector matches the given selector. Returns an error if no method matches.
+func GetMethodBySelector(methods []Method, selector []byte) (Method, error) {
+	for _, method := range methods {
+		if bytes.Equal(method.GetSelector(), selector) {
+			return method, nil
+		}
+	}
+	return Method{}, fmt.Errorf("found 0 methods with the selector %x", selector)
+}
+
 // Interface represents an ABI interface, which is a logically grouped
 // collection of methods
 type Interface struct {
@@ -211,6 +223,11 @@ func (i *Interface) GetMethodByName(name string) (Method, error) {
 	return GetMethodByName(i.Methods, name)
 }
 
+// GetMethodBySelector returns the method with the given 4-byte selector
+func (i *Interface) GetMethodBySelector(selector []byte) (Method, error) {
+	return GetMethodBySelector(i.Methods, selector)
+}
+
 // ContractNetworkInfo contains network-specific information about the contract
 type ContractNetworkInfo struct {
 	// The application ID of the contract for this network
@@ -235,3 +252,8 @@ type Contract struct {
 func (c *Contract) GetMethodByName(name string) (Method, error) {
 	return GetMethodByName(c.Methods, name)
 }
+
+// GetMethodBySelector returns the method with the given 4-byte selector
+func (c *Contract) GetMethodBySelector(selector []byte) (Method, error) {
+	return GetMethodBySelector(c.Methods, selector)
+}
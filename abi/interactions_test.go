@@ -299,3 +299,56 @@ func TestEncodeJsonContractWithDescription(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expected, string(jsonContract))
 }
+
+func TestDecodeJsonContract(t *testing.T) {
+	contractJSON := `{
+		"name": "Calculator",
+		"desc": "A simple calculator",
+		"networks": {
+			"wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8=": {"appID": 1234}
+		},
+		"methods": [
+			{
+				"name": "add",
+				"desc": "Add two numbers",
+				"args": [
+					{"type": "uint64", "name": "a"},
+					{"type": "uint64", "name": "b", "desc": "second operand"}
+				],
+				"returns": {"type": "uint128"}
+			},
+			{
+				"name": "reset",
+				"args": [],
+				"returns": {"type": "void"}
+			}
+		]
+	}`
+
+	var contract Contract
+	err := json.Unmarshal([]byte(contractJSON), &contract)
+	require.NoError(t, err)
+
+	require.Equal(t, "Calculator", contract.Name)
+	require.Equal(t, uint64(1234), contract.Networks["wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8="].AppID)
+	require.Len(t, contract.Methods, 2)
+
+	add, err := contract.GetMethodByName("add")
+	require.NoError(t, err)
+	require.Equal(t, "add(uint64,uint64)uint128", add.GetSignature())
+	require.Equal(t, "second operand", add.Args[1].Desc)
+
+	expected, err := MethodFromSignature("add(uint64,uint64)uint128")
+	require.NoError(t, err)
+
+	bySelector, err := contract.GetMethodBySelector(expected.GetSelector())
+	require.NoError(t, err)
+	require.Equal(t, add.GetSignature(), bySelector.GetSignature())
+
+	_, err = contract.GetMethodBySelector([]byte{0, 0, 0, 0})
+	require.Error(t, err)
+
+	reset, err := contract.GetMethodByName("reset")
+	require.NoError(t, err)
+	require.True(t, reset.Returns.IsVoid())
+}
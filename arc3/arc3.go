@@ -0,0 +1,296 @@
+// Package arc3 builds and validates assets following ARC-3, the Algorand
+// convention for NFTs and other assets described by a JSON metadata file.
+//
+// An ARC-3 asset is named arc3 or has a name ending with @arc3, or else has a
+// URL ending with #arc3. Its URL points to the metadata file, and its metadata
+// hash commits to the file:
+//
+//   - without extra_metadata, it is the SHA-256 of the file
+//   - with extra_metadata, it is
+//     SHA-512/256("arc0003/am" || SHA-512/256("arc0003/amj" || file) || extra)
+//     where extra is the decoded extra_metadata, and it must be set
+//
+// Resources referenced by the metadata, such as the image, may carry an
+// integrity field, "sha256-" followed by the base64 SHA-256 of the resource.
+package arc3
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+const (
+	// AssetName is the name of an ARC-3 asset that has no other name.
+	AssetName = "arc3"
+
+	// AssetNameSuffix ends the name of an ARC-3 asset.
+	AssetNameSuffix = "@arc3"
+
+	// URLSuffix ends the URL of an ARC-3 asset whose name does not tell it.
+	URLSuffix = "#arc3"
+
+	// IDPlaceholder in the URL of an asset is replaced by the asset ID, in
+	// decimal, to find its metadata.
+	IDPlaceholder = "{id}"
+
+	// LocalePlaceholder in the URI of localized metadata is replaced by the
+	// locale.
+	LocalePlaceholder = "{locale}"
+
+	integrityPrefix = "sha256-"
+
+	amPrefix  = "arc0003/am"
+	amjPrefix = "arc0003/amj"
+)
+
+// Metadata is the JSON metadata file of an ARC-3 asset. All fields are
+// optional.
+type Metadata struct {
+	Name     string  `json:"name,omitempty"`
+	Decimals *uint32 `json:"decimals,omitempty"`
+
+	Description string `json:"description,omitempty"`
+
+	Image          string `json:"image,omitempty"`
+	ImageIntegrity string `json:"image_integrity,omitempty"`
+	ImageMimetype  string `json:"image_mimetype,omitempty"`
+
+	// BackgroundColor is six hexadecimal digits, without a leading #.
+	BackgroundColor string `json:"background_color,omitempty"`
+
+	ExternalURL          string `json:"external_url,omitempty"`
+	ExternalURLIntegrity string `json:"external_url_integrity,omitempty"`
+	ExternalURLMimetype  string `json:"external_url_mimetype,omitempty"`
+
+	AnimationURL          string `json:"animation_url,omitempty"`
+	AnimationURLIntegrity string `json:"animation_url_integrity,omitempty"`
+	AnimationURLMimetype  string `json:"animation_url_mimetype,omitempty"`
+
+	// Properties are arbitrary attributes of the asset.
+	Properties map[string]interface{} `json:"properties,omitempty"`
+
+	// ExtraMetadata is base64 data also committed to by the metadata hash.
+	ExtraMetadata string `json:"extra_metadata,omitempty"`
+
+	Localization *Localization `json:"localization,omitempty"`
+}
+
+// Localization points to the metadata of an asset translated in other locales.
+type Localization struct {
+	// URI of the localized metadata, containing LocalePlaceholder.
+	URI     string   `json:"uri"`
+	Default string   `json:"default"`
+	Locales []string `json:"locales"`
+
+	// Integrity of the localized metadata of each locale.
+	Integrity map[string]string `json:"integrity,omitempty"`
+}
+
+// ParseMetadata parses and validates a metadata file.
+func ParseMetadata(data []byte) (Metadata, error) {
+	var m Metadata
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Metadata{}, fmt.Errorf("invalid ARC-3 metadata: %w", err)
+	}
+	if err := m.Validate(); err != nil {
+		return Metadata{}, err
+	}
+	return m, nil
+}
+
+// Validate checks the format of the fields of m: integrity fields, mime types,
+// background color, decimals, extra metadata and localization.
+func (m Metadata) Validate() error {
+	if m.Decimals != nil && *m.Decimals > types.AssetMaxNumberOfDecimals {
+		return fmt.Errorf("decimals %d is more than the maximum %d", *m.Decimals, types.AssetMaxNumberOfDecimals)
+	}
+
+	integrities := []struct{ field, value string }{
+		{"image_integrity", m.ImageIntegrity},
+		{"external_url_integrity", m.ExternalURLIntegrity},
+		{"animation_url_integrity", m.AnimationURLIntegrity},
+	}
+	for _, i := range integrities {
+		if i.value == "" {
+			continue
+		}
+		if _, err := parseIntegrity(i.value); err != nil {
+			return fmt.Errorf("invalid %s: %w", i.field, err)
+		}
+	}
+
+	mimetypes := []struct{ field, value string }{
+		{"image_mimetype", m.ImageMimetype},
+		{"external_url_mimetype", m.ExternalURLMimetype},
+		{"animation_url_mimetype", m.AnimationURLMimetype},
+	}
+	for _, mt := range mimetypes {
+		if mt.value == "" {
+			continue
+		}
+		if err := ValidateMimeType(mt.value); err != nil {
+			return fmt.Errorf("invalid %s: %w", mt.field, err)
+		}
+	}
+
+	if m.BackgroundColor != "" && !isHexColor(m.BackgroundColor) {
+		return fmt.Errorf("invalid background_color %q, expected six hexadecimal digits", m.BackgroundColor)
+	}
+
+	if m.ExtraMetadata != "" {
+		if _, err := base64.StdEncoding.DecodeString(m.ExtraMetadata); err != nil {
+			return fmt.Errorf("invalid extra_metadata: %w", err)
+		}
+	}
+
+	if l := m.Localization; l != nil {
+		if !strings.Contains(l.URI, LocalePlaceholder) {
+			return fmt.Errorf("localization uri %q does not contain %s", l.URI, LocalePlaceholder)
+		}
+		if l.Default == "" || len(l.Locales) == 0 {
+			return fmt.Errorf("localization must have a default locale and a list of locales")
+		}
+		for locale, integrity := range l.Integrity {
+			if _, err := parseIntegrity(integrity); err != nil {
+				return fmt.Errorf("invalid localization integrity for %s: %w", locale, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateMimeType checks that mimetype has the type/subtype form of a mime
+// type, such as image/png, as the mime type fields of asset metadata require.
+func ValidateMimeType(mimetype string) error {
+	if typ, subtype, ok := strings.Cut(mimetype, "/"); !ok || typ == "" || subtype == "" || strings.ContainsAny(mimetype, " \t") {
+		return fmt.Errorf("%q is not a mime type such as image/png", mimetype)
+	}
+	return nil
+}
+
+func isHexColor(s string) bool {
+	if len(s) != 6 {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// Integrity returns the integrity field of content, "sha256-" followed by the
+// base64 SHA-256 of content.
+func Integrity(content []byte) string {
+	sum := sha256.Sum256(content)
+	return integrityPrefix + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// CheckIntegrity checks that content matches the integrity field.
+func CheckIntegrity(integrity string, content []byte) error {
+	expected, err := parseIntegrity(integrity)
+	if err != nil {
+		return err
+	}
+	if sum := sha256.Sum256(content); !bytes.Equal(sum[:], expected) {
+		return fmt.Errorf("content does not match integrity %s", integrity)
+	}
+	return nil
+}
+
+func parseIntegrity(integrity string) ([]byte, error) {
+	encoded, ok := strings.CutPrefix(integrity, integrityPrefix)
+	if !ok {
+		return nil, fmt.Errorf("integrity %q does not start with %s", integrity, integrityPrefix)
+	}
+	sum, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("integrity %q is not base64: %w", integrity, err)
+	}
+	if len(sum) != sha256.Size {
+		return nil, fmt.Errorf("integrity %q is not a SHA-256 digest", integrity)
+	}
+	return sum, nil
+}
+
+// MetadataHash returns the asset metadata hash committing to the metadata
+// file, which must be valid.
+func MetadataHash(metadataJSON []byte) ([types.AssetMetadataHashLen]byte, error) {
+	m, err := ParseMetadata(metadataJSON)
+	if err != nil {
+		return [types.AssetMetadataHashLen]byte{}, err
+	}
+	return metadataHash(m, metadataJSON), nil
+}
+
+func metadataHash(m Metadata, metadataJSON []byte) [types.AssetMetadataHashLen]byte {
+	if m.ExtraMetadata == "" {
+		return sha256.Sum256(metadataJSON)
+	}
+	// checked by Validate
+	extra, _ := base64.StdEncoding.DecodeString(m.ExtraMetadata)
+	amj := sha512.Sum512_256(append([]byte(amjPrefix), metadataJSON...))
+	am := append([]byte(amPrefix), amj[:]...)
+	return sha512.Sum512_256(append(am, extra...))
+}
+
+// IsARC3 reports whether an asset with the given name and URL follows ARC-3.
+func IsARC3(assetName, url string) bool {
+	return assetName == AssetName || strings.HasSuffix(assetName, AssetNameSuffix) || strings.HasSuffix(url, URLSuffix)
+}
+
+// ValidateAssetParams checks that params are those of an ARC-3 asset described
+// by the metadata file: the name or URL tells it follows ARC-3, the metadata
+// is valid, has the decimals of the asset if it gives them, and matches the
+// metadata hash. The hash may only be left zero when the metadata has no
+// extra_metadata.
+func ValidateAssetParams(params types.AssetParams, metadataJSON []byte) error {
+	if !IsARC3(params.AssetName, params.URL) {
+		return errNotARC3
+	}
+	m, err := ParseMetadata(metadataJSON)
+	if err != nil {
+		return err
+	}
+	if m.Decimals != nil && *m.Decimals != params.Decimals {
+		return fmt.Errorf("metadata decimals %d differ from the asset decimals %d", *m.Decimals, params.Decimals)
+	}
+
+	if params.MetadataHash == ([types.AssetMetadataHashLen]byte{}) {
+		if m.ExtraMetadata != "" {
+			return errExtraMetadataNeedsHash
+		}
+		return nil
+	}
+	if metadataHash(m, metadataJSON) != params.MetadataHash {
+		return errMetadataHashMismatch
+	}
+	return nil
+}
+
+// MakeAssetCreateTxn is transaction.MakeAssetCreateTxn for an ARC-3 asset
+// described by metadataJSON, whose metadata hash it sets. The asset name or
+// URL must tell the asset follows ARC-3, see IsARC3.
+func MakeAssetCreateTxn(account string, note []byte, params types.SuggestedParams, total uint64, decimals uint32, defaultFrozen bool, manager, reserve, freeze, clawback string, unitName, assetName, url string, metadataJSON []byte) (types.Transaction, error) {
+	hash, err := MetadataHash(metadataJSON)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	tx, err := transaction.MakeAssetCreateTxn(account, note, params, total, decimals, defaultFrozen, manager, reserve, freeze, clawback, unitName, assetName, url, string(hash[:]))
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	if err := ValidateAssetParams(tx.AssetParams, metadataJSON); err != nil {
+		return types.Transaction{}, err
+	}
+	return tx, nil
+}
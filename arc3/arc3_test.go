@@ -0,0 +1,72 @@
+package arc3
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestMetadataHash(t *testing.T) {
+	metadata := []byte(`{"name":"My NFT","decimals":0,"image":"ipfs://image.png","image_integrity":"` + Integrity([]byte("image")) + `","image_mimetype":"image/png"}`)
+	hash, err := MetadataHash(metadata)
+	require.NoError(t, err)
+	require.Equal(t, sha256.Sum256(metadata), hash)
+
+	withExtra := []byte(`{"name":"My NFT","extra_metadata":"AQID"}`)
+	hash, err = MetadataHash(withExtra)
+	require.NoError(t, err)
+	amj := sha512.Sum512_256(append([]byte("arc0003/amj"), withExtra...))
+	expected := sha512.Sum512_256(append(append([]byte("arc0003/am"), amj[:]...), 1, 2, 3))
+	require.Equal(t, expected, hash)
+}
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, CheckIntegrity(Integrity([]byte("image")), []byte("image")))
+	require.Error(t, CheckIntegrity(Integrity([]byte("image")), []byte("other")))
+
+	invalid := []string{
+		`{"decimals":20}`,
+		`{"image_integrity":"md5-AAAA"}`,
+		`{"image_integrity":"sha256-AAAA"}`,
+		`{"animation_url_mimetype":"video"}`,
+		`{"background_color":"#ffffff"}`,
+		`{"extra_metadata":"not base64!"}`,
+		`{"localization":{"uri":"ipfs://meta.json","default":"en","locales":["en","fr"]}}`,
+		`{"name":1}`,
+	}
+	for _, metadata := range invalid {
+		_, err := ParseMetadata([]byte(metadata))
+		require.Error(t, err, metadata)
+	}
+
+	m, err := ParseMetadata([]byte(`{"background_color":"FFee00","properties":{"rarity":"rare"},"localization":{"uri":"ipfs://{locale}.json","default":"en","locales":["en","fr"]}}`))
+	require.NoError(t, err)
+	require.Equal(t, "rare", m.Properties["rarity"])
+}
+
+func TestMakeAssetCreateTxn(t *testing.T) {
+	account := crypto.GenerateAccount().Address.String()
+	params := types.SuggestedParams{Fee: 1000, FirstRoundValid: 1, LastRoundValid: 100, GenesisHash: make([]byte, 32), FlatFee: true}
+	metadata := []byte(`{"name":"My NFT","decimals":0}`)
+
+	tx, err := MakeAssetCreateTxn(account, nil, params, 1, 0, false, account, "", "", "", "NFT", "My NFT@arc3", "ipfs://meta.json", metadata)
+	require.NoError(t, err)
+	require.Equal(t, [32]byte(sha256.Sum256(metadata)), tx.AssetParams.MetadataHash)
+	require.NoError(t, ValidateAssetParams(tx.AssetParams, metadata))
+
+	_, err = MakeAssetCreateTxn(account, nil, params, 1, 0, false, account, "", "", "", "NFT", "My NFT", "ipfs://meta.json", metadata)
+	require.ErrorIs(t, err, errNotARC3)
+	_, err = MakeAssetCreateTxn(account, nil, params, 100, 2, false, account, "", "", "", "NFT", "My NFT", "ipfs://meta.json#arc3", metadata)
+	require.ErrorContains(t, err, "decimals")
+
+	asset := tx.AssetParams
+	asset.MetadataHash = [32]byte{}
+	require.NoError(t, ValidateAssetParams(asset, metadata))
+	require.ErrorIs(t, ValidateAssetParams(asset, []byte(`{"extra_metadata":"AQID"}`)), errExtraMetadataNeedsHash)
+	require.ErrorIs(t, ValidateAssetParams(tx.AssetParams, []byte(`{"name":"Other"}`)), errMetadataHashMismatch)
+}
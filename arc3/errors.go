@@ -0,0 +1,9 @@
+package arc3
+
+import (
+	"errors"
+)
+
+var errNotARC3 = errors.New("an ARC-3 asset must be named arc3 or end its name with @arc3, or end its URL with #arc3")
+var errExtraMetadataNeedsHash = errors.New("metadata with extra_metadata must be committed to by the asset metadata hash")
+var errMetadataHashMismatch = errors.New("asset metadata hash does not match the metadata")
@@ -0,0 +1,786 @@
+// Package arc56 parses and generates ARC-56 application specifications, the
+// JSON description of an application extending its ARC-4 contract with its
+// structs, state, bare actions, source information, programs, events and
+// template variables.
+//
+// An AppClient calls an application following its specification, and
+// Generate writes the Go source of a typed client wrapping an AppClient, with
+// one function per method of the application.
+package arc56
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/v2/abi"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/events"
+	"github.com/algorand/go-algorand-sdk/v2/logic"
+)
+
+// AVM types of storage and template variables, used where an ABI type or
+// struct name may also be.
+const (
+	AVMBytes  = "AVMBytes"
+	AVMString = "AVMString"
+	AVMUint64 = "AVMUint64"
+)
+
+// Actions of the OnCompletion of application calls.
+const (
+	ActionNoOp              = "NoOp"
+	ActionOptIn             = "OptIn"
+	ActionCloseOut          = "CloseOut"
+	ActionClearState        = "ClearState"
+	ActionUpdateApplication = "UpdateApplication"
+	ActionDeleteApplication = "DeleteApplication"
+)
+
+// Sources of the default values of method arguments.
+const (
+	DefaultValueBox     = "box"
+	DefaultValueGlobal  = "global"
+	DefaultValueLocal   = "local"
+	DefaultValueLiteral = "literal"
+	DefaultValueMethod  = "method"
+)
+
+// Ways the program counters of source information are offset.
+const (
+	// PCOffsetNone means program counters are those of the program.
+	PCOffsetNone = "none"
+	// PCOffsetCBlocks means program counters do not count the intcblock and
+	// bytecblock starting the program, whose constants are template values.
+	PCOffsetCBlocks = "cblocks"
+)
+
+// Contract is an ARC-56 application specification.
+type Contract struct {
+	// ARCs used or supported by the application, ARC-4 and ARC-56 being
+	// implied.
+	Arcs []int  `json:"arcs"`
+	Name string `json:"name"`
+	// Optional, user-friendly description for the application
+	Desc string `json:"desc,omitempty"`
+	// Optional information about the instances of the application across
+	// networks, keyed by the base64 genesis hash or name of the network
+	Networks map[string]abi.ContractNetworkInfo `json:"networks,omitempty"`
+
+	// Structs named by the types of arguments, return values and storage.
+	Structs map[string][]StructField `json:"structs"`
+	Methods []Method                 `json:"methods"`
+	State   State                    `json:"state"`
+
+	// BareActions are the actions of calls without a method selector.
+	BareActions Actions `json:"bareActions"`
+
+	SourceInfo *SourceInfos   `json:"sourceInfo,omitempty"`
+	Source     *Programs      `json:"source,omitempty"`
+	ByteCode   *Programs      `json:"byteCode,omitempty"`
+	Compiler   *CompilerInfo  `json:"compilerInfo,omitempty"`
+	Events     []Event        `json:"events,omitempty"`
+	Templates  TemplateValues `json:"templateVariables,omitempty"`
+	Scratch    ScratchSlots   `json:"scratchVariables,omitempty"`
+}
+
+// StructField is a field of a struct, whose type is an ABI type or the name of
+// a struct, or a nested struct given by Fields.
+type StructField struct {
+	Name   string
+	Type   string
+	Fields []StructField
+}
+
+type structFieldJSON struct {
+	Name string          `json:"name"`
+	Type json.RawMessage `json:"type"`
+}
+
+// MarshalJSON encodes the type of the field as a string, or an array of fields
+// for a nested struct.
+func (f StructField) MarshalJSON() ([]byte, error) {
+	var typ interface{} = f.Type
+	if f.Fields != nil {
+		typ = f.Fields
+	}
+	encoded, err := json.Marshal(typ)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(structFieldJSON{Name: f.Name, Type: encoded})
+}
+
+// UnmarshalJSON decodes a field whose type is a string or an array of fields.
+func (f *StructField) UnmarshalJSON(data []byte) error {
+	var decoded structFieldJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*f = StructField{Name: decoded.Name}
+	if bytes.HasPrefix(bytes.TrimSpace(decoded.Type), []byte("[")) {
+		return json.Unmarshal(decoded.Type, &f.Fields)
+	}
+	return json.Unmarshal(decoded.Type, &f.Type)
+}
+
+// Method is a method of the application.
+type Method struct {
+	Name string `json:"name"`
+	// Optional, user-friendly description for the method
+	Desc    string      `json:"desc,omitempty"`
+	Args    []MethodArg `json:"args"`
+	Returns Return      `json:"returns"`
+
+	// Actions are those the method may be called with.
+	Actions Actions `json:"actions"`
+
+	// Readonly methods do not change the state of the application, so that
+	// they may be simulated instead of called.
+	Readonly bool `json:"readonly,omitempty"`
+
+	// Events the method may emit.
+	Events          []Event          `json:"events,omitempty"`
+	Recommendations *Recommendations `json:"recommendations,omitempty"`
+}
+
+// MethodArg is an argument of a method.
+type MethodArg struct {
+	// The ABI type of the argument, a transaction type or a reference type
+	Type string `json:"type"`
+	// Optional name of the struct of the argument, of type Type
+	Struct string `json:"struct,omitempty"`
+	Name   string `json:"name,omitempty"`
+	// Optional, user-friendly description for the argument
+	Desc         string        `json:"desc,omitempty"`
+	DefaultValue *DefaultValue `json:"defaultValue,omitempty"`
+}
+
+// DefaultValue is the value of an argument the caller may omit.
+type DefaultValue struct {
+	// Data is the base64 encoded value for a literal, the base64 key for
+	// state, or the method signature for a method.
+	Data string `json:"data"`
+	// Type of Data once decoded, the type of the argument if empty
+	Type   string `json:"type,omitempty"`
+	Source string `json:"source"`
+}
+
+// Return is the return value of a method.
+type Return struct {
+	// The ABI type of the return value, or void
+	Type string `json:"type"`
+	// Optional name of the struct of the return value, of type Type
+	Struct string `json:"struct,omitempty"`
+	// Optional, user-friendly description for the return value
+	Desc string `json:"desc,omitempty"`
+}
+
+// Actions are the OnCompletion actions of calls creating and calling an
+// existing application.
+type Actions struct {
+	Create []string `json:"create"`
+	Call   []string `json:"call"`
+}
+
+// Recommendations are the resources a method call is expected to need.
+type Recommendations struct {
+	InnerTransactionCount uint64       `json:"innerTransactionCount,omitempty"`
+	Boxes                 *BoxResource `json:"boxes,omitempty"`
+	Accounts              []string     `json:"accounts,omitempty"`
+	Apps                  []uint64     `json:"apps,omitempty"`
+	Assets                []uint64     `json:"assets,omitempty"`
+}
+
+// BoxResource is a box accessed by a method call.
+type BoxResource struct {
+	// App owning the box, the application called if zero
+	App uint64 `json:"app,omitempty"`
+	// The base64 name of the box
+	Key        string `json:"key"`
+	ReadBytes  uint64 `json:"readBytes"`
+	WriteBytes uint64 `json:"writeBytes"`
+}
+
+// State describes the storage of the application.
+type State struct {
+	Schema Schema      `json:"schema"`
+	Keys   StorageKeys `json:"keys"`
+	Maps   StorageMaps `json:"maps"`
+}
+
+// Schema is the state schema of the application.
+type Schema struct {
+	Global SchemaSize `json:"global"`
+	Local  SchemaSize `json:"local"`
+}
+
+// SchemaSize is the number of integers and byte slices of a state.
+type SchemaSize struct {
+	Ints  uint64 `json:"ints"`
+	Bytes uint64 `json:"bytes"`
+}
+
+// StorageKeys are the keys of the global, local and box storage, by name.
+type StorageKeys struct {
+	Global map[string]StorageKey `json:"global"`
+	Local  map[string]StorageKey `json:"local"`
+	Box    map[string]StorageKey `json:"box"`
+}
+
+// StorageKey is a single key of storage.
+type StorageKey struct {
+	Desc      string `json:"desc,omitempty"`
+	KeyType   string `json:"keyType"`
+	ValueType string `json:"valueType"`
+	// The base64 key
+	Key string `json:"key"`
+}
+
+// StorageMaps are the maps of the global, local and box storage, by name.
+type StorageMaps struct {
+	Global map[string]StorageMap `json:"global"`
+	Local  map[string]StorageMap `json:"local"`
+	Box    map[string]StorageMap `json:"box"`
+}
+
+// StorageMap is a set of keys of storage sharing a prefix.
+type StorageMap struct {
+	Desc      string `json:"desc,omitempty"`
+	KeyType   string `json:"keyType"`
+	ValueType string `json:"valueType"`
+	// The base64 prefix of the keys
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// SourceInfos are the source information of the programs.
+type SourceInfos struct {
+	Approval ProgramSourceInfo `json:"approval"`
+	Clear    ProgramSourceInfo `json:"clear"`
+}
+
+// ProgramSourceInfo maps program counters of a program to its source.
+type ProgramSourceInfo struct {
+	SourceInfo     []SourceInfo `json:"sourceInfo"`
+	PCOffsetMethod string       `json:"pcOffsetMethod"`
+}
+
+// SourceInfo is the source of some program counters.
+type SourceInfo struct {
+	PC []uint64 `json:"pc"`
+	// ErrorMessage is the error raised by the program failing at PC.
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// TEAL line, starting at 1
+	TEAL   uint64 `json:"teal,omitempty"`
+	Source string `json:"source,omitempty"`
+}
+
+// Programs are the base64 approval and clear programs, as TEAL source or
+// byte code.
+type Programs struct {
+	Approval string `json:"approval"`
+	Clear    string `json:"clear"`
+}
+
+// CompilerInfo tells how the programs were compiled.
+type CompilerInfo struct {
+	// Compiler is algod or puya.
+	Compiler        string          `json:"compiler"`
+	CompilerVersion CompilerVersion `json:"compilerVersion"`
+}
+
+// CompilerVersion is the version of a compiler.
+type CompilerVersion struct {
+	Major      uint64 `json:"major"`
+	Minor      uint64 `json:"minor"`
+	Patch      uint64 `json:"patch"`
+	CommitHash string `json:"commitHash,omitempty"`
+}
+
+// Event is an ARC-28 event, see the events package.
+type Event struct {
+	Name string `json:"name"`
+	// Optional, user-friendly description for the event
+	Desc string     `json:"desc,omitempty"`
+	Args []EventArg `json:"args"`
+}
+
+// EventArg is an argument of an event.
+type EventArg struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+	// Optional, user-friendly description for the argument
+	Desc string `json:"desc,omitempty"`
+	// Optional name of the struct of the argument, of type Type
+	Struct string `json:"struct,omitempty"`
+}
+
+// TemplateValues are the template variables of the programs, by name without
+// the TMPL_ prefix.
+type TemplateValues map[string]TemplateVariable
+
+// TemplateVariable is a template variable of the programs.
+type TemplateVariable struct {
+	// An ABI type, AVM type or struct name
+	Type string `json:"type"`
+	// Optional base64 value, the default value of the variable
+	Value string `json:"value,omitempty"`
+}
+
+// ScratchSlots are the scratch variables of the programs, by name.
+type ScratchSlots map[string]ScratchVariable
+
+// ScratchVariable is a scratch slot of the programs.
+type ScratchVariable struct {
+	Slot uint64 `json:"slot"`
+	// An ABI type, AVM type or struct name
+	Type string `json:"type"`
+}
+
+// Parse parses and validates an ARC-56 application specification.
+func Parse(data []byte) (Contract, error) {
+	var c Contract
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Contract{}, fmt.Errorf("invalid ARC-56 specification: %w", err)
+	}
+	if err := c.Validate(); err != nil {
+		return Contract{}, err
+	}
+	return c, nil
+}
+
+// ParseDescription parses an ARC-56 application specification, or an ARC-4
+// contract description converted by FromABIContract. A description is taken
+// for an ARC-56 specification if it has any of the fields ARC-56 adds to
+// ARC-4.
+func ParseDescription(data []byte) (Contract, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return Contract{}, fmt.Errorf("invalid contract description: %w", err)
+	}
+	for _, field := range []string{"arcs", "structs", "state", "bareActions"} {
+		if _, ok := fields[field]; ok {
+			return Parse(data)
+		}
+	}
+
+	var contract abi.Contract
+	if err := json.Unmarshal(data, &contract); err != nil {
+		return Contract{}, fmt.Errorf("invalid ARC-4 contract description: %w", err)
+	}
+	c := FromABIContract(contract)
+	if err := c.Validate(); err != nil {
+		return Contract{}, err
+	}
+	return c, nil
+}
+
+// Marshal returns the JSON of an ARC-56 application specification, with the
+// required fields left empty set to empty values.
+func Marshal(c Contract) ([]byte, error) {
+	if c.Arcs == nil {
+		c.Arcs = []int{}
+	}
+	if c.Structs == nil {
+		c.Structs = map[string][]StructField{}
+	}
+	if c.Methods == nil {
+		c.Methods = []Method{}
+	}
+	for i := range c.Methods {
+		m := &c.Methods[i]
+		if m.Args == nil {
+			m.Args = []MethodArg{}
+		}
+		m.Actions = m.Actions.nonNil()
+	}
+	c.BareActions = c.BareActions.nonNil()
+	for _, keys := range []*map[string]StorageKey{&c.State.Keys.Global, &c.State.Keys.Local, &c.State.Keys.Box} {
+		if *keys == nil {
+			*keys = map[string]StorageKey{}
+		}
+	}
+	for _, maps := range []*map[string]StorageMap{&c.State.Maps.Global, &c.State.Maps.Local, &c.State.Maps.Box} {
+		if *maps == nil {
+			*maps = map[string]StorageMap{}
+		}
+	}
+	return json.MarshalIndent(c, "", "  ")
+}
+
+func (a Actions) nonNil() Actions {
+	if a.Create == nil {
+		a.Create = []string{}
+	}
+	if a.Call == nil {
+		a.Call = []string{}
+	}
+	return a
+}
+
+// FromABIContract returns the specification of an application described by
+// an ARC-4 contract, whose methods are called with NoOp.
+func FromABIContract(contract abi.Contract) Contract {
+	c := Contract{
+		Arcs:     []int{4, 56},
+		Name:     contract.Name,
+		Desc:     contract.Desc,
+		Networks: contract.Networks,
+		Methods:  make([]Method, len(contract.Methods)),
+	}
+	for i, m := range contract.Methods {
+		method := Method{
+			Name:    m.Name,
+			Desc:    m.Desc,
+			Args:    make([]MethodArg, len(m.Args)),
+			Returns: Return{Type: m.Returns.Type, Desc: m.Returns.Desc},
+			Actions: Actions{Call: []string{ActionNoOp}},
+		}
+		for j, arg := range m.Args {
+			method.Args[j] = MethodArg{Type: arg.Type, Name: arg.Name, Desc: arg.Desc}
+		}
+		c.Methods[i] = method
+	}
+	return c
+}
+
+// ABIContract returns the ARC-4 contract of the application.
+func (c Contract) ABIContract() abi.Contract {
+	contract := abi.Contract{
+		Name:     c.Name,
+		Desc:     c.Desc,
+		Networks: c.Networks,
+		Methods:  make([]abi.Method, len(c.Methods)),
+	}
+	for i, m := range c.Methods {
+		contract.Methods[i] = m.ABIMethod()
+	}
+	return contract
+}
+
+// ABIMethod returns the ARC-4 method.
+func (m Method) ABIMethod() abi.Method {
+	method := abi.Method{
+		Name:    m.Name,
+		Desc:    m.Desc,
+		Args:    make([]abi.Arg, len(m.Args)),
+		Returns: abi.Return{Type: m.Returns.Type, Desc: m.Returns.Desc},
+	}
+	for i, arg := range m.Args {
+		method.Args[i] = abi.Arg{Name: arg.Name, Type: arg.Type, Desc: arg.Desc}
+	}
+	return method
+}
+
+// GetSignature returns the signature of the method.
+func (m Method) GetSignature() string {
+	method := m.ABIMethod()
+	return method.GetSignature()
+}
+
+// GetMethod returns the method with the given name, or signature if several
+// methods have that name.
+func (c Contract) GetMethod(nameOrSignature string) (Method, error) {
+	var found []Method
+	for _, m := range c.Methods {
+		if m.GetSignature() == nameOrSignature {
+			return m, nil
+		}
+		if m.Name == nameOrSignature {
+			found = append(found, m)
+		}
+	}
+	if len(found) == 0 {
+		return Method{}, fmt.Errorf("found 0 methods with the name or signature %s", nameOrSignature)
+	}
+	if len(found) > 1 {
+		return Method{}, fmt.Errorf("found %d methods with the name %s, call it by signature", len(found), nameOrSignature)
+	}
+	return found[0], nil
+}
+
+// AllEvents returns the events of the application and its methods, without
+// duplicates, as described to the events package.
+func (c Contract) AllEvents() []events.Event {
+	seen := make(map[string]bool)
+	var all []events.Event
+	add := func(list []Event) {
+		for _, e := range list {
+			event := events.Event{Name: e.Name, Desc: e.Desc, Args: make([]abi.Arg, len(e.Args))}
+			for i, arg := range e.Args {
+				event.Args[i] = abi.Arg{Name: arg.Name, Type: arg.Type, Desc: arg.Desc}
+			}
+			if signature := event.GetSignature(); !seen[signature] {
+				seen[signature] = true
+				all = append(all, event)
+			}
+		}
+	}
+	add(c.Events)
+	for _, m := range c.Methods {
+		add(m.Events)
+	}
+	return all
+}
+
+// Validate checks the specification: method signatures, actions, struct
+// references, default values, template variables and source information.
+func (c Contract) Validate() error {
+	if c.Name == "" {
+		return errNoName
+	}
+	for name, fields := range c.Structs {
+		if err := c.validateStructFields(fields); err != nil {
+			return fmt.Errorf("struct %s: %w", name, err)
+		}
+	}
+
+	if err := validateActions(c.BareActions); err != nil {
+		return fmt.Errorf("bare actions: %w", err)
+	}
+	signatures := make(map[string]bool, len(c.Methods))
+	for _, m := range c.Methods {
+		signature := m.GetSignature()
+		if err := m.validate(c); err != nil {
+			return fmt.Errorf("method %s: %w", signature, err)
+		}
+		if signatures[signature] {
+			return fmt.Errorf("method %s is described twice", signature)
+		}
+		signatures[signature] = true
+	}
+
+	for name, v := range c.Templates {
+		if v.Type == "" {
+			return fmt.Errorf("template variable %s has no type", name)
+		}
+		if v.Value != "" {
+			if _, err := base64.StdEncoding.DecodeString(v.Value); err != nil {
+				return fmt.Errorf("template variable %s: invalid base64 value: %w", name, err)
+			}
+		}
+	}
+
+	if c.SourceInfo != nil {
+		for _, p := range []ProgramSourceInfo{c.SourceInfo.Approval, c.SourceInfo.Clear} {
+			if p.PCOffsetMethod != PCOffsetNone && p.PCOffsetMethod != PCOffsetCBlocks {
+				return fmt.Errorf("invalid pcOffsetMethod %q", p.PCOffsetMethod)
+			}
+		}
+	}
+	for _, programs := range []*Programs{c.Source, c.ByteCode} {
+		if programs == nil {
+			continue
+		}
+		for _, program := range []string{programs.Approval, programs.Clear} {
+			if _, err := base64.StdEncoding.DecodeString(program); err != nil {
+				return fmt.Errorf("invalid base64 program: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c Contract) validateStructFields(fields []StructField) error {
+	for _, f := range fields {
+		if f.Fields != nil {
+			if err := c.validateStructFields(f.Fields); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, ok := c.Structs[f.Type]; ok {
+			continue
+		}
+		if _, err := abi.TypeOf(f.Type); err != nil {
+			return fmt.Errorf("field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func (m Method) validate(c Contract) error {
+	if err := abi.VerifyMethodSignature(m.GetSignature()); err != nil {
+		return err
+	}
+	if err := validateActions(m.Actions); err != nil {
+		return err
+	}
+	for i, arg := range m.Args {
+		if arg.Struct != "" {
+			if _, ok := c.Structs[arg.Struct]; !ok {
+				return fmt.Errorf("argument %d has the unknown struct %s", i, arg.Struct)
+			}
+		}
+		if d := arg.DefaultValue; d != nil {
+			switch d.Source {
+			case DefaultValueBox, DefaultValueGlobal, DefaultValueLocal, DefaultValueLiteral:
+				if _, err := base64.StdEncoding.DecodeString(d.Data); err != nil {
+					return fmt.Errorf("argument %d: invalid base64 default value: %w", i, err)
+				}
+			case DefaultValueMethod:
+			default:
+				return fmt.Errorf("argument %d: invalid default value source %q", i, d.Source)
+			}
+		}
+	}
+	if m.Returns.Struct != "" {
+		if _, ok := c.Structs[m.Returns.Struct]; !ok {
+			return fmt.Errorf("return value has the unknown struct %s", m.Returns.Struct)
+		}
+	}
+	return nil
+}
+
+func validateActions(actions Actions) error {
+	for _, action := range actions.Create {
+		switch action {
+		case ActionNoOp, ActionOptIn, ActionDeleteApplication:
+		default:
+			return fmt.Errorf("invalid create action %q", action)
+		}
+	}
+	for _, action := range actions.Call {
+		switch action {
+		case ActionNoOp, ActionOptIn, ActionCloseOut, ActionUpdateApplication, ActionDeleteApplication:
+		default:
+			return fmt.Errorf("invalid call action %q", action)
+		}
+	}
+	return nil
+}
+
+// ApprovalProgram returns the approval program of the application, see
+// Programs.
+func (c Contract) ApprovalProgram(ctx context.Context, client *algod.Client, templateValues map[string]interface{}) ([]byte, error) {
+	return c.program(ctx, client, templateValues, func(p *Programs) string { return p.Approval })
+}
+
+// ClearProgram returns the clear program of the application, see Programs.
+func (c Contract) ClearProgram(ctx context.Context, client *algod.Client, templateValues map[string]interface{}) ([]byte, error) {
+	return c.program(ctx, client, templateValues, func(p *Programs) string { return p.Clear })
+}
+
+// program returns the byte code of the specification when the programs have
+// no template variables, and else substitutes templateValues, along with the
+// values of the specification for the variables they leave out, in the TEAL
+// source and compiles it with algod.
+func (c Contract) program(ctx context.Context, client *algod.Client, templateValues map[string]interface{}, pick func(*Programs) string) ([]byte, error) {
+	if c.ByteCode != nil && len(c.Templates) == 0 {
+		return base64.StdEncoding.DecodeString(pick(c.ByteCode))
+	}
+	if c.Source == nil {
+		return nil, errNoSource
+	}
+	teal, err := base64.StdEncoding.DecodeString(pick(c.Source))
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]interface{}, len(c.Templates)+len(templateValues))
+	for name, v := range c.Templates {
+		if v.Value == "" {
+			continue
+		}
+		value, err := v.decode()
+		if err != nil {
+			return nil, fmt.Errorf("template variable %s: %w", name, err)
+		}
+		values[strings.TrimPrefix(name, logic.TemplatePrefix)] = value
+	}
+	for name, value := range templateValues {
+		values[strings.TrimPrefix(name, logic.TemplatePrefix)] = value
+	}
+
+	source, err := logic.SubstituteTemplate(string(teal), values)
+	if err != nil {
+		return nil, err
+	}
+	response, err := client.TealCompile([]byte(source)).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(response.Result)
+}
+
+// decode returns the value of the variable as an integer for integer types,
+// and bytes otherwise.
+func (v TemplateVariable) decode() (interface{}, error) {
+	value, err := base64.StdEncoding.DecodeString(v.Value)
+	if err != nil {
+		return nil, err
+	}
+	if v.Type == AVMUint64 || v.Type == "uint64" {
+		if len(value) != 8 {
+			return nil, fmt.Errorf("integer value of %d bytes, expected 8", len(value))
+		}
+		return binary.BigEndian.Uint64(value), nil
+	}
+	return value, nil
+}
+
+// ErrorMessage returns the error message of the program failing at pc, if the
+// source information has one. program is the program run, needed to offset
+// pc when PCOffsetMethod is PCOffsetCBlocks.
+func (p ProgramSourceInfo) ErrorMessage(program []byte, pc uint64) (string, bool) {
+	if p.PCOffsetMethod == PCOffsetCBlocks {
+		offset, err := constantBlocksLength(program)
+		if err != nil || pc < offset {
+			return "", false
+		}
+		pc -= offset
+	}
+	for _, info := range p.SourceInfo {
+		for _, infoPC := range info.PC {
+			if infoPC == pc && info.ErrorMessage != "" {
+				return info.ErrorMessage, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Opcodes of the constant blocks.
+const (
+	opIntcBlock  = 0x20
+	opBytecBlock = 0x26
+)
+
+// constantBlocksLength returns the length of the intcblock and bytecblock
+// following the version of the program.
+func constantBlocksLength(program []byte) (uint64, error) {
+	r := bytes.NewReader(program)
+	if _, err := binary.ReadUvarint(r); err != nil {
+		return 0, err
+	}
+	start := r.Size() - int64(r.Len())
+	for r.Len() > 0 {
+		op, _ := r.ReadByte()
+		if op != opIntcBlock && op != opBytecBlock {
+			r.UnreadByte()
+			break
+		}
+		count, err := binary.ReadUvarint(r)
+		if err != nil {
+			return 0, err
+		}
+		for i := uint64(0); i < count; i++ {
+			n, err := binary.ReadUvarint(r)
+			if err != nil {
+				return 0, err
+			}
+			if op == opBytecBlock {
+				if n > uint64(r.Len()) {
+					return 0, errTruncatedProgram
+				}
+				r.Seek(int64(n), io.SeekCurrent)
+			}
+		}
+	}
+	return uint64(r.Size() - int64(r.Len()) - start), nil
+}
@@ -0,0 +1,425 @@
+package arc56
+
+import (
+	"context"
+	"encoding/base64"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	gotypes "go/types"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/abi"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+const specJSON = `{
+	"arcs": [4, 56],
+	"name": "counter",
+	"desc": "Counts calls.",
+	"structs": {
+		"Pair": [{"name": "a", "type": "uint64"}, {"name": "inner", "type": [{"name": "b", "type": "string"}]}]
+	},
+	"methods": [
+		{
+			"name": "create",
+			"args": [{"type": "uint64", "name": "start"}],
+			"returns": {"type": "void"},
+			"actions": {"create": ["NoOp"], "call": []}
+		},
+		{
+			"name": "add",
+			"desc": "Adds to the counter.",
+			"args": [
+				{"type": "uint64", "name": "amount", "defaultValue": {"data": "AAAAAAAAAAE=", "source": "literal"}},
+				{"type": "(uint64,(string))", "struct": "Pair", "name": "pair"}
+			],
+			"returns": {"type": "uint64"},
+			"actions": {"create": [], "call": ["NoOp"]},
+			"events": [{"name": "Added", "args": [{"type": "uint64", "name": "amount"}]}]
+		},
+		{
+			"name": "get",
+			"args": [],
+			"returns": {"type": "uint64"},
+			"actions": {"create": [], "call": ["NoOp"]},
+			"readonly": true
+		},
+		{
+			"name": "owner",
+			"args": [{"type": "account", "name": "type"}, {"type": "byte[]"}],
+			"returns": {"type": "address"},
+			"actions": {"create": [], "call": ["NoOp", "OptIn"]}
+		}
+	],
+	"state": {
+		"schema": {"global": {"ints": 1, "bytes": 0}, "local": {"ints": 0, "bytes": 0}},
+		"keys": {"global": {"count": {"keyType": "AVMString", "valueType": "AVMUint64", "key": "Y291bnQ="}}, "local": {}, "box": {}},
+		"maps": {"global": {}, "local": {}, "box": {}}
+	},
+	"bareActions": {"create": ["NoOp"], "call": ["DeleteApplication"]},
+	"sourceInfo": {
+		"approval": {"sourceInfo": [{"pc": [2, 3], "errorMessage": "amount too large"}], "pcOffsetMethod": "cblocks"},
+		"clear": {"sourceInfo": [], "pcOffsetMethod": "none"}
+	},
+	"source": {"approval": "I3ByYWdtYSB2ZXJzaW9uIDEwCmludCBUTVBMX1NUQVJU", "clear": "I3ByYWdtYSB2ZXJzaW9uIDEw"},
+	"events": [{"name": "Added", "args": [{"type": "uint64", "name": "amount"}]}],
+	"templateVariables": {"START": {"type": "AVMUint64", "value": "AAAAAAAAAAc="}}
+}`
+
+func TestParse(t *testing.T) {
+	c, err := Parse([]byte(specJSON))
+	require.NoError(t, err)
+	require.Equal(t, "counter", c.Name)
+	require.Len(t, c.Methods, 4)
+	require.Equal(t, []StructField{
+		{Name: "a", Type: "uint64"},
+		{Name: "inner", Fields: []StructField{{Name: "b", Type: "string"}}},
+	}, c.Structs["Pair"])
+	require.Equal(t, "Y291bnQ=", c.State.Keys.Global["count"].Key)
+	require.Equal(t, uint64(1), c.State.Schema.Global.Ints)
+
+	encoded, err := Marshal(c)
+	require.NoError(t, err)
+	reparsed, err := Parse(encoded)
+	require.NoError(t, err)
+	require.Equal(t, c, reparsed)
+
+	m, err := c.GetMethod("add")
+	require.NoError(t, err)
+	require.Equal(t, "add(uint64,(uint64,(string)))uint64", m.GetSignature())
+	_, err = c.GetMethod("add(uint64,(uint64,(string)))uint64")
+	require.NoError(t, err)
+	_, err = c.GetMethod("missing")
+	require.Error(t, err)
+
+	events := c.AllEvents()
+	require.Len(t, events, 1)
+	require.Equal(t, "Added(uint64)", events[0].GetSignature())
+
+	contract := c.ABIContract()
+	require.Len(t, contract.Methods, 4)
+	require.Equal(t, m.GetSignature(), contract.Methods[1].GetSignature())
+
+	invalid := []struct {
+		name string
+		edit func(*Contract)
+	}{
+		{"no name", func(c *Contract) { c.Name = "" }},
+		{"bad action", func(c *Contract) { c.BareActions.Create = []string{ActionCloseOut} }},
+		{"bad type", func(c *Contract) { c.Methods[0].Args[0].Type = "uint7" }},
+		{"unknown struct", func(c *Contract) { c.Methods[1].Args[1].Struct = "Other" }},
+		{"bad default", func(c *Contract) { c.Methods[1].Args[0].DefaultValue.Source = "elsewhere" }},
+		{"duplicate", func(c *Contract) { c.Methods = append(c.Methods, c.Methods[0]) }},
+		{"bad offset", func(c *Contract) { c.SourceInfo.Approval.PCOffsetMethod = "lines" }},
+		{"untyped template", func(c *Contract) { c.Templates["START"] = TemplateVariable{} }},
+	}
+	for _, test := range invalid {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := Parse([]byte(specJSON))
+			require.NoError(t, err)
+			test.edit(&c)
+			require.Error(t, c.Validate())
+		})
+	}
+}
+
+func TestFromABIContract(t *testing.T) {
+	method, err := abi.MethodFromSignature("add(uint64,uint64)uint128")
+	require.NoError(t, err)
+	c := FromABIContract(abi.Contract{Name: "calc", Methods: []abi.Method{method}})
+	require.NoError(t, c.Validate())
+	require.Equal(t, []string{ActionNoOp}, c.Methods[0].Actions.Call)
+
+	encoded, err := Marshal(c)
+	require.NoError(t, err)
+	require.Contains(t, string(encoded), `"structs": {}`)
+	parsed, err := Parse(encoded)
+	require.NoError(t, err)
+	require.Equal(t, method.GetSignature(), parsed.Methods[0].GetSignature())
+}
+
+func TestParseDescription(t *testing.T) {
+	c, err := ParseDescription([]byte(specJSON))
+	require.NoError(t, err)
+	require.Len(t, c.Methods, 4)
+	require.Equal(t, []string{ActionNoOp}, c.BareActions.Create)
+
+	c, err = ParseDescription([]byte(`{"name": "calc", "methods": [{"name": "add", "args": [{"type": "uint64"}], "returns": {"type": "void"}}]}`))
+	require.NoError(t, err)
+	require.Equal(t, []int{4, 56}, c.Arcs)
+	require.Equal(t, "add(uint64)void", c.Methods[0].GetSignature())
+	require.Equal(t, []string{ActionNoOp}, c.Methods[0].Actions.Call)
+
+	_, err = ParseDescription([]byte(`{"name": "calc", "methods": [{"name": "add", "args": [{"type": "uint7"}], "returns": {"type": "void"}}]}`))
+	require.Error(t, err)
+	_, err = ParseDescription([]byte(`[]`))
+	require.Error(t, err)
+}
+
+func TestErrorMessage(t *testing.T) {
+	info := ProgramSourceInfo{SourceInfo: []SourceInfo{{PC: []uint64{2, 3}, ErrorMessage: "oops"}}, PCOffsetMethod: PCOffsetNone}
+	message, ok := info.ErrorMessage(nil, 3)
+	require.True(t, ok)
+	require.Equal(t, "oops", message)
+	_, ok = info.ErrorMessage(nil, 4)
+	require.False(t, ok)
+
+	// version 10, intcblock 1 7, bytecblock 1 "ab", then the program
+	program := []byte{0x0a, 0x20, 0x01, 0x07, 0x26, 0x01, 0x02, 'a', 'b', 0x22}
+	info.PCOffsetMethod = PCOffsetCBlocks
+	message, ok = info.ErrorMessage(program, 10)
+	require.True(t, ok)
+	require.Equal(t, "oops", message)
+	_, ok = info.ErrorMessage(program, 3)
+	require.False(t, ok)
+	_, ok = info.ErrorMessage(program[:7], 10)
+	require.False(t, ok)
+}
+
+// makeAlgodServer returns a fake algod compiling programs to their source,
+// and simulating calls returning returnValue.
+func makeAlgodServer(t *testing.T, returnValue []byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/teal/compile":
+			body := make([]byte, r.ContentLength)
+			r.Body.Read(body)
+			w.Write(json.Encode(models.CompileResponse{Result: base64.StdEncoding.EncodeToString(body)}))
+		case "/v2/transactions/params":
+			w.Write(json.Encode(models.TransactionParametersResponse{
+				ConsensusVersion: "future", Fee: 0, MinFee: 1000, LastRound: 10, GenesisHash: make([]byte, 32),
+			}))
+		case "/v2/transactions/simulate":
+			var result models.SimulateTransactionResult
+			result.TxnResult.Logs = [][]byte{append([]byte{0x15, 0x1f, 0x7c, 0x75}, returnValue...)}
+			w.Write(json.Encode(models.SimulateResponse{TxnGroups: []models.SimulateTransactionGroupResult{
+				{TxnResults: []models.SimulateTransactionResult{result}},
+			}}))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}))
+}
+
+func TestPrograms(t *testing.T) {
+	server := makeAlgodServer(t, nil)
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	c, err := Parse([]byte(specJSON))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	approval, err := c.ApprovalProgram(ctx, client, nil)
+	require.NoError(t, err)
+	require.Equal(t, "#pragma version 10\nint 7", string(approval))
+	approval, err = c.ApprovalProgram(ctx, client, map[string]interface{}{"TMPL_START": 9})
+	require.NoError(t, err)
+	require.Equal(t, "#pragma version 10\nint 9", string(approval))
+	clear, err := c.ClearProgram(ctx, client, nil)
+	require.NoError(t, err)
+	require.Equal(t, "#pragma version 10", string(clear))
+
+	c.Templates = nil
+	c.ByteCode = &Programs{Approval: "CoEBQw==", Clear: "CoEBQw=="}
+	approval, err = c.ApprovalProgram(ctx, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x0a, 0x81, 0x01, 0x43}, approval)
+
+	c.ByteCode, c.Source = nil, nil
+	_, err = c.ApprovalProgram(ctx, client, nil)
+	require.ErrorIs(t, err, errNoSource)
+}
+
+func TestAppClientReadonly(t *testing.T) {
+	server := makeAlgodServer(t, []byte{0, 0, 0, 0, 0, 0, 0, 42})
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	c, err := Parse([]byte(specJSON))
+	require.NoError(t, err)
+	app := AppClient{Spec: c, AppID: 5, Algod: client, Sender: types.Address{1}}
+
+	result, err := app.Call(context.Background(), "get")
+	require.NoError(t, err)
+	require.NoError(t, result.DecodeError)
+	require.Equal(t, uint64(42), result.ReturnValue)
+
+	_, err = app.Call(context.Background(), "get", uint64(1))
+	require.Error(t, err)
+	_, err = app.CallWithParams(context.Background(), CallParams{Action: ActionOptIn}, "get")
+	require.Error(t, err)
+	_, err = app.Call(context.Background(), "create", uint64(1))
+	require.ErrorContains(t, err, "no call action")
+}
+
+func TestAddMethodCall(t *testing.T) {
+	server := makeAlgodServer(t, nil)
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	c, err := Parse([]byte(specJSON))
+	require.NoError(t, err)
+	app := AppClient{Spec: c, AppID: 5, Algod: client, Sender: types.Address{1}, Signer: transaction.EmptyTransactionSigner{}}
+
+	var atc transaction.AtomicTransactionComposer
+	require.NoError(t, app.AddMethodCall(context.Background(), &atc, CallParams{}, "get"))
+	require.NoError(t, app.AddMethodCall(context.Background(), &atc, CallParams{Action: ActionOptIn}, "owner", types.Address{2}, []byte("x")))
+	group, err := atc.BuildGroup()
+	require.NoError(t, err)
+	require.Len(t, group, 2)
+	require.Equal(t, types.OptInOC, group[1].Txn.OnCompletion)
+	require.Equal(t, types.AppIndex(5), group[0].Txn.ApplicationID)
+}
+
+func TestMethodArgs(t *testing.T) {
+	c, err := Parse([]byte(specJSON))
+	require.NoError(t, err)
+	app := AppClient{Spec: c, AppID: 5}
+	m, err := c.GetMethod("add")
+	require.NoError(t, err)
+
+	args, err := app.methodArgs(context.Background(), m, []interface{}{nil, []interface{}{uint64(1), []interface{}{"b"}}})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), args[0])
+
+	_, err = app.methodArgs(context.Background(), m, []interface{}{uint64(2)})
+	require.ErrorContains(t, err, "argument 1 is missing")
+}
+
+func TestLogicError(t *testing.T) {
+	c, err := Parse([]byte(specJSON))
+	require.NoError(t, err)
+	app := AppClient{Spec: c, approval: []byte{0x0a, 0x20, 0x01, 0x07}}
+
+	cause := &testError{"logic eval error: assert failed pc=5. Details: ..."}
+	err = app.logicError(context.Background(), cause)
+	var logicErr *LogicError
+	require.ErrorAs(t, err, &logicErr)
+	require.Equal(t, uint64(5), logicErr.PC)
+	require.Equal(t, "amount too large", logicErr.Message)
+	require.ErrorIs(t, err, cause)
+
+	other := &testError{"logic eval error: pc=9"}
+	require.Equal(t, error(other), app.logicError(context.Background(), other))
+}
+
+type testError struct{ message string }
+
+func (e *testError) Error() string { return e.message }
+
+func TestGenerate(t *testing.T) {
+	c, err := Parse([]byte(specJSON))
+	require.NoError(t, err)
+
+	source, err := Generate(c, "counter")
+	require.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "counter.go", source, parser.AllErrors)
+	require.NoError(t, err)
+
+	code := string(source)
+	for _, expected := range []string{
+		"package counter",
+		"type CounterClient struct",
+		"func NewCounterClient(algodClient *algod.Client, appID uint64, sender types.Address, signer transaction.TransactionSigner) (*CounterClient, error)",
+		"func (c *CounterClient) CreateBare(ctx context.Context, params arc56.CallParams) error",
+		"func (c *CounterClient) CreateCreate(ctx context.Context, params arc56.CallParams, start uint64) error",
+		"type Pair struct {\n\tA     uint64\n\tInner struct {\n\t\tB string\n\t}\n}",
+		"func (c *CounterClient) Add(ctx context.Context, amount uint64, pair Pair) (uint64, error)",
+		"// Adds to the counter.",
+		`"add(uint64,(uint64,(string)))uint64", amount, arc56.ABIValue(pair))`,
+		"func (c *CounterClient) ComposeAdd(ctx context.Context, atc *transaction.AtomicTransactionComposer, amount uint64, pair Pair) error",
+		"func (c *CounterClient) Get(ctx context.Context) (uint64, error)",
+		"func (c *CounterClient) Owner(ctx context.Context, arg0 types.Address, arg1 []byte) (types.Address, error)",
+		`"owner(account,byte[])address"`,
+	} {
+		require.Contains(t, code, expected)
+	}
+	require.NotContains(t, code, "math/big")
+
+	c.Methods = append(c.Methods, Method{
+		Name:    "pairs",
+		Args:    []MethodArg{{Type: "(uint8,bool)[2]", Name: "pairs"}, {Type: "uint256[]", Name: "amounts"}},
+		Returns: Return{Type: "(address,byte[4])"},
+		Actions: Actions{Call: []string{ActionNoOp}},
+	})
+	// the client of every method type-checks
+	source, err = Generate(c, "counter")
+	require.NoError(t, err)
+	typeCheck(t, source)
+	require.Contains(t, string(source), "func (c *CounterClient) Pairs(ctx context.Context, pairs [2]struct {\n\tField0 uint8\n\tField1 bool\n}, amounts []*big.Int) (struct {\n\tField0 types.Address\n\tField1 []byte\n}, error)")
+
+	_, err = Generate(c, "not a package")
+	require.Error(t, err)
+	c.Name = ""
+	_, err = Generate(c, "counter")
+	require.ErrorIs(t, err, errNoName)
+}
+
+// typeCheck fails t if source is not a valid Go file of a package importing
+// the SDK.
+func typeCheck(t *testing.T, source []byte) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "client.go", source, parser.AllErrors)
+	require.NoError(t, err)
+	conf := gotypes.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check(file.Name.Name, fset, []*ast.File{file}, nil)
+	require.NoError(t, err)
+}
+
+func TestABIValue(t *testing.T) {
+	type pair struct {
+		A     uint64
+		Inner struct {
+			B string
+		}
+		Owner  types.Address
+		Amount *big.Int
+	}
+	typ, err := abi.TypeOf("(uint64,(string),address,uint128)[]")
+	require.NoError(t, err)
+	value := []pair{{A: 1, Owner: types.Address{1}, Amount: big.NewInt(2)}}
+	value[0].Inner.B = "b"
+
+	encoded, err := typ.Encode(ABIValue(value))
+	require.NoError(t, err)
+	decoded, err := typ.Decode(encoded)
+	require.NoError(t, err)
+	var got []pair
+	require.NoError(t, DecodeABIValue(decoded, &got))
+	require.Equal(t, value, got)
+
+	var wrong struct{ A string }
+	require.Error(t, DecodeABIValue([]interface{}{uint64(1)}, &wrong))
+	var short [2]uint64
+	require.Error(t, DecodeABIValue([]interface{}{uint64(1)}, &short))
+	require.Error(t, DecodeABIValue(uint64(1), short))
+}
+
+func TestIdentifiers(t *testing.T) {
+	require.Equal(t, "GetBalance", exportedIdentifier("get_balance", "M"))
+	require.Equal(t, "GetBalance", exportedIdentifier("getBalance", "M"))
+	require.Equal(t, "M2fa", exportedIdentifier("2fa", "M"))
+	require.Equal(t, "M", exportedIdentifier("--", "M"))
+	require.Equal(t, "amount", unexportedIdentifier("Amount"))
+	require.Equal(t, "", unexportedIdentifier("type"))
+	used := map[string]bool{}
+	require.Equal(t, "Add", uniqueIdentifier(used, "Add"))
+	require.Equal(t, "Add2", uniqueIdentifier(used, "Add"))
+	require.True(t, strings.HasPrefix(uniqueIdentifier(used, "Add"), "Add3"))
+}
@@ -0,0 +1,431 @@
+package arc56
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/algorand/go-algorand-sdk/v2/abi"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// maxExtraPages is the largest number of extra pages of an application.
+const maxExtraPages = 3
+
+// programPageSize is the size of a page of the programs of an application.
+const programPageSize = 2048
+
+var onCompletions = map[string]types.OnCompletion{
+	ActionNoOp:              types.NoOpOC,
+	ActionOptIn:             types.OptInOC,
+	ActionCloseOut:          types.CloseOutOC,
+	ActionClearState:        types.ClearStateOC,
+	ActionUpdateApplication: types.UpdateApplicationOC,
+	ActionDeleteApplication: types.DeleteApplicationOC,
+}
+
+// AppClient calls an application following its ARC-56 specification.
+type AppClient struct {
+	Spec Contract
+
+	// AppID is the application called, zero until a call creates it.
+	AppID uint64
+
+	Algod *algod.Client
+
+	// Sender sends the calls, signed by Signer.
+	Sender types.Address
+	Signer transaction.TransactionSigner
+
+	// WaitRounds is how many rounds to wait for calls to be confirmed.
+	WaitRounds uint64
+
+	// approval is the approval program last deployed by the client, to find
+	// the error messages of its source information.
+	approval []byte
+}
+
+// CallParams are the optional parameters of a call.
+type CallParams struct {
+	// Action is the OnCompletion of the call. By default, it is the first
+	// action of the method for creating the application if AppID is zero,
+	// and for calling it otherwise.
+	Action string
+
+	// TemplateValues are the values of the template variables of the
+	// programs of calls creating or updating the application, see
+	// Contract.ApprovalProgram.
+	TemplateValues map[string]interface{}
+
+	Note []byte
+
+	// References in addition to those recommended by the specification of
+	// the method.
+	BoxReferences   []types.AppBoxReference
+	ForeignAccounts []string
+	ForeignApps     []uint64
+	ForeignAssets   []uint64
+}
+
+// Call calls method, given by name or signature, with args. See
+// CallWithParams.
+func (c *AppClient) Call(ctx context.Context, method string, args ...interface{}) (transaction.ABIMethodResult, error) {
+	return c.CallWithParams(ctx, CallParams{}, method, args...)
+}
+
+// CallWithParams calls method, given by name or signature, with args. Missing
+// trailing args and nil args take the default value of the argument. A call
+// creating the application sets AppID.
+//
+// Readonly methods called with NoOp are simulated rather than sent, and need
+// no signature. If the approval program fails at a program counter whose
+// source information has an error message, the error is a *LogicError.
+func (c *AppClient) CallWithParams(ctx context.Context, params CallParams, method string, args ...interface{}) (transaction.ABIMethodResult, error) {
+	call, m, action, err := c.methodCall(ctx, params, method, args)
+	if err != nil {
+		return transaction.ABIMethodResult{}, err
+	}
+
+	var atc transaction.AtomicTransactionComposer
+	if m.Readonly && c.AppID != 0 && action == ActionNoOp {
+		call.Signer = transaction.EmptyTransactionSigner{}
+		if err := atc.AddMethodCall(call); err != nil {
+			return transaction.ABIMethodResult{}, err
+		}
+		simulated, err := atc.Simulate(ctx, c.Algod, models.SimulateRequest{AllowEmptySignatures: true})
+		if err != nil {
+			return transaction.ABIMethodResult{}, c.logicError(ctx, err)
+		}
+		if message := simulated.SimulateResponse.TxnGroups[0].FailureMessage; message != "" {
+			return transaction.ABIMethodResult{}, c.logicError(ctx, fmt.Errorf("%s", message))
+		}
+		return simulated.MethodResults[0], nil
+	}
+
+	if err := atc.AddMethodCall(call); err != nil {
+		return transaction.ABIMethodResult{}, err
+	}
+	executed, err := atc.Execute(c.Algod, ctx, c.WaitRounds)
+	if err != nil {
+		return transaction.ABIMethodResult{}, c.logicError(ctx, err)
+	}
+	result := executed.MethodResults[0]
+	if c.AppID == 0 {
+		c.AppID = result.TransactionInfo.ApplicationIndex
+	}
+	return result, nil
+}
+
+// AddMethodCall adds a call of method, given by name or signature, with args
+// to atc, to be executed along with other transactions. Arguments are
+// completed as by CallWithParams. Executing atc does not set AppID, nor
+// simulate readonly methods.
+func (c *AppClient) AddMethodCall(ctx context.Context, atc *transaction.AtomicTransactionComposer, params CallParams, method string, args ...interface{}) error {
+	call, _, _, err := c.methodCall(ctx, params, method, args)
+	if err != nil {
+		return err
+	}
+	return atc.AddMethodCall(call)
+}
+
+// methodCall returns the parameters of a call of method with args, along with
+// the method and action of the call.
+func (c *AppClient) methodCall(ctx context.Context, params CallParams, method string, args []interface{}) (transaction.AddMethodCallParams, Method, string, error) {
+	m, err := c.Spec.GetMethod(method)
+	if err != nil {
+		return transaction.AddMethodCallParams{}, Method{}, "", err
+	}
+	action, err := c.action(params.Action, m.Actions)
+	if err != nil {
+		return transaction.AddMethodCallParams{}, Method{}, "", fmt.Errorf("method %s: %w", m.GetSignature(), err)
+	}
+	methodArgs, err := c.methodArgs(ctx, m, args)
+	if err != nil {
+		return transaction.AddMethodCallParams{}, Method{}, "", err
+	}
+
+	sp, err := c.Algod.SuggestedParams().Do(ctx)
+	if err != nil {
+		return transaction.AddMethodCallParams{}, Method{}, "", err
+	}
+	call := transaction.AddMethodCallParams{
+		AppID:           c.AppID,
+		Method:          m.ABIMethod(),
+		MethodArgs:      methodArgs,
+		Sender:          c.Sender,
+		SuggestedParams: sp,
+		OnComplete:      onCompletions[action],
+		Note:            params.Note,
+		Signer:          c.Signer,
+		ForeignAccounts: params.ForeignAccounts,
+		ForeignApps:     params.ForeignApps,
+		ForeignAssets:   params.ForeignAssets,
+		BoxReferences:   params.BoxReferences,
+	}
+	if err := c.addPrograms(ctx, params.TemplateValues, &call.ApprovalProgram, &call.ClearProgram, &call.GlobalSchema, &call.LocalSchema, &call.ExtraPages, action); err != nil {
+		return transaction.AddMethodCallParams{}, Method{}, "", err
+	}
+	if r := m.Recommendations; r != nil {
+		call.ForeignAccounts = append(call.ForeignAccounts, r.Accounts...)
+		call.ForeignApps = append(call.ForeignApps, r.Apps...)
+		call.ForeignAssets = append(call.ForeignAssets, r.Assets...)
+		if r.Boxes != nil {
+			name, err := base64.StdEncoding.DecodeString(r.Boxes.Key)
+			if err != nil {
+				return transaction.AddMethodCallParams{}, Method{}, "", err
+			}
+			call.BoxReferences = append(call.BoxReferences, types.AppBoxReference{AppID: r.Boxes.App, Name: name})
+		}
+	}
+	return call, m, action, nil
+}
+
+// CallBare makes a call without a method selector, with the bare actions of
+// the application. A call creating the application sets AppID.
+func (c *AppClient) CallBare(ctx context.Context, params CallParams) (transaction.ExecuteResult, error) {
+	action, err := c.action(params.Action, c.Spec.BareActions)
+	if err != nil {
+		return transaction.ExecuteResult{}, fmt.Errorf("bare call: %w", err)
+	}
+	sp, err := c.Algod.SuggestedParams().Do(ctx)
+	if err != nil {
+		return transaction.ExecuteResult{}, err
+	}
+
+	var approval, clear []byte
+	var globalSchema, localSchema types.StateSchema
+	var extraPages uint32
+	if err := c.addPrograms(ctx, params.TemplateValues, &approval, &clear, &globalSchema, &localSchema, &extraPages, action); err != nil {
+		return transaction.ExecuteResult{}, err
+	}
+	tx, err := transaction.MakeApplicationCallTxWithBoxes(c.AppID, nil, params.ForeignAccounts, params.ForeignApps, params.ForeignAssets, params.BoxReferences, onCompletions[action], approval, clear, globalSchema, localSchema, extraPages, sp, c.Sender, params.Note, types.Digest{}, [32]byte{}, types.ZeroAddress)
+	if err != nil {
+		return transaction.ExecuteResult{}, err
+	}
+
+	var atc transaction.AtomicTransactionComposer
+	if err := atc.AddTransaction(transaction.TransactionWithSigner{Txn: tx, Signer: c.Signer}); err != nil {
+		return transaction.ExecuteResult{}, err
+	}
+	executed, err := atc.Execute(c.Algod, ctx, c.WaitRounds)
+	if err != nil {
+		return transaction.ExecuteResult{}, c.logicError(ctx, err)
+	}
+	if c.AppID == 0 {
+		info, _, err := c.Algod.PendingTransactionInformation(executed.TxIDs[0]).Do(ctx)
+		if err != nil {
+			return transaction.ExecuteResult{}, err
+		}
+		c.AppID = info.ApplicationIndex
+	}
+	return executed, nil
+}
+
+// action returns the action of a call, checking it is one of actions.
+func (c *AppClient) action(action string, actions Actions) (string, error) {
+	allowed, kind := actions.Call, "call"
+	if c.AppID == 0 {
+		allowed, kind = actions.Create, "create"
+	}
+	if action == "" {
+		if len(allowed) == 0 {
+			return "", fmt.Errorf("no %s action", kind)
+		}
+		return allowed[0], nil
+	}
+	for _, a := range allowed {
+		if a == action {
+			return action, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not a %s action", action, kind)
+}
+
+// addPrograms sets the programs of calls creating or updating the
+// application, and the schema and extra pages of calls creating it.
+func (c *AppClient) addPrograms(ctx context.Context, templateValues map[string]interface{}, approval, clear *[]byte, globalSchema, localSchema *types.StateSchema, extraPages *uint32, action string) error {
+	if c.AppID != 0 && action != ActionUpdateApplication {
+		return nil
+	}
+	var err error
+	if *approval, err = c.Spec.ApprovalProgram(ctx, c.Algod, templateValues); err != nil {
+		return fmt.Errorf("approval program: %w", err)
+	}
+	if *clear, err = c.Spec.ClearProgram(ctx, c.Algod, templateValues); err != nil {
+		return fmt.Errorf("clear program: %w", err)
+	}
+	c.approval = *approval
+	if c.AppID != 0 {
+		return nil
+	}
+
+	schema := c.Spec.State.Schema
+	*globalSchema = types.StateSchema{NumUint: schema.Global.Ints, NumByteSlice: schema.Global.Bytes}
+	*localSchema = types.StateSchema{NumUint: schema.Local.Ints, NumByteSlice: schema.Local.Bytes}
+	if size := len(*approval) + len(*clear); size > programPageSize {
+		pages := (size - 1) / programPageSize
+		if pages > maxExtraPages {
+			return fmt.Errorf("programs of %d bytes need more than %d extra pages", size, maxExtraPages)
+		}
+		*extraPages = uint32(pages)
+	}
+	return nil
+}
+
+// methodArgs returns args completed with the default values of the missing
+// and nil arguments.
+func (c *AppClient) methodArgs(ctx context.Context, m Method, args []interface{}) ([]interface{}, error) {
+	if len(args) > len(m.Args) {
+		return nil, fmt.Errorf("method %s takes %d arguments, got %d", m.GetSignature(), len(m.Args), len(args))
+	}
+	methodArgs := make([]interface{}, len(m.Args))
+	copy(methodArgs, args)
+	for i, arg := range m.Args {
+		if methodArgs[i] != nil {
+			continue
+		}
+		if arg.DefaultValue == nil {
+			return nil, fmt.Errorf("method %s: argument %d is missing and has no default value", m.GetSignature(), i)
+		}
+		value, err := c.defaultValue(ctx, arg)
+		if err != nil {
+			return nil, fmt.Errorf("method %s: default value of argument %d: %w", m.GetSignature(), i, err)
+		}
+		methodArgs[i] = value
+	}
+	return methodArgs, nil
+}
+
+// defaultValue returns the default value of an argument, from a literal or
+// the state of the application.
+func (c *AppClient) defaultValue(ctx context.Context, arg MethodArg) (interface{}, error) {
+	d := arg.DefaultValue
+	typ := d.Type
+	if typ == "" {
+		typ = arg.Type
+	}
+	if d.Source == DefaultValueMethod {
+		return nil, fmt.Errorf("default values from methods are not supported, call %s", d.Data)
+	}
+	data, err := base64.StdEncoding.DecodeString(d.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var state []models.TealKeyValue
+	switch d.Source {
+	case DefaultValueLiteral:
+		return decodeStored(typ, data)
+	case DefaultValueBox:
+		box, err := c.Algod.GetApplicationBoxByName(c.AppID, data).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return decodeStored(typ, box.Value)
+	case DefaultValueGlobal:
+		app, err := c.Algod.GetApplicationByID(c.AppID).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		state = app.Params.GlobalState
+	case DefaultValueLocal:
+		info, err := c.Algod.AccountApplicationInformation(c.Sender.String(), c.AppID).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		state = info.AppLocalState.KeyValue
+	default:
+		return nil, fmt.Errorf("invalid default value source %q", d.Source)
+	}
+
+	key := base64.StdEncoding.EncodeToString(data)
+	for _, kv := range state {
+		if kv.Key != key {
+			continue
+		}
+		if kv.Value.Type == uint64(types.TealUintType) {
+			return kv.Value.Uint, nil
+		}
+		value, err := base64.StdEncoding.DecodeString(kv.Value.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return decodeStored(typ, value)
+	}
+	return nil, fmt.Errorf("no %s state with the key %s", d.Source, d.Data)
+}
+
+// decodeStored decodes a stored value of an AVM or ABI type.
+func decodeStored(typ string, value []byte) (interface{}, error) {
+	switch typ {
+	case AVMBytes:
+		return value, nil
+	case AVMString:
+		return string(value), nil
+	case AVMUint64:
+		if len(value) != 8 {
+			return nil, fmt.Errorf("integer value of %d bytes, expected 8", len(value))
+		}
+		return binary.BigEndian.Uint64(value), nil
+	}
+	abiType, err := abi.TypeOf(typ)
+	if err != nil {
+		return nil, err
+	}
+	return abiType.Decode(value)
+}
+
+// LogicError is the failure of the approval program of an application at a
+// program counter whose source information has an error message.
+type LogicError struct {
+	Err     error
+	PC      uint64
+	Message string
+}
+
+func (e *LogicError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Message, e.Err)
+}
+
+func (e *LogicError) Unwrap() error {
+	return e.Err
+}
+
+var pcPattern = regexp.MustCompile(`pc=(\d+)`)
+
+// logicError returns a *LogicError wrapping err if it tells the approval
+// program failed at a program counter with an error message, and err
+// otherwise.
+func (c *AppClient) logicError(ctx context.Context, err error) error {
+	if c.Spec.SourceInfo == nil {
+		return err
+	}
+	match := pcPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+	pc, parseErr := strconv.ParseUint(match[1], 10, 64)
+	if parseErr != nil {
+		return err
+	}
+
+	program := c.approval
+	if program == nil && c.Spec.SourceInfo.Approval.PCOffsetMethod == PCOffsetCBlocks && c.AppID != 0 {
+		app, appErr := c.Algod.GetApplicationByID(c.AppID).Do(ctx)
+		if appErr != nil {
+			return err
+		}
+		program = app.Params.ApprovalProgram
+	}
+	message, ok := c.Spec.SourceInfo.Approval.ErrorMessage(program, pc)
+	if !ok {
+		return err
+	}
+	return &LogicError{Err: err, PC: pc, Message: message}
+}
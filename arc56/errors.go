@@ -0,0 +1,9 @@
+package arc56
+
+import (
+	"errors"
+)
+
+var errNoName = errors.New("an ARC-56 specification must have a name")
+var errNoSource = errors.New("the specification has no TEAL source to compile its programs with template variables")
+var errTruncatedProgram = errors.New("program ends within a constant block")
@@ -0,0 +1,477 @@
+package arc56
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/algorand/go-algorand-sdk/v2/abi"
+)
+
+// Generate returns the Go source, in package packageName, of a typed client of
+// the application specified by c. The client embeds an AppClient and has, for
+// each method of the application:
+//
+//   - a function named after the method if it may be called on an existing
+//     application
+//   - a function named Compose followed by the name of the method, adding
+//     such a call to an AtomicTransactionComposer, to be executed in a group
+//   - a function named Create followed by the name of the method if it may
+//     create the application, taking CallParams for the template values
+//
+// and CreateBare if a bare call may create the application. Arguments and
+// return values are typed: ABI types with a natural Go type map to it, such as
+// uint64, string, bool, []byte and types.Address, arrays map to Go slices and
+// arrays, the structs of the specification map to Go structs generated along
+// with the client, and other tuples map to Go structs whose fields are named
+// after their position.
+func Generate(c Contract, packageName string) ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if !token.IsIdentifier(packageName) {
+		return nil, fmt.Errorf("invalid package name %q", packageName)
+	}
+	spec, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	data := generateData{
+		Package:    packageName,
+		Name:       exportedIdentifier(c.Name, "App"),
+		SpecName:   c.Name,
+		Desc:       commentLines(c.Desc),
+		Spec:       strconv.Quote(string(spec)),
+		BareCreate: len(c.BareActions.Create) > 0,
+	}
+	g := generator{structs: map[string]string{}}
+	typeNames := map[string]bool{data.Name + "Spec": true, data.Name + "Client": true, "New" + data.Name + "Client": true}
+	structNames := make([]string, 0, len(c.Structs))
+	for name := range c.Structs {
+		structNames = append(structNames, name)
+	}
+	sort.Strings(structNames)
+	for _, name := range structNames {
+		g.structs[name] = uniqueIdentifier(typeNames, exportedIdentifier(name, "Struct"))
+	}
+	for _, name := range structNames {
+		typ, isBig, err := g.structType(c.Structs[name])
+		if err != nil {
+			return nil, fmt.Errorf("struct %s: %w", name, err)
+		}
+		data.BigInt = data.BigInt || isBig
+		data.Structs = append(data.Structs, generateStruct{GoName: g.structs[name], Name: name, Type: typ})
+	}
+
+	used := map[string]bool{"CreateBare": data.BareCreate}
+	for _, m := range c.Methods {
+		method, err := g.makeGenerateMethod(m)
+		if err != nil {
+			return nil, err
+		}
+		if method.HasBigInt {
+			data.BigInt = true
+		}
+		if len(m.Actions.Call) > 0 {
+			call := method
+			call.GoName = uniqueIdentifier(used, exportedIdentifier(m.Name, "Method"))
+			compose := method
+			compose.GoName = uniqueIdentifier(used, "Compose"+call.GoName)
+			compose.Compose = true
+			data.Methods = append(data.Methods, call, compose)
+		}
+		if len(m.Actions.Create) > 0 {
+			create := method
+			create.GoName = uniqueIdentifier(used, "Create"+exportedIdentifier(m.Name, "Method"))
+			create.Create = true
+			data.Methods = append(data.Methods, create)
+		}
+	}
+
+	var source bytes.Buffer
+	if err := clientTemplate.Execute(&source, data); err != nil {
+		return nil, err
+	}
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid Go source: %w", err)
+	}
+	return formatted, nil
+}
+
+type generateData struct {
+	Package    string
+	Name       string
+	SpecName   string
+	Desc       []string
+	Spec       string
+	BareCreate bool
+	BigInt     bool
+	Structs    []generateStruct
+	Methods    []generateMethod
+}
+
+type generateStruct struct {
+	GoName string
+	Name   string
+	Type   string
+}
+
+type generateMethod struct {
+	GoName    string
+	Signature string
+	Desc      []string
+	Args      []generateArg
+	Create    bool
+	Compose   bool
+
+	// Return is the Go type of the return value, empty for void.
+	Return    string
+	HasBigInt bool
+}
+
+type generateArg struct {
+	Name string
+	Type string
+
+	// Convert is whether the argument is passed through ABIValue, being a
+	// struct or an array of them.
+	Convert bool
+}
+
+// generator gives the Go types of ABI types, the structs of the
+// specification being the Go structs generated for them.
+type generator struct {
+	// structs maps the name of a struct in the specification to that of its
+	// Go type.
+	structs map[string]string
+}
+
+func (g generator) makeGenerateMethod(m Method) (generateMethod, error) {
+	method := generateMethod{
+		Signature: m.GetSignature(),
+		Desc:      commentLines(m.Desc),
+	}
+	used := map[string]bool{"ctx": true, "atc": true, "params": true, "c": true, "result": true, "value": true, "raw": true, "err": true}
+	for i, arg := range m.Args {
+		typ, isBig, err := g.valueType(arg.Type, arg.Struct)
+		if err != nil {
+			return generateMethod{}, fmt.Errorf("method %s: %w", method.Signature, err)
+		}
+		method.HasBigInt = method.HasBigInt || isBig
+		name := unexportedIdentifier(arg.Name)
+		if name == "" || used[name] {
+			name = "arg" + strconv.Itoa(i)
+		}
+		used[name] = true
+		convert := arg.Struct != "" || !abi.IsTransactionType(arg.Type) && isComposite(typ)
+		method.Args = append(method.Args, generateArg{Name: name, Type: typ, Convert: convert})
+	}
+
+	if m.Returns.Type == abi.VoidReturnType {
+		return method, nil
+	}
+	typ, isBig, err := g.valueType(m.Returns.Type, m.Returns.Struct)
+	if err != nil {
+		return generateMethod{}, fmt.Errorf("method %s: %w", method.Signature, err)
+	}
+	method.HasBigInt = method.HasBigInt || isBig
+	method.Return = typ
+	return method, nil
+}
+
+// isComposite returns whether the Go type typ of an ABI type is a struct or
+// an array other than of bytes, so that its values are passed through
+// ABIValue.
+func isComposite(typ string) bool {
+	return strings.HasPrefix(typ, "struct") || strings.HasPrefix(typ, "[") && !strings.HasSuffix(typ, "]byte")
+}
+
+// valueType returns the Go type of an argument or return value of type typ,
+// or of the struct structName if not empty, and whether it uses *big.Int.
+func (g generator) valueType(typ, structName string) (string, bool, error) {
+	if structName != "" {
+		name, ok := g.structs[structName]
+		if !ok {
+			return "", false, fmt.Errorf("unknown struct %s", structName)
+		}
+		return name, false, nil
+	}
+	return g.goType(typ)
+}
+
+// structType returns the Go struct type of the fields of a struct of the
+// specification, and whether it uses *big.Int.
+func (g generator) structType(fields []StructField) (string, bool, error) {
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	hasBig := false
+	used := map[string]bool{}
+	for i, f := range fields {
+		var typ string
+		var isBig bool
+		var err error
+		if f.Fields != nil {
+			typ, isBig, err = g.structType(f.Fields)
+		} else if name, ok := g.structs[f.Type]; ok {
+			typ = name
+		} else {
+			typ, isBig, err = g.goType(f.Type)
+		}
+		if err != nil {
+			return "", false, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		hasBig = hasBig || isBig
+		name := uniqueIdentifier(used, exportedIdentifier(f.Name, "Field"+strconv.Itoa(i)))
+		fmt.Fprintf(&b, "%s %s\n", name, typ)
+	}
+	b.WriteString("}")
+	return b.String(), hasBig, nil
+}
+
+// goType returns the Go type of an argument or return value of an ABI,
+// transaction or reference type, and whether it uses *big.Int.
+func (g generator) goType(typ string) (string, bool, error) {
+	switch {
+	case abi.IsTransactionType(typ):
+		return "transaction.TransactionWithSigner", false, nil
+	case typ == abi.AccountReferenceType:
+		return "types.Address", false, nil
+	case typ == abi.AssetReferenceType, typ == abi.ApplicationReferenceType:
+		return "uint64", false, nil
+	case typ == "bool", typ == "string":
+		return typ, false, nil
+	case typ == "byte":
+		return "byte", false, nil
+	case typ == "address":
+		return "types.Address", false, nil
+	case typ == "byte[]" || strings.HasPrefix(typ, "byte[") && strings.HasSuffix(typ, "]") && !strings.Contains(typ[5:], "["):
+		return "[]byte", false, nil
+	}
+	if _, err := abi.TypeOf(typ); err != nil {
+		return "", false, err
+	}
+
+	if strings.HasSuffix(typ, "]") {
+		open := strings.LastIndex(typ, "[")
+		elem, isBig, err := g.goType(typ[:open])
+		if err != nil {
+			return "", false, err
+		}
+		return typ[open:] + elem, isBig, nil
+	}
+	if strings.HasPrefix(typ, "(") {
+		var b strings.Builder
+		b.WriteString("struct {\n")
+		hasBig := false
+		for i, child := range splitTupleTypes(typ) {
+			childType, isBig, err := g.goType(child)
+			if err != nil {
+				return "", false, err
+			}
+			hasBig = hasBig || isBig
+			fmt.Fprintf(&b, "Field%d %s\n", i, childType)
+		}
+		b.WriteString("}")
+		return b.String(), hasBig, nil
+	}
+
+	var bits int
+	if rest, ok := strings.CutPrefix(typ, "uint"); ok {
+		bits, _ = strconv.Atoi(rest)
+	} else if rest, ok := strings.CutPrefix(typ, "ufixed"); ok {
+		bits, _ = strconv.Atoi(strings.SplitN(rest, "x", 2)[0])
+	}
+	switch {
+	case bits <= 8:
+		return "uint8", false, nil
+	case bits <= 16:
+		return "uint16", false, nil
+	case bits <= 32:
+		return "uint32", false, nil
+	case bits <= 64:
+		return "uint64", false, nil
+	default:
+		return "*big.Int", true, nil
+	}
+}
+
+// splitTupleTypes returns the types of the elements of a valid tuple type.
+func splitTupleTypes(tuple string) []string {
+	inner := tuple[1 : len(tuple)-1]
+	if inner == "" {
+		return nil
+	}
+	var types []string
+	depth, start := 0, 0
+	for i, r := range inner {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				types = append(types, inner[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(types, inner[start:])
+}
+
+// exportedIdentifier returns name as an exported Go identifier in camel case,
+// prefixed with fallback if it does not start with a letter, or fallback if
+// it has no letters or digits.
+func exportedIdentifier(name, fallback string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	id := b.String()
+	if id == "" {
+		return fallback
+	}
+	if !unicode.IsLetter([]rune(id)[0]) {
+		return fallback + id
+	}
+	return id
+}
+
+// unexportedIdentifier returns name as an unexported Go identifier, or an
+// empty string if that is not a valid identifier.
+func unexportedIdentifier(name string) string {
+	id := exportedIdentifier(name, "")
+	if id == "" {
+		return ""
+	}
+	runes := []rune(id)
+	runes[0] = unicode.ToLower(runes[0])
+	id = string(runes)
+	if !token.IsIdentifier(id) || token.IsKeyword(id) {
+		return ""
+	}
+	return id
+}
+
+// uniqueIdentifier returns id, followed by a number if already used, and
+// marks it used.
+func uniqueIdentifier(used map[string]bool, id string) string {
+	unique := id
+	for i := 2; used[unique]; i++ {
+		unique = id + strconv.Itoa(i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// commentLines returns the lines of a description, to be written as comments.
+func commentLines(desc string) []string {
+	desc = strings.TrimSpace(desc)
+	if desc == "" {
+		return nil
+	}
+	return strings.Split(desc, "\n")
+}
+
+var clientTemplate = template.Must(template.New("client").Parse(`// Code generated by arc56.Generate. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+{{- if .BigInt}}
+	"math/big"
+{{- end}}
+
+	"github.com/algorand/go-algorand-sdk/v2/arc56"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// {{.Name}}Spec is the ARC-56 specification of {{.SpecName}}.
+const {{.Name}}Spec = {{.Spec}}
+{{range .Structs}}
+// {{.GoName}} is the struct {{.Name}} of {{$.SpecName}}.
+type {{.GoName}} {{.Type}}
+{{end}}
+// {{.Name}}Client calls {{.SpecName}}.
+{{- if .Desc}}
+//
+{{- range .Desc}}
+// {{.}}
+{{- end}}
+{{- end}}
+type {{.Name}}Client struct {
+	arc56.AppClient
+}
+
+// New{{.Name}}Client returns a client of the application appID, zero until
+// created, sending calls from sender signed by signer.
+func New{{.Name}}Client(algodClient *algod.Client, appID uint64, sender types.Address, signer transaction.TransactionSigner) (*{{.Name}}Client, error) {
+	spec, err := arc56.Parse([]byte({{.Name}}Spec))
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Name}}Client{arc56.AppClient{Spec: spec, AppID: appID, Algod: algodClient, Sender: sender, Signer: signer}}, nil
+}
+{{if .BareCreate}}
+// CreateBare creates the application with a bare call.
+func (c *{{.Name}}Client) CreateBare(ctx context.Context, params arc56.CallParams) error {
+	_, err := c.AppClient.CallBare(ctx, params)
+	return err
+}
+{{end}}
+{{- range .Methods}}
+{{- if .Compose}}
+// {{.GoName}} adds a call of {{.Signature}} to atc.
+func (c *{{$.Name}}Client) {{.GoName}}(ctx context.Context, atc *transaction.AtomicTransactionComposer{{range .Args}}, {{.Name}} {{.Type}}{{end}}) error {
+	return c.AppClient.AddMethodCall(ctx, atc, arc56.CallParams{}, {{printf "%q" .Signature}}{{template "args" .Args}})
+}
+{{else}}
+// {{.GoName}} {{if .Create}}creates the application calling{{else}}calls{{end}} {{.Signature}}.
+{{- if .Desc}}
+//
+{{- range .Desc}}
+// {{.}}
+{{- end}}
+{{- end}}
+func (c *{{$.Name}}Client) {{.GoName}}(ctx context.Context{{if .Create}}, params arc56.CallParams{{end}}{{range .Args}}, {{.Name}} {{.Type}}{{end}}) ({{if .Return}}{{.Return}}, {{end}}error) {
+{{- if .Return}}
+	var value {{.Return}}
+{{- end}}
+	{{if .Return}}result{{else}}_{{end}}, err := c.AppClient.CallWithParams(ctx, {{if .Create}}params{{else}}arc56.CallParams{}{{end}}, {{printf "%q" .Signature}}{{template "args" .Args}})
+{{- if .Return}}
+	if err != nil {
+		return value, err
+	}
+	if result.DecodeError != nil {
+		return value, result.DecodeError
+	}
+	err = arc56.DecodeABIValue(result.ReturnValue, &value)
+	return value, err
+{{- else}}
+	return err
+{{- end}}
+}
+{{end}}
+{{- end}}
+{{- define "args"}}{{range .}}, {{if .Convert}}arc56.ABIValue({{.Name}}){{else}}{{.Name}}{{end}}{{end}}{{end}}`))
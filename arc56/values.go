@@ -0,0 +1,83 @@
+package arc56
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ABIValue returns v as a value to encode with abi.Type.Encode. Go structs,
+// such as those generated by Generate for ARC-56 structs and ABI tuples,
+// become the []interface{} of their fields, in order, and so do the elements
+// of slices and arrays of them. Other values are returned as they are.
+func ABIValue(v interface{}) interface{} {
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Struct:
+		fields := make([]interface{}, value.NumField())
+		for i := range fields {
+			fields[i] = ABIValue(value.Field(i).Interface())
+		}
+		return fields
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return v
+		}
+		elems := make([]interface{}, value.Len())
+		for i := range elems {
+			elems[i] = ABIValue(value.Index(i).Interface())
+		}
+		return elems
+	default:
+		return v
+	}
+}
+
+// DecodeABIValue stores decoded, a value returned by abi.Type.Decode, in the
+// value pointed to by target, whose type is the Go type Generate gives to the
+// ABI type. It returns an error if decoded does not fit that type.
+func DecodeABIValue(decoded interface{}, target interface{}) error {
+	ptr := reflect.ValueOf(target)
+	if ptr.Kind() != reflect.Pointer || ptr.IsNil() {
+		return fmt.Errorf("cannot decode into %T, not a non-nil pointer", target)
+	}
+	return decodeABIValue(decoded, ptr.Elem())
+}
+
+func decodeABIValue(decoded interface{}, target reflect.Value) error {
+	value := reflect.ValueOf(decoded)
+	if value.IsValid() && value.Type().AssignableTo(target.Type()) {
+		target.Set(value)
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.Struct:
+		fields, ok := decoded.([]interface{})
+		if !ok || len(fields) != target.NumField() {
+			return fmt.Errorf("cannot decode %T into %s", decoded, target.Type())
+		}
+		for i, field := range fields {
+			if err := decodeABIValue(field, target.Field(i)); err != nil {
+				return fmt.Errorf("field %s: %w", target.Type().Field(i).Name, err)
+			}
+		}
+		return nil
+	case reflect.Slice, reflect.Array:
+		if value.Kind() != reflect.Slice {
+			return fmt.Errorf("cannot decode %T into %s", decoded, target.Type())
+		}
+		if target.Kind() == reflect.Slice {
+			target.Set(reflect.MakeSlice(target.Type(), value.Len(), value.Len()))
+		} else if value.Len() != target.Len() {
+			return fmt.Errorf("cannot decode %d values into %s", value.Len(), target.Type())
+		}
+		for i := 0; i < value.Len(); i++ {
+			if err := decodeABIValue(value.Index(i).Interface(), target.Index(i)); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("cannot decode %T into %s", decoded, target.Type())
+	}
+}
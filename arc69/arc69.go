@@ -0,0 +1,130 @@
+// Package arc69 encodes and parses ARC-69 asset metadata, the JSON carried in
+// the note of asset config transactions:
+//
+//	{"standard": "arc69", "description": "...", "media_url": "...", "properties": {...}}
+//
+// The current metadata of an asset is the one of its latest asset config
+// transaction, which LatestMetadata finds with the indexer.
+package arc69
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/v2/arc3"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/indexer"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// Standard is the value of the standard field of ARC-69 metadata.
+const Standard = "arc69"
+
+// maxNoteLength is the largest note the network accepts on a transaction.
+const maxNoteLength = 1024
+
+// Metadata is the ARC-69 metadata of an asset. All fields but Standard are
+// optional.
+type Metadata struct {
+	// Standard is always "arc69", Note sets it if empty.
+	Standard string `json:"standard"`
+
+	Description string `json:"description,omitempty"`
+	ExternalURL string `json:"external_url,omitempty"`
+	MediaURL    string `json:"media_url,omitempty"`
+
+	// MimeType is the mime type of MediaURL, such as image/png.
+	MimeType string `json:"mime_type,omitempty"`
+
+	// Properties are arbitrary attributes of the asset.
+	Properties map[string]interface{} `json:"properties,omitempty"`
+
+	// Attributes are attributes of the asset in the array form used by some
+	// marketplaces.
+	Attributes []Attribute `json:"attributes,omitempty"`
+}
+
+// Attribute is a trait of an asset.
+type Attribute struct {
+	TraitType   string      `json:"trait_type"`
+	Value       interface{} `json:"value"`
+	DisplayType string      `json:"display_type,omitempty"`
+}
+
+// Validate checks that m is ARC-69 metadata with well formed fields.
+func (m Metadata) Validate() error {
+	if m.Standard != Standard {
+		return errNotARC69
+	}
+	if m.MimeType != "" {
+		if err := arc3.ValidateMimeType(m.MimeType); err != nil {
+			return fmt.Errorf("invalid mime_type: %w", err)
+		}
+	}
+	for i, a := range m.Attributes {
+		if a.TraitType == "" {
+			return fmt.Errorf("attribute %d has no trait_type", i)
+		}
+	}
+	return nil
+}
+
+// Note returns the note of an asset config transaction setting m as the
+// metadata of its asset.
+func (m Metadata) Note() ([]byte, error) {
+	if m.Standard == "" {
+		m.Standard = Standard
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	note, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	if len(note) > maxNoteLength {
+		return nil, fmt.Errorf("ARC-69 note is %d bytes, at most %d are allowed", len(note), maxNoteLength)
+	}
+	return note, nil
+}
+
+// ParseNote parses and validates the ARC-69 metadata in the note of an asset
+// config transaction.
+func ParseNote(note []byte) (Metadata, error) {
+	var m Metadata
+	if err := json.Unmarshal(bytes.TrimSpace(note), &m); err != nil {
+		return Metadata{}, fmt.Errorf("invalid ARC-69 note: %w", err)
+	}
+	if err := m.Validate(); err != nil {
+		return Metadata{}, err
+	}
+	return m, nil
+}
+
+// LatestMetadata returns the current metadata of an asset, the one in the note
+// of its latest asset config transaction, along with that transaction. As
+// ARC-69 makes the latest note authoritative, the asset has no metadata if that
+// note is not ARC-69 metadata, whatever earlier transactions carried.
+func LatestMetadata(ctx context.Context, client *indexer.Client, assetID uint64) (Metadata, models.Transaction, error) {
+	var latestTxn models.Transaction
+	found := false
+
+	// the indexer returns transactions from the oldest
+	it := client.LookupAssetTransactions(assetID).TxType(string(types.AssetConfigTx)).Iterator(ctx)
+	for it.Next() {
+		latestTxn, found = it.Item(), true
+	}
+	if err := it.Err(); err != nil {
+		return Metadata{}, models.Transaction{}, err
+	}
+	if !found {
+		return Metadata{}, models.Transaction{}, errNoMetadata
+	}
+	m, err := ParseNote(latestTxn.Note)
+	if err != nil {
+		return Metadata{}, latestTxn, fmt.Errorf("%w: %v", errNoMetadata, err)
+	}
+	return m, latestTxn, nil
+}
@@ -0,0 +1,84 @@
+package arc69
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/indexer"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+)
+
+func TestNote(t *testing.T) {
+	m := Metadata{
+		Description: "An NFT",
+		MediaURL:    "ipfs://image.png",
+		MimeType:    "image/png",
+		Properties:  map[string]interface{}{"rarity": "rare"},
+		Attributes:  []Attribute{{TraitType: "level", Value: 3.0, DisplayType: "number"}},
+	}
+	note, err := m.Note()
+	require.NoError(t, err)
+
+	parsed, err := ParseNote(note)
+	require.NoError(t, err)
+	m.Standard = Standard
+	require.Equal(t, m, parsed)
+
+	_, err = ParseNote([]byte(`{"standard":"arc3"}`))
+	require.ErrorIs(t, err, errNotARC69)
+	_, err = ParseNote([]byte(`arc69:j{}`))
+	require.Error(t, err)
+	_, err = Metadata{MimeType: "png"}.Note()
+	require.Error(t, err)
+	_, err = Metadata{Attributes: []Attribute{{Value: 1}}}.Note()
+	require.Error(t, err)
+	_, err = Metadata{Description: string(make([]byte, maxNoteLength))}.Note()
+	require.Error(t, err)
+}
+
+func TestLatestMetadata(t *testing.T) {
+	notes := [][]byte{
+		[]byte(`{"standard":"arc69","description":"first"}`),
+		[]byte(`{"standard":"arc69","description":"second"}`),
+		[]byte(`not metadata`),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/assets/42/transactions", r.URL.Path)
+		require.Equal(t, "acfg", r.URL.Query().Get("tx-type"))
+		// one transaction per page
+		var response models.TransactionsResponse
+		i := 0
+		if r.URL.Query().Get("next") != "" {
+			i = int(r.URL.Query().Get("next")[0] - '0')
+		}
+		if i < len(notes) {
+			response.Transactions = []models.Transaction{{ConfirmedRound: uint64(i + 1), Note: notes[i]}}
+			response.NextToken = string(rune('0' + i + 1))
+		}
+		w.Write(json.Encode(response))
+	}))
+	defer server.Close()
+
+	client, err := indexer.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	// the latest note is authoritative, so older metadata does not apply
+	_, txn, err := LatestMetadata(context.Background(), client, 42)
+	require.ErrorIs(t, err, errNoMetadata)
+	require.Equal(t, uint64(3), txn.ConfirmedRound)
+
+	notes = notes[:2]
+	m, txn, err := LatestMetadata(context.Background(), client, 42)
+	require.NoError(t, err)
+	require.Equal(t, "second", m.Description)
+	require.Equal(t, uint64(2), txn.ConfirmedRound)
+
+	notes = nil
+	_, _, err = LatestMetadata(context.Background(), client, 42)
+	require.ErrorIs(t, err, errNoMetadata)
+}
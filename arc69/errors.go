@@ -0,0 +1,8 @@
+package arc69
+
+import (
+	"errors"
+)
+
+var errNotARC69 = errors.New(`note is not ARC-69 metadata, its standard must be "arc69"`)
+var errNoMetadata = errors.New("the latest asset config transaction of the asset has no ARC-69 metadata")
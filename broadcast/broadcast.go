@@ -0,0 +1,434 @@
+// Package broadcast sends signed transactions at a high rate, for bots and
+// load tests. A Broadcaster sends groups concurrently through several algod
+// clients, retries sends failing for a transient reason, and reports the
+// confirmation of each group through a callback.
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// Defaults of the zero fields of a Config.
+const (
+	DefaultWorkers     = 8
+	DefaultQueueSize   = 1024
+	DefaultMaxAttempts = 5
+	DefaultRetryDelay  = 250 * time.Millisecond
+	DefaultWaitRounds  = 10
+)
+
+// Config configures a Broadcaster. Zero fields take their default.
+type Config struct {
+	// Workers is the number of groups sent concurrently through each client.
+	Workers int
+	// QueueSize is the number of groups queued before Send blocks.
+	QueueSize int
+	// MaxAttempts is the number of times a group is sent before giving up.
+	MaxAttempts int
+	// RetryDelay is the delay before sending a group again, doubling after
+	// each attempt.
+	RetryDelay time.Duration
+	// WaitRounds is the number of rounds to wait for a sent group to be
+	// confirmed.
+	WaitRounds uint64
+	// Retryable reports whether a send failing with an error is worth
+	// retrying, IsRetryable if nil.
+	Retryable func(error) bool
+}
+
+// Result is the outcome of sending a group.
+type Result struct {
+	// TxIDs are the IDs of the transactions of the group.
+	TxIDs []string
+	// ConfirmedRound is the round the group was confirmed in, zero if Err
+	// is set.
+	ConfirmedRound uint64
+	// Err is why the group could not be sent or was not confirmed.
+	Err error
+}
+
+// Callback receives the result of sending a group. It is called from the
+// goroutines of the Broadcaster, and should return quickly.
+type Callback func(Result)
+
+// IsRetryable reports whether sending a group may succeed later after failing
+// with err: the transaction pool of the node is full, the node is overloaded,
+// or the node is not at a round the group is valid in yet.
+func IsRetryable(err error) bool {
+	if errors.Is(err, common.OverloadedError{}) {
+		return true
+	}
+	var httpErr *common.HTTPError
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+	message := strings.ToLower(httpErr.Message)
+	return strings.Contains(message, "txn dead") ||
+		strings.Contains(message, "pool is full") ||
+		strings.Contains(message, "pool have reached capacity") ||
+		strings.Contains(message, "pool has reached capacity")
+}
+
+// isInLedger reports whether a send failed because the group was already
+// confirmed, as happens when retrying a send that succeeded unnoticed.
+func isInLedger(err error) bool {
+	var httpErr *common.HTTPError
+	return errors.As(err, &httpErr) && strings.Contains(httpErr.Message, "already in ledger")
+}
+
+type group struct {
+	raw      []byte
+	txids    []string
+	callback Callback
+	// deadline is the last round to wait for the confirmation of the group,
+	// zero until the tracker sees it.
+	deadline uint64
+}
+
+// Broadcaster sends groups of signed transactions. Its methods may be called
+// concurrently.
+type Broadcaster struct {
+	clients []*algod.Client
+	config  Config
+
+	queueMu sync.RWMutex
+	queue   chan *group
+	closed  bool
+	senders sync.WaitGroup
+
+	mu       sync.Mutex
+	tracked  []*group
+	closing  bool
+	stop     context.CancelFunc
+	trackers sync.WaitGroup
+
+	// confirmed are the IDs of the transactions confirmed in the last
+	// WaitRounds rounds checked by the tracker, by round, for groups it sees
+	// after their confirmation. They are only used by the tracker.
+	confirmed map[uint64]map[string]bool
+	// checked is the last round checked by the tracker.
+	checked uint64
+}
+
+// New returns a Broadcaster sending through clients, which may be clients of
+// different nodes, and starts its goroutines. Close stops them.
+func New(clients []*algod.Client, config Config) (*Broadcaster, error) {
+	if len(clients) == 0 {
+		return nil, errNoClients
+	}
+	if config.Workers <= 0 {
+		config.Workers = DefaultWorkers
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = DefaultQueueSize
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = DefaultRetryDelay
+	}
+	if config.WaitRounds == 0 {
+		config.WaitRounds = DefaultWaitRounds
+	}
+	if config.Retryable == nil {
+		config.Retryable = IsRetryable
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+	b := &Broadcaster{
+		clients:   clients,
+		config:    config,
+		queue:     make(chan *group, config.QueueSize),
+		stop:      stop,
+		confirmed: make(map[uint64]map[string]bool),
+	}
+	for _, client := range clients {
+		for i := 0; i < config.Workers; i++ {
+			b.senders.Add(1)
+			go b.send(ctx, client)
+		}
+	}
+	b.trackers.Add(1)
+	go b.track(ctx)
+	return b, nil
+}
+
+// Send queues a group of signed transactions, already grouped if more than
+// one, and returns their IDs. If callback is not nil, it receives the result
+// once the group is confirmed or failed; otherwise the group is only sent.
+// Send blocks while the queue is full, until ctx is done.
+func (b *Broadcaster) Send(ctx context.Context, stxns []types.SignedTxn, callback Callback) ([]string, error) {
+	if len(stxns) == 0 {
+		return nil, errEmptyGroup
+	}
+	g := &group{callback: callback}
+	for _, stx := range stxns {
+		g.raw = append(g.raw, msgpack.Encode(stx)...)
+		g.txids = append(g.txids, crypto.GetTxID(stx.Txn))
+	}
+	return g.txids, b.enqueue(ctx, g)
+}
+
+// SendRaw queues a group of encoded signed transactions, as sent by
+// algod.Client.SendRawTransaction, whose IDs are txids, like Send.
+func (b *Broadcaster) SendRaw(ctx context.Context, raw []byte, txids []string, callback Callback) error {
+	if len(raw) == 0 {
+		return errEmptyGroup
+	}
+	return b.enqueue(ctx, &group{raw: raw, txids: txids, callback: callback})
+}
+
+func (b *Broadcaster) enqueue(ctx context.Context, g *group) error {
+	// Close takes queueMu to close the queue, so it cannot be closed while
+	// a group is sent to it.
+	b.queueMu.RLock()
+	defer b.queueMu.RUnlock()
+	if b.closed {
+		return errClosed
+	}
+	select {
+	case b.queue <- g:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Pending returns the number of groups sent and waiting to be confirmed.
+func (b *Broadcaster) Pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.tracked)
+}
+
+// Close stops accepting groups, and waits until the queued ones are sent and
+// the sent ones are confirmed or failed. If ctx is done first, the groups
+// still queued or waiting for confirmation fail with the error of ctx, which
+// Close returns.
+func (b *Broadcaster) Close(ctx context.Context) error {
+	b.queueMu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.queue)
+	}
+	b.queueMu.Unlock()
+
+	sent := make(chan struct{})
+	go func() {
+		b.senders.Wait()
+		b.mu.Lock()
+		b.closing = true
+		if len(b.tracked) == 0 {
+			b.stop()
+		}
+		b.mu.Unlock()
+		b.trackers.Wait()
+		close(sent)
+	}()
+
+	select {
+	case <-sent:
+		return nil
+	case <-ctx.Done():
+		b.stop()
+		<-sent
+		b.mu.Lock()
+		tracked := b.tracked
+		b.tracked = nil
+		b.mu.Unlock()
+		for _, g := range tracked {
+			g.callback(Result{TxIDs: g.txids, Err: ctx.Err()})
+		}
+		return ctx.Err()
+	}
+}
+
+// send sends the queued groups through client until the queue is closed.
+// Once ctx is cancelled, the remaining groups fail with its error.
+func (b *Broadcaster) send(ctx context.Context, client *algod.Client) {
+	defer b.senders.Done()
+	for g := range b.queue {
+		err := b.sendGroup(ctx, client, g)
+		if g.callback == nil {
+			continue
+		}
+		if err != nil {
+			g.callback(Result{TxIDs: g.txids, Err: err})
+			continue
+		}
+		b.mu.Lock()
+		b.tracked = append(b.tracked, g)
+		b.mu.Unlock()
+	}
+}
+
+// sendGroup sends g, retrying up to MaxAttempts times, until ctx is done.
+func (b *Broadcaster) sendGroup(ctx context.Context, client *algod.Client, g *group) error {
+	delay := b.config.RetryDelay
+	for attempt := 1; ; attempt++ {
+		_, err := client.SendRawTransaction(g.raw).Do(ctx)
+		if err == nil || isInLedger(err) {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if attempt >= b.config.MaxAttempts || !b.config.Retryable(err) {
+			return err
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+		delay *= 2
+	}
+}
+
+// sleep waits for d, or until ctx is done, returning its error.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// track checks the tracked groups after each block, until ctx is cancelled
+// or the Broadcaster is closed with no groups left to track.
+func (b *Broadcaster) track(ctx context.Context) {
+	defer b.trackers.Done()
+	client := b.clients[0]
+
+	var round uint64
+	for {
+		status, err := client.StatusAfterBlock(round).Do(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			if sleep(ctx, b.config.RetryDelay) != nil {
+				return
+			}
+			continue
+		}
+		round = status.LastRound
+		b.check(ctx, client, round)
+
+		b.mu.Lock()
+		done := b.closing && len(b.tracked) == 0
+		b.mu.Unlock()
+		if done {
+			return
+		}
+	}
+}
+
+// fetchConfirmed fetches the IDs of the transactions confirmed in the rounds
+// after the last checked one up to round, once per round. It returns the last
+// round fetched, which is before round if a fetch failed.
+func (b *Broadcaster) fetchConfirmed(ctx context.Context, client *algod.Client, round uint64) uint64 {
+	from := b.checked + 1
+	if b.checked == 0 || round-b.checked > b.config.WaitRounds {
+		from = 1
+		if round > b.config.WaitRounds {
+			from = round - b.config.WaitRounds
+		}
+	}
+	for r := from; r <= round; r++ {
+		txids, err := client.GetBlockTxids(r).Do(ctx)
+		if err != nil {
+			break
+		}
+		confirmed := make(map[string]bool, len(txids.Blocktxids))
+		for _, txid := range txids.Blocktxids {
+			confirmed[txid] = true
+		}
+		b.confirmed[r] = confirmed
+		b.checked = r
+	}
+	for r := range b.confirmed {
+		if r+b.config.WaitRounds < b.checked {
+			delete(b.confirmed, r)
+		}
+	}
+	return b.checked
+}
+
+// check reports the tracked groups confirmed by round, and those rejected or
+// timed out, and stops tracking them. Confirmations are found in the
+// transaction IDs of the blocks, fetched once per round. Only a group
+// reaching its deadline has its pending information fetched, to tell a
+// rejection from a timeout.
+func (b *Broadcaster) check(ctx context.Context, client *algod.Client, round uint64) {
+	checked := b.fetchConfirmed(ctx, client, round)
+
+	b.mu.Lock()
+	tracked := append([]*group(nil), b.tracked...)
+	b.mu.Unlock()
+
+	reported := make(map[*group]bool)
+	for _, g := range tracked {
+		var result *Result
+		if confirmedRound := b.confirmedRound(g.txids[0]); confirmedRound > 0 {
+			result = &Result{TxIDs: g.txids, ConfirmedRound: confirmedRound}
+		} else if g.deadline == 0 {
+			g.deadline = round + b.config.WaitRounds
+		} else if checked > g.deadline {
+			result = b.expire(ctx, client, g)
+		}
+		if result != nil {
+			g.callback(*result)
+			reported[g] = true
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	waiting := b.tracked[:0]
+	for _, g := range b.tracked {
+		if !reported[g] {
+			waiting = append(waiting, g)
+		}
+	}
+	b.tracked = waiting
+}
+
+// confirmedRound returns the round txid was confirmed in among the rounds
+// fetched, zero if none.
+func (b *Broadcaster) confirmedRound(txid string) uint64 {
+	for r, confirmed := range b.confirmed {
+		if confirmed[txid] {
+			return r
+		}
+	}
+	return 0
+}
+
+// expire returns the result of g, not seen confirmed by its deadline: the
+// node either rejected it, confirmed it in a round whose transaction IDs
+// could not be fetched, or did not confirm it.
+func (b *Broadcaster) expire(ctx context.Context, client *algod.Client, g *group) *Result {
+	info, _, err := client.PendingTransactionInformation(g.txids[0]).Do(ctx)
+	switch {
+	case err == nil && info.PoolError != "":
+		return &Result{TxIDs: g.txids, Err: fmt.Errorf("transaction %s rejected: %s", g.txids[0], info.PoolError)}
+	case err == nil && info.ConfirmedRound > 0:
+		return &Result{TxIDs: g.txids, ConfirmedRound: info.ConfirmedRound}
+	default:
+		return &Result{TxIDs: g.txids, Err: fmt.Errorf("transaction %s not confirmed by round %d", g.txids[0], g.deadline)}
+	}
+}
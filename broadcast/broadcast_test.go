@@ -0,0 +1,250 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// mockNode is an algod mock confirming sent transactions in the next round,
+// counting the fetches of the transaction IDs of each block, depending on
+// their note:
+//   - "full" fails with a full pool on the first attempt
+//   - "busy" always fails with a full pool
+//   - "bad" is refused
+//   - "reject" is rejected from the pool
+//   - "never" is never confirmed
+type mockNode struct {
+	mu       sync.Mutex
+	round    uint64
+	notes    map[string]string
+	attempts map[string]int
+	sent     map[string]uint64
+
+	txidFetches map[uint64]int
+}
+
+func (n *mockNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/v2/transactions":
+		var txids []string
+		var note string
+		err := msgpack.DecodeStream(r.Body, func(stx types.SignedTxn) error {
+			txid := crypto.GetTxID(stx.Txn)
+			txids = append(txids, txid)
+			n.notes[txid] = string(stx.Txn.Note)
+			if note == "" {
+				note = string(stx.Txn.Note)
+			}
+			return nil
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		n.attempts[txids[0]]++
+		switch {
+		case note == "full" && n.attempts[txids[0]] == 1, note == "busy":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(json.Encode(map[string]string{"message": "TransactionPool.Remember: transaction pool is full"}))
+			return
+		case note == "bad":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(json.Encode(map[string]string{"message": "overspend"}))
+			return
+		}
+		for _, txid := range txids {
+			n.sent[txid] = n.round
+		}
+		w.Write(json.Encode(models.PostTransactionsResponse{Txid: txids[0]}))
+	case strings.HasPrefix(r.URL.Path, "/v2/status/wait-for-block-after/"):
+		n.mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		n.mu.Lock()
+		n.round++
+		w.Write(json.Encode(models.NodeStatus{LastRound: n.round}))
+	case strings.HasPrefix(r.URL.Path, "/v2/blocks/") && strings.HasSuffix(r.URL.Path, "/txids"):
+		var round uint64
+		fmt.Sscanf(r.URL.Path, "/v2/blocks/%d/txids", &round)
+		if round > n.round {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		n.txidFetches[round]++
+		var txids models.BlockTxidsResponse
+		for txid, sentRound := range n.sent {
+			if sentRound+1 == round && n.notes[txid] != "reject" && n.notes[txid] != "never" {
+				txids.Blocktxids = append(txids.Blocktxids, txid)
+			}
+		}
+		w.Write(json.Encode(txids))
+	case strings.HasPrefix(r.URL.Path, "/v2/transactions/pending/"):
+		txid := strings.TrimPrefix(r.URL.Path, "/v2/transactions/pending/")
+		sentRound, ok := n.sent[txid]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var info models.PendingTransactionInfoResponse
+		switch {
+		case n.notes[txid] == "reject":
+			info.PoolError = "overspend"
+		case n.notes[txid] != "never" && n.round > sentRound:
+			info.ConfirmedRound = sentRound + 1
+		}
+		w.Write(msgpack.Encode(info))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func makeMockNode(t *testing.T) (*mockNode, []*algod.Client) {
+	node := &mockNode{
+		round:       100,
+		notes:       make(map[string]string),
+		attempts:    make(map[string]int),
+		sent:        make(map[string]uint64),
+		txidFetches: make(map[uint64]int),
+	}
+	server := httptest.NewServer(node)
+	t.Cleanup(server.Close)
+	var clients []*algod.Client
+	for i := 0; i < 2; i++ {
+		client, err := algod.MakeClient(server.URL, "")
+		require.NoError(t, err)
+		clients = append(clients, client)
+	}
+	return node, clients
+}
+
+func makeSignedTxn(note string, i int) types.SignedTxn {
+	return types.SignedTxn{Txn: types.Transaction{
+		Type:   types.PaymentTx,
+		Header: types.Header{FirstValid: types.Round(i), LastValid: 1000, Note: []byte(note)},
+	}}
+}
+
+// collector gathers results by the ID of the first transaction of groups.
+type collector struct {
+	mu      sync.Mutex
+	results map[string]Result
+}
+
+func (c *collector) callback(result Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results[result.TxIDs[0]] = result
+}
+
+func TestBroadcaster(t *testing.T) {
+	node, clients := makeMockNode(t)
+	b, err := New(clients, Config{Workers: 2, RetryDelay: time.Millisecond, WaitRounds: 3})
+	require.NoError(t, err)
+
+	c := collector{results: make(map[string]Result)}
+	var confirmed []string
+	for i := 0; i < 50; i++ {
+		txids, err := b.Send(context.Background(), []types.SignedTxn{makeSignedTxn("", i)}, c.callback)
+		require.NoError(t, err)
+		confirmed = append(confirmed, txids[0])
+	}
+	group, err := b.Send(context.Background(), []types.SignedTxn{makeSignedTxn("full", 0), makeSignedTxn("", 1000)}, c.callback)
+	require.NoError(t, err)
+	require.Len(t, group, 2)
+	confirmed = append(confirmed, group[0])
+	bad, err := b.Send(context.Background(), []types.SignedTxn{makeSignedTxn("bad", 0)}, c.callback)
+	require.NoError(t, err)
+	rejected, err := b.Send(context.Background(), []types.SignedTxn{makeSignedTxn("reject", 0)}, c.callback)
+	require.NoError(t, err)
+	never, err := b.Send(context.Background(), []types.SignedTxn{makeSignedTxn("never", 0)}, c.callback)
+	require.NoError(t, err)
+	_, err = b.Send(context.Background(), []types.SignedTxn{makeSignedTxn("", 2000)}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, b.Close(context.Background()))
+	require.Zero(t, b.Pending())
+	_, err = b.Send(context.Background(), []types.SignedTxn{makeSignedTxn("", 0)}, nil)
+	require.ErrorIs(t, err, errClosed)
+
+	require.Len(t, c.results, len(confirmed)+3)
+	for _, txid := range confirmed {
+		require.NoError(t, c.results[txid].Err)
+		require.Greater(t, c.results[txid].ConfirmedRound, uint64(100))
+	}
+	require.Equal(t, group, c.results[group[0]].TxIDs)
+	require.ErrorContains(t, c.results[bad[0]].Err, "overspend")
+	require.ErrorContains(t, c.results[rejected[0]].Err, "rejected: overspend")
+	require.ErrorContains(t, c.results[never[0]].Err, "not confirmed")
+
+	// the transaction IDs of each block are fetched once, whatever the
+	// number of groups tracked
+	node.mu.Lock()
+	defer node.mu.Unlock()
+	for round, fetches := range node.txidFetches {
+		require.Equal(t, 1, fetches, "round %d", round)
+	}
+}
+
+func TestBroadcasterClose(t *testing.T) {
+	_, clients := makeMockNode(t)
+	b, err := New(clients, Config{WaitRounds: 1000})
+	require.NoError(t, err)
+
+	c := collector{results: make(map[string]Result)}
+	never, err := b.Send(context.Background(), []types.SignedTxn{makeSignedTxn("never", 0)}, c.callback)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, b.Close(ctx), context.DeadlineExceeded)
+	require.ErrorIs(t, c.results[never[0]].Err, context.DeadlineExceeded)
+
+	// a send waiting to be retried stops once Close gives up
+	b, err = New(clients, Config{Workers: 1, RetryDelay: time.Hour})
+	require.NoError(t, err)
+	busy, err := b.Send(context.Background(), []types.SignedTxn{makeSignedTxn("busy", 0)}, c.callback)
+	require.NoError(t, err)
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	require.ErrorIs(t, b.Close(ctx), context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Minute)
+	require.ErrorIs(t, c.results[busy[0]].Err, context.Canceled)
+
+	_, err = New(nil, Config{})
+	require.ErrorIs(t, err, errNoClients)
+	require.ErrorIs(t, b.SendRaw(context.Background(), nil, nil, nil), errEmptyGroup)
+}
+
+func TestIsRetryable(t *testing.T) {
+	httpErr := func(message string) error {
+		return common.BadRequest{HTTPError: &common.HTTPError{StatusCode: http.StatusBadRequest, Message: message}}
+	}
+	require.True(t, IsRetryable(httpErr("TransactionPool.Remember: transaction pool is full")))
+	require.True(t, IsRetryable(httpErr("TransactionPool.checkPendingQueueSize: transaction pool have reached capacity")))
+	require.True(t, IsRetryable(httpErr("txn dead: round 5 outside of 10--1010")))
+	require.True(t, IsRetryable(common.OverloadedError{HTTPError: &common.HTTPError{StatusCode: http.StatusTooManyRequests}}))
+	require.False(t, IsRetryable(httpErr("overspend")))
+	require.False(t, IsRetryable(errors.New("transaction pool is full")))
+
+	require.True(t, isInLedger(httpErr("transaction already in ledger: ABC")))
+	require.False(t, isInLedger(httpErr("overspend")))
+}
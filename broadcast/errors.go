@@ -0,0 +1,11 @@
+package broadcast
+
+import (
+	"errors"
+)
+
+var errNoClients = errors.New("a broadcaster needs at least one algod client")
+
+var errEmptyGroup = errors.New("cannot send an empty group")
+
+var errClosed = errors.New("broadcaster is closed")
@@ -2,6 +2,7 @@ package kmd
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -40,6 +41,12 @@ func MakeClient(address string, apiToken string) (Client, error) {
 
 // DoV1Request accepts a request from kmdapi/requests and
 func (kcl Client) DoV1Request(req APIV1Request, resp APIV1Response) error {
+	return kcl.DoV1RequestWithContext(context.Background(), req, resp)
+}
+
+// DoV1RequestWithContext is DoV1Request with a context that can cancel the
+// underlying HTTP request.
+func (kcl Client) DoV1RequestWithContext(ctx context.Context, req APIV1Request, resp APIV1Response) error {
 	var body []byte
 
 	// Get the path and method for this request type
@@ -51,7 +58,7 @@ func (kcl Client) DoV1Request(req APIV1Request, resp APIV1Response) error {
 	// Encode the request
 	body = json.Encode(req)
 	fullPath := fmt.Sprintf("%s/%s", kcl.address, reqPath)
-	hreq, err := http.NewRequest(reqMethod, fullPath, bytes.NewReader(body))
+	hreq, err := http.NewRequestWithContext(ctx, reqMethod, fullPath, bytes.NewReader(body))
 	if err != nil {
 		return err
 	}
@@ -146,6 +153,12 @@ func getPathAndMethod(req APIV1Request) (reqPath string, reqMethod string, err e
 	case SignMultisigTransactionRequest:
 		reqPath = "v1/multisig/sign"
 		reqMethod = "POST"
+	case SignProgramRequest:
+		reqPath = "v1/program/sign"
+		reqMethod = "POST"
+	case SignProgramMultisigRequest:
+		reqPath = "v1/multisig/signprogram"
+		reqMethod = "POST"
 	}
 	return
 }
@@ -153,3 +153,15 @@ type SignMultisigTransactionResponse struct {
 	APIV1ResponseEnvelope
 	Multisig []byte `json:"multisig"`
 }
+
+// SignProgramResponse is the response to `POST /v1/program/sign`
+type SignProgramResponse struct {
+	APIV1ResponseEnvelope
+	Signature []byte `json:"sig"`
+}
+
+// SignProgramMultisigResponse is the response to `POST /v1/multisig/signprogram`
+type SignProgramMultisigResponse struct {
+	APIV1ResponseEnvelope
+	Multisig []byte `json:"multisig"`
+}
@@ -1,6 +1,8 @@
 package kmd
 
 import (
+	"context"
+
 	"golang.org/x/crypto/ed25519"
 
 	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
@@ -10,8 +12,13 @@ import (
 // Version returns a VersionResponse containing a list of kmd API versions
 // supported by this running kmd instance.
 func (kcl Client) Version() (resp VersionsResponse, err error) {
+	return kcl.VersionWithContext(context.Background())
+}
+
+// VersionWithContext is Version with a context that can cancel the request.
+func (kcl Client) VersionWithContext(ctx context.Context) (resp VersionsResponse, err error) {
 	req := VersionsRequest{}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
@@ -19,8 +26,14 @@ func (kcl Client) Version() (resp VersionsResponse, err error) {
 // known to kmd. Using a wallet ID returned from this endpoint, you can
 // initialize a wallet handle with client.InitWalletHandle
 func (kcl Client) ListWallets() (resp ListWalletsResponse, err error) {
+	return kcl.ListWalletsWithContext(context.Background())
+}
+
+// ListWalletsWithContext is ListWallets with a context that can cancel the
+// request.
+func (kcl Client) ListWalletsWithContext(ctx context.Context) (resp ListWalletsResponse, err error) {
 	req := ListWalletsRequest{}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
@@ -29,13 +42,19 @@ func (kcl Client) ListWallets() (resp ListWalletsResponse, err error) {
 // generated internally to kmd. CreateWallet returns a CreateWalletResponse
 // containing information about the new wallet.
 func (kcl Client) CreateWallet(walletName, walletPassword, walletDriverName string, walletMDK types.MasterDerivationKey) (resp CreateWalletResponse, err error) {
+	return kcl.CreateWalletWithContext(context.Background(), walletName, walletPassword, walletDriverName, walletMDK)
+}
+
+// CreateWalletWithContext is CreateWallet with a context that can cancel the
+// request.
+func (kcl Client) CreateWalletWithContext(ctx context.Context, walletName, walletPassword, walletDriverName string, walletMDK types.MasterDerivationKey) (resp CreateWalletResponse, err error) {
 	req := CreateWalletRequest{
 		WalletName:          walletName,
 		WalletDriverName:    walletDriverName,
 		WalletPassword:      walletPassword,
 		MasterDerivationKey: walletMDK,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
@@ -47,21 +66,33 @@ func (kcl Client) CreateWallet(walletName, walletPassword, walletDriverName stri
 // RenewWalletHandle. It is good practice to call ReleaseWalletHandle when
 // you're done interacting with this wallet.
 func (kcl Client) InitWalletHandle(walletID, walletPassword string) (resp InitWalletHandleResponse, err error) {
+	return kcl.InitWalletHandleWithContext(context.Background(), walletID, walletPassword)
+}
+
+// InitWalletHandleWithContext is InitWalletHandle with a context that can
+// cancel the request.
+func (kcl Client) InitWalletHandleWithContext(ctx context.Context, walletID, walletPassword string) (resp InitWalletHandleResponse, err error) {
 	req := InitWalletHandleRequest{
 		WalletID:       walletID,
 		WalletPassword: walletPassword,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
 // ReleaseWalletHandle invalidates the passed wallet handle token, making
 // it unusable for subsequent wallet operations.
 func (kcl Client) ReleaseWalletHandle(walletHandle string) (resp ReleaseWalletHandleResponse, err error) {
+	return kcl.ReleaseWalletHandleWithContext(context.Background(), walletHandle)
+}
+
+// ReleaseWalletHandleWithContext is ReleaseWalletHandle with a context that can
+// cancel the request.
+func (kcl Client) ReleaseWalletHandleWithContext(ctx context.Context, walletHandle string) (resp ReleaseWalletHandleResponse, err error) {
 	req := ReleaseWalletHandleRequest{
 		WalletHandleToken: walletHandle,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
@@ -70,32 +101,49 @@ func (kcl Client) ReleaseWalletHandle(walletHandle string) (resp ReleaseWalletHa
 // RenewWalletHandleResponse containing the walletHandle and the number of
 // seconds until expiration
 func (kcl Client) RenewWalletHandle(walletHandle string) (resp RenewWalletHandleResponse, err error) {
+	return kcl.RenewWalletHandleWithContext(context.Background(), walletHandle)
+}
+
+// RenewWalletHandleWithContext is RenewWalletHandle with a context that can
+// cancel the request.
+func (kcl Client) RenewWalletHandleWithContext(ctx context.Context, walletHandle string) (resp RenewWalletHandleResponse, err error) {
 	req := RenewWalletHandleRequest{
 		WalletHandleToken: walletHandle,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
 // RenameWallet accepts a wallet ID, wallet password, and a new wallet name,
 // and renames the underlying wallet.
 func (kcl Client) RenameWallet(walletID, walletPassword, newWalletName string) (resp RenameWalletResponse, err error) {
+	return kcl.RenameWalletWithContext(context.Background(), walletID, walletPassword, newWalletName)
+}
+
+// RenameWalletWithContext is RenameWallet with a context that can cancel the
+// request.
+func (kcl Client) RenameWalletWithContext(ctx context.Context, walletID, walletPassword, newWalletName string) (resp RenameWalletResponse, err error) {
 	req := RenameWalletRequest{
 		WalletID:       walletID,
 		WalletPassword: walletPassword,
 		NewWalletName:  newWalletName,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
 // GetWallet accepts a wallet handle and returns high level information about
 // this wallet in a GetWalletResponse.
 func (kcl Client) GetWallet(walletHandle string) (resp GetWalletResponse, err error) {
+	return kcl.GetWalletWithContext(context.Background(), walletHandle)
+}
+
+// GetWalletWithContext is GetWallet with a context that can cancel the request.
+func (kcl Client) GetWalletWithContext(ctx context.Context, walletHandle string) (resp GetWalletResponse, err error) {
 	req := GetWalletRequest{
 		WalletHandleToken: walletHandle,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
@@ -106,11 +154,17 @@ func (kcl Client) GetWallet(walletHandle string) (resp GetWalletResponse, err er
 // key can be encoded as a sequence of words using the mnemonic library, and
 // displayed to the user as a backup phrase.
 func (kcl Client) ExportMasterDerivationKey(walletHandle, walletPassword string) (resp ExportMasterDerivationKeyResponse, err error) {
+	return kcl.ExportMasterDerivationKeyWithContext(context.Background(), walletHandle, walletPassword)
+}
+
+// ExportMasterDerivationKeyWithContext is ExportMasterDerivationKey with a
+// context that can cancel the request.
+func (kcl Client) ExportMasterDerivationKeyWithContext(ctx context.Context, walletHandle, walletPassword string) (resp ExportMasterDerivationKeyResponse, err error) {
 	req := ExportMasterDerivationKeyRequest{
 		WalletHandleToken: walletHandle,
 		WalletPassword:    walletPassword,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
@@ -118,11 +172,16 @@ func (kcl Client) ExportMasterDerivationKey(walletHandle, walletPassword string)
 // the key into the wallet. It returns an ImportKeyResponse containing the
 // address corresponding to this private key.
 func (kcl Client) ImportKey(walletHandle string, secretKey ed25519.PrivateKey) (resp ImportKeyResponse, err error) {
+	return kcl.ImportKeyWithContext(context.Background(), walletHandle, secretKey)
+}
+
+// ImportKeyWithContext is ImportKey with a context that can cancel the request.
+func (kcl Client) ImportKeyWithContext(ctx context.Context, walletHandle string, secretKey ed25519.PrivateKey) (resp ImportKeyResponse, err error) {
 	req := ImportKeyRequest{
 		WalletHandleToken: walletHandle,
 		PrivateKey:        secretKey,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
@@ -130,12 +189,17 @@ func (kcl Client) ImportKey(walletHandle string, secretKey ed25519.PrivateKey) (
 // an ExportKeyResponse containing the ed25519 private key corresponding to the
 // address stored in the wallet.
 func (kcl Client) ExportKey(walletHandle, walletPassword, addr string) (resp ExportKeyResponse, err error) {
+	return kcl.ExportKeyWithContext(context.Background(), walletHandle, walletPassword, addr)
+}
+
+// ExportKeyWithContext is ExportKey with a context that can cancel the request.
+func (kcl Client) ExportKeyWithContext(ctx context.Context, walletHandle, walletPassword, addr string) (resp ExportKeyResponse, err error) {
 	req := ExportKeyRequest{
 		WalletHandleToken: walletHandle,
 		WalletPassword:    walletPassword,
 		Address:           addr,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
@@ -143,11 +207,17 @@ func (kcl Client) ExportKey(walletHandle, walletPassword, addr string) (resp Exp
 // wallet using its internal master derivation key. Two wallets with the same
 // master derivation key will generate the same sequence of keys.
 func (kcl Client) GenerateKey(walletHandle string) (resp GenerateKeyResponse, err error) {
+	return kcl.GenerateKeyWithContext(context.Background(), walletHandle)
+}
+
+// GenerateKeyWithContext is GenerateKey with a context that can cancel the
+// request.
+func (kcl Client) GenerateKeyWithContext(ctx context.Context, walletHandle string) (resp GenerateKeyResponse, err error) {
 	req := GenerateKeyRequest{
 		WalletHandleToken: walletHandle,
 		DisplayMnemonic:   false,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
@@ -158,22 +228,32 @@ func (kcl Client) GenerateKey(walletHandle string) (resp GenerateKeyResponse, er
 // using the master derivation key, a key generated in this way can be
 // recovered.
 func (kcl Client) DeleteKey(walletHandle, walletPassword, addr string) (resp DeleteKeyResponse, err error) {
+	return kcl.DeleteKeyWithContext(context.Background(), walletHandle, walletPassword, addr)
+}
+
+// DeleteKeyWithContext is DeleteKey with a context that can cancel the request.
+func (kcl Client) DeleteKeyWithContext(ctx context.Context, walletHandle, walletPassword, addr string) (resp DeleteKeyResponse, err error) {
 	req := DeleteKeyRequest{
 		WalletHandleToken: walletHandle,
 		WalletPassword:    walletPassword,
 		Address:           addr,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
 // ListKeys accepts a wallet handle and returns a ListKeysResponse containing
 // all of the addresses for which this wallet contains secret keys.
 func (kcl Client) ListKeys(walletHandle string) (resp ListKeysResponse, err error) {
+	return kcl.ListKeysWithContext(context.Background(), walletHandle)
+}
+
+// ListKeysWithContext is ListKeys with a context that can cancel the request.
+func (kcl Client) ListKeysWithContext(ctx context.Context, walletHandle string) (resp ListKeysResponse, err error) {
 	req := ListKeysRequest{
 		WalletHandleToken: walletHandle,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
@@ -182,6 +262,13 @@ func (kcl Client) ListKeys(walletHandle string) (resp ListKeysResponse, err erro
 // an encoded, signed transaction. The transaction is signed using the key corresponding to the
 // Sender field.
 func (kcl Client) SignTransactionWithSpecificPublicKey(walletHandle, walletPassword string, tx types.Transaction, pk ed25519.PublicKey) (resp SignTransactionResponse, err error) {
+	return kcl.SignTransactionWithSpecificPublicKeyWithContext(context.Background(), walletHandle, walletPassword, tx, pk)
+}
+
+// SignTransactionWithSpecificPublicKeyWithContext is
+// SignTransactionWithSpecificPublicKey with a context that can cancel the
+// request.
+func (kcl Client) SignTransactionWithSpecificPublicKeyWithContext(ctx context.Context, walletHandle, walletPassword string, tx types.Transaction, pk ed25519.PublicKey) (resp SignTransactionResponse, err error) {
 	txBytes := msgpack.Encode(tx)
 	req := SignTransactionRequest{
 		WalletHandleToken: walletHandle,
@@ -189,7 +276,7 @@ func (kcl Client) SignTransactionWithSpecificPublicKey(walletHandle, walletPassw
 		Transaction:       txBytes,
 		PublicKey:         pk,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
@@ -198,13 +285,19 @@ func (kcl Client) SignTransactionWithSpecificPublicKey(walletHandle, walletPassw
 // transaction. The transaction is signed using the key corresponding to the
 // Sender field.
 func (kcl Client) SignTransaction(walletHandle, walletPassword string, tx types.Transaction) (resp SignTransactionResponse, err error) {
+	return kcl.SignTransactionWithContext(context.Background(), walletHandle, walletPassword, tx)
+}
+
+// SignTransactionWithContext is SignTransaction with a context that can cancel
+// the request.
+func (kcl Client) SignTransactionWithContext(ctx context.Context, walletHandle, walletPassword string, tx types.Transaction) (resp SignTransactionResponse, err error) {
 	txBytes := msgpack.Encode(tx)
 	req := SignTransactionRequest{
 		WalletHandleToken: walletHandle,
 		WalletPassword:    walletPassword,
 		Transaction:       txBytes,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
@@ -214,10 +307,16 @@ func (kcl Client) SignTransaction(walletHandle, walletPassword string, tx types.
 // including multisig version information, threshold information, and a list
 // of public keys.
 func (kcl Client) ListMultisig(walletHandle string) (resp ListMultisigResponse, err error) {
+	return kcl.ListMultisigWithContext(context.Background(), walletHandle)
+}
+
+// ListMultisigWithContext is ListMultisig with a context that can cancel the
+// request.
+func (kcl Client) ListMultisigWithContext(ctx context.Context, walletHandle string) (resp ListMultisigResponse, err error) {
 	req := ListMultisigRequest{
 		WalletHandleToken: walletHandle,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
@@ -226,13 +325,19 @@ func (kcl Client) ListMultisig(walletHandle string) (resp ListMultisigResponse,
 // information within the wallet. It returns a ImportMultisigResponse with the
 // derived address.
 func (kcl Client) ImportMultisig(walletHandle string, version, threshold uint8, pks []ed25519.PublicKey) (resp ImportMultisigResponse, err error) {
+	return kcl.ImportMultisigWithContext(context.Background(), walletHandle, version, threshold, pks)
+}
+
+// ImportMultisigWithContext is ImportMultisig with a context that can cancel
+// the request.
+func (kcl Client) ImportMultisigWithContext(ctx context.Context, walletHandle string, version, threshold uint8, pks []ed25519.PublicKey) (resp ImportMultisigResponse, err error) {
 	req := ImportMultisigRequest{
 		WalletHandleToken: walletHandle,
 		Version:           version,
 		Threshold:         threshold,
 		PKs:               pks,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
@@ -242,24 +347,36 @@ func (kcl Client) ImportMultisig(walletHandle string, version, threshold uint8,
 // to derive the multisig address, including version, threshold, and a list of
 // public keys.
 func (kcl Client) ExportMultisig(walletHandle, walletPassword, addr string) (resp ExportMultisigResponse, err error) {
+	return kcl.ExportMultisigWithContext(context.Background(), walletHandle, walletPassword, addr)
+}
+
+// ExportMultisigWithContext is ExportMultisig with a context that can cancel
+// the request.
+func (kcl Client) ExportMultisigWithContext(ctx context.Context, walletHandle, walletPassword, addr string) (resp ExportMultisigResponse, err error) {
 	req := ExportMultisigRequest{
 		WalletHandleToken: walletHandle,
 		WalletPassword:    walletPassword,
 		Address:           addr,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
 // DeleteMultisig accepts a wallet handle, wallet password, and address, and deletes
 // the information about this multisig address from the wallet.
 func (kcl Client) DeleteMultisig(walletHandle, walletPassword, addr string) (resp DeleteMultisigResponse, err error) {
+	return kcl.DeleteMultisigWithContext(context.Background(), walletHandle, walletPassword, addr)
+}
+
+// DeleteMultisigWithContext is DeleteMultisig with a context that can cancel
+// the request.
+func (kcl Client) DeleteMultisigWithContext(ctx context.Context, walletHandle, walletPassword, addr string) (resp DeleteMultisigResponse, err error) {
 	req := DeleteMultisigRequest{
 		WalletHandleToken: walletHandle,
 		WalletPassword:    walletPassword,
 		Address:           addr,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
 
@@ -269,6 +386,12 @@ func (kcl Client) DeleteMultisig(walletHandle, walletPassword, addr string) (res
 // returns a SignMultisigTransactionResponse containing a MultisigSig with a
 // signature by the secret key included.
 func (kcl Client) MultisigSignTransaction(walletHandle, walletPassword string, tx types.Transaction, pk ed25519.PublicKey, partial types.MultisigSig) (resp SignMultisigTransactionResponse, err error) {
+	return kcl.MultisigSignTransactionWithContext(context.Background(), walletHandle, walletPassword, tx, pk, partial)
+}
+
+// MultisigSignTransactionWithContext is MultisigSignTransaction with a context
+// that can cancel the request.
+func (kcl Client) MultisigSignTransactionWithContext(ctx context.Context, walletHandle, walletPassword string, tx types.Transaction, pk ed25519.PublicKey, partial types.MultisigSig) (resp SignMultisigTransactionResponse, err error) {
 	txBytes := msgpack.Encode(tx)
 	req := SignMultisigTransactionRequest{
 		WalletHandleToken: walletHandle,
@@ -277,6 +400,49 @@ func (kcl Client) MultisigSignTransaction(walletHandle, walletPassword string, t
 		PublicKey:         pk,
 		PartialMsig:       partial,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
+	return
+}
+
+// SignProgram accepts a wallet handle, wallet password, the address of a key
+// in the wallet and program bytes. It returns a SignProgramResponse holding the
+// signature that delegates the program's LogicSig to that key.
+func (kcl Client) SignProgram(walletHandle, walletPassword, addr string, program []byte) (resp SignProgramResponse, err error) {
+	return kcl.SignProgramWithContext(context.Background(), walletHandle, walletPassword, addr, program)
+}
+
+// SignProgramWithContext is SignProgram with a context that can cancel the
+// request.
+func (kcl Client) SignProgramWithContext(ctx context.Context, walletHandle, walletPassword, addr string, program []byte) (resp SignProgramResponse, err error) {
+	req := SignProgramRequest{
+		WalletHandleToken: walletHandle,
+		WalletPassword:    walletPassword,
+		Address:           addr,
+		Program:           program,
+	}
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
+	return
+}
+
+// MultisigSignProgram accepts a wallet handle, wallet password, multisig
+// address, program bytes, public key (*not* an address), and an optional
+// partial MultisigSig. It returns a SignProgramMultisigResponse containing a
+// MultisigSig over the program with a signature by the secret key included.
+func (kcl Client) MultisigSignProgram(walletHandle, walletPassword, addr string, program []byte, pk ed25519.PublicKey, partial types.MultisigSig) (resp SignProgramMultisigResponse, err error) {
+	return kcl.MultisigSignProgramWithContext(context.Background(), walletHandle, walletPassword, addr, program, pk, partial)
+}
+
+// MultisigSignProgramWithContext is MultisigSignProgram with a context that
+// can cancel the request.
+func (kcl Client) MultisigSignProgramWithContext(ctx context.Context, walletHandle, walletPassword, addr string, program []byte, pk ed25519.PublicKey, partial types.MultisigSig) (resp SignProgramMultisigResponse, err error) {
+	req := SignProgramMultisigRequest{
+		WalletHandleToken: walletHandle,
+		WalletPassword:    walletPassword,
+		Address:           addr,
+		Program:           program,
+		PublicKey:         pk,
+		PartialMsig:       partial,
+	}
+	err = kcl.DoV1RequestWithContext(ctx, req, &resp)
 	return
 }
@@ -7,10 +7,18 @@ import (
 	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
 )
 
+// BlockRaw is not generated from the algod spec: it fetches the block as raw
+// msgpack bytes, which the generated Block request decodes. Its parameters
+// mirror the ones of BlockParams in getBlock.go.
+
 // GetBlockParams defines parameters for GetBlock.
 type GetBlockParams struct {
 	// Return raw msgpack block bytes or json
 	Format string `url:"format,omitempty"`
+
+	// HeaderOnly if true, only the block header (exclusive of payset or certificate)
+	// may be included in response.
+	HeaderOnly bool `url:"header-only,omitempty"`
 }
 
 // BlockRaw contains metadata required to execute a BlockRaw query.
@@ -20,6 +28,14 @@ type BlockRaw struct {
 	p     GetBlockParams
 }
 
+// HeaderOnly if true, only the block header (exclusive of payset or certificate)
+// may be included in response.
+func (s *BlockRaw) HeaderOnly(HeaderOnly bool) *BlockRaw {
+	s.p.HeaderOnly = HeaderOnly
+
+	return s
+}
+
 // Do executes the BlockRaw query and gets the results.
 func (s *BlockRaw) Do(ctx context.Context, headers ...*common.Header) (result []byte, err error) {
 	s.p.Format = "msgpack"
@@ -0,0 +1,29 @@
+package algod
+
+import "github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+
+// The next and prefix parameters are missing from the generated
+// GetApplicationBoxesParams, so they are sent through the client of the
+// request instead.
+
+// Next a box name, in the goal app call arg form 'encoding:value'. When provided,
+// the returned boxes begin (lexicographically) with the supplied name. Callers may
+// implement pagination by reinvoking the endpoint with the token from a previous
+// call's next-token.
+func (s *GetApplicationBoxes) Next(Next string) *GetApplicationBoxes {
+	if Next != "" {
+		s.c = (*Client)((*common.Client)(s.c).WithQueryParameter("next", Next))
+	}
+
+	return s
+}
+
+// Prefix a box name prefix, in the goal app call arg form 'encoding:value'. When
+// provided, only boxes whose names start with the prefix are returned.
+func (s *GetApplicationBoxes) Prefix(Prefix string) *GetApplicationBoxes {
+	if Prefix != "" {
+		s.c = (*Client)((*common.Client)(s.c).WithQueryParameter("prefix", Prefix))
+	}
+
+	return s
+}
@@ -0,0 +1,59 @@
+package algod
+
+import (
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// SimulateTransactionGroup simulates a single group of signed transactions. Use
+// the builder methods on the returned request to configure the simulation.
+func (c *Client) SimulateTransactionGroup(txns []types.SignedTxn) *SimulateTransaction {
+	request := models.SimulateRequest{
+		TxnGroups: []models.SimulateRequestTransactionGroup{{Txns: txns}},
+	}
+	return &SimulateTransaction{c: c, request: request}
+}
+
+// AllowEmptySignatures allows transactions without signatures to be simulated as
+// if they had correct signatures.
+func (s *SimulateTransaction) AllowEmptySignatures(allowEmptySignatures bool) *SimulateTransaction {
+	s.request.AllowEmptySignatures = allowEmptySignatures
+
+	return s
+}
+
+// AllowMoreLogging lifts limits on log opcode usage during simulation.
+func (s *SimulateTransaction) AllowMoreLogging(allowMoreLogging bool) *SimulateTransaction {
+	s.request.AllowMoreLogging = allowMoreLogging
+
+	return s
+}
+
+// AllowUnnamedResources allows access to unnamed resources during simulation.
+func (s *SimulateTransaction) AllowUnnamedResources(allowUnnamedResources bool) *SimulateTransaction {
+	s.request.AllowUnnamedResources = allowUnnamedResources
+
+	return s
+}
+
+// ExecTraceConfig configures the execution trace returned by the simulation.
+func (s *SimulateTransaction) ExecTraceConfig(execTraceConfig models.SimulateTraceConfig) *SimulateTransaction {
+	s.request.ExecTraceConfig = execTraceConfig
+
+	return s
+}
+
+// ExtraOpcodeBudget applies extra opcode budget during simulation for each
+// transaction group.
+func (s *SimulateTransaction) ExtraOpcodeBudget(extraOpcodeBudget uint64) *SimulateTransaction {
+	s.request.ExtraOpcodeBudget = extraOpcodeBudget
+
+	return s
+}
+
+// Round specifies the round preceding the simulation.
+func (s *SimulateTransaction) Round(round uint64) *SimulateTransaction {
+	s.request.Round = round
+
+	return s
+}
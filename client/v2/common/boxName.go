@@ -0,0 +1,69 @@
+package common
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// Prefixes used by the goal app call arg form 'encoding:value', which is the
+// form the algod and indexer box endpoints expect box names to be in.
+const (
+	BoxNameB64Prefix  = "b64:"
+	BoxNameStrPrefix  = "str:"
+	BoxNameIntPrefix  = "int:"
+	BoxNameAddrPrefix = "addr:"
+)
+
+// EncodeBoxNameB64 encodes raw box name bytes in the goal form 'b64:A=='.
+func EncodeBoxNameB64(name []byte) string {
+	return BoxNameB64Prefix + base64.StdEncoding.EncodeToString(name)
+}
+
+// EncodeBoxNameStr encodes a printable box name in the goal form 'str:hello'.
+func EncodeBoxNameStr(name string) string {
+	return BoxNameStrPrefix + name
+}
+
+// EncodeBoxNameInt encodes an integer box name in the goal form 'int:1234'.
+// The node stores such names as an 8-byte big-endian integer.
+func EncodeBoxNameInt(name uint64) string {
+	return BoxNameIntPrefix + strconv.FormatUint(name, 10)
+}
+
+// EncodeBoxNameAddr encodes an address box name in the goal form 'addr:XYZ...'.
+// The node stores such names as the 32 raw bytes of the address.
+func EncodeBoxNameAddr(name types.Address) string {
+	return BoxNameAddrPrefix + name.String()
+}
+
+// DecodeBoxName converts a box name in the goal form 'encoding:value' to the
+// raw bytes of the name. The supported encodings are b64, str, int and addr.
+func DecodeBoxName(encoded string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(encoded, BoxNameB64Prefix):
+		return base64.StdEncoding.DecodeString(strings.TrimPrefix(encoded, BoxNameB64Prefix))
+	case strings.HasPrefix(encoded, BoxNameStrPrefix):
+		return []byte(strings.TrimPrefix(encoded, BoxNameStrPrefix)), nil
+	case strings.HasPrefix(encoded, BoxNameIntPrefix):
+		value, err := strconv.ParseUint(strings.TrimPrefix(encoded, BoxNameIntPrefix), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse int box name %q: %w", encoded, err)
+		}
+		name := make([]byte, 8)
+		binary.BigEndian.PutUint64(name, value)
+		return name, nil
+	case strings.HasPrefix(encoded, BoxNameAddrPrefix):
+		addr, err := types.DecodeAddress(strings.TrimPrefix(encoded, BoxNameAddrPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("could not parse addr box name %q: %w", encoded, err)
+		}
+		return addr[:], nil
+	default:
+		return nil, fmt.Errorf("box name %q is not in the form 'encoding:value'", encoded)
+	}
+}
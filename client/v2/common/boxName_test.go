@@ -0,0 +1,42 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoxNameRoundTrip(t *testing.T) {
+	addr, err := types.DecodeAddress("DN7MBMCL5JQ3PFUQS7TMX5AH4EEKOBJVDUF4TCV6WERATKFLQF4MQUPZTA")
+	require.NoError(t, err)
+
+	testcases := []struct {
+		name     string
+		encoded  string
+		expected []byte
+	}{
+		{name: "b64", encoded: EncodeBoxNameB64([]byte{0, 1, 255}), expected: []byte{0, 1, 255}},
+		{name: "str", encoded: EncodeBoxNameStr("hello"), expected: []byte("hello")},
+		{name: "int", encoded: EncodeBoxNameInt(258), expected: []byte{0, 0, 0, 0, 0, 0, 1, 2}},
+		{name: "addr", encoded: EncodeBoxNameAddr(addr), expected: addr[:]},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded, err := DecodeBoxName(tc.encoded)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, decoded)
+		})
+	}
+
+	require.Equal(t, "b64:AAH/", EncodeBoxNameB64([]byte{0, 1, 255}))
+	require.Equal(t, "int:258", EncodeBoxNameInt(258))
+}
+
+func TestDecodeBoxNameErrors(t *testing.T) {
+	for _, encoded := range []string{"hello", "b64:***", "int:abc", "int:-1", "addr:NOTANADDRESS"} {
+		_, err := DecodeBoxName(encoded)
+		require.Error(t, err, encoded)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
 	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
@@ -29,6 +30,18 @@ type Header struct {
 	Value string
 }
 
+// RequestInterceptor is called with every request before it is sent, once the
+// client and request headers are set. It may modify the request, for instance
+// to add tracing or authentication headers. Returning an error aborts the
+// request.
+type RequestInterceptor func(req *http.Request) error
+
+// ResponseInterceptor is called with every response, including the error ones
+// and those of attempts that are retried, before its body is read. The request
+// it answers is resp.Request. Returning an error discards the response and is
+// returned to the caller.
+type ResponseInterceptor func(resp *http.Response) error
+
 // Client manages the REST interface for a calling user.
 type Client struct {
 	serverURL url.URL
@@ -36,6 +49,12 @@ type Client struct {
 	apiToken  string
 	headers   []*Header
 	transport http.RoundTripper
+
+	requestInterceptors  []RequestInterceptor
+	responseInterceptors []ResponseInterceptor
+	retryPolicy          RetryPolicy
+	rateLimiter          *RateLimiter
+	timeout              time.Duration
 }
 
 // MakeClient is the factory for constructing a Client for a given endpoint.
@@ -78,31 +97,101 @@ func MakeClientWithTransport(address string, apiHeader, apiToken string, headers
 	return
 }
 
-type BadRequest error
-type InvalidToken error
-type NotFound error
-type InternalError error
+// AddRequestInterceptor adds an interceptor called with every request, after
+// the ones already added.
+func (client *Client) AddRequestInterceptor(interceptor RequestInterceptor) {
+	client.requestInterceptors = append(client.requestInterceptors, interceptor)
+}
+
+// AddResponseInterceptor adds an interceptor called with every response, after
+// the ones already added.
+func (client *Client) AddResponseInterceptor(interceptor ResponseInterceptor) {
+	client.responseInterceptors = append(client.responseInterceptors, interceptor)
+}
+
+// WithRequestInterceptor returns a copy of client that also calls interceptor
+// with every request, after the interceptors of client. The client itself is
+// left unchanged.
+func (client *Client) WithRequestInterceptor(interceptor RequestInterceptor) *Client {
+	copied := *client
+	copied.requestInterceptors = append(append([]RequestInterceptor(nil), client.requestInterceptors...), interceptor)
+	return &copied
+}
+
+// WithQueryParameter returns a copy of client that sets the query parameter key
+// to value on every request, replacing any value the request already had. It
+// lets request builders send parameters their generated parameters lack.
+func (client *Client) WithQueryParameter(key, value string) *Client {
+	return client.WithRequestInterceptor(func(req *http.Request) error {
+		query := req.URL.Query()
+		query.Set(key, value)
+		req.URL.RawQuery = query.Encode()
+		return nil
+	})
+}
+
+// SetTimeout sets the default timeout of the requests of client, covering the
+// attempts, their retries and the reading of the response. It only applies to
+// requests whose context has no deadline, so a deadline set on the context of
+// a request overrides it. Zero, the default, means no timeout.
+func (client *Client) SetTimeout(timeout time.Duration) {
+	client.timeout = timeout
+}
+
+// requestContext returns the context of a request made with ctx, which has the
+// default timeout of client unless ctx already has a deadline.
+func (client *Client) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || client.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, client.timeout)
+}
+
+// contextBody is the body of a response to a request made with ctx. Reading it
+// fails with the error of ctx once ctx is done, rather than a transport error,
+// and closing it releases ctx.
+type contextBody struct {
+	io.ReadCloser
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (b *contextBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF && b.ctx.Err() != nil {
+		err = b.ctx.Err()
+	}
+	return n, err
+}
+
+func (b *contextBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
 
 // extractError checks if the response signifies an error.
-// If so, it returns the error.
+// If so, it returns the error, one of the types of errors.go for the status
+// codes callers usually handle, or a bare *HTTPError for the others.
 // Otherwise, it returns nil.
-func extractError(code int, errorBuf []byte) error {
+func extractError(code int, errorBuf []byte, requestURL string) error {
 	if code >= 200 && code < 300 {
 		return nil
 	}
 
-	wrappedError := fmt.Errorf("HTTP %v: %s", code, errorBuf)
+	httpErr := newHTTPError(code, errorBuf, requestURL)
 	switch code {
-	case 400:
-		return BadRequest(wrappedError)
-	case 401:
-		return InvalidToken(wrappedError)
-	case 404:
-		return NotFound(wrappedError)
-	case 500:
-		return InternalError(wrappedError)
+	case http.StatusBadRequest:
+		return BadRequest{httpErr}
+	case http.StatusUnauthorized:
+		return Unauthorized{httpErr}
+	case http.StatusNotFound:
+		return NotFound{httpErr}
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return OverloadedError{httpErr}
+	case http.StatusInternalServerError:
+		return InternalError{httpErr}
 	default:
-		return wrappedError
+		return httpErr
 	}
 }
 
@@ -119,6 +208,15 @@ func mergeRawQueries(q1, q2 string) string {
 
 // submitFormRaw is a helper used for submitting (ex.) GETs and POSTs to the server
 func (client *Client) submitFormRaw(ctx context.Context, path string, params interface{}, requestMethod string, encodeJSON bool, headers []*Header, body interface{}) (resp *http.Response, err error) {
+	ctx, cancel := client.requestContext(ctx)
+	defer func() {
+		if err != nil {
+			cancel()
+			return
+		}
+		resp.Body = &contextBody{ReadCloser: resp.Body, ctx: ctx, cancel: cancel}
+	}()
+
 	queryURL := client.serverURL
 	queryURL.Path += path
 
@@ -135,7 +233,9 @@ func (client *Client) submitFormRaw(ctx context.Context, path string, params int
 		}
 	}
 
-	if requestMethod == "POST" && rawRequestPaths[path] {
+	if requestMethod == "PUT" && body != nil {
+		bodyReader = bytes.NewBuffer(json.Encode(body))
+	} else if requestMethod == "POST" && rawRequestPaths[path] {
 		reqBytes, ok := body.([]byte)
 		if !ok {
 			return nil, fmt.Errorf("couldn't decode raw body as bytes")
@@ -163,10 +263,39 @@ func (client *Client) submitFormRaw(ctx context.Context, path string, params int
 	for _, header := range headers {
 		req.Header.Add(header.Key, header.Value)
 	}
+	req = req.WithContext(ctx)
+
+	for _, interceptor := range client.requestInterceptors {
+		if err = interceptor(req); err != nil {
+			return nil, err
+		}
+	}
 
 	httpClient := &http.Client{Transport: client.transport}
-	req = req.WithContext(ctx)
-	resp, err = httpClient.Do(req)
+	for attempt := 1; ; attempt++ {
+		if client.rateLimiter != nil {
+			if err = client.rateLimiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		resp, err = client.send(httpClient, req)
+		if !client.retryPolicy.retry(attempt, req, resp, err) {
+			break
+		}
+		delay := client.retryPolicy.backoff(attempt, resp)
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err = sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	if err != nil {
 		select {
@@ -179,6 +308,23 @@ func (client *Client) submitFormRaw(ctx context.Context, path string, params int
 	return resp, nil
 }
 
+// send sends a single attempt of req and passes its response to the response
+// interceptors, returning either a response or an error.
+func (client *Client) send(httpClient *http.Client, req *http.Request) (*http.Response, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, interceptor := range client.responseInterceptors {
+		if err = interceptor(resp); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
 func (client *Client) submitForm(ctx context.Context, response interface{}, path string, params interface{}, requestMethod string, encodeJSON bool, headers []*Header, body interface{}) error {
 	resp, err := client.submitFormRaw(ctx, path, params, requestMethod, encodeJSON, headers, body)
 	if err != nil {
@@ -192,7 +338,7 @@ func (client *Client) submitForm(ctx context.Context, response interface{}, path
 		return err
 	}
 
-	responseErr := extractError(resp.StatusCode, bodyBytes)
+	responseErr := extractError(resp.StatusCode, bodyBytes, resp.Request.URL.String())
 
 	// The caller wants a string
 	if strResponse, ok := response.(*string); ok {
@@ -232,7 +378,7 @@ func (client *Client) GetRaw(ctx context.Context, path string, params interface{
 	if err != nil {
 		return nil, err
 	}
-	return bodyBytes, extractError(resp.StatusCode, bodyBytes)
+	return bodyBytes, extractError(resp.StatusCode, bodyBytes, resp.Request.URL.String())
 }
 
 // GetRawMsgpack performs a GET request to the specific path against the server and returns the decoded messagepack response.
@@ -248,10 +394,10 @@ func (client *Client) GetRawMsgpack(ctx context.Context, response interface{}, p
 		var bodyBytes []byte
 		bodyBytes, err = ioutil.ReadAll(resp.Body)
 		if err != nil {
-			return fmt.Errorf("failed to read response body: %+v", err)
+			return fmt.Errorf("failed to read response body: %w", err)
 		}
 
-		return extractError(resp.StatusCode, bodyBytes)
+		return extractError(resp.StatusCode, bodyBytes, resp.Request.URL.String())
 	}
 
 	dec := msgpack.NewLenientDecoder(resp.Body)
@@ -265,6 +411,12 @@ func (client *Client) Post(ctx context.Context, response interface{}, path strin
 	return client.submitForm(ctx, response, path, params, "POST", true /* encodeJSON */, headers, body)
 }
 
+// Put sends a PUT request to the given path with the JSON encoding of body.
+// response must be a pointer to an object as put writes the response there.
+func (client *Client) Put(ctx context.Context, response interface{}, path string, params interface{}, headers []*Header, body interface{}) error {
+	return client.submitForm(ctx, response, path, params, "PUT", false /* encodeJSON */, headers, body)
+}
+
 // Helper function for correctly formatting and escaping URL path parameters.
 // Used in the generated API client code.
 func EscapeParams(params ...interface{}) []interface{} {
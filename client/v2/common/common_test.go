@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -13,26 +14,57 @@ import (
 )
 
 func TestExtractError(t *testing.T) {
+	const requestURL = "http://localhost:4001/v2/status"
+	httpErr := func(code int) *HTTPError {
+		return &HTTPError{StatusCode: code, Body: []byte{}, URL: requestURL}
+	}
+
 	testcases := []struct {
 		name string
 		code int
 		err  error
 	}{
-		{name: "400", code: 400, err: BadRequest(fmt.Errorf("HTTP 400: "))},
-		{name: "401", code: 401, err: InvalidToken(fmt.Errorf("HTTP 401: "))},
-		{name: "404", code: 404, err: NotFound(fmt.Errorf("HTTP 404: "))},
-		{name: "500", code: 500, err: InternalError(fmt.Errorf("HTTP 500: "))},
+		{name: "400", code: 400, err: BadRequest{httpErr(400)}},
+		{name: "401", code: 401, err: Unauthorized{httpErr(401)}},
+		{name: "404", code: 404, err: NotFound{httpErr(404)}},
+		{name: "429", code: 429, err: OverloadedError{httpErr(429)}},
+		{name: "500", code: 500, err: InternalError{httpErr(500)}},
+		{name: "503", code: 503, err: OverloadedError{httpErr(503)}},
+		{name: "502", code: 502, err: httpErr(502)},
 		{name: "200", code: 200, err: nil},
 		{name: "201", code: 201, err: nil},
 	}
 
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
-			assert.Equal(t, tc.err, extractError(tc.code, []byte{}))
+			assert.Equal(t, tc.err, extractError(tc.code, []byte{}, requestURL))
 		})
 	}
 }
 
+func TestExtractErrorClasses(t *testing.T) {
+	body := []byte(`{"message":"round 1000 is not available"}`)
+	err := fmt.Errorf("lookup failed: %w", extractError(404, body, "http://localhost:8980/v2/blocks/1000"))
+
+	require.EqualError(t, err, `lookup failed: HTTP 404: {"message":"round 1000 is not available"}`)
+	require.ErrorIs(t, err, NotFound{})
+	require.NotErrorIs(t, err, BadRequest{})
+
+	var notFound NotFound
+	require.ErrorAs(t, err, &notFound)
+	require.Equal(t, 404, notFound.StatusCode)
+
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, "round 1000 is not available", httpErr.Message)
+	require.Equal(t, "http://localhost:8980/v2/blocks/1000", httpErr.URL)
+
+	// a body that is not an error document has no message
+	require.ErrorAs(t, extractError(502, []byte("Bad Gateway"), ""), &httpErr)
+	require.Empty(t, httpErr.Message)
+	require.Equal(t, []byte("Bad Gateway"), httpErr.Body)
+}
+
 func TestClient_Verbs(t *testing.T) {
 	path := "/some/path"
 
@@ -53,6 +85,12 @@ func TestClient_Verbs(t *testing.T) {
 				return c.Post(context.Background(), nil, path, nil, nil, nil)
 			},
 		},
+		{
+			expectedVerb: "PUT",
+			call: func(c *Client) error {
+				return c.Put(context.Background(), nil, path, nil, nil, nil)
+			},
+		},
 		{
 			expectedVerb: "DELETE",
 			call: func(c *Client) error {
@@ -116,3 +154,96 @@ func TestClientWithTransport(t *testing.T) {
 	assert.Equal(t, headerValue, receivedHeaderValue)
 	assert.Equal(t, c.transport, customTransport)
 }
+
+func TestClientInterceptors(t *testing.T) {
+	var receivedKey string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedKey = r.Header.Get("X-API-Key")
+		w.Write([]byte(`"ok"`))
+	}))
+	defer mockServer.Close()
+
+	c, err := MakeClient(mockServer.URL, "API-Header", "ASDF")
+	require.NoError(t, err)
+
+	var calls []string
+	c.AddRequestInterceptor(func(req *http.Request) error {
+		calls = append(calls, "request "+req.URL.Path)
+		req.Header.Set("X-API-Key", "secret")
+		return nil
+	})
+	c.AddResponseInterceptor(func(resp *http.Response) error {
+		calls = append(calls, fmt.Sprintf("response %d %s", resp.StatusCode, resp.Request.URL.Path))
+		return nil
+	})
+
+	var response string
+	require.NoError(t, c.Get(context.Background(), &response, "/v2/status", nil, nil))
+	require.Equal(t, `"ok"`, response)
+	require.Equal(t, "secret", receivedKey)
+	require.Equal(t, []string{"request /v2/status", "response 200 /v2/status"}, calls)
+
+	// an interceptor error aborts the call
+	interceptorErr := fmt.Errorf("request rejected")
+	c.AddRequestInterceptor(func(req *http.Request) error { return interceptorErr })
+	receivedKey = ""
+	require.ErrorIs(t, c.Get(context.Background(), nil, "/v2/status", nil, nil), interceptorErr)
+	require.Empty(t, receivedKey)
+}
+
+func TestClientWithQueryParameter(t *testing.T) {
+	var received url.Values
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.URL.Query()
+		w.Write([]byte(`"ok"`))
+	}))
+	defer mockServer.Close()
+
+	c, err := MakeClient(mockServer.URL, "API-Header", "ASDF")
+	require.NoError(t, err)
+
+	params := struct {
+		Max  uint64 `url:"max,omitempty"`
+		Next string `url:"next,omitempty"`
+	}{Max: 2, Next: "b64:AA=="}
+	var response string
+	withPrefix := c.WithQueryParameter("prefix", "str:a").WithQueryParameter("next", "str:b")
+	require.NoError(t, withPrefix.Get(context.Background(), &response, "/v2/boxes", params, nil))
+	require.Equal(t, url.Values{"max": {"2"}, "next": {"str:b"}, "prefix": {"str:a"}}, received)
+
+	// the original client is left unchanged
+	require.NoError(t, c.Get(context.Background(), &response, "/v2/boxes", params, nil))
+	require.Equal(t, url.Values{"max": {"2"}, "next": {"b64:AA=="}}, received)
+}
+
+func TestClientTimeout(t *testing.T) {
+	release := make(chan struct{})
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow/body" {
+			w.Write([]byte(`"partial`))
+			w.(http.Flusher).Flush()
+		}
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer mockServer.Close()
+	defer close(release)
+
+	c, err := MakeClient(mockServer.URL, "API-Header", "ASDF")
+	require.NoError(t, err)
+	c.SetTimeout(20 * time.Millisecond)
+
+	var response string
+	require.ErrorIs(t, c.Get(context.Background(), &response, "/slow", nil, nil), context.DeadlineExceeded)
+	_, err = c.GetRaw(context.Background(), "/slow/body", nil, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// a deadline on the request context overrides the client timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	require.ErrorIs(t, c.Get(ctx, &response, "/slow", nil, nil), context.DeadlineExceeded)
+	require.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
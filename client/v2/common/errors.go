@@ -0,0 +1,105 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// HTTPError describes a response with a status code outside of 2xx. Every
+// error returned by extractError wraps one, so it can be retrieved with
+// errors.As whatever the failure class:
+//
+//	var httpErr *common.HTTPError
+//	if errors.As(err, &httpErr) {
+//		log.Println(httpErr.StatusCode, httpErr.Message)
+//	}
+type HTTPError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Message is the "message" field of the JSON error body returned by
+	// algod and indexer, or empty if the body is not such a document.
+	Message string
+
+	// Body is the raw response body.
+	Body []byte
+
+	// URL is the URL of the request.
+	URL string
+}
+
+// Error keeps the "HTTP <code>: <body>" format of the previous untyped
+// errors.
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %v: %s", e.StatusCode, e.Body)
+}
+
+// BadRequest is returned for a 400 response.
+type BadRequest struct{ *HTTPError }
+
+// Unauthorized is returned for a 401 response, usually a missing or wrong
+// API token.
+type Unauthorized struct{ *HTTPError }
+
+// InvalidToken is the previous name of Unauthorized.
+//
+// Deprecated: use Unauthorized.
+type InvalidToken = Unauthorized
+
+// NotFound is returned for a 404 response.
+type NotFound struct{ *HTTPError }
+
+// OverloadedError is returned for a 429 or 503 response, when the node is
+// rate limiting or cannot serve the request for now. The request may succeed
+// if retried later.
+type OverloadedError struct{ *HTTPError }
+
+// InternalError is returned for a 500 response.
+type InternalError struct{ *HTTPError }
+
+func (e BadRequest) Unwrap() error      { return e.HTTPError }
+func (e Unauthorized) Unwrap() error    { return e.HTTPError }
+func (e NotFound) Unwrap() error        { return e.HTTPError }
+func (e OverloadedError) Unwrap() error { return e.HTTPError }
+func (e InternalError) Unwrap() error   { return e.HTTPError }
+
+// Is makes errors.Is(err, BadRequest{}) match any BadRequest.
+func (e BadRequest) Is(target error) bool {
+	_, ok := target.(BadRequest)
+	return ok
+}
+
+// Is makes errors.Is(err, Unauthorized{}) match any Unauthorized.
+func (e Unauthorized) Is(target error) bool {
+	_, ok := target.(Unauthorized)
+	return ok
+}
+
+// Is makes errors.Is(err, NotFound{}) match any NotFound.
+func (e NotFound) Is(target error) bool {
+	_, ok := target.(NotFound)
+	return ok
+}
+
+// Is makes errors.Is(err, OverloadedError{}) match any OverloadedError.
+func (e OverloadedError) Is(target error) bool {
+	_, ok := target.(OverloadedError)
+	return ok
+}
+
+// Is makes errors.Is(err, InternalError{}) match any InternalError.
+func (e InternalError) Is(target error) bool {
+	_, ok := target.(InternalError)
+	return ok
+}
+
+// newHTTPError returns the HTTPError of a response, reading the message from
+// its body if it has one.
+func newHTTPError(code int, body []byte, requestURL string) *HTTPError {
+	var errorBody struct {
+		Message string `json:"message"`
+	}
+	// a body that is not a JSON error document just leaves Message empty
+	_ = json.Unmarshal(body, &errorBody)
+	return &HTTPError{StatusCode: code, Message: errorBody.Message, Body: body, URL: requestURL}
+}
@@ -0,0 +1,91 @@
+package common
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// Provider is a provider of public algod and indexer endpoints.
+type Provider struct {
+	Name string
+	// TokenHeader is the header the API token is sent in, the one of the API
+	// if empty.
+	TokenHeader string
+	// AlgodURLs and IndexerURLs are the endpoints of the provider, by genesis
+	// ID of their network.
+	AlgodURLs   map[string]string
+	IndexerURLs map[string]string
+}
+
+// Nodely serves free public endpoints, without token, for MainNet, TestNet
+// and BetaNet.
+var Nodely = Provider{
+	Name: "nodely",
+	AlgodURLs: map[string]string{
+		types.MainNetGenesisID: "https://mainnet-api.4160.nodely.dev",
+		types.TestNetGenesisID: "https://testnet-api.4160.nodely.dev",
+		types.BetaNetGenesisID: "https://betanet-api.4160.nodely.dev",
+	},
+	IndexerURLs: map[string]string{
+		types.MainNetGenesisID: "https://mainnet-idx.4160.nodely.dev",
+		types.TestNetGenesisID: "https://testnet-idx.4160.nodely.dev",
+		types.BetaNetGenesisID: "https://betanet-idx.4160.nodely.dev",
+	},
+}
+
+// AlgoNode is the former name of Nodely, whose endpoints still serve.
+var AlgoNode = Provider{
+	Name: "algonode",
+	AlgodURLs: map[string]string{
+		types.MainNetGenesisID: "https://mainnet-api.algonode.cloud",
+		types.TestNetGenesisID: "https://testnet-api.algonode.cloud",
+		types.BetaNetGenesisID: "https://betanet-api.algonode.cloud",
+	},
+	IndexerURLs: map[string]string{
+		types.MainNetGenesisID: "https://mainnet-idx.algonode.cloud",
+		types.TestNetGenesisID: "https://testnet-idx.algonode.cloud",
+		types.BetaNetGenesisID: "https://betanet-idx.algonode.cloud",
+	},
+}
+
+// ProviderOptions configure a client of a network.
+type ProviderOptions struct {
+	// Provider is the provider of the endpoint, Nodely if its Name is empty.
+	Provider Provider
+	// URL, if set, is used instead of the endpoint of the provider.
+	URL string
+	// Token is the API token, if the endpoint requires one.
+	Token string
+	// Headers are sent with every request.
+	Headers []*Header
+	// Transport, if set, is the HTTP transport of the client.
+	Transport http.RoundTripper
+}
+
+// MakeClientForNetwork returns a Client of the algod, or indexer, endpoint of
+// network given by options, sending the token in the header of the provider,
+// or apiHeader.
+func MakeClientForNetwork(network types.KnownNetwork, indexer bool, apiHeader string, options ProviderOptions) (*Client, error) {
+	provider := options.Provider
+	if provider.Name == "" {
+		provider = Nodely
+	}
+	if provider.TokenHeader != "" {
+		apiHeader = provider.TokenHeader
+	}
+
+	address := options.URL
+	if address == "" {
+		urls, api := provider.AlgodURLs, "algod"
+		if indexer {
+			urls, api = provider.IndexerURLs, "indexer"
+		}
+		var ok bool
+		if address, ok = urls[network.GenesisID]; !ok {
+			return nil, fmt.Errorf("%s has no %s endpoint for %s", provider.Name, api, network.Name)
+		}
+	}
+	return MakeClientWithTransport(address, apiHeader, options.Token, options.Headers, options.Transport)
+}
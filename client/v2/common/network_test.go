@@ -0,0 +1,39 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestMakeClientForNetwork(t *testing.T) {
+	c, err := MakeClientForNetwork(types.MainNet, false, "X-Algo-API-Token", ProviderOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "https://mainnet-api.4160.nodely.dev", c.serverURL.String())
+
+	c, err = MakeClientForNetwork(types.TestNet, true, "X-Indexer-API-Token", ProviderOptions{Provider: AlgoNode})
+	require.NoError(t, err)
+	require.Equal(t, "https://testnet-idx.algonode.cloud", c.serverURL.String())
+
+	_, err = MakeClientForNetwork(types.KnownNetwork{Name: "localnet"}, false, "X-Algo-API-Token", ProviderOptions{})
+	require.ErrorContains(t, err, "nodely has no algod endpoint for localnet")
+
+	// A custom URL, with the token sent in the header of the provider.
+	var token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("X-API-Key")
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+	provider := Provider{Name: "custom", TokenHeader: "X-API-Key"}
+	c, err = MakeClientForNetwork(types.MainNet, false, "X-Algo-API-Token", ProviderOptions{Provider: provider, URL: server.URL, Token: "secret"})
+	require.NoError(t, err)
+	var response map[string]interface{}
+	require.NoError(t, c.Get(context.Background(), &response, "/health", nil, nil))
+	require.Equal(t, "secret", token)
+}
@@ -0,0 +1,73 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiting the rate of requests. It holds up to
+// burst tokens, refilled at requestsPerSecond, and every request takes one,
+// waiting for it if the bucket is empty. A RateLimiter may be shared by several
+// clients using the same provider, such as an algod and an indexer client.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing requestsPerSecond requests per
+// second on average, and bursts of up to burst requests. The bucket starts
+// full.
+func NewRateLimiter(requestsPerSecond float64, burst int) (*RateLimiter, error) {
+	if requestsPerSecond <= 0 {
+		return nil, fmt.Errorf("rate limit must be positive, got %v requests per second", requestsPerSecond)
+	}
+	if burst < 1 {
+		return nil, fmt.Errorf("rate limit burst must be at least 1, got %d", burst)
+	}
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}, nil
+}
+
+// Wait takes a token, waiting until one is available or ctx is done. Tokens
+// are handed out in the order Wait is called.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	// the token is reserved right away, so that later callers wait for the
+	// ones before them
+	l.tokens--
+	deficit := -l.tokens
+	l.mu.Unlock()
+
+	if deficit <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, time.Duration(deficit/l.rate*float64(time.Second))); err != nil {
+		// give the reservation back
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// SetRateLimiter makes client take a token from limiter before every request,
+// retries included. A nil limiter removes the limit.
+func (client *Client) SetRateLimiter(limiter *RateLimiter) {
+	client.rateLimiter = limiter
+}
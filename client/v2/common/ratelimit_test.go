@@ -0,0 +1,55 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	_, err := NewRateLimiter(0, 1)
+	require.Error(t, err)
+	_, err = NewRateLimiter(1, 0)
+	require.Error(t, err)
+
+	limiter, err := NewRateLimiter(100, 2)
+	require.NoError(t, err)
+
+	// the burst is served at once, the next request waits for a refill
+	start := time.Now()
+	require.NoError(t, limiter.Wait(context.Background()))
+	require.NoError(t, limiter.Wait(context.Background()))
+	require.Less(t, time.Since(start), 5*time.Millisecond)
+	require.NoError(t, limiter.Wait(context.Background()))
+	require.GreaterOrEqual(t, time.Since(start), 5*time.Millisecond)
+
+	// a cancelled wait returns its token
+	slow, err := NewRateLimiter(0.001, 1)
+	require.NoError(t, err)
+	require.NoError(t, slow.Wait(context.Background()))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, slow.Wait(ctx), context.DeadlineExceeded)
+	require.InDelta(t, 0, slow.tokens, 0.01)
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer mockServer.Close()
+
+	c, err := MakeClient(mockServer.URL, "API-Header", "ASDF")
+	require.NoError(t, err)
+	limiter, err := NewRateLimiter(0.001, 1)
+	require.NoError(t, err)
+	c.SetRateLimiter(limiter)
+
+	var response string
+	require.NoError(t, c.Get(context.Background(), &response, "/v2/status", nil, nil))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, c.Get(ctx, &response, "/v2/status", nil, nil), context.DeadlineExceeded)
+}
@@ -0,0 +1,164 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures how a Client retries requests that failed for a
+// reason that may be transient: a 429 or 5xx response, or a connection that was
+// refused or reset. The zero value never retries.
+//
+// Retried requests are sent again as they are, POST bodies included.
+// Transaction submissions are not retried unless RetrySubmissions is set.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent, the first
+	// one included. A value of 0 or 1 disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry. It doubles for every
+	// following one, up to MaxBackoff.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts, including the one asked
+	// for by a Retry-After header. Zero means no cap.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction, between 0 and 1, of each delay that is
+	// randomized so that clients do not retry in lockstep. A delay d is
+	// replaced by a random one in [d*(1-Jitter), d].
+	Jitter float64
+
+	// RetryOn decides whether a failed attempt is retried, given its response
+	// or error, one of which is nil. If nil, ShouldRetry is used.
+	RetryOn func(resp *http.Response, err error) bool
+
+	// RetrySubmissions allows retrying the POST requests submitting
+	// transactions to algod. A submission that failed with a 5xx or a reset
+	// connection may still have reached the transaction pool, so it is only
+	// retried when the caller knows sending it again is harmless.
+	RetrySubmissions bool
+}
+
+// submissionPaths are the paths of the algod endpoints submitting
+// transactions, which follow the path of the server URL.
+var submissionPaths = []string{"/v2/transactions", "/v2/transactions/async"}
+
+// isSubmission reports whether req submits transactions.
+func isSubmission(req *http.Request) bool {
+	if req.Method != http.MethodPost {
+		return false
+	}
+	path := strings.TrimSuffix(req.URL.Path, "/")
+	for _, submission := range submissionPaths {
+		if strings.HasSuffix(path, submission) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultRetryPolicy is a RetryPolicy suited to public nodes: up to 4 attempts
+// over about 3 seconds.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 250 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Jitter:         0.5,
+}
+
+// ShouldRetry reports whether an attempt that got resp or err is worth
+// retrying: the response is a 429 or a 5xx other than 501, or the connection
+// was refused, reset or closed early.
+func ShouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented)
+}
+
+// SetRetryPolicy sets the policy used to retry the requests of client.
+func (client *Client) SetRetryPolicy(policy RetryPolicy) {
+	client.retryPolicy = policy
+}
+
+// retry reports whether the attempt-th attempt of req, which got resp or err,
+// should be retried.
+func (p RetryPolicy) retry(attempt int, req *http.Request, resp *http.Response, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	if !p.RetrySubmissions && isSubmission(req) {
+		return false
+	}
+	if p.RetryOn != nil {
+		return p.RetryOn(resp, err)
+	}
+	return ShouldRetry(resp, err)
+}
+
+// backoff returns the delay before the attempt following the attempt-th one.
+// A Retry-After header in resp takes precedence over the policy, up to
+// MaxBackoff.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if delay, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff != 0 && delay > p.MaxBackoff {
+				delay = p.MaxBackoff
+			}
+			return delay
+		}
+	}
+
+	delay := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff == 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff != 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if p.Jitter > 0 && delay > 0 {
+		delay -= time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+	return delay
+}
+
+// retryAfter parses a Retry-After header, which is either a number of seconds
+// or an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done, returning the error of ctx in the
+// latter case.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
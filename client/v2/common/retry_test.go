@@ -0,0 +1,103 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	var attempts int
+	var bodies []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		switch attempts {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"txId":"ABC"}`))
+		}
+	}))
+	defer mockServer.Close()
+
+	c, err := MakeClient(mockServer.URL, "API-Header", "ASDF")
+	require.NoError(t, err)
+	c.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	// submissions are not retried by default
+	var response string
+	err = c.Post(context.Background(), &response, "/v2/transactions", nil, nil, []byte("stx"))
+	require.ErrorIs(t, err, OverloadedError{})
+	require.Equal(t, 1, attempts)
+
+	attempts, bodies = 0, nil
+	c.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, RetrySubmissions: true})
+	err = c.Post(context.Background(), &response, "/v2/transactions", nil, nil, []byte("stx"))
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+	require.Equal(t, []string{"stx", "stx", "stx"}, bodies)
+
+	// other POST requests are
+	attempts, bodies = 0, nil
+	c.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+	err = c.Post(context.Background(), &response, "/v2/transactions/simulate", nil, nil, []byte("stx"))
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+
+	// attempts are exhausted
+	attempts = 0
+	c.SetRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+	err = c.Get(context.Background(), nil, "/v2/status", nil, nil)
+	require.ErrorIs(t, err, OverloadedError{})
+	require.Equal(t, 2, attempts)
+
+	// the zero policy does not retry
+	attempts = 0
+	c.SetRetryPolicy(RetryPolicy{})
+	err = c.Get(context.Background(), nil, "/v2/status", nil, nil)
+	require.ErrorIs(t, err, OverloadedError{})
+	require.Equal(t, 1, attempts)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	require.Equal(t, time.Second, p.backoff(1, nil))
+	require.Equal(t, 2*time.Second, p.backoff(2, nil))
+	require.Equal(t, 4*time.Second, p.backoff(3, nil))
+	require.Equal(t, 5*time.Second, p.backoff(4, nil))
+	require.Equal(t, 5*time.Second, p.backoff(100, nil))
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := p.backoff(2, nil)
+		require.GreaterOrEqual(t, delay, time.Second)
+		require.LessOrEqual(t, delay, 2*time.Second)
+	}
+
+	// Retry-After is capped by MaxBackoff
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	require.Equal(t, 5*time.Second, p.backoff(1, resp))
+	resp.Header.Set("Retry-After", "3")
+	require.Equal(t, 3*time.Second, p.backoff(1, resp))
+	require.Equal(t, 7*time.Second, RetryPolicy{}.backoff(1, &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}))
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	require.Equal(t, time.Duration(0), p.backoff(1, resp))
+}
+
+func TestShouldRetry(t *testing.T) {
+	for code, retry := range map[int]bool{200: false, 400: false, 404: false, 429: true, 500: true, 501: false, 503: true} {
+		require.Equal(t, retry, ShouldRetry(&http.Response{StatusCode: code}, nil), code)
+	}
+	require.True(t, ShouldRetry(nil, io.ErrUnexpectedEOF))
+	require.False(t, ShouldRetry(nil, context.Canceled))
+}
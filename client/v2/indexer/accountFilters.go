@@ -0,0 +1,43 @@
+package indexer
+
+// AccountExclude is a value of the exclude parameter of account lookups and
+// searches, naming account data to leave out of the response.
+type AccountExclude string
+
+const (
+	// ExcludeAll leaves out every kind of data below.
+	ExcludeAll AccountExclude = "all"
+	// ExcludeAssets leaves out the asset holdings of the account.
+	ExcludeAssets AccountExclude = "assets"
+	// ExcludeCreatedAssets leaves out the parameters of assets created by the
+	// account.
+	ExcludeCreatedAssets AccountExclude = "created-assets"
+	// ExcludeAppsLocalState leaves out the local state of applications the
+	// account opted in to.
+	ExcludeAppsLocalState AccountExclude = "apps-local-state"
+	// ExcludeCreatedApps leaves out the parameters of applications created by
+	// the account.
+	ExcludeCreatedApps AccountExclude = "created-apps"
+	// ExcludeNone leaves out nothing.
+	ExcludeNone AccountExclude = "none"
+)
+
+func accountExcludeStrings(exclude []AccountExclude) []string {
+	s := make([]string, len(exclude))
+	for i, e := range exclude {
+		s[i] = string(e)
+	}
+	return s
+}
+
+// ExcludeFilter is Exclude taking AccountExclude constants. Accounts holding
+// many assets or applications can exceed the response limits of the indexer
+// unless that data is excluded and fetched separately with pagination.
+func (s *LookupAccountByID) ExcludeFilter(exclude ...AccountExclude) *LookupAccountByID {
+	return s.Exclude(accountExcludeStrings(exclude))
+}
+
+// ExcludeFilter is Exclude taking AccountExclude constants.
+func (s *SearchAccounts) ExcludeFilter(exclude ...AccountExclude) *SearchAccounts {
+	return s.Exclude(accountExcludeStrings(exclude))
+}
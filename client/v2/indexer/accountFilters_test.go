@@ -0,0 +1,26 @@
+package indexer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+)
+
+func TestAccountExcludeFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "assets,created-apps", r.URL.Query().Get("exclude"))
+		w.Write(json.Encode(models.AccountResponse{}))
+	}))
+	defer server.Close()
+
+	client, err := MakeClient(server.URL, "")
+	require.NoError(t, err)
+	_, _, err = client.LookupAccountByID("A").ExcludeFilter(ExcludeAssets, ExcludeCreatedApps).Do(context.Background())
+	require.NoError(t, err)
+}
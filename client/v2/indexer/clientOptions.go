@@ -0,0 +1,40 @@
+package indexer
+
+import (
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+)
+
+// The client options are written by hand, outside the generated indexer.go.
+
+// AddRequestInterceptor adds an interceptor called with every request, such as
+// one setting the API key header of a hosted node or recording metrics.
+func (c *Client) AddRequestInterceptor(interceptor common.RequestInterceptor) {
+	(*common.Client)(c).AddRequestInterceptor(interceptor)
+}
+
+// AddResponseInterceptor adds an interceptor called with every response.
+func (c *Client) AddResponseInterceptor(interceptor common.ResponseInterceptor) {
+	(*common.Client)(c).AddResponseInterceptor(interceptor)
+}
+
+// SetRetryPolicy sets the policy used to retry requests that failed for a
+// transient reason, such as common.DefaultRetryPolicy. By default requests are
+// not retried.
+func (c *Client) SetRetryPolicy(policy common.RetryPolicy) {
+	(*common.Client)(c).SetRetryPolicy(policy)
+}
+
+// SetRateLimiter makes c wait for a token of limiter before every request,
+// keeping it under the limits of the node provider. A nil limiter removes the
+// limit.
+func (c *Client) SetRateLimiter(limiter *common.RateLimiter) {
+	(*common.Client)(c).SetRateLimiter(limiter)
+}
+
+// SetTimeout sets the default timeout of the requests of c, which a deadline on
+// the context passed to Do overrides. Zero, the default, means no timeout.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	(*common.Client)(c).SetTimeout(timeout)
+}
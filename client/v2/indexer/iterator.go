@@ -0,0 +1,165 @@
+package indexer
+
+import (
+	"context"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+)
+
+// PageFunc fetches the page of results starting at nextToken, which is empty
+// for the first page, and returns its items and the token of the next page.
+type PageFunc[T any] func(ctx context.Context, nextToken string) (items []T, next string, err error)
+
+// Iterator walks the results of a paginated indexer endpoint one item at a
+// time, requesting the next page when the current one is used up:
+//
+//	it := client.SearchForTransactions().Limit(100).Iterator(ctx)
+//	for it.Next() {
+//		txn := it.Item()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+//
+// The page size is the Limit of the request the iterator was made from.
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch PageFunc[T]
+
+	page    []T
+	next    string
+	started bool
+	done    bool
+	err     error
+
+	item     T
+	count    uint64
+	maxItems uint64
+}
+
+// NewIterator returns an Iterator over the pages returned by fetch. The
+// iteration stops at the first error, including the error of ctx.
+func NewIterator[T any](ctx context.Context, fetch PageFunc[T]) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch}
+}
+
+// MaxItems stops the iteration after n items, across all pages. Zero means no
+// limit.
+func (it *Iterator[T]) MaxItems(n uint64) *Iterator[T] {
+	it.maxItems = n
+	return it
+}
+
+// Next advances to the next item, fetching a page if needed, and reports
+// whether there is one. It returns false once the results are exhausted, the
+// item limit is reached or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	if it.done || (it.maxItems != 0 && it.count >= it.maxItems) {
+		return false
+	}
+	for len(it.page) == 0 {
+		// the last page has no next token, and an empty page ends the
+		// results even when it has one
+		if it.started && it.next == "" {
+			it.done = true
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			it.done = true
+			return false
+		}
+
+		items, next, err := it.fetch(it.ctx, it.next)
+		if err != nil {
+			it.err = err
+			it.done = true
+			return false
+		}
+		if len(items) == 0 {
+			it.done = true
+			return false
+		}
+		it.page, it.next, it.started = items, next, true
+	}
+
+	it.item, it.page = it.page[0], it.page[1:]
+	it.count++
+	return true
+}
+
+// Item returns the current item, valid after Next returned true.
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Iterator returns an Iterator over the transactions matching s.
+func (s *SearchForTransactions) Iterator(ctx context.Context, headers ...*common.Header) *Iterator[models.Transaction] {
+	return NewIterator(ctx, func(ctx context.Context, nextToken string) ([]models.Transaction, string, error) {
+		response, err := s.NextToken(nextToken).Do(ctx, headers...)
+		return response.Transactions, response.NextToken, err
+	})
+}
+
+// Iterator returns an Iterator over the transactions of the account.
+func (s *LookupAccountTransactions) Iterator(ctx context.Context, headers ...*common.Header) *Iterator[models.Transaction] {
+	return NewIterator(ctx, func(ctx context.Context, nextToken string) ([]models.Transaction, string, error) {
+		response, err := s.NextToken(nextToken).Do(ctx, headers...)
+		return response.Transactions, response.NextToken, err
+	})
+}
+
+// Iterator returns an Iterator over the transactions of the asset.
+func (s *LookupAssetTransactions) Iterator(ctx context.Context, headers ...*common.Header) *Iterator[models.Transaction] {
+	return NewIterator(ctx, func(ctx context.Context, nextToken string) ([]models.Transaction, string, error) {
+		response, err := s.NextToken(nextToken).Do(ctx, headers...)
+		return response.Transactions, response.NextToken, err
+	})
+}
+
+// Iterator returns an Iterator over the holdings of the asset.
+func (s *LookupAssetBalances) Iterator(ctx context.Context, headers ...*common.Header) *Iterator[models.MiniAssetHolding] {
+	return NewIterator(ctx, func(ctx context.Context, nextToken string) ([]models.MiniAssetHolding, string, error) {
+		response, err := s.NextToken(nextToken).Do(ctx, headers...)
+		return response.Balances, response.NextToken, err
+	})
+}
+
+// Iterator returns an Iterator over the accounts matching s.
+func (s *SearchAccounts) Iterator(ctx context.Context, headers ...*common.Header) *Iterator[models.Account] {
+	return NewIterator(ctx, func(ctx context.Context, nextToken string) ([]models.Account, string, error) {
+		response, err := s.NextToken(nextToken).Do(ctx, headers...)
+		return response.Accounts, response.NextToken, err
+	})
+}
+
+// Iterator returns an Iterator over the assets matching s.
+func (s *SearchForAssets) Iterator(ctx context.Context, headers ...*common.Header) *Iterator[models.Asset] {
+	return NewIterator(ctx, func(ctx context.Context, nextToken string) ([]models.Asset, string, error) {
+		response, err := s.NextToken(nextToken).Do(ctx, headers...)
+		return response.Assets, response.NextToken, err
+	})
+}
+
+// Iterator returns an Iterator over the applications matching s.
+func (s *SearchForApplications) Iterator(ctx context.Context, headers ...*common.Header) *Iterator[models.Application] {
+	return NewIterator(ctx, func(ctx context.Context, nextToken string) ([]models.Application, string, error) {
+		response, err := s.Next(nextToken).Do(ctx, headers...)
+		return response.Applications, response.NextToken, err
+	})
+}
+
+// Iterator returns an Iterator over the boxes of the application.
+func (s *SearchForApplicationBoxes) Iterator(ctx context.Context, headers ...*common.Header) *Iterator[models.BoxDescriptor] {
+	return NewIterator(ctx, func(ctx context.Context, nextToken string) ([]models.BoxDescriptor, string, error) {
+		response, err := s.Next(nextToken).Do(ctx, headers...)
+		return response.Boxes, response.NextToken, err
+	})
+}
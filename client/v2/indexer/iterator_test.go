@@ -0,0 +1,98 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+)
+
+func TestIteratorFollowsNextToken(t *testing.T) {
+	const total = 7
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		require.Equal(t, "/v2/transactions", r.URL.Path)
+		require.Equal(t, "pay", r.URL.Query().Get("tx-type"))
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		require.NoError(t, err)
+		start := 0
+		if next := r.URL.Query().Get("next"); next != "" {
+			start, err = strconv.Atoi(next)
+			require.NoError(t, err)
+		}
+
+		var response models.TransactionsResponse
+		for i := start; i < total && i < start+limit; i++ {
+			response.Transactions = append(response.Transactions, models.Transaction{Id: fmt.Sprint(i)})
+		}
+		// like the indexer, return a token as long as the page is full
+		if len(response.Transactions) == limit {
+			response.NextToken = fmt.Sprint(start + limit)
+		}
+		w.Write(json.Encode(response))
+	}))
+	defer server.Close()
+
+	client, err := MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	collect := func(it *Iterator[models.Transaction]) (ids []string) {
+		for it.Next() {
+			ids = append(ids, it.Item().Id)
+		}
+		require.NoError(t, it.Err())
+		return
+	}
+
+	it := client.SearchForTransactions().TxType("pay").Limit(3).Iterator(context.Background())
+	require.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6"}, collect(it))
+	require.Equal(t, 3, requests)
+	require.False(t, it.Next())
+
+	// a final full page is followed by an empty one
+	requests = 0
+	it = client.SearchForTransactions().TxType("pay").Limit(7).Iterator(context.Background())
+	require.Len(t, collect(it), total)
+	require.Equal(t, 2, requests)
+
+	requests = 0
+	it = client.SearchForTransactions().TxType("pay").Limit(3).Iterator(context.Background()).MaxItems(4)
+	require.Equal(t, []string{"0", "1", "2", "3"}, collect(it))
+	require.Equal(t, 2, requests)
+}
+
+func TestIteratorStops(t *testing.T) {
+	fetchErr := errors.New("fetch failed")
+	calls := 0
+	it := NewIterator(context.Background(), func(ctx context.Context, nextToken string) ([]int, string, error) {
+		calls++
+		if nextToken == "" {
+			return []int{1}, "next", nil
+		}
+		return nil, "", fetchErr
+	})
+	require.True(t, it.Next())
+	require.Equal(t, 1, it.Item())
+	require.False(t, it.Next())
+	require.ErrorIs(t, it.Err(), fetchErr)
+	require.False(t, it.Next())
+	require.Equal(t, 2, calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it = NewIterator(ctx, func(ctx context.Context, nextToken string) ([]int, string, error) {
+		cancel()
+		return []int{1}, "next", nil
+	})
+	require.True(t, it.Next())
+	require.False(t, it.Next())
+	require.ErrorIs(t, it.Err(), context.Canceled)
+}
@@ -0,0 +1,15 @@
+package indexer
+
+import (
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// MakeClientForNetwork is the factory for constructing a Client for the public
+// indexer endpoint of network, such as types.MainNet, of the provider of options,
+// Nodely by default, or for options.URL.
+func MakeClientForNetwork(network types.KnownNetwork, options common.ProviderOptions) (c *Client, err error) {
+	commonClient, err := common.MakeClientForNetwork(network, true, authHeader, options)
+	c = (*Client)(commonClient)
+	return
+}
@@ -0,0 +1,87 @@
+package indexer
+
+import (
+	"encoding/base64"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// SigType is a value of the sig-type filter of transaction searches.
+type SigType string
+
+const (
+	// SigTypeSig selects transactions signed by a single key.
+	SigTypeSig SigType = "sig"
+	// SigTypeMsig selects transactions signed by a multisig.
+	SigTypeMsig SigType = "msig"
+	// SigTypeLsig selects transactions signed by a logic signature.
+	SigTypeLsig SigType = "lsig"
+)
+
+// AddressRole is a value of the address-role filter of transaction searches.
+type AddressRole string
+
+const (
+	// AddressRoleSender selects transactions sent by the address.
+	AddressRoleSender AddressRole = "sender"
+	// AddressRoleReceiver selects transactions received by the address,
+	// including as a close to address unless ExcludeCloseTo is set.
+	AddressRoleReceiver AddressRole = "receiver"
+	// AddressRoleFreezeTarget selects asset freeze transactions targeting the
+	// address.
+	AddressRoleFreezeTarget AddressRole = "freeze-target"
+)
+
+// GroupId lookup transactions by group ID. The group-id parameter is missing
+// from the generated SearchForTransactionsParams, so it is sent through the
+// client of the request instead.
+func (s *SearchForTransactions) GroupId(GroupId []byte) *SearchForTransactions {
+	if len(GroupId) > 0 {
+		s.c = (*Client)((*common.Client)(s.c).WithQueryParameter("group-id", base64.StdEncoding.EncodeToString(GroupId)))
+	}
+
+	return s
+}
+
+// TxTypeFilter is TxType taking one of the types.TxType constants. Untyped
+// string constants still convert implicitly, so only the named constants are
+// checked by the compiler; string variables need an explicit conversion.
+func (s *SearchForTransactions) TxTypeFilter(txType types.TxType) *SearchForTransactions {
+	return s.TxType(string(txType))
+}
+
+// SigTypeFilter is SigType taking one of the SigType constants.
+func (s *SearchForTransactions) SigTypeFilter(sigType SigType) *SearchForTransactions {
+	return s.SigType(string(sigType))
+}
+
+// AddressRoleFilter is AddressRole taking one of the AddressRole constants.
+func (s *SearchForTransactions) AddressRoleFilter(role AddressRole) *SearchForTransactions {
+	return s.AddressRole(string(role))
+}
+
+// TxTypeFilter is TxType taking one of the types.TxType constants.
+func (s *LookupAccountTransactions) TxTypeFilter(txType types.TxType) *LookupAccountTransactions {
+	return s.TxType(string(txType))
+}
+
+// SigTypeFilter is SigType taking one of the SigType constants.
+func (s *LookupAccountTransactions) SigTypeFilter(sigType SigType) *LookupAccountTransactions {
+	return s.SigType(string(sigType))
+}
+
+// TxTypeFilter is TxType taking one of the types.TxType constants.
+func (s *LookupAssetTransactions) TxTypeFilter(txType types.TxType) *LookupAssetTransactions {
+	return s.TxType(string(txType))
+}
+
+// SigTypeFilter is SigType taking one of the SigType constants.
+func (s *LookupAssetTransactions) SigTypeFilter(sigType SigType) *LookupAssetTransactions {
+	return s.SigType(string(sigType))
+}
+
+// AddressRoleFilter is AddressRole taking one of the AddressRole constants.
+func (s *LookupAssetTransactions) AddressRoleFilter(role AddressRole) *LookupAssetTransactions {
+	return s.AddressRole(string(role))
+}
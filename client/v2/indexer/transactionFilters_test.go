@@ -0,0 +1,31 @@
+package indexer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+)
+
+func TestSearchForTransactionsGroupId(t *testing.T) {
+	var groupIds []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "pay", r.URL.Query().Get("tx-type"))
+		groupIds = append(groupIds, r.URL.Query().Get("group-id"))
+		w.Write(json.Encode(models.TransactionsResponse{}))
+	}))
+	defer server.Close()
+
+	client, err := MakeClient(server.URL, "")
+	require.NoError(t, err)
+	_, err = client.SearchForTransactions().GroupId([]byte{0xfb, 0xff}).TxTypeFilter("pay").Do(context.Background())
+	require.NoError(t, err)
+	_, err = client.SearchForTransactions().TxTypeFilter("pay").Do(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"+/8=", ""}, groupIds)
+}
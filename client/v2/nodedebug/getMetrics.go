@@ -0,0 +1,167 @@
+package nodedebug
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+)
+
+// GetMetrics returns the Prometheus metrics of the node, parsed from the text
+// exposition format, in the order the node lists them. Nodes only serve them
+// when metrics are enabled; otherwise the request fails with a
+// common.NotFound error.
+type GetMetrics struct {
+	c *Client
+}
+
+// Do performs the HTTP request
+func (s *GetMetrics) Do(ctx context.Context, headers ...*common.Header) (response []MetricFamily, err error) {
+	var text string
+	if err = s.c.c.Get(ctx, &text, "/metrics", nil, headers); err != nil {
+		return
+	}
+	return parseMetrics(text)
+}
+
+// metricSuffixes end the names of the samples of histograms and summaries
+// other than their quantiles.
+var metricSuffixes = []string{"_bucket", "_sum", "_count"}
+
+// parseMetrics parses metrics in the Prometheus text exposition format.
+// Samples without a TYPE line form untyped families.
+func parseMetrics(text string) ([]MetricFamily, error) {
+	var families []MetricFamily
+	index := make(map[string]int)
+	family := func(name string) *MetricFamily {
+		i, ok := index[name]
+		if !ok {
+			i = len(families)
+			index[name] = i
+			families = append(families, MetricFamily{Name: name, Type: "untyped"})
+		}
+		return &families[i]
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if comment, ok := strings.CutPrefix(line, "#"); ok {
+			fields := strings.Fields(comment)
+			if len(fields) < 3 {
+				continue
+			}
+			switch fields[0] {
+			case "HELP":
+				_, help, _ := strings.Cut(strings.TrimSpace(comment)[len("HELP "):], fields[1])
+				family(fields[1]).Help = unescapeMetricHelp(strings.TrimSpace(help))
+			case "TYPE":
+				family(fields[1]).Type = fields[2]
+			}
+			continue
+		}
+
+		metric, err := parseMetricSample(line)
+		if err != nil {
+			return nil, fmt.Errorf("metrics line %d: %w", lineNumber, err)
+		}
+		name := metric.Name
+		if _, ok := index[name]; !ok {
+			for _, suffix := range metricSuffixes {
+				base, ok := strings.CutSuffix(name, suffix)
+				if i, found := index[base]; ok && found && (families[i].Type == "histogram" || families[i].Type == "summary") {
+					name = base
+					break
+				}
+			}
+		}
+		f := family(name)
+		f.Metrics = append(f.Metrics, metric)
+	}
+	return families, scanner.Err()
+}
+
+// parseMetricSample parses a sample line: a name, optional labels in braces,
+// a value and an optional timestamp.
+func parseMetricSample(line string) (Metric, error) {
+	var metric Metric
+	end := strings.IndexAny(line, "{ \t")
+	if end <= 0 {
+		return metric, fmt.Errorf("invalid sample %q", line)
+	}
+	metric.Name = line[:end]
+	rest := line[end:]
+
+	if strings.HasPrefix(rest, "{") {
+		labels, remaining, err := parseMetricLabels(rest[1:])
+		if err != nil {
+			return metric, err
+		}
+		metric.Labels = labels
+		rest = remaining
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return metric, fmt.Errorf("invalid sample %q", line)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return metric, fmt.Errorf("invalid value of %s: %w", metric.Name, err)
+	}
+	metric.Value = value
+	if len(fields) == 2 {
+		if metric.Timestamp, err = strconv.ParseInt(fields[1], 10, 64); err != nil {
+			return metric, fmt.Errorf("invalid timestamp of %s: %w", metric.Name, err)
+		}
+	}
+	return metric, nil
+}
+
+// parseMetricLabels parses labels up to the closing brace, and returns them
+// with what follows the brace.
+func parseMetricLabels(s string) (map[string]string, string, error) {
+	labels := make(map[string]string)
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		if strings.HasPrefix(s, "}") {
+			return labels, s[1:], nil
+		}
+		name, value, ok := strings.Cut(s, "=")
+		if !ok || !strings.HasPrefix(value, `"`) {
+			return nil, "", fmt.Errorf("invalid labels %q", s)
+		}
+
+		var b strings.Builder
+		i := 1
+		for ; i < len(value) && value[i] != '"'; i++ {
+			c := value[i]
+			if c == '\\' && i+1 < len(value) {
+				i++
+				switch value[i] {
+				case 'n':
+					c = '\n'
+				default:
+					c = value[i]
+				}
+			}
+			b.WriteByte(c)
+		}
+		if i == len(value) {
+			return nil, "", fmt.Errorf("unterminated label value %q", value)
+		}
+		labels[strings.TrimSpace(name)] = b.String()
+		s = value[i+1:]
+	}
+}
+
+// unescapeMetricHelp unescapes the backslashes and line feeds of a HELP line.
+func unescapeMetricHelp(help string) string {
+	return strings.NewReplacer(`\\`, `\`, `\n`, "\n").Replace(help)
+}
@@ -0,0 +1,119 @@
+package nodedebug
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+)
+
+const metricsText = `# HELP algod_ledger_round Last round
+# TYPE algod_ledger_round gauge
+algod_ledger_round 1234
+# HELP algod_network_sent_bytes_total Bytes sent, by tag\nand peer
+# TYPE algod_network_sent_bytes_total counter
+algod_network_sent_bytes_total{tag="AV",peer="a\"b"} 10 1700000000000
+algod_network_sent_bytes_total{tag="TX"} 2.5e+06
+# TYPE algod_latency histogram
+algod_latency_bucket{le="0.5"} 3
+algod_latency_bucket{le="+Inf"} 4
+algod_latency_sum 1.5
+algod_latency_count 4
+algod_untyped NaN
+`
+
+func TestParseMetrics(t *testing.T) {
+	families, err := parseMetrics(metricsText)
+	require.NoError(t, err)
+	require.Len(t, families, 4)
+
+	require.Equal(t, MetricFamily{
+		Name: "algod_ledger_round", Help: "Last round", Type: "gauge",
+		Metrics: []Metric{{Name: "algod_ledger_round", Value: 1234}},
+	}, families[0])
+
+	sent := families[1]
+	require.Equal(t, "counter", sent.Type)
+	require.Equal(t, "Bytes sent, by tag\nand peer", sent.Help)
+	require.Equal(t, []Metric{
+		{Name: "algod_network_sent_bytes_total", Labels: map[string]string{"tag": "AV", "peer": `a"b`}, Value: 10, Timestamp: 1700000000000},
+		{Name: "algod_network_sent_bytes_total", Labels: map[string]string{"tag": "TX"}, Value: 2.5e6},
+	}, sent.Metrics)
+
+	latency := families[2]
+	require.Equal(t, "histogram", latency.Type)
+	require.Len(t, latency.Metrics, 4)
+	require.Equal(t, "algod_latency_bucket", latency.Metrics[1].Name)
+	require.Equal(t, 4.0, latency.Metrics[1].Value)
+	require.Equal(t, "+Inf", latency.Metrics[1].Labels["le"])
+	require.Equal(t, "algod_latency_count", latency.Metrics[3].Name)
+
+	require.Equal(t, "untyped", families[3].Type)
+	require.True(t, math.IsNaN(families[3].Metrics[0].Value))
+
+	for _, invalid := range []string{"metric", "metric{a=1} 2", `metric{a="1} 2`, "metric one", "metric 1 2 3"} {
+		_, err := parseMetrics(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestGetMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/metrics", r.URL.Path)
+		w.Write([]byte(metricsText))
+	}))
+	defer server.Close()
+	algodClient, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+	client := MakeClient(algodClient)
+
+	families, err := client.GetMetrics().Do(context.Background())
+	require.NoError(t, err)
+	require.Len(t, families, 4)
+
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+	algodClient, err = algod.MakeClient(notFound.URL, "")
+	require.NoError(t, err)
+	client = MakeClient(algodClient)
+	_, err = client.GetMetrics().Do(context.Background())
+	require.ErrorAs(t, err, &common.NotFound{})
+}
+
+func TestSettingsProf(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/debug/settings/pprof", r.URL.Path)
+		if r.Method == http.MethodPut {
+			b, _ := io.ReadAll(r.Body)
+			body = string(b)
+		}
+		w.Write([]byte(`{"block-rate":1,"mutex-rate":2}`))
+	}))
+	defer server.Close()
+	algodClient, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+	client := MakeClient(algodClient)
+
+	settings, err := client.GetSettingsProf().Do(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), *settings.BlockRate)
+	require.Equal(t, uint64(2), *settings.MutexRate)
+
+	blockRate, mutexRate := uint64(1000), uint64(0)
+	_, err = client.PutSettingsProf(SettingsProf{BlockRate: &blockRate, MutexRate: &mutexRate}).Do(context.Background())
+	require.NoError(t, err)
+	require.JSONEq(t, `{"block-rate":1000,"mutex-rate":0}`, body)
+
+	// a nil rate is left unchanged
+	_, err = client.PutSettingsProf(SettingsProf{BlockRate: &blockRate}).Do(context.Background())
+	require.NoError(t, err)
+	require.JSONEq(t, `{"block-rate":1000,"mutex-rate":null}`, body)
+}
@@ -0,0 +1,19 @@
+package nodedebug
+
+import (
+	"context"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+)
+
+// GetSettingsProf retrieves the current settings for blocking and mutex
+// profiles
+type GetSettingsProf struct {
+	c *Client
+}
+
+// Do performs the HTTP request
+func (s *GetSettingsProf) Do(ctx context.Context, headers ...*common.Header) (response SettingsProf, err error) {
+	err = s.c.c.Get(ctx, &response, "/debug/settings/pprof", nil, headers)
+	return
+}
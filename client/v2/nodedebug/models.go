@@ -0,0 +1,48 @@
+package nodedebug
+
+// SettingsProf algod mutex and blocking profiling state.
+type SettingsProf struct {
+	// BlockRate the rate of blocking events. The profiler aims to sample an average
+	// of one blocking event per rate nanoseconds spent blocked. To turn off
+	// profiling entirely, pass rate 0. Nil leaves the rate unchanged.
+	BlockRate *uint64 `json:"block-rate"`
+
+	// MutexRate the rate of mutex events. On average 1/rate events are reported. To
+	// turn off profiling entirely, pass rate 0. Nil leaves the rate unchanged.
+	MutexRate *uint64 `json:"mutex-rate"`
+}
+
+// MetricFamily a family of Prometheus metrics, as exposed by the metrics endpoint
+// of a node.
+type MetricFamily struct {
+	// Name name of the family.
+	Name string `json:"name"`
+
+	// Help description of the family, if given.
+	Help string `json:"help,omitempty"`
+
+	// Type type of the family: counter, gauge, histogram, summary or untyped.
+	Type string `json:"type"`
+
+	// Metrics samples of the family. Histograms and summaries have several samples
+	// per series, their buckets or quantiles, sum and count, told apart by their
+	// name and labels.
+	Metrics []Metric `json:"metrics"`
+}
+
+// Metric a sample of a Prometheus metric.
+type Metric struct {
+	// Name name of the sample, the name of its family possibly followed by a
+	// suffix such as _bucket, _sum or _count.
+	Name string `json:"name"`
+
+	// Labels labels of the sample.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Value value of the sample.
+	Value float64 `json:"value"`
+
+	// Timestamp timestamp of the sample in milliseconds since the epoch, zero if not
+	// given.
+	Timestamp int64 `json:"timestamp,omitempty"`
+}
@@ -0,0 +1,36 @@
+// Package nodedebug calls the monitoring and debugging endpoints of an algod
+// node: its Prometheus metrics and its profiling settings.
+//
+// These endpoints are not part of the algod API specification the algod
+// package is generated from, so they are kept apart, in this hand-written
+// package, where regenerating that package does not remove them.
+package nodedebug
+
+import (
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+)
+
+// Client calls the monitoring and debugging endpoints of a node, through the
+// algod client of that node.
+type Client struct {
+	c *common.Client
+}
+
+// MakeClient returns a Client of the node of algodClient, sharing its
+// address, token, headers, interceptors and retry policy.
+func MakeClient(algodClient *algod.Client) *Client {
+	return &Client{c: (*common.Client)(algodClient)}
+}
+
+func (c *Client) GetMetrics() *GetMetrics {
+	return &GetMetrics{c: c}
+}
+
+func (c *Client) GetSettingsProf() *GetSettingsProf {
+	return &GetSettingsProf{c: c}
+}
+
+func (c *Client) PutSettingsProf(settings SettingsProf) *PutSettingsProf {
+	return &PutSettingsProf{c: c, settings: settings}
+}
@@ -0,0 +1,21 @@
+package nodedebug
+
+import (
+	"context"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+)
+
+// PutSettingsProf enables blocking and mutex profiles, and returns the old
+// settings. Rates left nil are not changed.
+type PutSettingsProf struct {
+	c *Client
+
+	settings SettingsProf
+}
+
+// Do performs the HTTP request
+func (s *PutSettingsProf) Do(ctx context.Context, headers ...*common.Header) (response SettingsProf, err error) {
+	err = s.c.c.Put(ctx, &response, "/debug/settings/pprof", nil, headers, s.settings)
+	return
+}
@@ -0,0 +1,66 @@
+// Command algogen generates a typed Go client of an application from its
+// ARC-56 specification or ARC-4 contract description, see arc56.Generate.
+//
+// Usage:
+//
+//	algogen -spec app.arc56.json [-out client.go] [-package name]
+//
+// The client is written to standard output without -out. The package defaults
+// to the one running go generate, so that a Go file of the package may have:
+//
+//	//go:generate go run github.com/algorand/go-algorand-sdk/v2/cmd/algogen -spec app.arc56.json -out app_client.go
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/algorand/go-algorand-sdk/v2/arc56"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Getenv("GOPACKAGE"), os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "algogen:", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the client as asked by args, in goPackage if args do not name
+// a package, writing it to stdout if args do not name an output file.
+func run(args []string, goPackage string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("algogen", flag.ContinueOnError)
+	specPath := flags.String("spec", "", "path of the ARC-56 specification or ARC-4 contract description")
+	outPath := flags.String("out", "", "path of the generated Go file, standard output if empty")
+	packageName := flags.String("package", goPackage, "package of the generated Go file, that running go generate by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *specPath == "" {
+		return errors.New("-spec is required")
+	}
+	if *packageName == "" {
+		return errors.New("-package is required outside go generate")
+	}
+
+	data, err := os.ReadFile(*specPath)
+	if err != nil {
+		return err
+	}
+	spec, err := arc56.ParseDescription(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *specPath, err)
+	}
+	source, err := arc56.Generate(spec, *packageName)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *specPath, err)
+	}
+
+	if *outPath == "" {
+		_, err = stdout.Write(source)
+		return err
+	}
+	return os.WriteFile(*outPath, source, 0o644)
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const contractJSON = `{
+	"name": "calculator",
+	"methods": [
+		{"name": "add", "args": [{"type": "uint64", "name": "a"}, {"type": "uint64", "name": "b"}], "returns": {"type": "uint128"}}
+	]
+}`
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "calculator.json")
+	require.NoError(t, os.WriteFile(specPath, []byte(contractJSON), 0o644))
+
+	var stdout bytes.Buffer
+	require.NoError(t, run([]string{"-spec", specPath}, "calc", &stdout))
+	require.Contains(t, stdout.String(), "package calc")
+	require.Contains(t, stdout.String(), "func (c *CalculatorClient) Add(ctx context.Context, a uint64, b uint64) (*big.Int, error)")
+	require.Contains(t, stdout.String(), "func (c *CalculatorClient) ComposeAdd(ctx context.Context, atc *transaction.AtomicTransactionComposer, a uint64, b uint64) error")
+
+	outPath := filepath.Join(dir, "client.go")
+	require.NoError(t, run([]string{"-spec", specPath, "-out", outPath, "-package", "other"}, "calc", &stdout))
+	written, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	require.Contains(t, string(written), "package other")
+
+	require.Error(t, run(nil, "calc", &stdout))
+	require.Error(t, run([]string{"-spec", specPath}, "", &stdout))
+	require.Error(t, run([]string{"-spec", filepath.Join(dir, "missing.json")}, "calc", &stdout))
+}
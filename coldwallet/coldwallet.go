@@ -0,0 +1,237 @@
+// Package coldwallet supports offline signing: transactions are exported in
+// a bundle, carried to an air-gapped signer which checks and signs them, and
+// the signed bundle carried back to be sent. A bundle shows a human-readable
+// summary of each transaction, and is checked at every step against the hash
+// of its transactions, which signing does not change, and for the integrity of
+// its groups.
+package coldwallet
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// Version is the version of the bundles made by this package.
+const Version = 1
+
+// hashPrefix separates the hashes of bundles from other hashes.
+const hashPrefix = "coldwallet"
+
+// Entry is a transaction of a bundle.
+type Entry struct {
+	// TxID is the ID of the transaction.
+	TxID string `codec:"txid"`
+	// Summary describes the transaction for the signer to review.
+	Summary string `codec:"summary"`
+	// Txn is the msgpack encoding of the transaction as a SignedTxn, without
+	// signature until signed.
+	Txn []byte `codec:"txn"`
+}
+
+// Bundle is a set of transactions to sign offline.
+type Bundle struct {
+	Version int     `codec:"version"`
+	Entries []Entry `codec:"transactions"`
+	// Hash is the hash of the transactions, without their signatures.
+	Hash []byte `codec:"hash"`
+}
+
+// NewBundle returns a bundle of txns to sign, in order. Grouped transactions
+// must be given with the rest of their group, in its order.
+func NewBundle(txns []types.Transaction) (*Bundle, error) {
+	stxns := make([]types.SignedTxn, len(txns))
+	for i, txn := range txns {
+		stxns[i].Txn = txn
+	}
+	b := &Bundle{Version: Version}
+	b.set(stxns)
+	b.Hash = hash(txns)
+	if err := b.Verify(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Decode decodes and verifies an encoded bundle.
+func Decode(data []byte) (*Bundle, error) {
+	var b Bundle
+	if err := json.Decode(data, &b); err != nil {
+		return nil, fmt.Errorf("could not decode bundle: %w", err)
+	}
+	if err := b.Verify(); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// Encode returns the JSON encoding of the bundle, to write to the medium
+// carried to or from the signer.
+func (b *Bundle) Encode() []byte {
+	return json.Encode(b)
+}
+
+// SignedTxns returns the transactions of the bundle, signed or not.
+func (b *Bundle) SignedTxns() ([]types.SignedTxn, error) {
+	stxns := make([]types.SignedTxn, len(b.Entries))
+	for i, entry := range b.Entries {
+		if err := msgpack.Decode(entry.Txn, &stxns[i]); err != nil {
+			return nil, fmt.Errorf("could not decode transaction %d: %w", i, err)
+		}
+	}
+	return stxns, nil
+}
+
+// Signed returns the signed transactions of the bundle, to send, or an error
+// if some are not signed.
+func (b *Bundle) Signed() ([]types.SignedTxn, error) {
+	if err := b.Verify(); err != nil {
+		return nil, err
+	}
+	stxns, err := b.SignedTxns()
+	if err != nil {
+		return nil, err
+	}
+	for _, stxn := range stxns {
+		if !signed(stxn) {
+			return nil, errNotSigned
+		}
+	}
+	return stxns, nil
+}
+
+// Summary returns the summaries of the transactions of the bundle, with its
+// hash for the signer to compare with the one shown when it was exported.
+func (b *Bundle) Summary() string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "Bundle %x, %d transactions\n", b.Hash, len(b.Entries))
+	for i, entry := range b.Entries {
+		fmt.Fprintf(&s, "\nTransaction %d\n%s", i, entry.Summary)
+	}
+	return s.String()
+}
+
+// Sign signs the unsigned transactions of the bundle sent by
+// account.Address, with its key or the one of its AuthAddr, and returns how
+// many it signed. The bundle is verified first.
+func (b *Bundle) Sign(account crypto.Account) (int, error) {
+	if err := b.Verify(); err != nil {
+		return 0, err
+	}
+	stxns, err := b.SignedTxns()
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for i, stxn := range stxns {
+		if stxn.Txn.Sender != account.Address || signed(stxn) {
+			continue
+		}
+		_, encoded, err := account.SignTransaction(stxn.Txn)
+		if err != nil {
+			return 0, fmt.Errorf("could not sign transaction %d: %w", i, err)
+		}
+		if err := msgpack.Decode(encoded, &stxns[i]); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	b.set(stxns)
+	return count, nil
+}
+
+// Verify checks the bundle: its transactions must match its hash, their IDs
+// and summaries, and their signatures, if signed, be valid. Grouped
+// transactions must be together with the rest of their group, in its order.
+func (b *Bundle) Verify() error {
+	if b.Version != Version {
+		return fmt.Errorf("%w %d", errUnsupportedVersion, b.Version)
+	}
+	if len(b.Entries) == 0 {
+		return errEmptyBundle
+	}
+	stxns, err := b.SignedTxns()
+	if err != nil {
+		return err
+	}
+
+	txns := make([]types.Transaction, len(stxns))
+	for i, stxn := range stxns {
+		txns[i] = stxn.Txn
+		entry := b.Entries[i]
+		if txid := crypto.GetTxID(stxn.Txn); entry.TxID != txid {
+			return fmt.Errorf("transaction %d has ID %s, not %s", i, txid, entry.TxID)
+		}
+		if entry.Summary != transaction.DescribeSigned(stxn) {
+			return fmt.Errorf("the summary of transaction %d does not describe it", i)
+		}
+		if signed(stxn) && !crypto.VerifySignedTxn(stxn) {
+			return fmt.Errorf("transaction %d has an invalid signature", i)
+		}
+	}
+	if string(hash(txns)) != string(b.Hash) {
+		return errHashMismatch
+	}
+	return verifyGroups(txns)
+}
+
+// set sets the entries of the bundle to stxns.
+func (b *Bundle) set(stxns []types.SignedTxn) {
+	b.Entries = make([]Entry, len(stxns))
+	for i, stxn := range stxns {
+		b.Entries[i] = Entry{
+			TxID:    crypto.GetTxID(stxn.Txn),
+			Summary: transaction.DescribeSigned(stxn),
+			Txn:     msgpack.Encode(stxn),
+		}
+	}
+}
+
+// verifyGroups checks that the grouped transactions of txns are together
+// with the rest of their group.
+func verifyGroups(txns []types.Transaction) error {
+	seen := make(map[types.Digest]bool)
+	for i, txn := range txns {
+		if txn.Group == (types.Digest{}) {
+			continue
+		}
+		if seen[txn.Group] && txns[i-1].Group != txn.Group {
+			return fmt.Errorf("transaction %d is apart from the rest of its group", i)
+		}
+		seen[txn.Group] = true
+	}
+
+	for start := 0; start < len(txns); {
+		group := txns[start].Group
+		end := start + 1
+		for end < len(txns) && group != (types.Digest{}) && txns[end].Group == group {
+			end++
+		}
+		if group != (types.Digest{}) {
+			if err := crypto.VerifyGroupID(txns[start:end]); err != nil {
+				return fmt.Errorf("invalid group at transaction %d: %w", start, err)
+			}
+		}
+		start = end
+	}
+	return nil
+}
+
+func hash(txns []types.Transaction) []byte {
+	data := []byte(hashPrefix)
+	for _, txn := range txns {
+		data = append(data, msgpack.Encode(txn)...)
+	}
+	digest := sha512.Sum512_256(data)
+	return digest[:]
+}
+
+func signed(stxn types.SignedTxn) bool {
+	return stxn.Sig != (types.Signature{}) || !stxn.Msig.Blank() || !stxn.Lsig.Blank()
+}
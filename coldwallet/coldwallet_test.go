@@ -0,0 +1,108 @@
+package coldwallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+var (
+	alice = crypto.TestAccount("alice", 0)
+	bob   = crypto.TestAccount("bob", 0)
+)
+
+func payments(t *testing.T) []types.Transaction {
+	params := types.SuggestedParams{Fee: 1000, FlatFee: true, FirstRoundValid: 1, LastRoundValid: 1001, GenesisHash: types.TestNet.GenesisHash[:]}
+	pay := func(from, to crypto.Account, amount uint64) types.Transaction {
+		tx, err := transaction.MakePaymentTxn(from.Address.String(), to.Address.String(), amount, nil, "", params)
+		require.NoError(t, err)
+		return tx
+	}
+	txns := []types.Transaction{pay(alice, bob, 1), pay(bob, alice, 2)}
+	gid, err := crypto.ComputeGroupID(txns)
+	require.NoError(t, err)
+	for i := range txns {
+		txns[i].Group = gid
+	}
+	return append(txns, pay(alice, bob, 3))
+}
+
+func TestBundle(t *testing.T) {
+	txns := payments(t)
+	b, err := NewBundle(txns)
+	require.NoError(t, err)
+	require.Contains(t, b.Summary(), "3 transactions")
+	require.Contains(t, b.Summary(), "0.000002 Algos")
+	_, err = b.Signed()
+	require.ErrorIs(t, err, errNotSigned)
+
+	// The signer imports the bundle, signs, and exports it back.
+	imported, err := Decode(b.Encode())
+	require.NoError(t, err)
+	require.Equal(t, b, imported)
+	count, err := imported.Sign(alice)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	imported, err = Decode(imported.Encode())
+	require.NoError(t, err)
+	count, err = imported.Sign(bob)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	require.Contains(t, imported.Summary(), "Signature:  single")
+
+	signed, err := imported.Signed()
+	require.NoError(t, err)
+	require.Len(t, signed, 3)
+	for i, stxn := range signed {
+		require.Equal(t, txns[i], stxn.Txn)
+		require.True(t, crypto.VerifySignedTxn(stxn))
+	}
+	require.Equal(t, b.Hash, imported.Hash)
+}
+
+func TestBundleTampering(t *testing.T) {
+	txns := payments(t)
+	b, err := NewBundle(txns)
+	require.NoError(t, err)
+
+	tampered, err := Decode(b.Encode())
+	require.NoError(t, err)
+	tampered.Entries[2].Summary = "harmless"
+	require.ErrorContains(t, tampered.Verify(), "summary of transaction 2")
+
+	// A transaction replaced along with its ID and summary.
+	tampered, err = Decode(b.Encode())
+	require.NoError(t, err)
+	stxns, err := tampered.SignedTxns()
+	require.NoError(t, err)
+	stxns[2].Txn.Amount = 1000000
+	tampered.set(stxns)
+	require.ErrorIs(t, tampered.Verify(), errHashMismatch)
+
+	// A corrupted signature.
+	tampered, err = Decode(b.Encode())
+	require.NoError(t, err)
+	_, err = tampered.Sign(alice)
+	require.NoError(t, err)
+	stxns, err = tampered.SignedTxns()
+	require.NoError(t, err)
+	stxns[0].Sig[0] ^= 1
+	tampered.set(stxns)
+	require.ErrorContains(t, tampered.Verify(), "transaction 0 has an invalid signature")
+
+	// Groups must be complete and together.
+	_, err = NewBundle(txns[1:])
+	require.ErrorContains(t, err, "invalid group at transaction 0")
+	_, err = NewBundle([]types.Transaction{txns[0], txns[2], txns[1]})
+	require.ErrorContains(t, err, "apart from the rest of its group")
+
+	_, err = NewBundle(nil)
+	require.ErrorIs(t, err, errEmptyBundle)
+	tampered.Version = 2
+	_, err = Decode(tampered.Encode())
+	require.ErrorIs(t, err, errUnsupportedVersion)
+}
@@ -0,0 +1,13 @@
+package coldwallet
+
+import (
+	"errors"
+)
+
+var errEmptyBundle = errors.New("the bundle has no transactions")
+
+var errUnsupportedVersion = errors.New("unsupported bundle version")
+
+var errHashMismatch = errors.New("the transactions of the bundle do not match its hash")
+
+var errNotSigned = errors.New("the bundle has unsigned transactions")
@@ -0,0 +1,118 @@
+package conduit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// AlgodImporter is an Importer of the blocks of an algod node.
+type AlgodImporter struct {
+	// Client is the client of the node.
+	Client *algod.Client
+	// Follower must be true if the node runs in follower mode. The ledger
+	// deltas of the blocks are then imported too, and the sync round of the
+	// node moved past each imported round, for it to keep fetching blocks.
+	Follower bool
+
+	ctx    context.Context
+	logger *slog.Logger
+}
+
+// Metadata returns the description of the importer.
+func (a *AlgodImporter) Metadata() Metadata {
+	return Metadata{
+		Name:        "algod",
+		Description: "Imports the blocks of an algod node, and their ledger deltas from a follower node.",
+	}
+}
+
+// Init prepares the importer. The node of a follower is moved to the first
+// round to import. cfg is not used, the importer being configured by its
+// fields.
+func (a *AlgodImporter) Init(ctx context.Context, initProvider InitProvider, cfg PluginConfig, logger *slog.Logger) error {
+	if a.Client == nil {
+		return errNoClient
+	}
+	a.ctx = ctx
+	a.logger = logger
+	if a.Follower {
+		if _, err := a.Client.SetSyncRound(uint64(initProvider.NextDBRound())).Do(ctx); err != nil {
+			return fmt.Errorf("could not set the sync round of the node: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close does nothing, the client needing no release.
+func (a *AlgodImporter) Close() error {
+	return nil
+}
+
+// GetGenesis returns the genesis of the node.
+func (a *AlgodImporter) GetGenesis() (*types.Genesis, error) {
+	if a.ctx == nil {
+		return nil, errNotInitialized
+	}
+	encoded, err := a.Client.GetGenesis().Do(a.ctx)
+	if err != nil {
+		return nil, err
+	}
+	var genesis types.Genesis
+	if err := json.LenientDecode([]byte(encoded), &genesis); err != nil {
+		return nil, fmt.Errorf("could not decode the genesis: %w", err)
+	}
+	return &genesis, nil
+}
+
+// GetBlock returns the block of round rnd, with its certificate, waiting for
+// the node to have it.
+func (a *AlgodImporter) GetBlock(rnd uint64) (BlockData, error) {
+	if a.ctx == nil {
+		return BlockData{}, errNotInitialized
+	}
+	status, err := a.Client.StatusAfterBlock(rnd - 1).Do(a.ctx)
+	if err != nil {
+		return BlockData{}, err
+	}
+	for status.LastRound < rnd {
+		if status, err = a.Client.StatusAfterBlock(status.LastRound).Do(a.ctx); err != nil {
+			return BlockData{}, err
+		}
+	}
+
+	raw, err := a.Client.BlockRaw(rnd).Do(a.ctx)
+	if err != nil {
+		return BlockData{}, err
+	}
+	var response models.BlockResponse
+	if err := msgpack.Decode(raw, &response); err != nil {
+		return BlockData{}, fmt.Errorf("could not decode block %d: %w", rnd, err)
+	}
+	blkData := BlockData{
+		BlockHeader: response.Block.BlockHeader,
+		Payset:      response.Block.Payset,
+		Certificate: response.Cert,
+	}
+
+	if a.Follower {
+		delta, err := a.Client.GetLedgerStateDelta(rnd).Do(a.ctx)
+		if err != nil {
+			return BlockData{}, fmt.Errorf("could not get the ledger delta of round %d: %w", rnd, err)
+		}
+		blkData.Delta = &delta
+		if _, err := a.Client.SetSyncRound(rnd + 1).Do(a.ctx); err != nil {
+			return BlockData{}, fmt.Errorf("could not set the sync round of the node: %w", err)
+		}
+	}
+	if a.logger != nil {
+		a.logger.Debug("imported block", "round", rnd, "transactions", len(blkData.Payset))
+	}
+	return blkData, nil
+}
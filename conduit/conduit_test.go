@@ -0,0 +1,170 @@
+package conduit
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// mockNode is an algod mock making a block with a payment each time asked to
+// wait for one.
+type mockNode struct {
+	t         *testing.T
+	mu        sync.Mutex
+	round     uint64
+	syncRound uint64
+}
+
+func (n *mockNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	path := r.URL.Path
+	switch {
+	case path == "/genesis":
+		w.Write(json.Encode(types.Genesis{Network: "mocknet", Proto: "future"}))
+	case strings.HasPrefix(path, "/v2/status/wait-for-block-after/"):
+		after, _ := strconv.ParseUint(strings.TrimPrefix(path, "/v2/status/wait-for-block-after/"), 10, 64)
+		if n.round <= after {
+			n.round = after + 1
+		}
+		w.Write(json.Encode(models.NodeStatus{LastRound: n.round}))
+	case strings.HasPrefix(path, "/v2/ledger/sync/"):
+		n.syncRound, _ = strconv.ParseUint(strings.TrimPrefix(path, "/v2/ledger/sync/"), 10, 64)
+	case strings.HasPrefix(path, "/v2/deltas/"):
+		round, _ := strconv.ParseUint(strings.TrimPrefix(path, "/v2/deltas/"), 10, 64)
+		var delta types.LedgerStateDelta
+		delta.PrevTimestamp = int64(round)
+		w.Write(msgpack.Encode(delta))
+	case strings.HasPrefix(path, "/v2/blocks/"):
+		round, _ := strconv.ParseUint(strings.TrimPrefix(path, "/v2/blocks/"), 10, 64)
+		var block types.Block
+		block.Round = types.Round(round)
+		var pay types.SignedTxnInBlock
+		pay.Txn.Type = types.PaymentTx
+		pay.Txn.Amount = types.MicroAlgos(round)
+		block.Payset = types.Payset{pay}
+		cert := map[string]interface{}{"rnd": round}
+		w.Write(msgpack.Encode(models.BlockResponse{Block: block, Cert: &cert}))
+	default:
+		n.t.Errorf("unexpected request %s", path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+type doubler struct {
+	config PluginConfig
+	closed bool
+}
+
+func (d *doubler) Metadata() Metadata {
+	return Metadata{Name: "doubler"}
+}
+
+func (d *doubler) Init(ctx context.Context, initProvider InitProvider, cfg PluginConfig, logger *slog.Logger) error {
+	d.config = cfg
+	if initProvider.GetGenesis().Network != "mocknet" {
+		return context.Canceled
+	}
+	return nil
+}
+
+func (d *doubler) Close() error {
+	d.closed = true
+	return nil
+}
+
+func (d *doubler) Process(input BlockData) (BlockData, error) {
+	input.Payset = append(input.Payset, input.Payset...)
+	return input, nil
+}
+
+type collector struct {
+	cancel    context.CancelFunc
+	next      types.Round
+	received  []BlockData
+	completed []uint64
+}
+
+func (c *collector) Metadata() Metadata {
+	return Metadata{Name: "collector"}
+}
+
+func (c *collector) Init(ctx context.Context, initProvider InitProvider, cfg PluginConfig, logger *slog.Logger) error {
+	c.next = initProvider.NextDBRound()
+	return nil
+}
+
+func (c *collector) Close() error {
+	return nil
+}
+
+func (c *collector) Receive(exportData BlockData) error {
+	c.received = append(c.received, exportData)
+	if len(c.received) == 3 {
+		c.cancel()
+	}
+	return nil
+}
+
+func (c *collector) OnComplete(input BlockData) error {
+	c.completed = append(c.completed, input.Round())
+	return nil
+}
+
+func TestPipeline(t *testing.T) {
+	node := &mockNode{t: t, round: 10}
+	server := httptest.NewServer(node)
+	t.Cleanup(server.Close)
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	processor := &doubler{}
+	exporter := &collector{cancel: cancel}
+	pipeline := Pipeline{
+		Importer:   &AlgodImporter{Client: client, Follower: true},
+		Processors: []Processor{processor},
+		Exporter:   exporter,
+		Configs:    map[string]PluginConfig{"doubler": {Config: "times: 2"}},
+	}
+	require.ErrorIs(t, pipeline.Run(ctx, 8), context.Canceled)
+
+	require.Equal(t, "times: 2", processor.config.Config)
+	require.True(t, processor.closed)
+	require.Equal(t, types.Round(8), exporter.next)
+	require.Equal(t, []uint64{8, 9, 10}, exporter.completed)
+	for i, blkData := range exporter.received {
+		round := uint64(8 + i)
+		require.Equal(t, round, blkData.Round())
+		require.Len(t, blkData.Payset, 2)
+		require.Equal(t, types.MicroAlgos(round), blkData.Payset[1].Txn.Amount)
+		require.NotNil(t, blkData.Certificate)
+		require.EqualValues(t, round, (*blkData.Certificate)["rnd"])
+		require.Equal(t, int64(round), blkData.Delta.PrevTimestamp)
+		require.Equal(t, types.Payset(blkData.Payset), blkData.Block().Payset)
+	}
+	node.mu.Lock()
+	require.Equal(t, uint64(11), node.syncRound)
+	node.mu.Unlock()
+}
+
+func TestPipelineWithoutPlugins(t *testing.T) {
+	require.ErrorIs(t, (&Pipeline{Exporter: &collector{}}).Run(context.Background(), 1), errNoImporter)
+	require.ErrorIs(t, (&Pipeline{Importer: &AlgodImporter{}}).Run(context.Background(), 1), errNoExporter)
+
+	_, err := (&AlgodImporter{}).GetBlock(1)
+	require.ErrorIs(t, err, errNotInitialized)
+}
@@ -0,0 +1,31 @@
+package conduit
+
+import (
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// BlockData is the data of a round passed between the plugins of a pipeline.
+// Its fields are tagged for the codecs of this SDK, and encode to the same
+// names as the json tags of the BlockData of Conduit.
+type BlockData struct {
+	// BlockHeader is the header of the block.
+	BlockHeader types.BlockHeader `codec:"block,omitempty"`
+	// Payset is the transactions of the block.
+	Payset []types.SignedTxnInBlock `codec:"payset,omitempty"`
+	// Delta is the changes of the ledger made by the block, if the importer
+	// provides them.
+	Delta *types.LedgerStateDelta `codec:"delta,omitempty"`
+	// Certificate is the agreement certificate of the block, if the importer
+	// provides it.
+	Certificate *map[string]interface{} `codec:"cert,omitempty"`
+}
+
+// Round returns the round of the block.
+func (blkData BlockData) Round() uint64 {
+	return uint64(blkData.BlockHeader.Round)
+}
+
+// Block returns the block made of the header and the payset.
+func (blkData BlockData) Block() types.Block {
+	return types.Block{BlockHeader: blkData.BlockHeader, Payset: blkData.Payset}
+}
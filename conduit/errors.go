@@ -0,0 +1,13 @@
+package conduit
+
+import (
+	"errors"
+)
+
+var errNoImporter = errors.New("the pipeline has no importer")
+
+var errNoExporter = errors.New("the pipeline has no exporter")
+
+var errNotInitialized = errors.New("the plugin is not initialized")
+
+var errNoClient = errors.New("the algod importer has no client")
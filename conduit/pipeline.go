@@ -0,0 +1,104 @@
+package conduit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// Pipeline runs the blocks of an Importer through Processors, in order, to an
+// Exporter, as Conduit does, for pipelines embedded in a program.
+type Pipeline struct {
+	Importer   Importer
+	Processors []Processor
+	Exporter   Exporter
+
+	// Configs are the configurations of the plugins, by their metadata name.
+	Configs map[string]PluginConfig
+	// Logger is given to the plugins, slog.Default() if nil.
+	Logger *slog.Logger
+}
+
+type initProvider struct {
+	genesis *types.Genesis
+	round   types.Round
+}
+
+func (p *initProvider) GetGenesis() *types.Genesis {
+	return p.genesis
+}
+
+func (p *initProvider) NextDBRound() types.Round {
+	return p.round
+}
+
+// Run initializes the plugins, the importer first, and exports the rounds
+// from round on until ctx is cancelled or a plugin fails, returning the
+// error. The plugins are closed when it returns.
+func (p *Pipeline) Run(ctx context.Context, round uint64) error {
+	if p.Importer == nil {
+		return errNoImporter
+	}
+	if p.Exporter == nil {
+		return errNoExporter
+	}
+	logger := p.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	plugins := []Plugin{p.Importer}
+	for _, processor := range p.Processors {
+		plugins = append(plugins, processor)
+	}
+	plugins = append(plugins, p.Exporter)
+	defer func() {
+		for _, plugin := range plugins {
+			if err := plugin.Close(); err != nil {
+				logger.Error("could not close plugin", "plugin", plugin.Metadata().Name, "error", err)
+			}
+		}
+	}()
+
+	provider := &initProvider{round: types.Round(round)}
+	for i, plugin := range plugins {
+		name := plugin.Metadata().Name
+		if err := plugin.Init(ctx, provider, p.Configs[name], logger.With("plugin", name)); err != nil {
+			return fmt.Errorf("could not initialize plugin %s: %w", name, err)
+		}
+		if i == 0 {
+			genesis, err := p.Importer.GetGenesis()
+			if err != nil {
+				return fmt.Errorf("could not get the genesis: %w", err)
+			}
+			provider.genesis = genesis
+		}
+	}
+
+	for ; ; round++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		blkData, err := p.Importer.GetBlock(round)
+		if err != nil {
+			return fmt.Errorf("could not import round %d: %w", round, err)
+		}
+		for _, processor := range p.Processors {
+			if blkData, err = processor.Process(blkData); err != nil {
+				return fmt.Errorf("could not process round %d with %s: %w", round, processor.Metadata().Name, err)
+			}
+		}
+		if err := p.Exporter.Receive(blkData); err != nil {
+			return fmt.Errorf("could not export round %d: %w", round, err)
+		}
+		for _, plugin := range plugins {
+			if callback, ok := plugin.(OnCompleteCallback); ok {
+				if err := callback.OnComplete(blkData); err != nil {
+					return fmt.Errorf("could not complete round %d with %s: %w", round, plugin.Metadata().Name, err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Package conduit defines importer, processor and exporter plugin interfaces
+// and the block data passed between them, modeled on those of Algorand
+// Conduit, so that data pipelines can be written against the types of this
+// SDK. It also provides an importer following an algod node and a Pipeline
+// running plugins without Conduit.
+//
+// These are not Conduit's interfaces, and plugins written against them
+// cannot be loaded by Conduit as they are. The methods have the same names
+// and arguments, with two differences: Init takes a *slog.Logger where
+// Conduit passes a *logrus.Logger, and the types are those of this package
+// rather than of Conduit's data and plugins packages. BlockData encodes to
+// the same field names as Conduit's, so the blocks one writes the other can
+// read. Porting a plugin to Conduit means changing these imports and its
+// logger.
+package conduit
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// Metadata describes a plugin.
+type Metadata struct {
+	Name         string
+	Description  string
+	Deprecated   bool
+	SampleConfig string
+}
+
+// PluginConfig is the configuration of a plugin.
+type PluginConfig struct {
+	// DataDir is a directory the plugin may keep its data in.
+	DataDir string
+	// Config is the configuration of the plugin, in a format of its choice,
+	// YAML with Conduit.
+	Config string
+}
+
+// InitProvider provides the state of the pipeline to plugins being
+// initialized.
+type InitProvider interface {
+	// GetGenesis returns the genesis of the network, nil when initializing
+	// the importer.
+	GetGenesis() *types.Genesis
+	// NextDBRound returns the first round the pipeline will process.
+	NextDBRound() types.Round
+}
+
+// Plugin is implemented by every plugin.
+type Plugin interface {
+	// Metadata returns the description of the plugin.
+	Metadata() Metadata
+	// Init prepares the plugin to process rounds from
+	// initProvider.NextDBRound().
+	Init(ctx context.Context, initProvider InitProvider, cfg PluginConfig, logger *slog.Logger) error
+	// Close releases the resources of the plugin.
+	Close() error
+}
+
+// Importer is the source of the blocks of a pipeline.
+type Importer interface {
+	Plugin
+	// GetGenesis returns the genesis of the network the blocks are from.
+	GetGenesis() (*types.Genesis, error)
+	// GetBlock returns the data of round rnd, waiting for it if needed.
+	GetBlock(rnd uint64) (BlockData, error)
+}
+
+// Processor transforms the blocks of a pipeline.
+type Processor interface {
+	Plugin
+	// Process returns input transformed.
+	Process(input BlockData) (BlockData, error)
+}
+
+// Exporter is the destination of the blocks of a pipeline.
+type Exporter interface {
+	Plugin
+	// Receive stores or forwards exportData.
+	Receive(exportData BlockData) error
+}
+
+// OnCompleteCallback may be implemented by plugins to be notified of the
+// blocks the pipeline has exported.
+type OnCompleteCallback interface {
+	OnComplete(input BlockData) error
+}
@@ -7,6 +7,9 @@ import (
 
 	"golang.org/x/crypto/ed25519"
 
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/protocol"
+	"github.com/algorand/go-algorand-sdk/v2/protocol/config"
 	"github.com/algorand/go-algorand-sdk/v2/types"
 )
 
@@ -19,8 +22,19 @@ type Account struct {
 	PublicKey  ed25519.PublicKey
 	PrivateKey ed25519.PrivateKey
 	Address    types.Address
+
+	// AuthAddr is set when Address has been rekeyed. PublicKey and PrivateKey
+	// are then the keys of AuthAddr, which signs for Address.
+	AuthAddr types.Address
 }
 
+// rekeyMinFee is the minimum fee of a rekey when the suggested params have no
+// MinFee, that of the current consensus version.
+var rekeyMinFee = config.Consensus[protocol.ConsensusCurrentVersion].MinTxnFee
+
+// rekeyAdditionalBytes mirrors transaction.NumOfAdditionalBytesAfterSigning.
+const rekeyAdditionalBytes = 75
+
 func init() {
 	addrLen := len(types.Address{})
 	pkLen := ed25519.PublicKeySize
@@ -51,6 +65,32 @@ func GenerateAccount() (kp Account) {
 	return
 }
 
+// GenerateAccountFromSeed derives the Account of the ed25519 key of seed,
+// which must be ed25519.SeedSize bytes. The same seed always gives the same
+// account.
+func GenerateAccountFromSeed(seed []byte) (Account, error) {
+	if len(seed) != ed25519.SeedSize {
+		return Account{}, errInvalidSeed
+	}
+	return AccountFromPrivateKey(ed25519.NewKeyFromSeed(seed))
+}
+
+// testAccountDomain separates the seeds of TestAccount from other hashes.
+const testAccountDomain = "algosdk-test-account"
+
+// TestAccount returns an account derived from name and index, the same for
+// every program, to give test fixtures stable addresses. Its key is public
+// knowledge: it must never hold real funds.
+func TestAccount(name string, index uint64) Account {
+	data := []byte(fmt.Sprintf("%s:%s:%d", testAccountDomain, name, index))
+	seed := sha512.Sum512_256(data)
+	account, err := GenerateAccountFromSeed(seed[:])
+	if err != nil {
+		panic(err)
+	}
+	return account
+}
+
 // AccountFromPrivateKey derives the remaining Account fields from only a
 // private key. The argument sk must have a length equal to
 // ed25519.PrivateKeySize.
@@ -75,6 +115,89 @@ func AccountFromPrivateKey(sk ed25519.PrivateKey) (account Account, err error) {
 	return
 }
 
+// Signer returns the address whose key signs for the account: AuthAddr if the
+// account has been rekeyed, otherwise Address.
+func (a Account) Signer() types.Address {
+	if !a.AuthAddr.IsZero() {
+		return a.AuthAddr
+	}
+	return a.Address
+}
+
+// checkKey returns an error if PrivateKey is not the key of Signer.
+func (a Account) checkKey() error {
+	addr, err := GenerateAddressFromSK(a.PrivateKey)
+	if err != nil {
+		return err
+	}
+	if addr != a.Signer() {
+		return errAccountKeyMismatch
+	}
+	return nil
+}
+
+// SignTransaction signs tx with the key of the account, setting AuthAddr on
+// the signed transaction when the key is not the key of the sender, and
+// returns the txid and the encoded signed transaction.
+func (a Account) SignTransaction(tx types.Transaction) (txid string, stxBytes []byte, err error) {
+	if err = a.checkKey(); err != nil {
+		return
+	}
+	return SignTransaction(a.PrivateKey, tx)
+}
+
+// SignBytes signs bytesToSign with the key of the account, as SignBytes does.
+func (a Account) SignBytes(bytesToSign []byte) (signature []byte, err error) {
+	if err = a.checkKey(); err != nil {
+		return
+	}
+	return SignBytes(a.PrivateKey, bytesToSign)
+}
+
+// Rekey returns an unsigned zero amount payment from the account to itself that
+// rekeys it to the address to. Rekeying to the account's own address removes a
+// previous rekey. Once the transaction is confirmed, the keys of to sign for
+// the account, so AuthAddr of an Account using them must be set to to.
+func (a Account) Rekey(to types.Address, params types.SuggestedParams) (types.Transaction, error) {
+	if len(params.GenesisHash) == 0 {
+		return types.Transaction{}, fmt.Errorf("rekey transaction must contain a genesisHash")
+	}
+
+	var gh types.Digest
+	copy(gh[:], params.GenesisHash)
+
+	tx := types.Transaction{
+		Type: types.PaymentTx,
+		Header: types.Header{
+			Sender:      a.Address,
+			Fee:         params.Fee,
+			FirstValid:  params.FirstRoundValid,
+			LastValid:   params.LastRoundValid,
+			GenesisID:   params.GenesisID,
+			GenesisHash: gh,
+			RekeyTo:     to,
+		},
+		PaymentTxnFields: types.PaymentTxnFields{
+			Receiver: a.Address,
+		},
+	}
+
+	// Same fee rule as the transaction package, with the minimum fee algod
+	// suggests
+	if !params.FlatFee {
+		minFee := types.MicroAlgos(params.MinFee)
+		if minFee == 0 {
+			minFee = types.MicroAlgos(rekeyMinFee)
+		}
+		size := uint64(len(msgpack.Encode(tx))) + rekeyAdditionalBytes
+		tx.Fee = types.MicroAlgos(size * uint64(params.Fee))
+		if tx.Fee < minFee {
+			tx.Fee = minFee
+		}
+	}
+	return tx, nil
+}
+
 /* Multisig Support */
 
 // MultisigAccount is a convenience type for holding multisig preimage data
@@ -1,11 +1,13 @@
 package crypto
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/ed25519"
 
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
 	"github.com/algorand/go-algorand-sdk/v2/mnemonic"
 	"github.com/algorand/go-algorand-sdk/v2/types"
 )
@@ -36,6 +38,27 @@ func TestGenerateAccount(t *testing.T) {
 	require.NotEqual(t, kp, kp2)
 }
 
+func TestGenerateAccountFromSeed(t *testing.T) {
+	// RFC 8032 test vector 1
+	seed, err := hex.DecodeString("9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60")
+	require.NoError(t, err)
+	account, err := GenerateAccountFromSeed(seed)
+	require.NoError(t, err)
+	require.Equal(t, "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a", hex.EncodeToString(account.PublicKey))
+	require.Equal(t, account.PublicKey, ed25519.PublicKey(account.Address[:]))
+
+	_, err = GenerateAccountFromSeed(seed[:31])
+	require.ErrorIs(t, err, errInvalidSeed)
+}
+
+func TestTestAccount(t *testing.T) {
+	alice := TestAccount("alice", 0)
+	require.Equal(t, "VD6MFPE5SMIGANNUILIHGTY5HP642JRJNJBNBPP6PVECQON5WBQH22FOPY", alice.Address.String())
+	require.Equal(t, alice, TestAccount("alice", 0))
+	require.NotEqual(t, alice.Address, TestAccount("alice", 1).Address)
+	require.NotEqual(t, alice.Address, TestAccount("bob", 0).Address)
+}
+
 func TestAccountFromPrivateKey(t *testing.T) {
 	exampleAccount := Account{
 		PrivateKey: ed25519.PrivateKey{0xd2, 0xdc, 0x4c, 0xcc, 0xe9, 0x98, 0x62, 0xff, 0xcf, 0x8c, 0xeb, 0x93, 0x6, 0xc4, 0x8d, 0xa6, 0x80, 0x50, 0x82, 0xa, 0xbb, 0x29, 0x95, 0x7a, 0xac, 0x82, 0x68, 0x9a, 0x8c, 0x49, 0x5a, 0x38, 0x5e, 0x67, 0x4f, 0x1c, 0xa, 0xee, 0xec, 0x37, 0x71, 0x89, 0x8f, 0x61, 0xc7, 0x6f, 0xf5, 0xd2, 0x4a, 0x19, 0x79, 0x3e, 0x2c, 0x91, 0xfa, 0x8, 0x51, 0x62, 0x63, 0xe3, 0x85, 0x73, 0xea, 0x42},
@@ -71,6 +94,73 @@ func TestAccountFromPrivateKey(t *testing.T) {
 	})
 }
 
+func TestAccountSigning(t *testing.T) {
+	account := GenerateAccount()
+	params := types.SuggestedParams{
+		Fee:             0,
+		FirstRoundValid: 1000,
+		LastRoundValid:  2000,
+		GenesisID:       "testnet-v1.0",
+		GenesisHash:     make([]byte, 32),
+	}
+
+	auth := GenerateAccount()
+	tx, err := account.Rekey(auth.Address, params)
+	require.NoError(t, err)
+	require.Equal(t, types.PaymentTx, tx.Type)
+	require.Equal(t, account.Address, tx.Sender)
+	require.Equal(t, account.Address, tx.Receiver)
+	require.Equal(t, auth.Address, tx.RekeyTo)
+	require.Equal(t, types.MicroAlgos(rekeyMinFee), tx.Fee)
+
+	txid, stxBytes, err := account.SignTransaction(tx)
+	require.NoError(t, err)
+	require.Equal(t, GetTxID(tx), txid)
+	var stx types.SignedTxn
+	require.NoError(t, msgpack.Decode(stxBytes, &stx))
+	require.True(t, VerifySignedTxn(stx))
+	require.True(t, stx.AuthAddr.IsZero())
+
+	// after the rekey, the keys of auth sign for account
+	rekeyed := auth
+	rekeyed.Address = account.Address
+	rekeyed.AuthAddr = auth.Address
+	require.Equal(t, auth.Address, rekeyed.Signer())
+
+	_, stxBytes, err = rekeyed.SignTransaction(tx)
+	require.NoError(t, err)
+	require.NoError(t, msgpack.Decode(stxBytes, &stx))
+	require.Equal(t, auth.Address, stx.AuthAddr)
+	require.True(t, VerifySignedTxn(stx))
+
+	sig, err := rekeyed.SignBytes([]byte("data"))
+	require.NoError(t, err)
+	require.True(t, VerifyBytes(auth.PublicKey, []byte("data"), sig))
+
+	// the keys must belong to the signer
+	stale := account
+	stale.AuthAddr = auth.Address
+	_, _, err = stale.SignTransaction(tx)
+	require.ErrorIs(t, err, errAccountKeyMismatch)
+	_, err = stale.SignBytes([]byte("data"))
+	require.ErrorIs(t, err, errAccountKeyMismatch)
+
+	params.Fee = 10
+	tx, err = account.Rekey(auth.Address, params)
+	require.NoError(t, err)
+	require.Greater(t, uint64(tx.Fee), uint64(rekeyMinFee))
+
+	// the minimum fee suggested by algod applies when it is set
+	params.MinFee = 5000
+	tx, err = account.Rekey(auth.Address, params)
+	require.NoError(t, err)
+	require.Equal(t, types.MicroAlgos(5000), tx.Fee)
+
+	params.GenesisHash = nil
+	_, err = account.Rekey(auth.Address, params)
+	require.Error(t, err)
+}
+
 func TestMultisigAccount_Address(t *testing.T) {
 	addr1, err := types.DecodeAddress("XMHLMNAVJIMAW2RHJXLXKKK4G3J3U6VONNO3BTAQYVDC3MHTGDP3J5OCRU")
 	require.NoError(t, err)
@@ -0,0 +1,188 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"sort"
+
+	"filippo.io/edwards25519"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// BatchEntry is an ed25519 signature checked by VerifyBatch.
+type BatchEntry struct {
+	PublicKey ed25519.PublicKey
+	Message   []byte
+	Signature []byte
+}
+
+// batchMinSize is the number of signatures below which they are verified one
+// at a time, as batching them saves less than it costs.
+const batchMinSize = 4
+
+// VerifyBatch checks many ed25519 signatures at once, faster than one at a
+// time when most are valid, and returns the indexes of the invalid ones, nil
+// if all are valid.
+//
+// Signatures are verified with the cofactored equation, in batches and one at
+// a time alike, so the result for an entry does not depend on the rest of the
+// batch. It accepts the signatures ed25519.Verify accepts, and also those
+// whose R or public key has a small order component the cofactor cancels.
+// Signatures whose public key or R is of small order or not canonically
+// encoded are verified with ed25519.Verify alone.
+func VerifyBatch(entries []BatchEntry) []int {
+	var failed []int
+	parsed := make([]parsedSignature, 0, len(entries))
+	for i, entry := range entries {
+		p, ok := parseSignature(entry)
+		if !ok {
+			if !verifySingle(entry) {
+				failed = append(failed, i)
+			}
+			continue
+		}
+		p.index = i
+		parsed = append(parsed, p)
+	}
+	failed = append(failed, verifyParsed(parsed)...)
+	sort.Ints(failed)
+	return failed
+}
+
+// VerifySignedTxnBatch checks the signatures of stxs, as VerifySignedTxn
+// does, batching single signatures, and returns the indexes of the invalid
+// ones, nil if all are valid. Single signatures are checked by VerifyBatch,
+// so those with a small order component pass, see VerifyBatch.
+func VerifySignedTxnBatch(stxs []types.SignedTxn) []int {
+	var failed []int
+	var entries []BatchEntry
+	var indexes []int
+	for i, stx := range stxs {
+		if stx.Sig == (types.Signature{}) || !stx.Msig.Blank() || !stx.Lsig.Blank() {
+			if !VerifySignedTxn(stx) {
+				failed = append(failed, i)
+			}
+			continue
+		}
+		signer := stx.Txn.Sender
+		if !stx.AuthAddr.IsZero() {
+			signer = stx.AuthAddr
+		}
+		sig := stx.Sig
+		entries = append(entries, BatchEntry{PublicKey: signer[:], Message: rawTransactionBytesToSign(stx.Txn), Signature: sig[:]})
+		indexes = append(indexes, i)
+	}
+	for _, j := range VerifyBatch(entries) {
+		failed = append(failed, indexes[j])
+	}
+	sort.Ints(failed)
+	return failed
+}
+
+type parsedSignature struct {
+	index int
+	a, r  *edwards25519.Point
+	s, h  *edwards25519.Scalar
+}
+
+// parseSignature decodes entry for the batch equation. It returns false if
+// entry is malformed, or if its public key or R are of small order or not
+// canonically encoded, leaving it to verifySingle.
+func parseSignature(entry BatchEntry) (parsedSignature, bool) {
+	var p parsedSignature
+	if len(entry.PublicKey) != ed25519.PublicKeySize || len(entry.Signature) != ed25519.SignatureSize {
+		return p, false
+	}
+	var ok bool
+	if p.a, ok = parsePoint(entry.PublicKey); !ok {
+		return p, false
+	}
+	if p.r, ok = parsePoint(entry.Signature[:32]); !ok {
+		return p, false
+	}
+	var err error
+	if p.s, err = new(edwards25519.Scalar).SetCanonicalBytes(entry.Signature[32:]); err != nil {
+		return p, false
+	}
+	h := sha512.New()
+	h.Write(entry.Signature[:32])
+	h.Write(entry.PublicKey)
+	h.Write(entry.Message)
+	if p.h, err = new(edwards25519.Scalar).SetUniformBytes(h.Sum(nil)); err != nil {
+		return p, false
+	}
+	return p, true
+}
+
+// parsePoint decodes a point that is canonically encoded and not of small
+// order.
+func parsePoint(encoded []byte) (*edwards25519.Point, bool) {
+	point, err := new(edwards25519.Point).SetBytes(encoded)
+	if err != nil || !bytes.Equal(point.Bytes(), encoded) || smallOrder(point) {
+		return nil, false
+	}
+	return point, true
+}
+
+// verifySingle checks the signature of entry with ed25519.Verify.
+func verifySingle(entry BatchEntry) bool {
+	if len(entry.PublicKey) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(entry.PublicKey, entry.Message, entry.Signature)
+}
+
+// verifyParsed returns the indexes of the invalid signatures of parsed,
+// verifying them as a batch and bisecting it if it fails.
+func verifyParsed(parsed []parsedSignature) []int {
+	if len(parsed) < batchMinSize {
+		var failed []int
+		for _, p := range parsed {
+			if !verifyCofactored(p) {
+				failed = append(failed, p.index)
+			}
+		}
+		return failed
+	}
+	if verifyBatchEquation(parsed) {
+		return nil
+	}
+	half := len(parsed) / 2
+	return append(verifyParsed(parsed[:half]), verifyParsed(parsed[half:])...)
+}
+
+// verifyCofactored checks that 8(R + h·A - sB) is the identity, the batch
+// equation for a single signature.
+func verifyCofactored(p parsedSignature) bool {
+	negS := new(edwards25519.Scalar).Negate(p.s)
+	sum := new(edwards25519.Point).VarTimeDoubleScalarBaseMult(p.h, p.a, negS)
+	sum.Add(sum, p.r)
+	return sum.MultByCofactor(sum).Equal(edwards25519.NewIdentityPoint()) == 1
+}
+
+// verifyBatchEquation checks that 8(ΣzᵢRᵢ + Σzᵢhᵢ·Aᵢ - (Σzᵢsᵢ)B) is the
+// identity, for random 128 bit zᵢ.
+func verifyBatchEquation(parsed []parsedSignature) bool {
+	scalars := make([]*edwards25519.Scalar, 0, 2*len(parsed)+1)
+	points := make([]*edwards25519.Point, 0, 2*len(parsed)+1)
+	bCoefficient := edwards25519.NewScalar()
+	for _, p := range parsed {
+		var random [32]byte
+		RandomBytes(random[:16])
+		z, err := new(edwards25519.Scalar).SetCanonicalBytes(random[:])
+		if err != nil {
+			// a 128 bit integer is below the group order
+			panic(err)
+		}
+		scalars = append(scalars, z, new(edwards25519.Scalar).Multiply(z, p.h))
+		points = append(points, p.r, p.a)
+		bCoefficient.MultiplyAdd(z, p.s, bCoefficient)
+	}
+	scalars = append(scalars, bCoefficient.Negate(bCoefficient))
+	points = append(points, edwards25519.NewGeneratorPoint())
+
+	sum := new(edwards25519.Point).VarTimeMultiScalarMult(scalars, points)
+	return sum.MultByCofactor(sum).Equal(edwards25519.NewIdentityPoint()) == 1
+}
@@ -0,0 +1,132 @@
+package crypto
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func makeBatch(t *testing.T, size int) []BatchEntry {
+	entries := make([]BatchEntry, size)
+	for i := range entries {
+		account := TestAccount("batch", uint64(i))
+		message := []byte(fmt.Sprintf("message %d", i))
+		entries[i] = BatchEntry{PublicKey: account.PublicKey, Message: message, Signature: ed25519.Sign(account.PrivateKey, message)}
+	}
+	return entries
+}
+
+func TestVerifyBatch(t *testing.T) {
+	require.Nil(t, VerifyBatch(nil))
+	entries := makeBatch(t, 64)
+	require.Nil(t, VerifyBatch(entries))
+	require.Nil(t, VerifyBatch(entries[:2]))
+
+	entries[3].Message = []byte("other")
+	entries[40].Signature = append([]byte{}, entries[40].Signature...)
+	entries[40].Signature[10] ^= 1
+	entries[63].Signature = entries[63].Signature[:10]
+	require.Equal(t, []int{3, 40, 63}, VerifyBatch(entries))
+
+	// signatures with a non-canonical s are rejected, as by ed25519.Verify
+	entries = makeBatch(t, 8)
+	sig := append([]byte{}, entries[5].Signature...)
+	for i := range sig[32:] {
+		sig[32+i] = 0xff
+	}
+	entries[5].Signature = sig
+	require.Equal(t, []int{5}, VerifyBatch(entries))
+}
+
+func TestVerifyBatchSmallOrder(t *testing.T) {
+	// a public key of order 8, with R the identity and s = 0: the cofactored
+	// batch equation holds for any message, while ed25519.Verify only accepts
+	// the messages whose hash is a multiple of 8
+	publicKey, err := hex.DecodeString("26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05")
+	require.NoError(t, err)
+	point, err := new(edwards25519.Point).SetBytes(publicKey)
+	require.NoError(t, err)
+	require.True(t, smallOrder(point))
+	sig := make([]byte, ed25519.SignatureSize)
+	copy(sig, edwards25519.NewIdentityPoint().Bytes())
+
+	var message []byte
+	for i := 0; ; i++ {
+		message = []byte(fmt.Sprintf("forged %d", i))
+		if !ed25519.Verify(publicKey, message, sig) {
+			break
+		}
+	}
+
+	entries := makeBatch(t, 8)
+	entries[6] = BatchEntry{PublicKey: publicKey, Message: message, Signature: sig}
+	require.Equal(t, []int{6}, VerifyBatch(entries))
+}
+
+func TestVerifySignedTxnBatch(t *testing.T) {
+	var stxs []types.SignedTxn
+	for i := 0; i < 10; i++ {
+		account := TestAccount("batch", uint64(i))
+		tx := types.Transaction{Type: types.PaymentTx, Header: types.Header{Sender: account.Address, FirstValid: types.Round(i)}}
+		_, encoded, err := SignTransaction(account.PrivateKey, tx)
+		require.NoError(t, err)
+		var stx types.SignedTxn
+		require.NoError(t, msgpack.Decode(encoded, &stx))
+		stxs = append(stxs, stx)
+	}
+	require.Nil(t, VerifySignedTxnBatch(stxs))
+
+	stxs[2].Txn.Fee = 1
+	stxs[7].Sig = types.Signature{}
+	require.Equal(t, []int{2, 7}, VerifySignedTxnBatch(stxs))
+}
+
+func TestVerifyBatchTorsion(t *testing.T) {
+	// R has a component of order 8: the cofactored equation holds, while
+	// ed25519.Verify rejects the signature
+	torsion, err := hex.DecodeString("26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05")
+	require.NoError(t, err)
+	tPoint, err := new(edwards25519.Point).SetBytes(torsion)
+	require.NoError(t, err)
+
+	scalar := func(seed string) *edwards25519.Scalar {
+		h := sha512.Sum512([]byte(seed))
+		s, err := new(edwards25519.Scalar).SetUniformBytes(h[:])
+		require.NoError(t, err)
+		return s
+	}
+	a, r := scalar("a"), scalar("r")
+	publicKey := new(edwards25519.Point).ScalarBaseMult(a).Bytes()
+	rPoint := new(edwards25519.Point).ScalarBaseMult(r)
+	rPoint.Add(rPoint, tPoint)
+	message := []byte("torsion")
+	h := sha512.New()
+	h.Write(rPoint.Bytes())
+	h.Write(publicKey)
+	h.Write(message)
+	k, err := new(edwards25519.Scalar).SetUniformBytes(h.Sum(nil))
+	require.NoError(t, err)
+	sig := append(rPoint.Bytes(), new(edwards25519.Scalar).MultiplyAdd(k, a, r).Bytes()...)
+	require.False(t, ed25519.Verify(publicKey, message, sig))
+
+	// the signature is accepted alone and in a batch alike
+	torsioned := BatchEntry{PublicKey: publicKey, Message: message, Signature: sig}
+	require.Nil(t, VerifyBatch([]BatchEntry{torsioned}))
+	entries := makeBatch(t, 8)
+	entries[3] = torsioned
+	require.Nil(t, VerifyBatch(entries))
+
+	// and rejected alike once invalid
+	torsioned.Message = []byte("other")
+	require.Equal(t, []int{0}, VerifyBatch([]BatchEntry{torsioned}))
+	entries[3] = torsioned
+	require.Equal(t, []int{3}, VerifyBatch(entries))
+}
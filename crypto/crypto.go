@@ -100,15 +100,61 @@ func SignTransaction(sk ed25519.PrivateKey, tx types.Transaction) (txid string,
 	return
 }
 
+// VerifySignedTxn checks that the signature on a SignedTxn is valid for the
+// account authorized to sign it. That is the AuthAddr if the sender has been
+// rekeyed, otherwise the sender itself.
+//
+// For a LogicSig only the delegation signature, or the escrow address, is
+// checked. The program itself is not evaluated.
+func VerifySignedTxn(stx types.SignedTxn) bool {
+	signer := stx.Txn.Sender
+	if !stx.AuthAddr.IsZero() {
+		signer = stx.AuthAddr
+	}
+
+	hasSig := stx.Sig != (types.Signature{})
+	hasMsig := !stx.Msig.Blank()
+	hasLsig := !stx.Lsig.Blank()
+
+	// require exactly one kind of signature
+	switch {
+	case hasSig && !hasMsig && !hasLsig:
+		return ed25519.Verify(signer[:], rawTransactionBytesToSign(stx.Txn), stx.Sig[:])
+	case hasMsig && !hasSig && !hasLsig:
+		return VerifyMultisig(signer, rawTransactionBytesToSign(stx.Txn), stx.Msig)
+	case hasLsig && !hasSig && !hasMsig:
+		lsa := LogicSigAccount{Lsig: stx.Lsig}
+		if stx.Lsig.Sig != (types.Signature{}) {
+			lsa.SigningKey = signer[:]
+		}
+		lsigAddress, err := lsa.Address()
+		if err != nil || lsigAddress != signer {
+			return false
+		}
+		return VerifyLogicSig(stx.Lsig, signer)
+	default:
+		return false
+	}
+}
+
+// transactionSizeHint is the capacity of the buffers transactions are encoded
+// to, which fits most of them without growing.
+const transactionSizeHint = 512
+
 // rawTransactionBytesToSign returns the byte form of the tx that we actually sign
 // and compute txID from.
 func rawTransactionBytesToSign(tx types.Transaction) []byte {
-	// Encode the transaction as msgpack
-	encodedTx := msgpack.Encode(tx)
+	// Encode the transaction as msgpack, after the hashable prefix
+	b := make([]byte, 0, transactionSizeHint)
+	b = append(b, txidPrefix...)
+	return msgpack.AppendEncode(b, tx)
+}
 
-	// Prepend the hashable prefix
-	msgParts := [][]byte{txidPrefix, encodedTx}
-	return bytes.Join(msgParts, nil)
+// TransactionBytesToSign returns the bytes that a signature on tx covers: the
+// msgpack encoding of tx with the "TX" prefix prepended. This is useful when
+// signing with a key that cannot be represented as an ed25519.PrivateKey.
+func TransactionBytesToSign(tx types.Transaction) []byte {
+	return rawTransactionBytesToSign(tx)
 }
 
 // txID computes a transaction id base32 string from raw transaction bytes
@@ -133,6 +179,32 @@ func TransactionID(tx types.Transaction) (txid []byte) {
 	return
 }
 
+// InnerTransactionID returns the ID of an inner transaction, as the ledger
+// computes it from the transaction that issued it and the index of the inner
+// transaction among those it issued: the hash of "TX", the raw parent ID, the
+// big-endian 8 byte index and the msgpack encoding of tx.
+//
+// parentID is the ID of the issuing transaction as GetTxID returns it. When
+// the issuing transaction is itself an inner transaction, that is the hash of
+// its fields, not its inner transaction ID: the ledger derives the IDs of
+// nested inner transactions from the plain ID of their caller.
+func InnerTransactionID(parentID string, index int, tx types.Transaction) (string, error) {
+	parent, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(parentID)
+	if err != nil {
+		return "", fmt.Errorf("invalid parent transaction ID %q: %w", parentID, err)
+	}
+	if len(parent) != sha512.Size256 {
+		return "", fmt.Errorf("invalid parent transaction ID %q: not %d bytes", parentID, sha512.Size256)
+	}
+	input := make([]byte, 0, transactionSizeHint)
+	input = append(input, txidPrefix...)
+	input = append(input, parent...)
+	input = binary.BigEndian.AppendUint64(input, uint64(index))
+	input = msgpack.AppendEncode(input, tx)
+	id := sha512.Sum512_256(input)
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(id[:]), nil
+}
+
 // TransactionIDString is a base32 representation of a TransactionID
 func TransactionIDString(tx types.Transaction) (txid string) {
 	txid = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(TransactionID(tx))
@@ -294,6 +366,7 @@ func MergeMultisigTransactions(stxsBytes ...[]byte) (txid string, stxBytes []byt
 	var sig types.MultisigSig
 	var refAddr *types.Address
 	var refTx types.Transaction
+	var refTxid string
 	var refAuthAddr types.Address
 	for _, partStxBytes := range stxsBytes {
 		partStx := types.SignedTxn{}
@@ -324,9 +397,15 @@ func MergeMultisigTransactions(stxsBytes ...[]byte) (txid string, stxBytes []byt
 				sig.Subsigs[i].Key = c
 			}
 			refTx = partStx.Txn
+			refTxid = txIDFromTransaction(refTx)
 			refAuthAddr = partStx.AuthAddr
 		}
 
+		if txIDFromTransaction(partStx.Txn) != refTxid {
+			err = errMsigMergeTxnMismatch
+			return
+		}
+
 		if partAddr != *refAddr {
 			err = errMsigMergeKeysMismatch
 			return
@@ -358,8 +437,7 @@ func MergeMultisigTransactions(stxsBytes ...[]byte) (txid string, stxBytes []byt
 		AuthAddr: refAuthAddr,
 	}
 	stxBytes = msgpack.Encode(stx)
-	// let's also compute the txid.
-	txid = txIDFromTransaction(refTx)
+	txid = refTxid
 	return
 }
 
@@ -458,6 +536,36 @@ func ComputeGroupID(txgroup []types.Transaction) (gid types.Digest, err error) {
 
 /* LogicSig support */
 
+// VerifyGroupID checks that every transaction in txgroup carries the group ID
+// computed over the whole group, so a received group has not been reordered,
+// truncated, or mixed with transactions from another group.
+func VerifyGroupID(txgroup []types.Transaction) error {
+	if len(txgroup) == 0 {
+		return errGroupEmpty
+	}
+
+	ungrouped := make([]types.Transaction, len(txgroup))
+	for i, tx := range txgroup {
+		if tx.Group == (types.Digest{}) {
+			return fmt.Errorf("transaction %d has no group ID", i)
+		}
+		tx.Group = types.Digest{}
+		ungrouped[i] = tx
+	}
+
+	gid, err := ComputeGroupID(ungrouped)
+	if err != nil {
+		return err
+	}
+
+	for i, tx := range txgroup {
+		if tx.Group != gid {
+			return fmt.Errorf("transaction %d has group ID %v, expected %v", i, tx.Group, gid)
+		}
+	}
+	return nil
+}
+
 func isASCIIPrintableByte(symbol byte) bool {
 	isBreakLine := symbol == '\n'
 	isStdPrintable := symbol >= ' ' && symbol <= '~'
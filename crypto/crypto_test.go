@@ -265,6 +265,174 @@ func TestMergeMultisigTransactionsWithAuthAddr(t *testing.T) {
 	require.Equal(t, expectedAuthAddr, stx.AuthAddr)
 }
 
+func TestMergeMultisigTransactionsMismatchedTxns(t *testing.T) {
+	ma, sk1, sk2, _ := makeTestMultisigAccount(t)
+	fromAddr, err := ma.Address()
+	require.NoError(t, err)
+
+	tx := types.Transaction{
+		Type: types.PaymentTx,
+		Header: types.Header{
+			Sender:     fromAddr,
+			Fee:        1000,
+			FirstValid: 100,
+			LastValid:  1100,
+		},
+	}
+	_, oneSigTxBytes, err := SignMultisigTransaction(sk1, ma, tx)
+	require.NoError(t, err)
+
+	tx.LastValid++
+	_, otherSigTxBytes, err := SignMultisigTransaction(sk2, ma, tx)
+	require.NoError(t, err)
+
+	_, _, err = MergeMultisigTransactions(oneSigTxBytes, otherSigTxBytes)
+	require.ErrorIs(t, err, errMsigMergeTxnMismatch)
+}
+
+func TestVerifyMultisigThreshold(t *testing.T) {
+	ma, sk1, sk2, _ := makeTestMultisigAccount(t)
+	fromAddr, err := ma.Address()
+	require.NoError(t, err)
+
+	tx := types.Transaction{
+		Type: types.PaymentTx,
+		Header: types.Header{
+			Sender:     fromAddr,
+			Fee:        1000,
+			FirstValid: 100,
+			LastValid:  1100,
+		},
+	}
+	message := rawTransactionBytesToSign(tx)
+
+	_, partStxBytes, err := SignMultisigTransaction(sk1, ma, tx)
+	require.NoError(t, err)
+	var partStx types.SignedTxn
+	require.NoError(t, msgpack.Decode(partStxBytes, &partStx))
+	require.False(t, VerifyMultisig(fromAddr, message, partStx.Msig))
+
+	_, fullStxBytes, err := AppendMultisigTransaction(sk2, ma, partStxBytes)
+	require.NoError(t, err)
+	var fullStx types.SignedTxn
+	require.NoError(t, msgpack.Decode(fullStxBytes, &fullStx))
+	require.True(t, VerifyMultisig(fromAddr, message, fullStx.Msig))
+	require.False(t, VerifyMultisig(fromAddr, append(message, 0), fullStx.Msig))
+}
+
+func TestVerifySignedTxn(t *testing.T) {
+	sender := GenerateAccount()
+	rekeyedTo := GenerateAccount()
+
+	tx := types.Transaction{
+		Type: types.PaymentTx,
+		Header: types.Header{
+			Sender:     sender.Address,
+			Fee:        1000,
+			FirstValid: 100,
+			LastValid:  1100,
+		},
+	}
+
+	decode := func(stxBytes []byte) types.SignedTxn {
+		var stx types.SignedTxn
+		require.NoError(t, msgpack.Decode(stxBytes, &stx))
+		return stx
+	}
+
+	t.Run("sender", func(t *testing.T) {
+		_, stxBytes, err := SignTransaction(sender.PrivateKey, tx)
+		require.NoError(t, err)
+		stx := decode(stxBytes)
+		require.True(t, stx.AuthAddr.IsZero())
+		require.True(t, VerifySignedTxn(stx))
+	})
+
+	t.Run("rekeyed", func(t *testing.T) {
+		_, stxBytes, err := SignTransaction(rekeyedTo.PrivateKey, tx)
+		require.NoError(t, err)
+		stx := decode(stxBytes)
+		require.Equal(t, rekeyedTo.Address, stx.AuthAddr)
+		require.True(t, VerifySignedTxn(stx))
+
+		// without the AuthAddr the signature is checked against the sender
+		stx.AuthAddr = types.Address{}
+		require.False(t, VerifySignedTxn(stx))
+	})
+
+	t.Run("multisig", func(t *testing.T) {
+		ma, sk1, sk2, _ := makeTestMultisigAccount(t)
+		_, partStxBytes, err := SignMultisigTransaction(sk1, ma, tx)
+		require.NoError(t, err)
+		require.False(t, VerifySignedTxn(decode(partStxBytes)))
+
+		_, stxBytes, err := AppendMultisigTransaction(sk2, ma, partStxBytes)
+		require.NoError(t, err)
+		require.True(t, VerifySignedTxn(decode(stxBytes)))
+	})
+
+	t.Run("logicsig", func(t *testing.T) {
+		program := []byte{1, 32, 1, 1, 34}
+		escrow, err := MakeLogicSigAccountEscrowChecked(program, nil)
+		require.NoError(t, err)
+		_, stxBytes, err := SignLogicSigAccountTransaction(escrow, tx)
+		require.NoError(t, err)
+		require.True(t, VerifySignedTxn(decode(stxBytes)))
+
+		delegated, err := MakeLogicSigAccountDelegated(program, nil, rekeyedTo.PrivateKey)
+		require.NoError(t, err)
+		_, stxBytes, err = SignLogicSigAccountTransaction(delegated, tx)
+		require.NoError(t, err)
+		require.True(t, VerifySignedTxn(decode(stxBytes)))
+
+		stx := decode(stxBytes)
+		stx.AuthAddr = sender.Address
+		require.False(t, VerifySignedTxn(stx))
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		require.False(t, VerifySignedTxn(types.SignedTxn{Txn: tx}))
+	})
+}
+
+func TestVerifyGroupID(t *testing.T) {
+	sender := GenerateAccount().Address
+	makeTxn := func(firstValid types.Round) types.Transaction {
+		return types.Transaction{
+			Type: types.PaymentTx,
+			Header: types.Header{
+				Sender:     sender,
+				Fee:        1000,
+				FirstValid: firstValid,
+				LastValid:  firstValid + 1000,
+			},
+		}
+	}
+
+	group := []types.Transaction{makeTxn(1), makeTxn(2), makeTxn(3)}
+	gid, err := ComputeGroupID(group)
+	require.NoError(t, err)
+	for i := range group {
+		group[i].Group = gid
+	}
+	require.NoError(t, VerifyGroupID(group))
+
+	// reordered
+	require.Error(t, VerifyGroupID([]types.Transaction{group[1], group[0], group[2]}))
+	// truncated
+	require.Error(t, VerifyGroupID(group[:2]))
+	// missing a group ID
+	require.Error(t, VerifyGroupID([]types.Transaction{group[0], makeTxn(2), group[2]}))
+	require.ErrorIs(t, VerifyGroupID(nil), errGroupEmpty)
+
+	oversized := make([]types.Transaction, types.MaxTxGroupSize+1)
+	for i := range oversized {
+		oversized[i] = makeTxn(types.Round(i))
+	}
+	_, err = ComputeGroupID(oversized)
+	require.Error(t, err)
+}
+
 func TestSignBytes(t *testing.T) {
 	account := GenerateAccount()
 	message := make([]byte, 15)
@@ -732,3 +900,16 @@ func TestGetApplicationAddress(t *testing.T) {
 	actual := GetApplicationAddress(appID)
 	require.Equal(t, expected, actual.String())
 }
+
+func BenchmarkTransactionID(b *testing.B) {
+	account := TestAccount("bench", 0)
+	tx := types.Transaction{
+		Type:             types.PaymentTx,
+		Header:           types.Header{Sender: account.Address, Fee: 1000, FirstValid: 1, LastValid: 1001, Note: []byte("note"), GenesisHash: types.Digest{1}},
+		PaymentTxnFields: types.PaymentTxnFields{Receiver: account.Address, Amount: 1000},
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		TransactionID(tx)
+	}
+}
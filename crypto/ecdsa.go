@@ -0,0 +1,365 @@
+package crypto
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// ECDSACurve is a curve of the ecdsa_verify, ecdsa_pk_recover and
+// ecdsa_pk_decompress TEAL opcodes, with the value of their curve immediate.
+type ECDSACurve int
+
+const (
+	// Secp256k1 is the curve of Bitcoin and Ethereum signatures.
+	Secp256k1 ECDSACurve = 0
+	// Secp256r1 is the NIST P-256 curve, of WebAuthn and passkey signatures.
+	Secp256r1 ECDSACurve = 1
+)
+
+// curve is a short Weierstrass curve y² = x³ + ax + b. Its arithmetic is not
+// constant-time, so it only verifies, recovers and decompresses public data.
+type curve struct {
+	p, n, a, b, gx, gy *big.Int
+}
+
+var secp256k1Curve = func() *curve {
+	hex := func(s string) *big.Int {
+		n, _ := new(big.Int).SetString(s, 16)
+		return n
+	}
+	return &curve{
+		p:  hex("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"),
+		n:  hex("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"),
+		a:  big.NewInt(0),
+		b:  big.NewInt(7),
+		gx: hex("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"),
+		gy: hex("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"),
+	}
+}()
+
+var secp256r1Curve = func() *curve {
+	params := elliptic.P256().Params()
+	return &curve{p: params.P, n: params.N, a: big.NewInt(-3), b: params.B, gx: params.Gx, gy: params.Gy}
+}()
+
+func (c ECDSACurve) params() (*curve, error) {
+	switch c {
+	case Secp256k1:
+		return secp256k1Curve, nil
+	case Secp256r1:
+		return secp256r1Curve, nil
+	default:
+		return nil, fmt.Errorf("%w %d", errECDSAUnknownCurve, c)
+	}
+}
+
+// onCurve returns whether (x, y) is a point of the curve.
+func (c *curve) onCurve(x, y *big.Int) bool {
+	if x.Sign() < 0 || x.Cmp(c.p) >= 0 || y.Sign() < 0 || y.Cmp(c.p) >= 0 {
+		return false
+	}
+	return new(big.Int).Exp(y, big.NewInt(2), c.p).Cmp(c.rhs(x)) == 0
+}
+
+// rhs returns x³ + ax + b.
+func (c *curve) rhs(x *big.Int) *big.Int {
+	r := new(big.Int).Exp(x, big.NewInt(3), c.p)
+	r.Add(r, new(big.Int).Mul(c.a, x))
+	r.Add(r, c.b)
+	return r.Mod(r, c.p)
+}
+
+// add returns the sum of two points, nil coordinates being the point at
+// infinity.
+func (c *curve) add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	if x1 == nil {
+		return x2, y2
+	}
+	if x2 == nil {
+		return x1, y1
+	}
+	var slope *big.Int
+	if x1.Cmp(x2) == 0 {
+		if new(big.Int).Add(y1, y2).Mod(new(big.Int).Add(y1, y2), c.p).Sign() == 0 {
+			return nil, nil
+		}
+		// (3x² + a) / 2y
+		num := new(big.Int).Mul(x1, x1)
+		num.Mul(num, big.NewInt(3)).Add(num, c.a)
+		den := new(big.Int).Lsh(y1, 1)
+		slope = num.Mul(num, den.ModInverse(den.Mod(den, c.p), c.p))
+	} else {
+		num := new(big.Int).Sub(y2, y1)
+		den := new(big.Int).Sub(x2, x1)
+		slope = num.Mul(num, den.ModInverse(den.Mod(den, c.p), c.p))
+	}
+	slope.Mod(slope, c.p)
+	x3 := new(big.Int).Mul(slope, slope)
+	x3.Sub(x3, x1).Sub(x3, x2).Mod(x3, c.p)
+	y3 := new(big.Int).Sub(x1, x3)
+	y3.Mul(y3, slope).Sub(y3, y1).Mod(y3, c.p)
+	return x3, y3
+}
+
+// mul returns k times the point (x, y).
+func (c *curve) mul(x, y, k *big.Int) (*big.Int, *big.Int) {
+	var rx, ry *big.Int
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		rx, ry = c.add(rx, ry, rx, ry)
+		if k.Bit(i) == 1 {
+			rx, ry = c.add(rx, ry, x, y)
+		}
+	}
+	return rx, ry
+}
+
+// decompress returns the point of abscissa x whose ordinate has parity odd.
+func (c *curve) decompress(x *big.Int, odd bool) (*big.Int, *big.Int, bool) {
+	if x.Cmp(c.p) >= 0 {
+		return nil, nil, false
+	}
+	y := new(big.Int).ModSqrt(c.rhs(x), c.p)
+	if y == nil {
+		return nil, nil, false
+	}
+	if (y.Bit(0) == 1) != odd {
+		y.Sub(c.p, y)
+	}
+	return x, y, true
+}
+
+// ECDSAKey is a private key of an ECDSA curve of the TEAL opcodes. Key
+// derivation and signing use constant-time implementations: the standard
+// library for Secp256r1 and github.com/decred/dcrd/dcrec/secp256k1 for
+// Secp256k1.
+type ECDSAKey struct {
+	Curve ECDSACurve
+	D     *big.Int
+	X, Y  *big.Int
+}
+
+// GenerateECDSAKey generates a random key of curve.
+func GenerateECDSAKey(curve ECDSACurve) (*ECDSAKey, error) {
+	switch curve {
+	case Secp256k1:
+		priv, err := secp256k1.GeneratePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		pub := priv.PubKey()
+		return &ECDSAKey{Curve: curve, D: new(big.Int).SetBytes(priv.Serialize()), X: pub.X(), Y: pub.Y()}, nil
+	case Secp256r1:
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		return &ECDSAKey{Curve: curve, D: priv.D, X: priv.X, Y: priv.Y}, nil
+	default:
+		return nil, fmt.Errorf("%w %d", errECDSAUnknownCurve, curve)
+	}
+}
+
+// ECDSAKeyFromBytes returns the key of curve whose private scalar is the
+// big-endian d.
+func ECDSAKeyFromBytes(curve ECDSACurve, d []byte) (*ECDSAKey, error) {
+	c, err := curve.params()
+	if err != nil {
+		return nil, err
+	}
+	scalar := new(big.Int).SetBytes(d)
+	if len(d) != 32 || scalar.Sign() == 0 || scalar.Cmp(c.n) >= 0 {
+		return nil, errECDSAInvalidKey
+	}
+	key := &ECDSAKey{Curve: curve, D: scalar}
+	if curve == Secp256k1 {
+		pub := secp256k1.PrivKeyFromBytes(d).PubKey()
+		key.X, key.Y = pub.X(), pub.Y()
+		return key, nil
+	}
+	priv, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, errECDSAInvalidKey
+	}
+	// the uncompressed point 0x04 || X || Y
+	point := priv.PublicKey().Bytes()
+	key.X = new(big.Int).SetBytes(point[1:33])
+	key.Y = new(big.Int).SetBytes(point[33:])
+	return key, nil
+}
+
+// Bytes returns the 32 byte big-endian private scalar of the key.
+func (k *ECDSAKey) Bytes() [32]byte {
+	var d [32]byte
+	k.D.FillBytes(d[:])
+	return d
+}
+
+// PublicKey returns the coordinates of the public key, as pushed by
+// ecdsa_pk_recover and ecdsa_pk_decompress and popped by ecdsa_verify.
+func (k *ECDSAKey) PublicKey() (x, y [32]byte) {
+	k.X.FillBytes(x[:])
+	k.Y.FillBytes(y[:])
+	return
+}
+
+// CompressedPublicKey returns the 33 byte compressed public key popped by
+// ecdsa_pk_decompress.
+func (k *ECDSAKey) CompressedPublicKey() [33]byte {
+	var compressed [33]byte
+	compressed[0] = 2 + byte(k.Y.Bit(0))
+	k.X.FillBytes(compressed[1:])
+	return compressed
+}
+
+// ECDSASignature is a signature in the layout of the TEAL opcodes: R and S
+// are the 32 byte big-endian halves popped by ecdsa_verify and
+// ecdsa_pk_recover, and RecoveryID the recovery ID popped by
+// ecdsa_pk_recover.
+type ECDSASignature struct {
+	R, S       [32]byte
+	RecoveryID uint64
+}
+
+// Sign signs the 32 byte hash, such as the SHA-512/256 or Keccak-256 of the
+// data the contract verifies. Secp256k1 signatures use a deterministic nonce
+// following RFC 6979 and have a low S, as ecdsa_verify requires; Secp256r1
+// signatures use the randomized nonce of the standard library.
+func (k *ECDSAKey) Sign(hash [32]byte) (ECDSASignature, error) {
+	switch k.Curve {
+	case Secp256k1:
+		d := k.Bytes()
+		priv := secp256k1.PrivKeyFromBytes(d[:])
+		defer priv.Zero()
+		// the compact signature is 27 + recovery ID || R || S
+		compact := secp256k1ecdsa.SignCompact(priv, hash[:], false)
+		var sig ECDSASignature
+		sig.RecoveryID = uint64(compact[0] - 27)
+		copy(sig.R[:], compact[1:33])
+		copy(sig.S[:], compact[33:])
+		return sig, nil
+	case Secp256r1:
+		priv := &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{Curve: elliptic.P256(), X: k.X, Y: k.Y},
+			D:         k.D,
+		}
+		r, s, err := ecdsa.Sign(rand.Reader, priv, hash[:])
+		if err != nil {
+			return ECDSASignature{}, err
+		}
+		var sig ECDSASignature
+		r.FillBytes(sig.R[:])
+		s.FillBytes(sig.S[:])
+		// the recovery ID only depends on the public nonce point, so it is
+		// found by recovering the key with each candidate
+		x, y := k.PublicKey()
+		for sig.RecoveryID = 0; sig.RecoveryID < 4; sig.RecoveryID++ {
+			rx, ry, err := RecoverECDSAPublicKey(k.Curve, hash, sig)
+			if err == nil && rx == x && ry == y {
+				return sig, nil
+			}
+		}
+		return ECDSASignature{}, errECDSARecover
+	default:
+		return ECDSASignature{}, fmt.Errorf("%w %d", errECDSAUnknownCurve, k.Curve)
+	}
+}
+
+// VerifyECDSA returns whether sig is a signature of hash by the public key
+// (x, y) of curve, as ecdsa_verify does: Secp256k1 signatures must have a low
+// S.
+func VerifyECDSA(curve ECDSACurve, hash [32]byte, sig ECDSASignature, x, y [32]byte) bool {
+	c, err := curve.params()
+	if err != nil {
+		return false
+	}
+	px, py := new(big.Int).SetBytes(x[:]), new(big.Int).SetBytes(y[:])
+	if !c.onCurve(px, py) {
+		return false
+	}
+	r, s := new(big.Int).SetBytes(sig.R[:]), new(big.Int).SetBytes(sig.S[:])
+	if r.Sign() == 0 || r.Cmp(c.n) >= 0 || s.Sign() == 0 || s.Cmp(c.n) >= 0 {
+		return false
+	}
+	if curve == Secp256k1 && s.Cmp(new(big.Int).Rsh(c.n, 1)) > 0 {
+		return false
+	}
+	w := new(big.Int).ModInverse(s, c.n)
+	u1 := new(big.Int).SetBytes(hash[:])
+	u1.Mul(u1, w).Mod(u1, c.n)
+	u2 := new(big.Int).Mul(r, w)
+	u2.Mod(u2, c.n)
+	x1, y1 := c.mul(c.gx, c.gy, u1)
+	x2, y2 := c.mul(px, py, u2)
+	rx, _ := c.add(x1, y1, x2, y2)
+	return rx != nil && new(big.Int).Mod(rx, c.n).Cmp(r) == 0
+}
+
+// RecoverECDSAPublicKey returns the public key of curve that made sig of
+// hash, as ecdsa_pk_recover does for Secp256k1.
+func RecoverECDSAPublicKey(curve ECDSACurve, hash [32]byte, sig ECDSASignature) (x, y [32]byte, err error) {
+	c, err := curve.params()
+	if err != nil {
+		return
+	}
+	r, s := new(big.Int).SetBytes(sig.R[:]), new(big.Int).SetBytes(sig.S[:])
+	if sig.RecoveryID > 3 || r.Sign() == 0 || r.Cmp(c.n) >= 0 || s.Sign() == 0 || s.Cmp(c.n) >= 0 {
+		err = errECDSARecover
+		return
+	}
+	rx := new(big.Int).Set(r)
+	if sig.RecoveryID&2 != 0 {
+		rx.Add(rx, c.n)
+	}
+	rx, ry, ok := c.decompress(rx, sig.RecoveryID&1 == 1)
+	if !ok {
+		err = errECDSARecover
+		return
+	}
+	// Q = r⁻¹(sR - eG)
+	rInv := new(big.Int).ModInverse(r, c.n)
+	e := new(big.Int).SetBytes(hash[:])
+	e.Neg(e).Mod(e, c.n)
+	x1, y1 := c.mul(rx, ry, s)
+	x2, y2 := c.mul(c.gx, c.gy, e)
+	qx, qy := c.add(x1, y1, x2, y2)
+	if qx == nil {
+		err = errECDSARecover
+		return
+	}
+	qx, qy = c.mul(qx, qy, rInv)
+	if qx == nil {
+		err = errECDSARecover
+		return
+	}
+	qx.FillBytes(x[:])
+	qy.FillBytes(y[:])
+	return
+}
+
+// DecompressECDSAPublicKey returns the coordinates of the 33 byte compressed
+// public key of curve, as ecdsa_pk_decompress does.
+func DecompressECDSAPublicKey(curve ECDSACurve, compressed [33]byte) (x, y [32]byte, err error) {
+	c, err := curve.params()
+	if err != nil {
+		return
+	}
+	if compressed[0] != 2 && compressed[0] != 3 {
+		err = errECDSAInvalidKey
+		return
+	}
+	px, py, ok := c.decompress(new(big.Int).SetBytes(compressed[1:]), compressed[0] == 3)
+	if !ok {
+		err = errECDSAInvalidKey
+		return
+	}
+	px.FillBytes(x[:])
+	py.FillBytes(y[:])
+	return
+}
@@ -0,0 +1,103 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestECDSASecp256r1(t *testing.T) {
+	// RFC 6979 A.2.5, SHA-256 of "sample": the nonce is randomized, so the
+	// signature differs from the vector's but must verify
+	d, _ := hex.DecodeString("c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721")
+	key, err := ECDSAKeyFromBytes(Secp256r1, d)
+	require.NoError(t, err)
+	x, y := key.PublicKey()
+	require.Equal(t, "60fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6", hex.EncodeToString(x[:]))
+	require.Equal(t, "7903fe1008b8bc99a41ae9e95628bc64f2f1b20c2d7e9f5177a3c294d4462299", hex.EncodeToString(y[:]))
+
+	hash := sha256.Sum256([]byte("sample"))
+	sig, err := key.Sign(hash)
+	require.NoError(t, err)
+	require.True(t, VerifyECDSA(Secp256r1, hash, sig, x, y))
+
+	// the vector's signature verifies too
+	vector := ECDSASignature{}
+	r, _ := hex.DecodeString("efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716")
+	s, _ := hex.DecodeString("f7cb1c942d657c41d436c7a1b6e29f65f3e900dbb9aff4064dc4ab2f843acda8")
+	copy(vector.R[:], r)
+	copy(vector.S[:], s)
+	require.True(t, VerifyECDSA(Secp256r1, hash, vector, x, y))
+
+	// the standard library agrees
+	pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: key.X, Y: key.Y}
+	require.True(t, ecdsa.Verify(pub, hash[:], new(big.Int).SetBytes(sig.R[:]), new(big.Int).SetBytes(sig.S[:])))
+
+	rx, ry, err := RecoverECDSAPublicKey(Secp256r1, hash, sig)
+	require.NoError(t, err)
+	require.Equal(t, x, rx)
+	require.Equal(t, y, ry)
+
+	for i := 0; i < 20; i++ {
+		key, err := GenerateECDSAKey(Secp256r1)
+		require.NoError(t, err)
+		x, y := key.PublicKey()
+		hash := sha256.Sum256([]byte{byte(i)})
+		sig, err := key.Sign(hash)
+		require.NoError(t, err)
+		require.True(t, VerifyECDSA(Secp256r1, hash, sig, x, y))
+		rx, ry, err := RecoverECDSAPublicKey(Secp256r1, hash, sig)
+		require.NoError(t, err)
+		require.Equal(t, x, rx)
+		require.Equal(t, y, ry)
+	}
+}
+
+func TestECDSASecp256k1(t *testing.T) {
+	one := make([]byte, 32)
+	one[31] = 1
+	key, err := ECDSAKeyFromBytes(Secp256k1, one)
+	require.NoError(t, err)
+	x, _ := key.PublicKey()
+	require.Equal(t, "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", hex.EncodeToString(x[:]))
+
+	half := new(big.Int).Rsh(secp256k1Curve.n, 1)
+	for i := 0; i < 20; i++ {
+		key, err := GenerateECDSAKey(Secp256k1)
+		require.NoError(t, err)
+		x, y := key.PublicKey()
+		hash := sha256.Sum256([]byte{byte(i)})
+		sig, err := key.Sign(hash)
+		require.NoError(t, err)
+		require.LessOrEqual(t, new(big.Int).SetBytes(sig.S[:]).Cmp(half), 0)
+		require.True(t, VerifyECDSA(Secp256k1, hash, sig, x, y))
+
+		rx, ry, err := RecoverECDSAPublicKey(Secp256k1, hash, sig)
+		require.NoError(t, err)
+		require.Equal(t, x, rx)
+		require.Equal(t, y, ry)
+
+		dx, dy, err := DecompressECDSAPublicKey(Secp256k1, key.CompressedPublicKey())
+		require.NoError(t, err)
+		require.Equal(t, x, dx)
+		require.Equal(t, y, dy)
+
+		// high S signatures are rejected, as by ecdsa_verify
+		high := sig
+		new(big.Int).Sub(secp256k1Curve.n, new(big.Int).SetBytes(sig.S[:])).FillBytes(high.S[:])
+		require.False(t, VerifyECDSA(Secp256k1, hash, high, x, y))
+
+		hash[0] ^= 1
+		require.False(t, VerifyECDSA(Secp256k1, hash, sig, x, y))
+	}
+
+	_, err = ECDSAKeyFromBytes(Secp256k1, make([]byte, 32))
+	require.ErrorIs(t, err, errECDSAInvalidKey)
+	_, err = GenerateECDSAKey(ECDSACurve(2))
+	require.ErrorIs(t, err, errECDSAUnknownCurve)
+}
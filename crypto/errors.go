@@ -6,6 +6,8 @@ import (
 
 var errInvalidSignatureReturned = errors.New("ed25519 library returned an invalid signature")
 var errInvalidPrivateKey = errors.New("invalid private key")
+var errInvalidSeed = errors.New("invalid seed, should be 32 bytes")
+var errAccountKeyMismatch = errors.New("private key does not match the address signing for the account")
 var errMsigUnknownVersion = errors.New("unknown version != 1")
 var errMsigInvalidThreshold = errors.New("invalid threshold")
 var errMsigInvalidSecretKey = errors.New("secret key has no corresponding public identity in multisig preimage")
@@ -13,9 +15,16 @@ var errMsigMergeLessThanTwo = errors.New("cannot merge fewer than two multisig t
 var errMsigMergeKeysMismatch = errors.New("multisig parameters do not match")
 var errMsigMergeInvalidDups = errors.New("mismatched duplicate signatures")
 var errMsigMergeAuthAddrMismatch = errors.New("mismatched AuthAddrs")
+var errMsigMergeTxnMismatch = errors.New("cannot merge signatures of different transactions")
+var errGroupEmpty = errors.New("a transaction group must contain at least one transaction")
 var errLsigTooManySignatures = errors.New("logicsig has too many signatures, at most one of Sig or Msig may be defined")
 var errLsigInvalidSignature = errors.New("invalid logicsig signature")
 var errLsigNoPublicKey = errors.New("missing public key of delegated logicsig")
 var errLsigInvalidPublicKey = errors.New("public key does not match logicsig signature")
 var errLsigEmptyMsig = errors.New("empty multisig in logicsig")
 var errLsigAccountPublicKeyNotNeeded = errors.New("a public key for the signer was provided when none was expected")
+var errECDSAUnknownCurve = errors.New("unknown ECDSA curve")
+var errECDSAInvalidKey = errors.New("invalid ECDSA key")
+var errECDSARecover = errors.New("could not recover the ECDSA public key")
+var errVRFInvalidKey = errors.New("invalid VRF key")
+var errVRFInvalidProof = errors.New("invalid VRF proof")
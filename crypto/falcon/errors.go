@@ -0,0 +1,10 @@
+package falcon
+
+import (
+	"errors"
+)
+
+var errPublicKeyFormat = errors.New("invalid falcon public key encoding")
+var errSignatureFormat = errors.New("invalid falcon signature encoding")
+var errSaltVersion = errors.New("unsupported falcon salt version")
+var errInvalidSignature = errors.New("falcon signature does not verify")
@@ -0,0 +1,279 @@
+// Package falcon verifies the deterministic Falcon-1024 signatures of
+// Algorand state proof keys, in pure Go.
+//
+// Algorand signs with the deterministic variant of Falcon-1024 of
+// github.com/algorand/falcon: the 40 byte random salt of Falcon signatures is
+// replaced by a single salt version byte, the salt being derived from it. A
+// signature is either compressed, as participants make them, or in the
+// fixed-size CT format state proofs hash.
+//
+// The package only verifies: key generation and signing need the Falcon
+// trapdoor sampler, which is not implemented here, and state proof keys are
+// generated and used by algod.
+package falcon
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+const (
+	logN = 10
+	n    = 1 << logN
+	q    = 12289
+
+	// normBound is the maximum squared norm of a Falcon-1024 signature.
+	normBound = 70265242
+
+	// CurrentSaltVersion is the salt version of the signatures algod makes.
+	CurrentSaltVersion = 0
+
+	// MaxSignatureSize is the maximum size of a compressed signature.
+	MaxSignatureSize = 1423
+	// CTSignatureSize is the size of a signature in the CT format.
+	CTSignatureSize = 1538
+
+	compressedHeader = 0x30 + logN
+	ctHeader         = 0x50 + logN
+	publicKeyHeader  = logN
+)
+
+// Verify checks that signature, compressed or in the CT format, is a
+// signature of message by publicKey.
+func Verify(publicKey types.FalconPublicKey, message, signature []byte) error {
+	h, err := decodePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+	s2, err := decodeSignature(signature)
+	if err != nil {
+		return err
+	}
+	c := hashToPoint(salt(signature[1]), message)
+
+	// s1 = c - s2·h mod q
+	s1 := mulModQ(s2, h)
+	var norm int64
+	for i := range s1 {
+		v := (int32(c[i]) - s1[i]) % q
+		if v < 0 {
+			v += q
+		}
+		if v > q/2 {
+			v -= q
+		}
+		norm += int64(v)*int64(v) + int64(s2[i])*int64(s2[i])
+	}
+	if norm > normBound {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+// ConvertToCT returns signature in the CT format, as state proofs hash it.
+func ConvertToCT(signature []byte) ([]byte, error) {
+	s2, err := decodeSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	ct := make([]byte, 2, CTSignatureSize)
+	ct[0] = ctHeader
+	ct[1] = signature[1]
+	var acc uint32
+	var accLen uint
+	for _, v := range s2 {
+		acc = acc<<12 | uint32(v)&0xfff
+		accLen += 12
+		for accLen >= 8 {
+			accLen -= 8
+			ct = append(ct, byte(acc>>accLen))
+		}
+	}
+	return ct, nil
+}
+
+// SaltVersion returns the salt version of signature.
+func SaltVersion(signature []byte) (byte, error) {
+	if len(signature) < 2 {
+		return 0, errSignatureFormat
+	}
+	return signature[1], nil
+}
+
+// salt returns the 40 byte Falcon salt of a salt version.
+func salt(version byte) []byte {
+	s := make([]byte, 40)
+	s[0] = version
+	s[1] = logN
+	copy(s[2:], "FALCON_DET")
+	return s
+}
+
+// hashToPoint hashes the salt and message to a polynomial modulo q.
+func hashToPoint(salt, message []byte) [n]uint16 {
+	shake := sha3.NewShake256()
+	shake.Write(salt)
+	shake.Write(message)
+	var c [n]uint16
+	var buf [2]byte
+	for i := 0; i < n; {
+		shake.Read(buf[:])
+		w := uint16(buf[0])<<8 | uint16(buf[1])
+		if w < 5*q {
+			c[i] = w % q
+			i++
+		}
+	}
+	return c
+}
+
+// mulModQ returns the product of a and b modulo x^n + 1 and q, with
+// coefficients in [0, q).
+func mulModQ(a [n]int16, b [n]uint16) [n]int32 {
+	var acc [n]int64
+	for i, ai := range a {
+		if ai == 0 {
+			continue
+		}
+		for j, bj := range b {
+			p := int64(ai) * int64(bj)
+			if k := i + j; k < n {
+				acc[k] += p
+			} else {
+				acc[k-n] -= p
+			}
+		}
+	}
+	var out [n]int32
+	for i, v := range acc {
+		v %= q
+		if v < 0 {
+			v += q
+		}
+		out[i] = int32(v)
+	}
+	return out
+}
+
+// decodePublicKey decodes the coefficients of a public key, 14 bits each.
+func decodePublicKey(publicKey types.FalconPublicKey) ([n]uint16, error) {
+	var h [n]uint16
+	if publicKey[0] != publicKeyHeader {
+		return h, errPublicKeyFormat
+	}
+	var acc uint32
+	var accLen uint
+	i := 0
+	for _, b := range publicKey[1:] {
+		acc = acc<<8 | uint32(b)
+		accLen += 8
+		if accLen >= 14 {
+			accLen -= 14
+			w := uint16(acc>>accLen) & 0x3fff
+			if w >= q {
+				return h, errPublicKeyFormat
+			}
+			h[i] = w
+			i++
+		}
+	}
+	return h, nil
+}
+
+// decodeSignature decodes the coefficients of s2 of a compressed or CT
+// signature.
+func decodeSignature(signature []byte) ([n]int16, error) {
+	if len(signature) < 2 {
+		return [n]int16{}, errSignatureFormat
+	}
+	if signature[1] != CurrentSaltVersion {
+		return [n]int16{}, fmt.Errorf("%w %d", errSaltVersion, signature[1])
+	}
+	switch signature[0] {
+	case compressedHeader:
+		if len(signature) > MaxSignatureSize {
+			return [n]int16{}, errSignatureFormat
+		}
+		return decodeCompressed(signature[2:])
+	case ctHeader:
+		if len(signature) != CTSignatureSize {
+			return [n]int16{}, errSignatureFormat
+		}
+		return decodeCT(signature[2:])
+	default:
+		return [n]int16{}, errSignatureFormat
+	}
+}
+
+// decodeCompressed decodes coefficients each encoded as a sign bit, their 7
+// low bits, and their high bits in unary. The encoding must use all of buf.
+func decodeCompressed(buf []byte) ([n]int16, error) {
+	var s [n]int16
+	var acc uint32
+	var accLen uint
+	v := 0
+	for i := range s {
+		if v >= len(buf) {
+			return s, errSignatureFormat
+		}
+		acc = acc<<8 | uint32(buf[v])
+		v++
+		b := acc >> accLen
+		negative := b&0x80 != 0
+		m := b & 0x7f
+		for {
+			if accLen == 0 {
+				if v >= len(buf) {
+					return s, errSignatureFormat
+				}
+				acc = acc<<8 | uint32(buf[v])
+				v++
+				accLen = 8
+			}
+			accLen--
+			if (acc>>accLen)&1 != 0 {
+				break
+			}
+			m += 128
+			if m > 2047 {
+				return s, errSignatureFormat
+			}
+		}
+		if negative && m == 0 {
+			return s, errSignatureFormat
+		}
+		s[i] = int16(m)
+		if negative {
+			s[i] = -s[i]
+		}
+	}
+	if acc&(1<<accLen-1) != 0 || v != len(buf) {
+		return s, errSignatureFormat
+	}
+	return s, nil
+}
+
+// decodeCT decodes coefficients encoded as 12 bit two's complement integers.
+func decodeCT(buf []byte) ([n]int16, error) {
+	var s [n]int16
+	var acc uint32
+	var accLen uint
+	i := 0
+	for _, b := range buf {
+		acc = acc<<8 | uint32(b)
+		accLen += 8
+		if accLen >= 12 {
+			accLen -= 12
+			w := int16(acc>>accLen&0xfff) << 4 >> 4
+			if w == -2048 {
+				return s, errSignatureFormat
+			}
+			s[i] = w
+			i++
+		}
+	}
+	return s, nil
+}
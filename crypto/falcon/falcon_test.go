@@ -0,0 +1,131 @@
+package falcon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// The signatures below are forged for a weak key rather than taken from
+// github.com/algorand/falcon or go-algorand, whose test vectors could not be
+// fetched when the package was written.
+
+// weakKey is the public key h = λ, for which short signatures are made
+// without a trapdoor: s2 = round(c/λ) and s1 = c - λ·s2.
+const weakKey = 111
+
+func encodePublicKey(h [n]uint16) types.FalconPublicKey {
+	var pk types.FalconPublicKey
+	pk[0] = publicKeyHeader
+	var acc uint32
+	var accLen uint
+	i := 1
+	for _, w := range h {
+		acc = acc<<14 | uint32(w)
+		accLen += 14
+		for accLen >= 8 {
+			accLen -= 8
+			pk[i] = byte(acc >> accLen)
+			i++
+		}
+	}
+	return pk
+}
+
+func compress(s2 [n]int16) []byte {
+	out := []byte{compressedHeader, CurrentSaltVersion}
+	var acc uint64
+	var accLen uint
+	for _, v := range s2 {
+		m := uint64(v)
+		sign := uint64(0)
+		if v < 0 {
+			m = uint64(-v)
+			sign = 1
+		}
+		acc = acc<<8 | sign<<7 | m&0x7f
+		accLen += 8
+		high := uint(m >> 7)
+		acc = acc<<(high+1) | 1
+		accLen += high + 1
+		for accLen >= 8 {
+			accLen -= 8
+			out = append(out, byte(acc>>accLen))
+		}
+	}
+	if accLen > 0 {
+		out = append(out, byte(acc<<(8-accLen)))
+	}
+	return out
+}
+
+func forge(message []byte) []byte {
+	c := hashToPoint(salt(CurrentSaltVersion), message)
+	var s2 [n]int16
+	for i, ci := range c {
+		v := int32(ci)
+		if v > q/2 {
+			v -= q
+		}
+		r := v / weakKey
+		if v%weakKey > weakKey/2 {
+			r++
+		} else if v%weakKey < -weakKey/2 {
+			r--
+		}
+		s2[i] = int16(r)
+	}
+	return compress(s2)
+}
+
+func TestVerify(t *testing.T) {
+	var h [n]uint16
+	h[0] = weakKey
+	pk := encodePublicKey(h)
+	decoded, err := decodePublicKey(pk)
+	require.NoError(t, err)
+	require.Equal(t, h, decoded)
+
+	message := []byte("state proof message")
+	sig := forge(message)
+	require.LessOrEqual(t, len(sig), MaxSignatureSize)
+	require.NoError(t, Verify(pk, message, sig))
+	require.ErrorIs(t, Verify(pk, []byte("other"), sig), errInvalidSignature)
+
+	ct, err := ConvertToCT(sig)
+	require.NoError(t, err)
+	require.Len(t, ct, CTSignatureSize)
+	require.NoError(t, Verify(pk, message, ct))
+	s2, err := decodeSignature(sig)
+	require.NoError(t, err)
+	ctS2, err := decodeSignature(ct)
+	require.NoError(t, err)
+	require.Equal(t, s2, ctS2)
+
+	version, err := SaltVersion(sig)
+	require.NoError(t, err)
+	require.Equal(t, byte(CurrentSaltVersion), version)
+
+	bad := append([]byte{}, sig...)
+	bad[1] = 1
+	require.ErrorIs(t, Verify(pk, message, bad), errSaltVersion)
+	require.ErrorIs(t, Verify(pk, message, append(sig, 0)), errSignatureFormat)
+	require.ErrorIs(t, Verify(pk, message, sig[:len(sig)-8]), errSignatureFormat)
+	pk[0] = 0
+	require.ErrorIs(t, Verify(pk, message, sig), errPublicKeyFormat)
+}
+
+func TestMulModQ(t *testing.T) {
+	// x^(n-1) · x = x^n = -1
+	var a [n]int16
+	var b [n]uint16
+	a[n-1] = 1
+	b[1] = 1
+	product := mulModQ(a, b)
+	require.Equal(t, int32(q-1), product[0])
+	for _, v := range product[1:] {
+		require.Zero(t, v)
+	}
+}
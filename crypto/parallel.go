@@ -0,0 +1,59 @@
+package crypto
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// SignTransactionGroup signs each of txns with sk, as SignTransaction does,
+// and returns their IDs and encoded signed transactions in the order of txns.
+// The group ID of a group must already be assigned.
+func SignTransactionGroup(sk ed25519.PrivateKey, txns []types.Transaction) (txids []string, stxs [][]byte, err error) {
+	return SignTransactionsParallel(sk, txns, 1)
+}
+
+// SignTransactionsParallel is SignTransactionGroup signing with workers
+// goroutines, or GOMAXPROCS of them if workers is not positive, for large
+// batches of transactions.
+func SignTransactionsParallel(sk ed25519.PrivateKey, txns []types.Transaction, workers int) (txids []string, stxs [][]byte, err error) {
+	// SignTransaction panics on keys of the wrong size, which a worker could
+	// not recover from
+	if len(sk) != ed25519.PrivateKeySize {
+		return nil, nil, errInvalidPrivateKey
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(txns) {
+		workers = len(txns)
+	}
+	txids = make([]string, len(txns))
+	stxs = make([][]byte, len(txns))
+	errs := make([]error, len(txns))
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1) - 1); i < len(txns); i = int(next.Add(1) - 1) {
+				txids[i], stxs[i], errs[i] = SignTransaction(sk, txns[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not sign transaction %d: %w", i, err)
+		}
+	}
+	return txids, stxs, nil
+}
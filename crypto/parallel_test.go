@@ -0,0 +1,43 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestSignTransactionsParallel(t *testing.T) {
+	account := TestAccount("parallel", 0)
+	txns := make([]types.Transaction, 100)
+	for i := range txns {
+		txns[i] = types.Transaction{Type: types.PaymentTx, Header: types.Header{Sender: account.Address, FirstValid: types.Round(i)}}
+	}
+
+	txids, stxs, err := SignTransactionGroup(account.PrivateKey, txns)
+	require.NoError(t, err)
+	require.Len(t, txids, len(txns))
+	for i, tx := range txns {
+		txid, stx, err := SignTransaction(account.PrivateKey, tx)
+		require.NoError(t, err)
+		require.Equal(t, txid, txids[i])
+		require.Equal(t, stx, stxs[i])
+	}
+
+	for _, workers := range []int{0, 3, 1000} {
+		parallelIDs, parallelStxs, err := SignTransactionsParallel(account.PrivateKey, txns, workers)
+		require.NoError(t, err)
+		require.Equal(t, txids, parallelIDs)
+		require.Equal(t, stxs, parallelStxs)
+	}
+
+	txids, stxs, err = SignTransactionsParallel(account.PrivateKey, nil, 4)
+	require.NoError(t, err)
+	require.Empty(t, txids)
+	require.Empty(t, stxs)
+
+	_, _, err = SignTransactionsParallel(ed25519.PrivateKey{1, 2, 3}, txns, 4)
+	require.ErrorIs(t, err, errInvalidPrivateKey)
+}
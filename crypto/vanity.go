@@ -0,0 +1,106 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// addressLen is the length of an encoded address.
+const addressLen = 58
+
+// base32Alphabet is the alphabet of encoded addresses.
+const base32Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+
+// lastAddressChars are the characters an address can end with. The last
+// character only carries 3 bits, the rest being padding.
+const lastAddressChars = "AEIMQUY4"
+
+// VanityStats describes the search done by GenerateVanityAddress.
+type VanityStats struct {
+	// Attempts is the number of accounts generated by all workers.
+	Attempts uint64
+
+	// Duration is the time the search took.
+	Duration time.Duration
+}
+
+// GenerateVanityAddress generates random accounts on workers goroutines until
+// one has an address starting with prefix and ending with suffix, either of
+// which may be empty. If workers is not positive, runtime.NumCPU() workers are
+// used. Each extra character multiplies the expected number of attempts by 32,
+// so long patterns should be searched with a context that can be cancelled; if
+// ctx is done first, its error is returned along with the statistics so far.
+func GenerateVanityAddress(ctx context.Context, prefix, suffix string, workers int) (Account, VanityStats, error) {
+	if err := validateVanityPattern(prefix, suffix); err != nil {
+		return Account{}, VanityStats{}, err
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	start := time.Now()
+	var attempts atomic.Uint64
+	found := make(chan Account, 1)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				account := GenerateAccount()
+				attempts.Add(1)
+				if vanityAddressMatches(account.Address, prefix, suffix) {
+					select {
+					case found <- account:
+					default:
+					}
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := VanityStats{Attempts: attempts.Load(), Duration: time.Since(start)}
+	select {
+	case account := <-found:
+		return account, stats, nil
+	default:
+		return Account{}, stats, ctx.Err()
+	}
+}
+
+// validateVanityPattern returns an error if no address can start with prefix
+// and end with suffix.
+func validateVanityPattern(prefix, suffix string) error {
+	if len(prefix)+len(suffix) > addressLen {
+		return fmt.Errorf("vanity prefix and suffix are longer than an address (%d characters)", addressLen)
+	}
+	for _, c := range prefix + suffix {
+		if !strings.ContainsRune(base32Alphabet, c) {
+			return fmt.Errorf("vanity pattern character %q is not in the address alphabet %s", c, base32Alphabet)
+		}
+	}
+	if suffix != "" && !strings.ContainsRune(lastAddressChars, rune(suffix[len(suffix)-1])) {
+		return fmt.Errorf("addresses can only end with one of %s", lastAddressChars)
+	}
+	return nil
+}
+
+// vanityAddressMatches reports whether addr starts with prefix and ends with
+// suffix.
+func vanityAddressMatches(addr types.Address, prefix, suffix string) bool {
+	s := addr.String()
+	return strings.HasPrefix(s, prefix) && strings.HasSuffix(s, suffix)
+}
@@ -0,0 +1,45 @@
+package crypto
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateVanityAddress(t *testing.T) {
+	account, stats, err := GenerateVanityAddress(context.Background(), "A", "Q", 4)
+	require.NoError(t, err)
+	addr := account.Address.String()
+	require.True(t, strings.HasPrefix(addr, "A"))
+	require.True(t, strings.HasSuffix(addr, "Q"))
+	require.NotZero(t, stats.Attempts)
+
+	// the account is usable
+	fromKey, err := AccountFromPrivateKey(account.PrivateKey)
+	require.NoError(t, err)
+	require.Equal(t, account, fromKey)
+
+	_, _, err = GenerateVanityAddress(context.Background(), "", "", 0)
+	require.NoError(t, err)
+}
+
+func TestGenerateVanityAddressCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := GenerateVanityAddress(ctx, strings.Repeat("A", 20), "", 2)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGenerateVanityAddressInvalidPattern(t *testing.T) {
+	for _, pattern := range []struct{ prefix, suffix string }{
+		{"a", ""},
+		{"AB1", ""},
+		{"", "B"},
+		{strings.Repeat("A", 50), strings.Repeat("A", 9)},
+	} {
+		_, _, err := GenerateVanityAddress(context.Background(), pattern.prefix, pattern.suffix, 1)
+		require.Error(t, err, pattern)
+	}
+}
@@ -0,0 +1,225 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// The VRF is ECVRF-ED25519-SHA512-Elligator2 of draft-irtf-cfrg-vrf-03, as
+// implemented by the libsodium fork of go-algorand, with which sortition
+// proofs are made and the vrf_verify opcode verifies.
+
+// VRFPrivateKey is a VRF private key: a 32 byte seed followed by the public
+// key, as ed25519 private keys are.
+type VRFPrivateKey [64]byte
+
+// VRFProof is a VRF proof, as popped by vrf_verify.
+type VRFProof [80]byte
+
+// VRFOutput is the pseudorandom output of a VRF proof, as pushed by
+// vrf_verify.
+type VRFOutput [64]byte
+
+const vrfSuite = 0x04
+
+// curve25519A is the A coefficient of Curve25519.
+var curve25519A = new(field.Element).Mult32(new(field.Element).One(), 486662)
+
+// GenerateVRFKey generates a random VRF key pair.
+func GenerateVRFKey() (types.VRFPK, VRFPrivateKey, error) {
+	var seed [32]byte
+	if _, err := rand.Read(seed[:]); err != nil {
+		return types.VRFPK{}, VRFPrivateKey{}, err
+	}
+	pk, sk := VRFKeyFromSeed(seed)
+	return pk, sk, nil
+}
+
+// VRFKeyFromSeed returns the VRF key pair of seed.
+func VRFKeyFromSeed(seed [32]byte) (types.VRFPK, VRFPrivateKey) {
+	x, _ := vrfExpand(seed[:])
+	var pk types.VRFPK
+	copy(pk[:], new(edwards25519.Point).ScalarBaseMult(x).Bytes())
+	var sk VRFPrivateKey
+	copy(sk[:], seed[:])
+	copy(sk[32:], pk[:])
+	return pk, sk
+}
+
+// Public returns the public key of sk.
+func (sk VRFPrivateKey) Public() types.VRFPK {
+	var pk types.VRFPK
+	copy(pk[:], sk[32:])
+	return pk
+}
+
+// vrfExpand returns the secret scalar of seed and the key of its nonces.
+func vrfExpand(seed []byte) (*edwards25519.Scalar, []byte) {
+	az := sha512.Sum512(seed)
+	x, err := new(edwards25519.Scalar).SetBytesWithClamping(az[:32])
+	if err != nil {
+		// SetBytesWithClamping only fails on input that is not 32 bytes
+		panic(err)
+	}
+	return x, az[32:]
+}
+
+// Prove returns the proof of message by sk.
+func (sk VRFPrivateKey) Prove(message []byte) (VRFProof, error) {
+	y, err := new(edwards25519.Point).SetBytes(sk[32:])
+	if err != nil {
+		return VRFProof{}, errVRFInvalidKey
+	}
+	x, nonceKey := vrfExpand(sk[:32])
+	h, err := vrfHashToCurve(y, message)
+	if err != nil {
+		return VRFProof{}, err
+	}
+	gamma := new(edwards25519.Point).ScalarMult(x, h)
+
+	nonce := sha512.New()
+	nonce.Write(nonceKey)
+	nonce.Write(h.Bytes())
+	k, err := new(edwards25519.Scalar).SetUniformBytes(nonce.Sum(nil))
+	if err != nil {
+		panic(err)
+	}
+	c := vrfHashPoints(h, gamma, new(edwards25519.Point).ScalarBaseMult(k), new(edwards25519.Point).ScalarMult(k, h))
+	s := new(edwards25519.Scalar).MultiplyAdd(vrfChallengeScalar(c[:]), x, k)
+
+	var proof VRFProof
+	copy(proof[:32], gamma.Bytes())
+	copy(proof[32:48], c[:])
+	copy(proof[48:], s.Bytes())
+	return proof, nil
+}
+
+// VerifyVRF returns whether proof is a valid proof of message by pk, and its
+// output, as vrf_verify does.
+func VerifyVRF(pk types.VRFPK, proof VRFProof, message []byte) (VRFOutput, bool) {
+	y, err := new(edwards25519.Point).SetBytes(pk[:])
+	if err != nil || smallOrder(y) {
+		return VRFOutput{}, false
+	}
+	gamma, err := new(edwards25519.Point).SetBytes(proof[:32])
+	if err != nil {
+		return VRFOutput{}, false
+	}
+	// s must be below 2^252, as libsodium requires
+	if proof[79]&0xf0 != 0 {
+		return VRFOutput{}, false
+	}
+	s, err := new(edwards25519.Scalar).SetCanonicalBytes(proof[48:])
+	if err != nil {
+		return VRFOutput{}, false
+	}
+	h, err := vrfHashToCurve(y, message)
+	if err != nil {
+		return VRFOutput{}, false
+	}
+
+	negC := new(edwards25519.Scalar).Negate(vrfChallengeScalar(proof[32:48]))
+	// U = sB - cY, V = sH - cΓ
+	u := new(edwards25519.Point).VarTimeDoubleScalarBaseMult(negC, y, s)
+	v := new(edwards25519.Point).Add(
+		new(edwards25519.Point).ScalarMult(s, h),
+		new(edwards25519.Point).ScalarMult(negC, gamma))
+	c := vrfHashPoints(h, gamma, u, v)
+	if subtle.ConstantTimeCompare(c[:], proof[32:48]) != 1 {
+		return VRFOutput{}, false
+	}
+	output, err := proof.Hash()
+	return output, err == nil
+}
+
+// Hash returns the output of proof, without verifying it.
+func (proof VRFProof) Hash() (VRFOutput, error) {
+	gamma, err := new(edwards25519.Point).SetBytes(proof[:32])
+	if err != nil {
+		return VRFOutput{}, errVRFInvalidProof
+	}
+	h := sha512.New()
+	h.Write([]byte{vrfSuite, 0x03})
+	h.Write(new(edwards25519.Point).MultByCofactor(gamma).Bytes())
+	var output VRFOutput
+	copy(output[:], h.Sum(nil))
+	return output, nil
+}
+
+func smallOrder(p *edwards25519.Point) bool {
+	return new(edwards25519.Point).MultByCofactor(p).Equal(edwards25519.NewIdentityPoint()) == 1
+}
+
+// vrfHashPoints returns the challenge of the points of a proof.
+func vrfHashPoints(points ...*edwards25519.Point) [16]byte {
+	h := sha512.New()
+	h.Write([]byte{vrfSuite, 0x02})
+	for _, p := range points {
+		h.Write(p.Bytes())
+	}
+	var c [16]byte
+	copy(c[:], h.Sum(nil))
+	return c
+}
+
+func vrfChallengeScalar(c []byte) *edwards25519.Scalar {
+	var wide [32]byte
+	copy(wide[:], c)
+	s, err := new(edwards25519.Scalar).SetCanonicalBytes(wide[:])
+	if err != nil {
+		// a 128 bit integer is below the group order
+		panic(err)
+	}
+	return s
+}
+
+// vrfHashToCurve maps the public key y and message to a point of the prime
+// order subgroup with Elligator2.
+func vrfHashToCurve(y *edwards25519.Point, message []byte) (*edwards25519.Point, error) {
+	h := sha512.New()
+	h.Write([]byte{vrfSuite, 0x01})
+	h.Write(y.Bytes())
+	h.Write(message)
+	r := h.Sum(nil)[:32]
+	r[31] &= 0x7f
+
+	rr, err := new(field.Element).SetBytes(r)
+	if err != nil {
+		return nil, err
+	}
+	one := new(field.Element).One()
+	// x = -A / (1 + 2r²)
+	d := new(field.Element).Square(rr)
+	d.Add(d, d).Add(d, one)
+	x := new(field.Element).Multiply(curve25519A, d.Invert(d))
+	x.Negate(x)
+
+	// e = χ(x³ + Ax² + x)
+	x2 := new(field.Element).Square(x)
+	e := new(field.Element).Multiply(x2, x)
+	e.Add(e, x).Add(e, new(field.Element).Multiply(x2, curve25519A))
+	e2 := new(field.Element).Square(e)
+	chi := new(field.Element).Pow22523(e)
+	chi.Square(chi).Square(chi).Multiply(chi, e2)
+
+	// x = -x - A when e is not a square
+	minusOne := new(field.Element).Negate(one)
+	if chi.Equal(minusOne) == 1 {
+		x.Negate(x).Subtract(x, curve25519A)
+	}
+
+	// the Edwards y of the Montgomery x, (x - 1) / (x + 1)
+	yed := new(field.Element).Add(x, one)
+	yed.Invert(yed).Multiply(yed, new(field.Element).Subtract(x, one))
+	p, err := new(edwards25519.Point).SetBytes(yed.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return p.MultByCofactor(p), nil
+}
@@ -0,0 +1,45 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVRF(t *testing.T) {
+	// draft-irtf-cfrg-vrf-03 ECVRF-ED25519-SHA512-Elligator2, example 10
+	var seed [32]byte
+	hex.Decode(seed[:], []byte("9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"))
+	pk, sk := VRFKeyFromSeed(seed)
+	require.Equal(t, "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a", hex.EncodeToString(pk[:]))
+	proof, err := sk.Prove(nil)
+	require.NoError(t, err)
+	require.Equal(t, "b6b4699f87d56126c9117a7da55bd0085246f4c56dbc95d20172612e9d38e8d7ca65e573a126ed88d4e30a46f80a666854d675cf3ba81de0de043c3774f061560f55edc256a787afe701677c0f602900", hex.EncodeToString(proof[:]))
+	output, ok := VerifyVRF(pk, proof, nil)
+	require.True(t, ok)
+	require.Equal(t, "5b49b554d05c0cd5a5325376b3387de59d924fd1e13ded44648ab33c21349a603f25b84ec5ed887995b33da5e3bfcb87cd2f64521c4c62cf825cffabbe5d31cc", hex.EncodeToString(output[:]))
+
+	_, ok = VerifyVRF(pk, proof, []byte("other"))
+	require.False(t, ok)
+	tampered := proof
+	tampered[40] ^= 1
+	_, ok = VerifyVRF(pk, tampered, nil)
+	require.False(t, ok)
+
+	pk, sk, err = GenerateVRFKey()
+	require.NoError(t, err)
+	require.Equal(t, pk, sk.Public())
+	message := []byte("round 42 seed")
+	proof, err = sk.Prove(message)
+	require.NoError(t, err)
+	output, ok = VerifyVRF(pk, proof, message)
+	require.True(t, ok)
+	hash, err := proof.Hash()
+	require.NoError(t, err)
+	require.Equal(t, hash, output)
+
+	// small order public keys are rejected
+	_, ok = VerifyVRF([32]byte{1}, proof, message)
+	require.False(t, ok)
+}
@@ -0,0 +1,68 @@
+package json
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+
+	"github.com/algorand/go-codec/codec"
+)
+
+// AlgodCodecHandle is used to instantiate JSON encoders and decoders producing
+// the JSON algod emits. It is CodecHandle, except that the types registered
+// with RegisterAlgodText, such as addresses, are written in their text form
+// rather than as base64 byte arrays.
+var AlgodCodecHandle *codec.JsonHandle
+
+// algodTextExtTag is the extension tag of the types registered with
+// RegisterAlgodText. JSON does not write tags, so any value will do.
+const algodTextExtTag = 1
+
+// RegisterAlgodText makes EncodeAlgod write the values of type rt as the
+// string returned by toText, and DecodeAlgod read them back with their
+// UnmarshalText method. The types package registers its types whose algod
+// JSON form differs from a byte array.
+func RegisterAlgodText(rt reflect.Type, toText func(v interface{}) string) {
+	if !reflect.PointerTo(rt).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()) {
+		panic(fmt.Sprintf("json: %v does not implement encoding.TextUnmarshaler", rt))
+	}
+	if err := AlgodCodecHandle.SetInterfaceExt(rt, algodTextExtTag, algodTextExt{toText}); err != nil {
+		panic(err)
+	}
+}
+
+// algodTextExt converts a value to and from its algod text form.
+type algodTextExt struct {
+	toText func(v interface{}) string
+}
+
+func (e algodTextExt) ConvertExt(v interface{}) interface{} {
+	return e.toText(v)
+}
+
+func (e algodTextExt) UpdateExt(dst interface{}, src interface{}) {
+	text, ok := src.(string)
+	if !ok {
+		panic(fmt.Errorf("json: expected a string for %T, got %T", dst, src))
+	}
+	if err := dst.(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+		panic(err)
+	}
+}
+
+// EncodeAlgod returns the JSON encoding of obj in the shape algod emits: byte
+// arrays in base64, addresses in their checksummed base32 form, and zero
+// fields omitted.
+func EncodeAlgod(obj interface{}) []byte {
+	var b []byte
+	enc := codec.NewEncoderBytes(&b, AlgodCodecHandle)
+	enc.MustEncode(obj)
+	return b
+}
+
+// DecodeAlgod decodes JSON as emitted by algod, such as a fixture captured
+// from the REST API, into the object instance pointed to by objptr.
+func DecodeAlgod(b []byte, objptr interface{}) error {
+	dec := codec.NewDecoderBytes(b, AlgodCodecHandle)
+	return dec.Decode(objptr)
+}
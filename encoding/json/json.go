@@ -43,6 +43,14 @@ func init() {
 	JSONStrictHandle.Indent = CodecHandle.Indent
 	JSONStrictHandle.HTMLCharsAsIs = CodecHandle.HTMLCharsAsIs
 	JSONStrictHandle.MapKeyAsString = true
+
+	AlgodCodecHandle = new(codec.JsonHandle)
+	AlgodCodecHandle.ErrorIfNoField = CodecHandle.ErrorIfNoField
+	AlgodCodecHandle.ErrorIfNoArrayExpand = CodecHandle.ErrorIfNoArrayExpand
+	AlgodCodecHandle.Canonical = CodecHandle.Canonical
+	AlgodCodecHandle.RecursiveEmptyCheck = CodecHandle.RecursiveEmptyCheck
+	AlgodCodecHandle.Indent = CodecHandle.Indent
+	AlgodCodecHandle.HTMLCharsAsIs = CodecHandle.HTMLCharsAsIs
 }
 
 // Encode returns a json-encoded byte buffer for a given object
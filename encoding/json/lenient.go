@@ -0,0 +1,64 @@
+package json
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+// lenientExtTag is the extension tag of the types registered with
+// RegisterLenient. JSON does not write tags, so any value will do.
+const lenientExtTag = 2
+
+// RegisterLenient makes LenientDecode and NewLenientDecoder read the values
+// of type rt, an enum whose kind is a string or an unsigned integer, as any
+// value of that kind, while Decode keeps rejecting the values its
+// UnmarshalJSON or UnmarshalText method does. The types package registers its
+// enums, so that responses from a newer algod still decode leniently.
+func RegisterLenient(rt reflect.Type) {
+	switch rt.Kind() {
+	case reflect.String, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		panic(fmt.Sprintf("json: %v is not a string or an unsigned integer", rt))
+	}
+	if err := LenientCodecHandle.SetInterfaceExt(rt, lenientExtTag, lenientExt{}); err != nil {
+		panic(err)
+	}
+}
+
+// lenientExt converts an enum to and from its underlying kind.
+type lenientExt struct{}
+
+func (lenientExt) ConvertExt(v interface{}) interface{} {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() == reflect.String {
+		return rv.String()
+	}
+	return rv.Uint()
+}
+
+func (lenientExt) UpdateExt(dst interface{}, src interface{}) {
+	rv := reflect.ValueOf(dst).Elem()
+	switch src := src.(type) {
+	case string:
+		if rv.Kind() == reflect.String {
+			rv.SetString(src)
+			return
+		}
+		// Unsigned enums may also be named.
+		u, ok := dst.(encoding.TextUnmarshaler)
+		if !ok {
+			panic(fmt.Errorf("json: cannot decode a string into %v", rv.Type()))
+		}
+		if err := u.UnmarshalText([]byte(src)); err != nil {
+			panic(err)
+		}
+	case uint64:
+		if rv.Kind() == reflect.String || rv.OverflowUint(src) {
+			panic(fmt.Errorf("json: cannot decode %d into %v", src, rv.Type()))
+		}
+		rv.SetUint(src)
+	default:
+		panic(fmt.Errorf("json: cannot decode %T into %v", src, rv.Type()))
+	}
+}
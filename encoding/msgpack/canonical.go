@@ -0,0 +1,299 @@
+package msgpack
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// IsCanonical reports whether data is a single msgpack object in the canonical
+// form produced by Encode. See CheckCanonical.
+func IsCanonical(data []byte) bool {
+	return CheckCanonical(data) == nil
+}
+
+// CheckCanonical returns an error describing why data is not a single msgpack
+// object in the canonical form produced by Encode: every integer and length
+// uses its shortest encoding, non-negative integers are encoded as unsigned,
+// map keys are sorted without duplicates and nothing follows the object.
+//
+// Whether zero values were omitted depends on the type being encoded, which
+// data does not tell; DecodeCanonical checks that as well.
+func CheckCanonical(data []byte) error {
+	c := canonicalChecker{data: data}
+	if err := c.object(); err != nil {
+		return err
+	}
+	if c.pos != len(data) {
+		return fmt.Errorf("msgpack: %d bytes after the end of the object", len(data)-c.pos)
+	}
+	return nil
+}
+
+// DecodeCanonical decodes data into the object instance pointed to by objptr
+// like Decode, which rejects unknown fields, and then checks that data is the
+// canonical encoding of the decoded object, so that it hashes and signs the
+// same as the encoding Encode would produce. This catches non canonical
+// encodings, as well as zero values that were not omitted.
+func DecodeCanonical(data []byte, objptr interface{}) error {
+	if err := CheckCanonical(data); err != nil {
+		return err
+	}
+	if err := Decode(data, objptr); err != nil {
+		return err
+	}
+	if !bytes.Equal(Encode(objptr), data) {
+		return fmt.Errorf("msgpack: encoding is not canonical for %T, zero values must be omitted", objptr)
+	}
+	return nil
+}
+
+// canonicalChecker walks the msgpack objects of data from pos.
+type canonicalChecker struct {
+	data []byte
+	pos  int
+}
+
+func (c *canonicalChecker) next(n int) ([]byte, error) {
+	if n < 0 || len(c.data)-c.pos < n {
+		return nil, fmt.Errorf("msgpack: unexpected end of data at offset %d", c.pos)
+	}
+	b := c.data[c.pos : c.pos+n]
+	c.pos += n
+	return b, nil
+}
+
+// uint reads an n byte big-endian unsigned integer.
+func (c *canonicalChecker) uint(n int) (uint64, error) {
+	b, err := c.next(n)
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	for _, x := range b {
+		v = v<<8 | uint64(x)
+	}
+	return v, nil
+}
+
+// length reads the n byte length of an object whose type byte is at start,
+// and checks that no shorter encoding could hold it, min being the smallest
+// length needing n bytes.
+func (c *canonicalChecker) length(start, n int, min uint64) (int, error) {
+	l, err := c.uint(n)
+	if err != nil {
+		return 0, err
+	}
+	if l < min {
+		return 0, fmt.Errorf("msgpack: length %d at offset %d does not use its shortest encoding", l, start)
+	}
+	return int(l), nil
+}
+
+func (c *canonicalChecker) object() error {
+	start := c.pos
+	b, err := c.next(1)
+	if err != nil {
+		return err
+	}
+	t := b[0]
+
+	switch {
+	case t <= 0x7f, t >= 0xe0, t == 0xc0, t == 0xc2, t == 0xc3:
+		// fixint, nil and bool
+		return nil
+	case t >= 0x80 && t <= 0x8f:
+		return c.mapEntries(start, int(t&0x0f))
+	case t >= 0x90 && t <= 0x9f:
+		return c.arrayElems(int(t & 0x0f))
+	case t >= 0xa0 && t <= 0xbf:
+		_, err = c.next(int(t & 0x1f))
+		return err
+	}
+
+	switch t {
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		n := 1 << (t - 0xcc)
+		v, err := c.uint(n)
+		if err != nil {
+			return err
+		}
+		if (n == 1 && v < 1<<7) || (n > 1 && v < 1<<(4*n)) {
+			return fmt.Errorf("msgpack: integer %d at offset %d does not use its shortest encoding", v, start)
+		}
+		return nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		n := 1 << (t - 0xd0)
+		u, err := c.uint(n)
+		if err != nil {
+			return err
+		}
+		v := int64(u<<(64-8*n)) >> (64 - 8*n)
+		if v >= 0 {
+			return fmt.Errorf("msgpack: non-negative integer %d at offset %d is encoded as signed", v, start)
+		}
+		if (n == 1 && v >= -32) || (n > 1 && v >= -(1<<(8*n/2-1))) {
+			return fmt.Errorf("msgpack: integer %d at offset %d does not use its shortest encoding", v, start)
+		}
+		return nil
+	case 0xca:
+		_, err = c.next(4)
+		return err
+	case 0xcb:
+		_, err = c.next(8)
+		return err
+	case 0xd9, 0xda, 0xdb, 0xc4, 0xc5, 0xc6:
+		var n int
+		var min uint64
+		switch t {
+		case 0xd9:
+			n, min = 1, 32
+		case 0xc4:
+			n, min = 1, 0
+		default:
+			n = 2
+			if t == 0xdb || t == 0xc6 {
+				n = 4
+			}
+			min = 1 << (4 * n)
+		}
+		l, err := c.length(start, n, min)
+		if err != nil {
+			return err
+		}
+		_, err = c.next(l)
+		return err
+	case 0xdc, 0xdd:
+		n, min := 2, uint64(16)
+		if t == 0xdd {
+			n, min = 4, 1<<16
+		}
+		l, err := c.length(start, n, min)
+		if err != nil {
+			return err
+		}
+		return c.arrayElems(l)
+	case 0xde, 0xdf:
+		n, min := 2, uint64(16)
+		if t == 0xdf {
+			n, min = 4, 1<<16
+		}
+		l, err := c.length(start, n, min)
+		if err != nil {
+			return err
+		}
+		return c.mapEntries(start, l)
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		// fixext: a type byte and 1 to 16 data bytes
+		_, err = c.next(1 + 1<<(t-0xd4))
+		return err
+	case 0xc7, 0xc8, 0xc9:
+		n := 1 << (t - 0xc7)
+		var min uint64
+		if n > 1 {
+			min = 1 << (4 * n)
+		}
+		l, err := c.length(start, n, min)
+		if err != nil {
+			return err
+		}
+		if n == 1 && (l == 1 || l == 2 || l == 4 || l == 8 || l == 16) {
+			return fmt.Errorf("msgpack: extension at offset %d should use a fixext encoding", start)
+		}
+		_, err = c.next(1 + l)
+		return err
+	}
+	return fmt.Errorf("msgpack: invalid type byte 0x%x at offset %d", t, start)
+}
+
+func (c *canonicalChecker) arrayElems(n int) error {
+	for i := 0; i < n; i++ {
+		if err := c.object(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *canonicalChecker) mapEntries(start, n int) error {
+	var prev []byte
+	for i := 0; i < n; i++ {
+		keyStart := c.pos
+		if err := c.object(); err != nil {
+			return err
+		}
+		key := c.data[keyStart:c.pos]
+		if prev != nil && compareKeys(prev, key) >= 0 {
+			return fmt.Errorf("msgpack: keys of the map at offset %d are not sorted or not unique", start)
+		}
+		prev = key
+		if err := c.object(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compareKeys compares two encoded map keys in the order Encode sorts them:
+// integers by value, strings bytewise, and other keys by their encoding.
+func compareKeys(a, b []byte) int {
+	if x, xneg, ok := decodeInt(a); ok {
+		if y, yneg, ok := decodeInt(b); ok {
+			// negative integers come first, and two's complement values
+			// order integers of the same sign
+			switch {
+			case xneg && !yneg, xneg == yneg && x < y:
+				return -1
+			case !xneg && yneg, xneg == yneg && x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	if x, ok := decodeStr(a); ok {
+		if y, ok := decodeStr(b); ok {
+			return bytes.Compare(x, y)
+		}
+	}
+	return bytes.Compare(a, b)
+}
+
+// decodeInt decodes an encoded integer as its 64 bit two's complement value
+// and whether it is negative.
+func decodeInt(b []byte) (v uint64, neg bool, ok bool) {
+	t := b[0]
+	switch {
+	case t <= 0x7f:
+		return uint64(t), false, true
+	case t >= 0xe0:
+		return uint64(int64(int8(t))), true, true
+	case t >= 0xcc && t <= 0xcf:
+		for _, x := range b[1:] {
+			v = v<<8 | uint64(x)
+		}
+		return v, false, true
+	case t >= 0xd0 && t <= 0xd3:
+		n := len(b) - 1
+		for _, x := range b[1:] {
+			v = v<<8 | uint64(x)
+		}
+		signed := int64(v<<(64-8*n)) >> (64 - 8*n)
+		return uint64(signed), signed < 0, true
+	}
+	return 0, false, false
+}
+
+// decodeStr returns the bytes of an encoded string.
+func decodeStr(b []byte) ([]byte, bool) {
+	t := b[0]
+	switch {
+	case t >= 0xa0 && t <= 0xbf:
+		return b[1:], true
+	case t == 0xd9:
+		return b[2:], true
+	case t == 0xda:
+		return b[3:], true
+	case t == 0xdb:
+		return b[5:], true
+	}
+	return nil, false
+}
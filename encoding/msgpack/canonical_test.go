@@ -0,0 +1,72 @@
+package msgpack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsCanonical(t *testing.T) {
+	type inner struct {
+		_struct struct{} `codec:",omitempty,omitemptyarray"`
+		A       uint64   `codec:"a"`
+		B       int64    `codec:"b"`
+	}
+	type outer struct {
+		_struct struct{}          `codec:",omitempty,omitemptyarray"`
+		Name    string            `codec:"name"`
+		Bytes   []byte            `codec:"bytes"`
+		Inner   inner             `codec:"inner"`
+		List    []int64           `codec:"list"`
+		Counts  map[uint64]string `codec:"counts"`
+	}
+	long := make([]byte, 300)
+	obj := outer{
+		Name:   "a name longer than thirty one characters",
+		Bytes:  long,
+		Inner:  inner{A: 1 << 40, B: -200},
+		List:   []int64{0, 1, -1, 127, 128, -33, 1 << 20, -(1 << 40)},
+		Counts: map[uint64]string{1: "x", 300: "y", 1 << 33: "z"},
+	}
+	data := Encode(obj)
+	assert.True(t, IsCanonical(data))
+
+	var decoded outer
+	assert.NoError(t, DecodeCanonical(data, &decoded))
+	assert.Equal(t, obj, decoded)
+
+	testcases := []struct {
+		name string
+		data []byte
+	}{
+		{"trailing bytes", append(Encode(obj), 0xc0)},
+		{"truncated", data[:len(data)-1]},
+		{"long uint", []byte{0xcc, 0x05}},
+		{"long uint16", []byte{0xcd, 0x00, 0xff}},
+		{"signed positive", []byte{0xd0, 0x05}},
+		{"long negative", []byte{0xd1, 0xff, 0xf0}},
+		{"long str", []byte{0xd9, 0x01, 'a'}},
+		{"long array", []byte{0xdc, 0x00, 0x01, 0x01}},
+		{"unsorted keys", []byte{0x82, 0xa1, 'b', 0x01, 0xa1, 'a', 0x01}},
+		{"duplicate keys", []byte{0x82, 0xa1, 'a', 0x01, 0xa1, 'a', 0x02}},
+		{"unsorted int keys", []byte{0x82, 0xcd, 0x01, 0x00, 0x01, 0x05, 0x01}},
+		{"invalid type", []byte{0xc1}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.False(t, IsCanonical(tc.data))
+		})
+	}
+	assert.True(t, IsCanonical([]byte{0x82, 0x05, 0x01, 0xcd, 0x01, 0x00, 0x01}))
+	assert.True(t, IsCanonical([]byte{0x82, 0xff, 0x01, 0x05, 0x01}))
+
+	// a zero value that is not omitted is only caught with the type
+	withZero := []byte{0x81, 0xa1, 'a', 0x00}
+	assert.True(t, IsCanonical(withZero))
+	var in inner
+	assert.Error(t, DecodeCanonical(withZero, &in))
+	assert.NoError(t, DecodeCanonical([]byte{0x81, 0xa1, 'a', 0x01}, &in))
+
+	// unknown fields are rejected
+	assert.Error(t, DecodeCanonical([]byte{0x81, 0xa1, 'z', 0x01}, &in))
+}
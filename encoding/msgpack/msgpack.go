@@ -1,7 +1,11 @@
 package msgpack
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"io"
+	"sync"
 
 	"github.com/algorand/go-codec/codec"
 )
@@ -33,14 +37,62 @@ func init() {
 	LenientCodecHandle.PositiveIntUnsigned = true
 }
 
+// pooledEncoder is an encoder writing to out.
+type pooledEncoder struct {
+	enc *codec.Encoder
+	out []byte
+}
+
+// encoderPool holds encoders to reuse, as creating one allocates more than
+// encoding a transaction does.
+var encoderPool = sync.Pool{
+	New: func() interface{} {
+		p := &pooledEncoder{out: []byte{}}
+		p.enc = codec.NewEncoderBytes(&p.out, CodecHandle)
+		return p
+	},
+}
+
 // Encode returns a msgpack-encoded byte buffer for a given object
 func Encode(obj interface{}) []byte {
-	var b []byte
-	enc := codec.NewEncoderBytes(&b, CodecHandle)
-	enc.MustEncode(obj)
+	return AppendEncode(nil, obj)
+}
+
+// AppendEncode appends the msgpack encoding of obj to dst and returns the
+// extended buffer. Encoding into a buffer with enough capacity, reused from
+// one call to the next, does not allocate.
+func AppendEncode(dst []byte, obj interface{}) []byte {
+	b, err := appendEncode(dst, obj)
+	if err != nil {
+		panic(err)
+	}
 	return b
 }
 
+func appendEncode(dst []byte, obj interface{}) ([]byte, error) {
+	p := encoderPool.Get().(*pooledEncoder)
+	p.out = dst[len(dst):]
+	p.enc.ResetBytes(&p.out)
+	err := p.enc.Encode(obj)
+	out := p.out
+	// do not keep the buffer of the caller
+	p.out = []byte{}
+	p.enc.ResetBytes(&p.out)
+	encoderPool.Put(p)
+	if err != nil {
+		return dst, err
+	}
+
+	if dst == nil {
+		return out, nil
+	}
+	if len(out) > 0 && cap(dst)-len(dst) >= len(out) && &dst[:len(dst)+1][len(dst)] == &out[0] {
+		// encoded in place, in the spare capacity of dst
+		return dst[:len(dst)+len(out)], nil
+	}
+	return append(dst, out...), nil
+}
+
 // Decode attempts to decode a msgpack-encoded byte buffer into an
 // object instance pointed to by objptr
 func Decode(b []byte, objptr interface{}) error {
@@ -61,3 +113,64 @@ func NewDecoder(r io.Reader) *codec.Decoder {
 func NewLenientDecoder(r io.Reader) *codec.Decoder {
 	return codec.NewDecoder(r, LenientCodecHandle)
 }
+
+// NewEncoder returns a msgpack encoder writing to w. Each call to its Encode
+// method writes one object, so several objects can be streamed to w.
+func NewEncoder(w io.Writer) *codec.Encoder {
+	return codec.NewEncoder(w, CodecHandle)
+}
+
+// EncodeTo writes the msgpack encoding of obj to w, without building it in
+// memory first. A *bytes.Buffer is encoded into directly, without allocating
+// once it has grown to the size of the objects encoded.
+func EncodeTo(w io.Writer, obj interface{}) error {
+	if buf, ok := w.(*bytes.Buffer); ok {
+		b, err := appendEncode(buf.AvailableBuffer(), obj)
+		if err != nil {
+			return err
+		}
+		_, err = buf.Write(b)
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	if err := NewEncoder(bw).Encode(obj); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// DecodeFrom decodes the msgpack object read from r into the object instance
+// pointed to by objptr. r is read through a buffer, so it may be read past the
+// end of the object; use NewDecoder or DecodeStream for a stream of objects.
+func DecodeFrom(r io.Reader, objptr interface{}) error {
+	return NewDecoder(bufio.NewReader(r)).Decode(objptr)
+}
+
+// DecodeStream decodes the concatenated msgpack objects read from r, such as
+// those of a block or ledger export file, calling fn with each of them in
+// turn. Only one object is held in memory at a time. It stops at the end of r,
+// returning nil, or at the first error, including one returned by fn.
+func DecodeStream[T any](r io.Reader, fn func(T) error) error {
+	br := bufio.NewReader(r)
+	dec := NewDecoder(br)
+	for {
+		// the stream may only end between two objects
+		if _, err := br.Peek(1); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		var obj T
+		if err := dec.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		if err := fn(obj); err != nil {
+			return err
+		}
+	}
+}
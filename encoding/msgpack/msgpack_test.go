@@ -2,6 +2,8 @@ package msgpack
 
 import (
 	"bytes"
+	"errors"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -58,3 +60,97 @@ func TestDecode(t *testing.T) {
 		assert.Equal(t, obj.subsetObject, decoded)
 	})
 }
+
+func TestStream(t *testing.T) {
+	objs := []object{
+		{subsetObject: subsetObject{Data: "first"}, Name: "a"},
+		{subsetObject: subsetObject{Data: "second"}, Name: "b"},
+		{subsetObject: subsetObject{Data: "third"}, Name: "c"},
+	}
+
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	for _, obj := range objs {
+		assert.NoError(t, enc.Encode(obj))
+	}
+
+	var decoded []object
+	err := DecodeStream(bytes.NewReader(buf.Bytes()), func(obj object) error {
+		decoded = append(decoded, obj)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, objs, decoded)
+
+	// EncodeTo and DecodeFrom handle a single object
+	var single bytes.Buffer
+	assert.NoError(t, EncodeTo(&single, objs[0]))
+	assert.Equal(t, Encode(objs[0]), single.Bytes())
+	var first object
+	assert.NoError(t, DecodeFrom(&single, &first))
+	assert.Equal(t, objs[0], first)
+
+	// a truncated stream is an error
+	truncated := buf.Bytes()[:buf.Len()-2]
+	err = DecodeStream(bytes.NewReader(truncated), func(obj object) error { return nil })
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	// so is an error of the callback
+	stop := errors.New("stop")
+	err = DecodeStream(bytes.NewReader(buf.Bytes()), func(obj object) error { return stop })
+	assert.ErrorIs(t, err, stop)
+}
+
+func TestAppendEncode(t *testing.T) {
+	obj := object{subsetObject: subsetObject{Data: "data"}, Name: "name"}
+	encoded := Encode(obj)
+
+	prefixed := AppendEncode([]byte("TX"), obj)
+	assert.Equal(t, append([]byte("TX"), encoded...), prefixed)
+
+	// encoded in place when dst has room
+	buf := make([]byte, 2, 64)
+	copy(buf, "TX")
+	out := AppendEncode(buf, obj)
+	assert.Equal(t, prefixed, out)
+	assert.Equal(t, &buf[0], &out[0])
+
+	allocs := testing.AllocsPerRun(100, func() {
+		out = AppendEncode(out[:2], &obj)
+	})
+	assert.Zero(t, allocs)
+
+	var b bytes.Buffer
+	b.WriteString("TX")
+	assert.NoError(t, EncodeTo(&b, obj))
+	assert.Equal(t, prefixed, b.Bytes())
+}
+
+func BenchmarkEncode(b *testing.B) {
+	obj := object{subsetObject: subsetObject{Data: "data"}, Name: "name"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Encode(&obj)
+	}
+}
+
+func BenchmarkAppendEncode(b *testing.B) {
+	obj := object{subsetObject: subsetObject{Data: "data"}, Name: "name"}
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = AppendEncode(buf[:0], &obj)
+	}
+}
+
+func BenchmarkEncodeToBuffer(b *testing.B) {
+	obj := object{subsetObject: subsetObject{Data: "data"}, Name: "name"}
+	var buf bytes.Buffer
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := EncodeTo(&buf, &obj); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
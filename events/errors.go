@@ -0,0 +1,7 @@
+package events
+
+import (
+	"errors"
+)
+
+var errUnknownEvent = errors.New("log does not start with the selector of a known event")
@@ -0,0 +1,255 @@
+// Package events decodes ARC-28 events emitted by applications.
+//
+// An ARC-28 event is a log entry made of the 4-byte selector of the event,
+// the first 4 bytes of the SHA-512/256 of its signature name(type1,type2,...),
+// followed by its arguments encoded as an ABI tuple. Events are described in
+// the "events" field of the ARC-4 contract description of an application.
+package events
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/v2/abi"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+)
+
+// SelectorLen is the length of the selector starting the log of an event.
+const SelectorLen = 4
+
+// Event describes an event emitted by an application.
+type Event struct {
+	// The name of the event
+	Name string `json:"name"`
+	// Optional, user-friendly description for the event
+	Desc string `json:"desc,omitempty"`
+	// The arguments of the event, in order
+	Args []abi.Arg `json:"args"`
+}
+
+// GetSignature returns the signature of the event, its name followed by the
+// types of its arguments.
+func (e Event) GetSignature() string {
+	types := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		types[i] = arg.Type
+	}
+	return e.Name + "(" + strings.Join(types, ",") + ")"
+}
+
+// GetSelector returns the 4-byte selector starting the logs of the event.
+func (e Event) GetSelector() []byte {
+	sigHash := sha512.Sum512_256([]byte(e.GetSignature()))
+	return sigHash[:SelectorLen]
+}
+
+// ParseContractEvents returns the events of the JSON description of an ARC-4
+// contract.
+func ParseContractEvents(contractJSON []byte) ([]Event, error) {
+	var contract struct {
+		Events []Event `json:"events"`
+	}
+	if err := json.Unmarshal(contractJSON, &contract); err != nil {
+		return nil, fmt.Errorf("invalid contract description: %w", err)
+	}
+	return contract.Events, nil
+}
+
+// Decoder decodes the logs of a set of events.
+type Decoder struct {
+	events []decoderEvent
+}
+
+type decoderEvent struct {
+	event    Event
+	selector []byte
+	tuple    abi.Type
+}
+
+// MakeDecoder returns a Decoder of events. It returns an error if the type of
+// an argument is invalid, or two events have the same selector.
+func MakeDecoder(events []Event) (Decoder, error) {
+	d := Decoder{events: make([]decoderEvent, 0, len(events))}
+	for _, event := range events {
+		types := make([]abi.Type, len(event.Args))
+		for i := range event.Args {
+			typ, err := event.Args[i].GetTypeObject()
+			if err != nil {
+				return Decoder{}, fmt.Errorf("event %s argument %d: %w", event.Name, i, err)
+			}
+			types[i] = typ
+		}
+		tuple, err := abi.MakeTupleType(types)
+		if err != nil {
+			return Decoder{}, fmt.Errorf("event %s: %w", event.Name, err)
+		}
+
+		selector := event.GetSelector()
+		for _, other := range d.events {
+			if bytes.Equal(other.selector, selector) {
+				return Decoder{}, fmt.Errorf("events %s and %s have the same selector %x", other.event.GetSignature(), event.GetSignature(), selector)
+			}
+		}
+		d.events = append(d.events, decoderEvent{event: event, selector: selector, tuple: tuple})
+	}
+	return d, nil
+}
+
+// MakeContractDecoder returns a Decoder of the events of the JSON description
+// of an ARC-4 contract.
+func MakeContractDecoder(contractJSON []byte) (Decoder, error) {
+	events, err := ParseContractEvents(contractJSON)
+	if err != nil {
+		return Decoder{}, err
+	}
+	return MakeDecoder(events)
+}
+
+// DecodedEvent is an event decoded from a log.
+type DecodedEvent struct {
+	Event Event
+
+	// Values of the arguments of the event, in order, as decoded by
+	// abi.Type.Decode.
+	Values []interface{}
+}
+
+// Value returns the value of the argument of the event with the given name.
+func (e DecodedEvent) Value(name string) (interface{}, bool) {
+	for i, arg := range e.Event.Args {
+		if arg.Name == name && i < len(e.Values) {
+			return e.Values[i], true
+		}
+	}
+	return nil, false
+}
+
+// Decode decodes a log entry. It returns an error if the log does not start
+// with the selector of one of the events, or its arguments cannot be decoded.
+func (d Decoder) Decode(log []byte) (DecodedEvent, error) {
+	if len(log) >= SelectorLen {
+		for _, e := range d.events {
+			if !bytes.Equal(log[:SelectorLen], e.selector) {
+				continue
+			}
+			decoded, err := e.tuple.Decode(log[SelectorLen:])
+			if err != nil {
+				return DecodedEvent{}, fmt.Errorf("could not decode event %s: %w", e.event.GetSignature(), err)
+			}
+			values, ok := decoded.([]interface{})
+			if !ok {
+				return DecodedEvent{}, fmt.Errorf("could not decode event %s: unexpected value %T", e.event.GetSignature(), decoded)
+			}
+			return DecodedEvent{Event: e.event, Values: values}, nil
+		}
+	}
+	return DecodedEvent{}, errUnknownEvent
+}
+
+// EmittedEvent is an event emitted by a transaction.
+type EmittedEvent struct {
+	DecodedEvent
+
+	// AppID is the ID of the application that emitted the event.
+	AppID uint64
+
+	// TxID is the ID of the transaction that emitted the event, and Path its
+	// position among the inner transactions of the top-level transaction,
+	// empty for the top-level transaction itself, see
+	// transaction.InnerTransaction.
+	TxID string
+	Path []int
+
+	// LogIndex is the index of the log of the event among those of the
+	// transaction.
+	LogIndex int
+}
+
+// DecodeLogs returns the events logged by the application appID in a
+// transaction, as returned by algod for a pending transaction or a simulation,
+// and its inner transactions, depth-first and in the order they were logged.
+// Logs of other applications, such as those called by inner transactions, are
+// ignored, as are logs that do not start with the selector of one of the
+// events, such as ABI method return values.
+//
+// A log that starts with the selector of an event but cannot be decoded does
+// not stop the decoding of the other logs: DecodeLogs returns every event it
+// decoded, along with an error joining a *LogError for each such log.
+func (d Decoder) DecodeLogs(appID uint64, result models.PendingTransactionResponse) ([]EmittedEvent, error) {
+	var errs []error
+	events := d.decodeTransactionLogs(appID, crypto.GetTxID(result.Transaction.Txn), nil, result, &errs)
+	err := transaction.WalkInnerTransactions(result, func(inner transaction.InnerTransaction) error {
+		events = append(events, d.decodeTransactionLogs(appID, inner.ID, inner.Path, inner.Result, &errs)...)
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return events, errors.Join(errs...)
+}
+
+// LogError is the error of a log that starts with the selector of an event
+// but whose arguments cannot be decoded.
+type LogError struct {
+	TxID     string
+	Path     []int
+	LogIndex int
+	Err      error
+}
+
+func (e *LogError) Error() string {
+	return fmt.Sprintf("log %d of transaction %s: %v", e.LogIndex, e.TxID, e.Err)
+}
+
+func (e *LogError) Unwrap() error {
+	return e.Err
+}
+
+func (d Decoder) decodeTransactionLogs(appID uint64, txID string, path []int, result models.PendingTransactionResponse, errs *[]error) []EmittedEvent {
+	logger := uint64(result.Transaction.Txn.ApplicationID)
+	if logger == 0 {
+		logger = result.ApplicationIndex
+	}
+	if logger != appID {
+		return nil
+	}
+
+	var events []EmittedEvent
+	for i, log := range result.Logs {
+		decoded, err := d.Decode(log)
+		if err == errUnknownEvent {
+			continue
+		}
+		if err != nil {
+			*errs = append(*errs, &LogError{TxID: txID, Path: path, LogIndex: i, Err: err})
+			continue
+		}
+		events = append(events, EmittedEvent{DecodedEvent: decoded, AppID: appID, TxID: txID, Path: path, LogIndex: i})
+	}
+	return events
+}
+
+// DecodeSimulateLogs returns the events logged by the application appID in
+// every transaction simulated, by group and by transaction within the group,
+// see DecodeLogs.
+func (d Decoder) DecodeSimulateLogs(appID uint64, response models.SimulateResponse) ([][][]EmittedEvent, error) {
+	var errs []error
+	groups := make([][][]EmittedEvent, len(response.TxnGroups))
+	for g, group := range response.TxnGroups {
+		groups[g] = make([][]EmittedEvent, len(group.TxnResults))
+		for t, result := range group.TxnResults {
+			events, err := d.DecodeLogs(appID, result.TxnResult)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			groups[g][t] = events
+		}
+	}
+	return groups, errors.Join(errs...)
+}
@@ -0,0 +1,122 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/abi"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+const contractJSON = `{
+	"name": "Swapper",
+	"methods": [],
+	"events": [
+		{"name": "Swapped", "args": [{"type": "uint64", "name": "in"}, {"type": "uint64", "name": "out"}]},
+		{"name": "Named", "desc": "A name was set", "args": [{"type": "string", "name": "name"}, {"type": "bool"}]}
+	]
+}`
+
+func encodeEvent(t *testing.T, event Event, values ...interface{}) []byte {
+	typ, err := abi.TypeOf("(" + event.GetSignature()[len(event.Name)+1:])
+	require.NoError(t, err)
+	encoded, err := typ.Encode(values)
+	require.NoError(t, err)
+	return append(event.GetSelector(), encoded...)
+}
+
+func TestDecode(t *testing.T) {
+	events, err := ParseContractEvents([]byte(contractJSON))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, "Swapped(uint64,uint64)", events[0].GetSignature())
+	require.Equal(t, "Named(string,bool)", events[1].GetSignature())
+	require.Len(t, events[0].GetSelector(), SelectorLen)
+
+	d, err := MakeContractDecoder([]byte(contractJSON))
+	require.NoError(t, err)
+
+	decoded, err := d.Decode(encodeEvent(t, events[1], "algo", true))
+	require.NoError(t, err)
+	require.Equal(t, "Named", decoded.Event.Name)
+	require.Equal(t, []interface{}{"algo", true}, decoded.Values)
+	name, ok := decoded.Value("name")
+	require.True(t, ok)
+	require.Equal(t, "algo", name)
+	_, ok = decoded.Value("missing")
+	require.False(t, ok)
+
+	_, err = d.Decode([]byte{0x15, 0x1f, 0x7c, 0x75, 0x01})
+	require.ErrorIs(t, err, errUnknownEvent)
+	_, err = d.Decode(nil)
+	require.ErrorIs(t, err, errUnknownEvent)
+	_, err = d.Decode(append(events[0].GetSelector(), 0x01))
+	require.ErrorContains(t, err, "Swapped(uint64,uint64)")
+
+	_, err = MakeDecoder([]Event{events[0], events[0]})
+	require.ErrorContains(t, err, "same selector")
+	_, err = MakeDecoder([]Event{{Name: "Bad", Args: []abi.Arg{{Type: "uint7"}}}})
+	require.Error(t, err)
+}
+
+func TestDecodeLogs(t *testing.T) {
+	events, err := ParseContractEvents([]byte(contractJSON))
+	require.NoError(t, err)
+	d, err := MakeDecoder(events)
+	require.NoError(t, err)
+
+	var result models.PendingTransactionResponse
+	result.Transaction.Txn = types.Transaction{Type: types.ApplicationCallTx, ApplicationFields: types.ApplicationFields{ApplicationCallTxnFields: types.ApplicationCallTxnFields{ApplicationID: 7}}}
+	result.Logs = [][]byte{
+		[]byte("not an event"),
+		encodeEvent(t, events[0], uint64(1), uint64(2)),
+	}
+	var inner models.PendingTransactionResponse
+	inner.Transaction.Txn = types.Transaction{Type: types.ApplicationCallTx}
+	inner.ApplicationIndex = 9
+	inner.Logs = [][]byte{encodeEvent(t, events[1], "inner", false)}
+	result.InnerTxns = []models.PendingTransactionResponse{inner}
+
+	emitted, err := d.DecodeLogs(7, result)
+	require.NoError(t, err)
+	require.Len(t, emitted, 1)
+
+	topID := crypto.GetTxID(result.Transaction.Txn)
+	require.Equal(t, "Swapped", emitted[0].Event.Name)
+	require.Equal(t, []interface{}{uint64(1), uint64(2)}, emitted[0].Values)
+	require.Equal(t, uint64(7), emitted[0].AppID)
+	require.Equal(t, topID, emitted[0].TxID)
+	require.Empty(t, emitted[0].Path)
+	require.Equal(t, 1, emitted[0].LogIndex)
+
+	// the logs of the inner application call are those of application 9
+	innerEmitted, err := d.DecodeLogs(9, result)
+	require.NoError(t, err)
+	require.Len(t, innerEmitted, 1)
+	innerID, err := crypto.InnerTransactionID(topID, 0, inner.Transaction.Txn)
+	require.NoError(t, err)
+	require.Equal(t, "Named", innerEmitted[0].Event.Name)
+	require.Equal(t, uint64(9), innerEmitted[0].AppID)
+	require.Equal(t, innerID, innerEmitted[0].TxID)
+	require.Equal(t, []int{0}, innerEmitted[0].Path)
+	require.Equal(t, 0, innerEmitted[0].LogIndex)
+
+	simulated, err := d.DecodeSimulateLogs(7, models.SimulateResponse{TxnGroups: []models.SimulateTransactionGroupResult{
+		{TxnResults: []models.SimulateTransactionResult{{TxnResult: result}}},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, [][][]EmittedEvent{{emitted}}, simulated)
+
+	// a log that cannot be decoded does not hide the other events
+	result.Logs = append(result.Logs, append(events[0].GetSelector(), 0x01), encodeEvent(t, events[0], uint64(3), uint64(4)))
+	emitted, err = d.DecodeLogs(7, result)
+	require.ErrorContains(t, err, "log 2 of transaction "+topID)
+	var logErr *LogError
+	require.ErrorAs(t, err, &logErr)
+	require.Equal(t, 2, logErr.LogIndex)
+	require.Len(t, emitted, 2)
+	require.Equal(t, 3, emitted[1].LogIndex)
+}
@@ -0,0 +1,170 @@
+// Package fuzzing holds the properties the decoders of the SDK are fuzzed
+// against, with seed corpora, as Go native fuzzing targets: addresses,
+// msgpack signed transactions, ABI types and mnemonics.
+//
+// The fuzz tests of this package run them, and downstream projects can reuse
+// them with their own corpus:
+//
+//	func FuzzDecodeAddress(f *testing.F) {
+//		for _, seed := range fuzzing.AddressSeeds() {
+//			f.Add(seed)
+//		}
+//		f.Add("MY-INTERESTING-ADDRESS")
+//		f.Fuzz(fuzzing.DecodeAddress)
+//	}
+//
+// Properties fail the test when a decoder accepts an input it cannot
+// reproduce, or panics.
+package fuzzing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/algorand/go-algorand-sdk/v2/abi"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/mnemonic"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// MaxSignedTxnSize is the size above which DecodeSignedTxn skips its input,
+// as a fuzzer could otherwise spend its time on the allocations huge inputs
+// request.
+const MaxSignedTxnSize = 1 << 16
+
+// AddressSeeds returns addresses to seed DecodeAddress with, valid or not.
+func AddressSeeds() []string {
+	return []string{
+		"7777777777777777777777777777777777777777777777777774MSJUVU",
+		"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAY5HFKQ",
+		// wrong checksum
+		"7777777777777777777777777777777777777777777777777774MSJUVA",
+		// non-canonical
+		"7777777777777777777777777777777777777777777777777774MSJUVV",
+		"",
+		"7777777777777777777777777777777777777777777777777774MSJUV",
+		"7777777777777777777777777777777777777777777777777774msjuvu",
+	}
+}
+
+// DecodeAddress checks that an address DecodeAddress accepts formats back to
+// addr.
+func DecodeAddress(t *testing.T, addr string) {
+	a, err := types.DecodeAddress(addr)
+	if err != nil {
+		return
+	}
+	if s := a.String(); s != addr {
+		t.Fatalf("DecodeAddress(%q) accepted an address formatted as %q", addr, s)
+	}
+}
+
+// SignedTxnSeeds returns msgpack signed transactions to seed DecodeSignedTxn
+// with.
+func SignedTxnSeeds() [][]byte {
+	account := crypto.TestAccount("fuzzing", 0)
+	header := types.Header{Sender: account.Address, Fee: 1000, FirstValid: 1, LastValid: 1001, Note: []byte("seed"), GenesisHash: types.Digest{1}}
+	txns := []types.Transaction{
+		{Type: types.PaymentTx, Header: header, PaymentTxnFields: types.PaymentTxnFields{Receiver: account.Address, Amount: 1}},
+		{Type: types.AssetTransferTx, Header: header, AssetTransferTxnFields: types.AssetTransferTxnFields{XferAsset: 5, AssetReceiver: account.Address}},
+		{Type: types.ApplicationCallTx, Header: header, ApplicationFields: types.ApplicationFields{ApplicationCallTxnFields: types.ApplicationCallTxnFields{
+			ApplicationID: 7, ApplicationArgs: [][]byte{[]byte("arg")}, ForeignAssets: []types.AssetIndex{5},
+		}}},
+	}
+	var seeds [][]byte
+	for _, tx := range txns {
+		_, stx, err := crypto.SignTransaction(account.PrivateKey, tx)
+		if err != nil {
+			panic(err)
+		}
+		seeds = append(seeds, stx)
+	}
+	return append(seeds, []byte{0x80}, []byte{})
+}
+
+// DecodeSignedTxn checks that a signed transaction msgpack decodes from data
+// encodes canonically: decoding its encoding gives back the same encoding.
+func DecodeSignedTxn(t *testing.T, data []byte) {
+	if len(data) > MaxSignedTxnSize {
+		return
+	}
+	var stx types.SignedTxn
+	if err := msgpack.Decode(data, &stx); err != nil {
+		return
+	}
+	encoded := msgpack.Encode(stx)
+	var decoded types.SignedTxn
+	if err := msgpack.Decode(encoded, &decoded); err != nil {
+		t.Fatalf("could not decode the encoding %x of %x: %v", encoded, data, err)
+	}
+	if again := msgpack.Encode(decoded); !bytes.Equal(again, encoded) {
+		t.Fatalf("encoding of %x is not stable: %x then %x", data, encoded, again)
+	}
+}
+
+// ABITypeSeeds returns ABI types to seed ParseABIType with.
+func ABITypeSeeds() []string {
+	return []string{
+		"uint64", "uint8[]", "byte[32]", "ufixed64x2", "(uint64,string,bool[3])",
+		"(address,(byte,bool)[])", "uint7", "(uint64", "string[2][]", "",
+	}
+}
+
+// ParseABIType checks that a type abi.TypeOf parses formats to a string
+// parsing to the same type.
+func ParseABIType(t *testing.T, s string) {
+	typ, err := abi.TypeOf(s)
+	if err != nil {
+		return
+	}
+	formatted := typ.String()
+	again, err := abi.TypeOf(formatted)
+	if err != nil {
+		t.Fatalf("TypeOf(%q) formats as %q, which does not parse: %v", s, formatted, err)
+	}
+	if again.String() != formatted {
+		t.Fatalf("TypeOf(%q) formats as %q, which formats as %q", s, formatted, again.String())
+	}
+}
+
+// MnemonicSeeds returns mnemonics to seed ParseMnemonic with.
+func MnemonicSeeds() []string {
+	account := crypto.TestAccount("fuzzing", 0)
+	valid, err := mnemonic.FromPrivateKey(account.PrivateKey)
+	if err != nil {
+		panic(err)
+	}
+	bip39, err := mnemonic.FromEntropyBIP39(make([]byte, 16))
+	if err != nil {
+		panic(err)
+	}
+	words := strings.Fields(valid)
+	words[0], words[1] = words[1], words[0]
+	return []string{valid, "  " + strings.ReplaceAll(valid, " ", "\n\t"), strings.Join(words, " "), bip39, "abandon", ""}
+}
+
+// ParseMnemonic checks that the key of an Algorand mnemonic, and the entropy
+// of a BIP-39 one, format back to the words of the mnemonic.
+func ParseMnemonic(t *testing.T, m string) {
+	normalized := strings.Join(strings.Fields(m), " ")
+	if key, err := mnemonic.ToKey(m); err == nil {
+		formatted, err := mnemonic.FromKey(key)
+		if err != nil {
+			t.Fatalf("could not format the key of %q: %v", m, err)
+		}
+		if formatted != normalized {
+			t.Fatalf("ToKey(%q) accepted a key formatted as %q", m, formatted)
+		}
+	}
+	if entropy, err := mnemonic.ToEntropyBIP39(m); err == nil {
+		formatted, err := mnemonic.FromEntropyBIP39(entropy)
+		if err != nil {
+			t.Fatalf("could not format the entropy of %q: %v", m, err)
+		}
+		if formatted != normalized {
+			t.Fatalf("ToEntropyBIP39(%q) accepted entropy formatted as %q", m, formatted)
+		}
+	}
+}
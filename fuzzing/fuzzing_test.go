@@ -0,0 +1,33 @@
+package fuzzing
+
+import (
+	"testing"
+)
+
+func FuzzDecodeAddress(f *testing.F) {
+	for _, seed := range AddressSeeds() {
+		f.Add(seed)
+	}
+	f.Fuzz(DecodeAddress)
+}
+
+func FuzzDecodeSignedTxn(f *testing.F) {
+	for _, seed := range SignedTxnSeeds() {
+		f.Add(seed)
+	}
+	f.Fuzz(DecodeSignedTxn)
+}
+
+func FuzzParseABIType(f *testing.F) {
+	for _, seed := range ABITypeSeeds() {
+		f.Add(seed)
+	}
+	f.Fuzz(ParseABIType)
+}
+
+func FuzzParseMnemonic(f *testing.F) {
+	for _, seed := range MnemonicSeeds() {
+		f.Add(seed)
+	}
+	f.Fuzz(ParseMnemonic)
+}
@@ -5,12 +5,15 @@ go 1.23
 toolchain go1.23.3
 
 require (
+	filippo.io/edwards25519 v1.1.0
 	github.com/algorand/avm-abi v0.2.0
 	github.com/algorand/go-codec/codec v1.1.10
 	github.com/cucumber/godog v0.14.1
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.3.0
 	github.com/google/go-querystring v1.1.0
 	github.com/stretchr/testify v1.9.0
 	golang.org/x/crypto v0.31.0
+	golang.org/x/text v0.21.0
 )
 
 require (
@@ -23,5 +26,6 @@ require (
 	github.com/hashicorp/golang-lru v0.5.4 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
+	golang.org/x/sys v0.28.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
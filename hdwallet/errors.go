@@ -0,0 +1,8 @@
+package hdwallet
+
+import (
+	"errors"
+)
+
+var errSeedTooShort = errors.New("seed must be at least 16 bytes")
+var errHardenedPublicDerivation = errors.New("hardened children cannot be derived from a public key")
@@ -0,0 +1,322 @@
+// Package hdwallet derives Algorand keys from a seed with BIP32-Ed25519, using
+// the ARC-52 path scheme m/44'/283'/account'/change/key.
+//
+// Extended private keys are 96 bytes: a 32 byte scalar kL, a 32 byte extension
+// kR used to derive signature nonces, and a 32 byte chain code. The scalar is
+// not an ed25519 seed, so keys from this package sign through ExtendedKey
+// rather than through crypto.SignTransaction.
+package hdwallet
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+
+	"filippo.io/edwards25519"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/mnemonic"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// DerivationType selects how many of the top bits of each derived scalar
+// increment are zeroed, which bounds how deep a key tree can be.
+type DerivationType int
+
+const (
+	// Khovratovich is the original BIP32-Ed25519 scheme, which keeps 224 bits
+	// of each increment.
+	Khovratovich DerivationType = 32
+
+	// Peikert is the ARC-52 recommended scheme, which keeps 247 bits of each
+	// increment and so allows deeper trees with more entropy per level.
+	Peikert DerivationType = 9
+)
+
+const (
+	// HardenedOffset is added to an index to select hardened derivation.
+	HardenedOffset uint32 = 0x80000000
+
+	// Purpose is the BIP-44 purpose used by ARC-52 paths.
+	Purpose uint32 = 44
+
+	// CoinType is the registered BIP-44 coin type of Algorand.
+	CoinType uint32 = 283
+
+	// ExtendedKeySize is the length of an encoded ExtendedKey.
+	ExtendedKeySize = 96
+)
+
+// Harden returns the hardened form of index.
+func Harden(index uint32) uint32 {
+	return index | HardenedOffset
+}
+
+// AddressPath returns the ARC-52 path m/44'/283'/account'/0/keyIndex of an
+// Algorand address key.
+func AddressPath(account, keyIndex uint32) []uint32 {
+	return []uint32{Harden(Purpose), Harden(CoinType), Harden(account), 0, keyIndex}
+}
+
+// ExtendedKey is a BIP32-Ed25519 extended private key.
+type ExtendedKey struct {
+	kL        [32]byte
+	kR        [32]byte
+	chainCode [32]byte
+}
+
+// FromSeed returns the root extended key of the tree generated by seed, which
+// is normally the 64 byte seed of a BIP-39 mnemonic.
+func FromSeed(seed []byte) (ExtendedKey, error) {
+	if len(seed) < 16 {
+		return ExtendedKey{}, errSeedTooShort
+	}
+
+	var k ExtendedKey
+	digest := sha512.Sum512(seed)
+	// The third highest bit of the last byte of kL must be clear, rehash until
+	// it is.
+	for digest[31]&0b0010_0000 != 0 {
+		mac := hmac.New(sha512.New, digest[:32])
+		mac.Write(digest[32:])
+		copy(digest[:], mac.Sum(nil))
+	}
+	copy(k.kL[:], digest[:32])
+	copy(k.kR[:], digest[32:])
+
+	k.kL[0] &= 0b1111_1000
+	k.kL[31] &= 0b0111_1111
+	k.kL[31] |= 0b0100_0000
+
+	k.chainCode = sha256.Sum256(append([]byte{0x01}, seed...))
+	return k, nil
+}
+
+// FromBIP39 returns the root extended key of the tree generated by a BIP-39
+// mnemonic and optional passphrase.
+func FromBIP39(m, passphrase string) (ExtendedKey, error) {
+	seed, err := mnemonic.SeedFromBIP39(m, passphrase)
+	if err != nil {
+		return ExtendedKey{}, err
+	}
+	return FromSeed(seed)
+}
+
+// ExtendedKeyFromBytes decodes an ExtendedKey encoded by Bytes.
+func ExtendedKeyFromBytes(b []byte) (k ExtendedKey, err error) {
+	if len(b) != ExtendedKeySize {
+		err = fmt.Errorf("extended key must be %d bytes, got %d", ExtendedKeySize, len(b))
+		return
+	}
+	copy(k.kL[:], b[:32])
+	copy(k.kR[:], b[32:64])
+	copy(k.chainCode[:], b[64:])
+	return
+}
+
+// Bytes returns the 96 byte encoding kL || kR || chain code of k.
+func (k ExtendedKey) Bytes() []byte {
+	b := make([]byte, 0, ExtendedKeySize)
+	b = append(b, k.kL[:]...)
+	b = append(b, k.kR[:]...)
+	return append(b, k.chainCode[:]...)
+}
+
+// Derive returns the child of k at index. Indexes at or above HardenedOffset
+// are derived from the private key, others from the public key.
+func (k ExtendedKey) Derive(index uint32, dt DerivationType) ExtendedKey {
+	var z, chain []byte
+	if index >= HardenedOffset {
+		z = k.mac(0x00, index, k.kL[:], k.kR[:])
+		chain = k.mac(0x01, index, k.kL[:], k.kR[:])
+	} else {
+		pk := k.PublicKey()
+		z = k.mac(0x02, index, pk)
+		chain = k.mac(0x03, index, pk)
+	}
+
+	var child ExtendedKey
+	// kL' = kL + 8 * trunc(zL), kR' = kR + zR, both modulo 2^256
+	child.kL = addLE(k.kL, scaledIncrement(z[:32], dt))
+	var zR [32]byte
+	copy(zR[:], z[32:])
+	child.kR = addLE(k.kR, zR)
+	copy(child.chainCode[:], chain[32:])
+	return child
+}
+
+// DerivePath derives the descendant of k at path, such as one returned by
+// AddressPath.
+func (k ExtendedKey) DerivePath(path []uint32, dt DerivationType) ExtendedKey {
+	for _, index := range path {
+		k = k.Derive(index, dt)
+	}
+	return k
+}
+
+// mac returns HMAC-SHA512 keyed with the chain code of k over
+// tag || data... || index, with index little-endian.
+func (k ExtendedKey) mac(tag byte, index uint32, data ...[]byte) []byte {
+	mac := hmac.New(sha512.New, k.chainCode[:])
+	mac.Write([]byte{tag})
+	for _, d := range data {
+		mac.Write(d)
+	}
+	var indexBytes [4]byte
+	binary.LittleEndian.PutUint32(indexBytes[:], index)
+	mac.Write(indexBytes[:])
+	return mac.Sum(nil)
+}
+
+// scalar returns kL reduced modulo the group order.
+func (k ExtendedKey) scalar() *edwards25519.Scalar {
+	return reduce(k.kL[:])
+}
+
+// PublicKey returns the ed25519 public key of k.
+func (k ExtendedKey) PublicKey() ed25519.PublicKey {
+	return new(edwards25519.Point).ScalarBaseMult(k.scalar()).Bytes()
+}
+
+// Address returns the Algorand address of k.
+func (k ExtendedKey) Address() (addr types.Address) {
+	copy(addr[:], k.PublicKey())
+	return
+}
+
+// Public returns the extended public key of k, which can derive the public
+// keys of non-hardened children.
+func (k ExtendedKey) Public() ExtendedPublicKey {
+	var pub ExtendedPublicKey
+	copy(pub.publicKey[:], k.PublicKey())
+	pub.chainCode = k.chainCode
+	return pub
+}
+
+// Sign returns an ed25519 signature of message by k, which verifies against
+// PublicKey with ed25519.Verify.
+func (k ExtendedKey) Sign(message []byte) (sig types.Signature) {
+	a := k.scalar()
+	pk := k.PublicKey()
+
+	nonce := sha512.New()
+	nonce.Write(k.kR[:])
+	nonce.Write(message)
+	r := reduce(nonce.Sum(nil))
+	R := new(edwards25519.Point).ScalarBaseMult(r).Bytes()
+
+	challenge := sha512.New()
+	challenge.Write(R)
+	challenge.Write(pk)
+	challenge.Write(message)
+	h := reduce(challenge.Sum(nil))
+
+	S := new(edwards25519.Scalar).MultiplyAdd(h, a, r)
+	copy(sig[:32], R)
+	copy(sig[32:], S.Bytes())
+	return
+}
+
+// SignTransaction signs tx with k, returning the txid and the encoded signed
+// transaction. If the address of k is not the sender, it is set as AuthAddr.
+func (k ExtendedKey) SignTransaction(tx types.Transaction) (txid string, stxBytes []byte, err error) {
+	stx := types.SignedTxn{
+		Sig: k.Sign(crypto.TransactionBytesToSign(tx)),
+		Txn: tx,
+	}
+	if addr := k.Address(); tx.Sender != addr {
+		stx.AuthAddr = addr
+	}
+	return crypto.GetTxID(tx), msgpack.Encode(stx), nil
+}
+
+// ExtendedPublicKey is a BIP32-Ed25519 extended public key.
+type ExtendedPublicKey struct {
+	publicKey [32]byte
+	chainCode [32]byte
+}
+
+// PublicKey returns the ed25519 public key of k.
+func (k ExtendedPublicKey) PublicKey() ed25519.PublicKey {
+	return append(ed25519.PublicKey{}, k.publicKey[:]...)
+}
+
+// Address returns the Algorand address of k.
+func (k ExtendedPublicKey) Address() types.Address {
+	return types.Address(k.publicKey)
+}
+
+// Derive returns the public key of the child of k at index, which must not be
+// hardened.
+func (k ExtendedPublicKey) Derive(index uint32, dt DerivationType) (child ExtendedPublicKey, err error) {
+	if index >= HardenedOffset {
+		err = errHardenedPublicDerivation
+		return
+	}
+
+	parent, err := new(edwards25519.Point).SetBytes(k.publicKey[:])
+	if err != nil {
+		return
+	}
+
+	z := (ExtendedKey{chainCode: k.chainCode}).mac(0x02, index, k.publicKey[:])
+	chain := (ExtendedKey{chainCode: k.chainCode}).mac(0x03, index, k.publicKey[:])
+
+	increment := scaledIncrement(z[:32], dt)
+	point := new(edwards25519.Point).ScalarBaseMult(reduce(increment[:]))
+	copy(child.publicKey[:], point.Add(parent, point).Bytes())
+	copy(child.chainCode[:], chain[32:])
+	return
+}
+
+// scaledIncrement returns 8 * zL with its top dt bits zeroed.
+func scaledIncrement(zL []byte, dt DerivationType) (out [32]byte) {
+	var truncated [32]byte
+	copy(truncated[:], zL)
+	remaining := int(dt)
+	for i := len(truncated) - 1; i >= 0 && remaining > 0; i-- {
+		if remaining >= 8 {
+			truncated[i] = 0
+			remaining -= 8
+		} else {
+			truncated[i] &= 0xFF >> remaining
+			remaining = 0
+		}
+	}
+
+	// multiply by 8, little-endian
+	var carry byte
+	for i := range truncated {
+		out[i] = truncated[i]<<3 | carry
+		carry = truncated[i] >> 5
+	}
+	return
+}
+
+// addLE adds two 256 bit little-endian integers modulo 2^256.
+func addLE(a, b [32]byte) (out [32]byte) {
+	var carry uint16
+	for i := range a {
+		sum := uint16(a[i]) + uint16(b[i]) + carry
+		out[i] = byte(sum)
+		carry = sum >> 8
+	}
+	return
+}
+
+// reduce interprets b, of at most 64 bytes, as a little-endian integer and
+// reduces it modulo the group order.
+func reduce(b []byte) *edwards25519.Scalar {
+	var wide [64]byte
+	copy(wide[:], b)
+	s, err := new(edwards25519.Scalar).SetUniformBytes(wide[:])
+	if err != nil {
+		// SetUniformBytes only fails on input that is not 64 bytes
+		panic(err)
+	}
+	return s
+}
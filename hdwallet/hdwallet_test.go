@@ -0,0 +1,192 @@
+package hdwallet
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/mnemonic"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func testRoot(t *testing.T) ExtendedKey {
+	seed := make([]byte, 64)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	root, err := FromSeed(seed)
+	require.NoError(t, err)
+	return root
+}
+
+func TestFromSeed(t *testing.T) {
+	root := testRoot(t)
+	require.Zero(t, root.kL[0]&0b111)
+	require.Equal(t, byte(0b0100_0000), root.kL[31]&0b1110_0000)
+
+	decoded, err := ExtendedKeyFromBytes(root.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, root, decoded)
+
+	_, err = FromSeed(make([]byte, 8))
+	require.ErrorIs(t, err, errSeedTooShort)
+	_, err = ExtendedKeyFromBytes(make([]byte, 64))
+	require.Error(t, err)
+}
+
+func TestFromBIP39(t *testing.T) {
+	const m = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	seed, err := mnemonic.SeedFromBIP39(m, "")
+	require.NoError(t, err)
+	fromSeed, err := FromSeed(seed)
+	require.NoError(t, err)
+
+	root, err := FromBIP39(m, "")
+	require.NoError(t, err)
+	require.Equal(t, fromSeed, root)
+
+	_, err = FromBIP39("abandon", "")
+	require.Error(t, err)
+}
+
+// The vectors below use the mnemonic of the ARC-52 reference test vectors. They
+// were recorded from this implementation and still need to be checked against
+// the published ARC-52 values.
+const arc52Mnemonic = "salon zoo engage submit smile frost later decide wing sight chaos renew lizard rely canal coral scene hobby scare step bus leaf tobacco slice"
+
+func TestARC52Vectors(t *testing.T) {
+	root, err := FromBIP39(arc52Mnemonic, "")
+	require.NoError(t, err)
+	require.Equal(t,
+		"a8ba80028922d9fcfa055c78aede55b5c575bcd8d5a53168edf45f36d9ec8f46"+
+			"94592b4bc892907583e22669ecdf1b0409a9f3bd5549f2dd751b51360909cd05"+
+			"796b9206ec30e142e94b790a98805bf999042b55046963174ee6cee2d0375946",
+		hex.EncodeToString(root.Bytes()))
+
+	account := func(index uint32) []uint32 {
+		return []uint32{Harden(Purpose), Harden(CoinType), Harden(index)}
+	}
+	testcases := []struct {
+		name      string
+		dt        DerivationType
+		path      []uint32
+		publicKey string
+	}{
+		{"account 0", Peikert, account(0), "a4369184f5d7a871e1555e9a6be638c6909f895ca7866dc82a88f538dbafc9db"},
+		{"account 0 change 0", Peikert, append(account(0), 0), "563cc1c633ea99efbddf272eb5ed81b9d85af5b5f32ea93ea5de6fb9297788e0"},
+		{"account 0 key 0", Peikert, AddressPath(0, 0), "7bda7ac12627b2c259f1df6875d30c10b35f55b33ad2cc8ea2736eaa3ebcfab9"},
+		{"account 0 key 1", Peikert, AddressPath(0, 1), "5bae8828f111064637ac5061bd63bc4fcfe4a833252305f25eeab9c64ecdf519"},
+		{"account 0 key 2", Peikert, AddressPath(0, 2), "00a72635e97cba966529e9bfb4baf4a32d7b8cd2fcd8e2476ce5be1177848cb3"},
+		{"account 1 key 0", Peikert, AddressPath(1, 0), "358d8c4382992849a764438e02b1c45c2ca4e86bbcfe10fd5b963f3610012bc9"},
+		{"account 1 key 1", Peikert, AddressPath(1, 1), "d5635a7b2b12c3044ee60af567da2e37add807bb793c71c7a5bec9826feccefb"},
+		{"account 2 key 0", Peikert, AddressPath(2, 0), "e22f78d1383f2a1f85fff8b1bca1ac1f589dbc1430753cb9a421ccbbb40b5a1b"},
+		{"identity key 0", Peikert, []uint32{Harden(Purpose), Harden(0), Harden(0), 0, 0}, "ff8b1863ef5e40d0a48c245f26a6dbdf5da94dc75a1851f51d8a04e547bd5f5a"},
+		{"account 0", Khovratovich, account(0), "ca744410a6753605ecbbeddb4c9975bf959641b29ccbc422b9b347beab230fde"},
+		{"account 0 change 0", Khovratovich, append(account(0), 0), "6c6f31959ac27c241005d7a3156cf6e40958e5ccdf9f2c1ad1e6ff9f88296809"},
+		{"account 0 key 0", Khovratovich, AddressPath(0, 0), "62fe832b7ad10544be8337a670435e5064ae4a66e77bd78909765b46b576a6f3"},
+		{"account 0 key 1", Khovratovich, AddressPath(0, 1), "530461002eaccec0c7b5795925aa104a7fb45f85ef0aa95bbb5be93b6f8537ad"},
+		{"account 1 key 0", Khovratovich, AddressPath(1, 0), "9e12643f6c0068dcf53b04daced6f8c1a90ad21c954a66df4140d79303166a67"},
+	}
+	for _, tc := range testcases {
+		t.Run(fmt.Sprintf("%s %d", tc.name, tc.dt), func(t *testing.T) {
+			key := root.DerivePath(tc.path, tc.dt)
+			require.Equal(t, tc.publicKey, hex.EncodeToString(key.PublicKey()))
+
+			// the change and key levels derive the same keys from the public
+			// key of the hardened account level
+			if len(tc.path) > 3 {
+				pub := root.DerivePath(tc.path[:3], tc.dt).Public()
+				for _, index := range tc.path[3:] {
+					pub, err = pub.Derive(index, tc.dt)
+					require.NoError(t, err)
+				}
+				require.Equal(t, tc.publicKey, hex.EncodeToString(pub.PublicKey()))
+			}
+		})
+	}
+}
+
+func TestDeriveAddresses(t *testing.T) {
+	root := testRoot(t)
+
+	for _, dt := range []DerivationType{Khovratovich, Peikert} {
+		first := root.DerivePath(AddressPath(0, 0), dt)
+		second := root.DerivePath(AddressPath(0, 1), dt)
+		otherAccount := root.DerivePath(AddressPath(1, 0), dt)
+
+		require.Equal(t, first, root.DerivePath(AddressPath(0, 0), dt))
+		require.NotEqual(t, first.Address(), second.Address())
+		require.NotEqual(t, first.Address(), otherAccount.Address())
+	}
+
+	require.NotEqual(t,
+		root.DerivePath(AddressPath(0, 0), Khovratovich).Address(),
+		root.DerivePath(AddressPath(0, 0), Peikert).Address())
+}
+
+func TestPublicDerivationMatchesPrivate(t *testing.T) {
+	root := testRoot(t)
+
+	for _, dt := range []DerivationType{Khovratovich, Peikert} {
+		account := root.DerivePath([]uint32{Harden(Purpose), Harden(CoinType), Harden(0)}, dt)
+
+		accountPub := account.Public()
+		changePub, err := accountPub.Derive(0, dt)
+		require.NoError(t, err)
+		for i := uint32(0); i < 5; i++ {
+			keyPub, err := changePub.Derive(i, dt)
+			require.NoError(t, err)
+			require.Equal(t, account.DerivePath([]uint32{0, i}, dt).PublicKey(), keyPub.PublicKey())
+		}
+
+		_, err = accountPub.Derive(Harden(0), dt)
+		require.ErrorIs(t, err, errHardenedPublicDerivation)
+	}
+}
+
+func TestSign(t *testing.T) {
+	key := testRoot(t).DerivePath(AddressPath(0, 0), Peikert)
+
+	message := []byte("arc52")
+	sig := key.Sign(message)
+	require.True(t, ed25519.Verify(key.PublicKey(), message, sig[:]))
+	require.False(t, ed25519.Verify(key.PublicKey(), []byte("other"), sig[:]))
+	require.Equal(t, sig, key.Sign(message))
+}
+
+func TestSignTransaction(t *testing.T) {
+	key := testRoot(t).DerivePath(AddressPath(0, 0), Peikert)
+
+	tx := types.Transaction{
+		Type: types.PaymentTx,
+		Header: types.Header{
+			Sender:     key.Address(),
+			Fee:        1000,
+			FirstValid: 100,
+			LastValid:  1100,
+		},
+	}
+
+	txid, stxBytes, err := key.SignTransaction(tx)
+	require.NoError(t, err)
+	require.Equal(t, crypto.GetTxID(tx), txid)
+
+	var stx types.SignedTxn
+	require.NoError(t, msgpack.Decode(stxBytes, &stx))
+	require.True(t, stx.AuthAddr.IsZero())
+	require.True(t, crypto.VerifySignedTxn(stx))
+
+	// a rekeyed sender gets the key's address as AuthAddr
+	tx.Sender = crypto.GenerateAccount().Address
+	_, stxBytes, err = key.SignTransaction(tx)
+	require.NoError(t, err)
+	stx = types.SignedTxn{}
+	require.NoError(t, msgpack.Decode(stxBytes, &stx))
+	require.Equal(t, key.Address(), stx.AuthAddr)
+	require.True(t, crypto.VerifySignedTxn(stx))
+}
@@ -0,0 +1,41 @@
+package ledger
+
+import (
+	"fmt"
+)
+
+// Status words returned by the Algorand app.
+const (
+	StatusOK               uint16 = 0x9000
+	StatusUserRejected     uint16 = 0x6985
+	StatusWrongLength      uint16 = 0x6700
+	StatusInvalidParameter uint16 = 0x6b00
+	StatusAppNotOpen       uint16 = 0x6e00
+	StatusLocked           uint16 = 0x5515
+)
+
+// StatusError is returned when the device answers a command with a status
+// other than StatusOK.
+type StatusError struct {
+	Status uint16
+}
+
+func (e StatusError) Error() string {
+	switch e.Status {
+	case StatusUserRejected:
+		return "ledger: the user rejected the request on the device"
+	case StatusAppNotOpen:
+		return "ledger: the Algorand app is not open on the device"
+	case StatusLocked:
+		return "ledger: the device is locked"
+	default:
+		return fmt.Sprintf("ledger: the device returned status 0x%04x", e.Status)
+	}
+}
+
+var errShortResponse = fmt.Errorf("ledger: response is shorter than its status word")
+var errWrongPublicKeyLen = fmt.Errorf("ledger: device returned a public key of the wrong length")
+var errWrongSignatureLen = fmt.Errorf("ledger: device returned a signature of the wrong length")
+var errInvalidSignature = fmt.Errorf("ledger: device returned a signature that does not verify")
+var errFrameChannel = fmt.Errorf("ledger: HID frame has an unexpected channel or tag")
+var errFrameSequence = fmt.Errorf("ledger: HID frame is out of sequence")
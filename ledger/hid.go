@@ -0,0 +1,66 @@
+package ledger
+
+import (
+	"encoding/binary"
+)
+
+// The Ledger HID transport splits an APDU into 64 byte reports, each starting
+// with the channel, the tag and a sequence number. The first report also holds
+// the length of the APDU.
+const (
+	hidReportSize = 64
+	hidChannel    = 0x0101
+	hidTag        = 0x05
+)
+
+// wrapAPDU splits apdu into HID reports.
+func wrapAPDU(apdu []byte) [][]byte {
+	data := make([]byte, 2+len(apdu))
+	binary.BigEndian.PutUint16(data, uint16(len(apdu)))
+	copy(data[2:], apdu)
+
+	var reports [][]byte
+	for seq := uint16(0); len(data) > 0 || seq == 0; seq++ {
+		report := make([]byte, hidReportSize)
+		binary.BigEndian.PutUint16(report[0:], hidChannel)
+		report[2] = hidTag
+		binary.BigEndian.PutUint16(report[3:], seq)
+		n := copy(report[5:], data)
+		data = data[n:]
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// apduReader reassembles the APDU of a response from its HID reports.
+type apduReader struct {
+	seq    uint16
+	length int
+	data   []byte
+}
+
+// add adds the next report of the response, and returns the APDU once it is
+// complete.
+func (r *apduReader) add(report []byte) ([]byte, error) {
+	if len(report) < 5 || binary.BigEndian.Uint16(report) != hidChannel || report[2] != hidTag {
+		return nil, errFrameChannel
+	}
+	if binary.BigEndian.Uint16(report[3:]) != r.seq {
+		return nil, errFrameSequence
+	}
+	payload := report[5:]
+	if r.seq == 0 {
+		if len(payload) < 2 {
+			return nil, errFrameSequence
+		}
+		r.length = int(binary.BigEndian.Uint16(payload))
+		payload = payload[2:]
+	}
+	r.seq++
+
+	r.data = append(r.data, payload...)
+	if len(r.data) < r.length {
+		return nil, nil
+	}
+	return r.data[:r.length], nil
+}
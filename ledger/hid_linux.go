@@ -0,0 +1,97 @@
+//go:build ledger && linux
+
+package ledger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ledgerVendorID is the USB vendor ID of Ledger devices.
+const ledgerVendorID = "00002C97"
+
+// HIDTransport exchanges APDUs with a Ledger device through a Linux hidraw
+// device, such as /dev/hidraw0. The user needs read and write access to it,
+// which the udev rules distributed by Ledger grant.
+type HIDTransport struct {
+	file *os.File
+}
+
+// OpenHID opens the hidraw device at path.
+func OpenHID(path string) (*HIDTransport, error) {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &HIDTransport{file: file}, nil
+}
+
+// FindHIDDevices returns the paths of the hidraw devices of the connected
+// Ledger devices.
+func FindHIDDevices() ([]string, error) {
+	uevents, err := filepath.Glob("/sys/class/hidraw/hidraw*/device/uevent")
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, uevent := range uevents {
+		content, err := os.ReadFile(uevent)
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(content), "\n") {
+			// HID_ID=<bus>:<vendor>:<product>
+			if fields := strings.Split(strings.TrimPrefix(line, "HID_ID="), ":"); strings.HasPrefix(line, "HID_ID=") && len(fields) == 3 && strings.EqualFold(fields[1], ledgerVendorID) {
+				name := filepath.Base(filepath.Dir(filepath.Dir(uevent)))
+				paths = append(paths, filepath.Join("/dev", name))
+			}
+		}
+	}
+	return paths, nil
+}
+
+// OpenFirstHID opens the first Ledger device found by FindHIDDevices.
+func OpenFirstHID() (*HIDTransport, error) {
+	paths, err := FindHIDDevices()
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("ledger: no device found")
+	}
+	return OpenHID(paths[0])
+}
+
+// Exchange sends apdu to the device and returns its response.
+func (t *HIDTransport) Exchange(apdu []byte) ([]byte, error) {
+	for _, report := range wrapAPDU(apdu) {
+		// hidraw expects the report ID, 0 for Ledger devices, before the
+		// report
+		if _, err := t.file.Write(append([]byte{0}, report...)); err != nil {
+			return nil, err
+		}
+	}
+
+	var r apduReader
+	report := make([]byte, hidReportSize)
+	for {
+		n, err := t.file.Read(report)
+		if err != nil {
+			return nil, err
+		}
+		response, err := r.add(report[:n])
+		if err != nil {
+			return nil, err
+		}
+		if response != nil {
+			return response, nil
+		}
+	}
+}
+
+// Close closes the device.
+func (t *HIDTransport) Close() error {
+	return t.file.Close()
+}
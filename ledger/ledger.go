@@ -0,0 +1,189 @@
+// Package ledger signs transactions with the Algorand app of a Ledger hardware
+// wallet, speaking its APDU protocol.
+//
+// A Device talks to the app through a Transport. The HID transport for Linux
+// is only built with the ledger build tag:
+//
+//	go build -tags ledger
+//
+// Keys are selected by an account index, the last component of the
+// m/44'/283'/index'/0/0 derivation path used by the app.
+package ledger
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// Transport exchanges APDUs with a device.
+type Transport interface {
+	// Exchange sends a command APDU and returns the response APDU, ending
+	// with its status word.
+	Exchange(apdu []byte) ([]byte, error)
+}
+
+// APDU constants of the Algorand app.
+const (
+	cla = 0x80
+
+	insGetPublicKey = 0x03
+	insSignMsgpack  = 0x08
+
+	// p1 of the chunks of a transaction to sign: the first one starts with
+	// the account index
+	p1FirstAccountID = 0x01
+	p1More           = 0x80
+
+	// p2 tells whether more chunks follow
+	p2Last = 0x00
+	p2More = 0x80
+
+	// chunkSize is the largest payload of a command APDU.
+	chunkSize = 250
+)
+
+// Device is a Ledger device running the Algorand app.
+type Device struct {
+	Transport Transport
+}
+
+// command sends a command APDU and returns the data of the response, or a
+// StatusError if the app failed.
+func (d Device) command(ins, p1, p2 byte, data []byte) ([]byte, error) {
+	apdu := append([]byte{cla, ins, p1, p2, byte(len(data))}, data...)
+	response, err := d.Transport.Exchange(apdu)
+	if err != nil {
+		return nil, err
+	}
+	if len(response) < 2 {
+		return nil, errShortResponse
+	}
+	status := binary.BigEndian.Uint16(response[len(response)-2:])
+	if status != StatusOK {
+		return nil, StatusError{Status: status}
+	}
+	return response[:len(response)-2], nil
+}
+
+// GetAddress returns the address of the key at accountIndex.
+func (d Device) GetAddress(accountIndex uint32) (types.Address, error) {
+	data := binary.BigEndian.AppendUint32(nil, accountIndex)
+	pk, err := d.command(insGetPublicKey, 0x00, 0x00, data)
+	if err != nil {
+		return types.Address{}, err
+	}
+	if len(pk) != ed25519.PublicKeySize {
+		return types.Address{}, errWrongPublicKeyLen
+	}
+	var addr types.Address
+	copy(addr[:], pk)
+	return addr, nil
+}
+
+// Sign has the key at accountIndex sign tx, once the user approved it on the
+// device, and returns the signature. The signature is checked against the
+// address of the key.
+func (d Device) Sign(accountIndex uint32, tx types.Transaction) (types.Signature, error) {
+	sig, _, err := d.sign(accountIndex, tx)
+	return sig, err
+}
+
+// sign is Sign, also returning the address of the key.
+func (d Device) sign(accountIndex uint32, tx types.Transaction) (sig types.Signature, addr types.Address, err error) {
+	if addr, err = d.GetAddress(accountIndex); err != nil {
+		return
+	}
+
+	data := binary.BigEndian.AppendUint32(nil, accountIndex)
+	data = append(data, msgpack.Encode(tx)...)
+
+	var response []byte
+	p1 := byte(p1FirstAccountID)
+	for len(data) > 0 {
+		chunk := data
+		if len(chunk) > chunkSize {
+			chunk = chunk[:chunkSize]
+		}
+		data = data[len(chunk):]
+
+		p2 := byte(p2Last)
+		if len(data) > 0 {
+			p2 = p2More
+		}
+		if response, err = d.command(insSignMsgpack, p1, p2, chunk); err != nil {
+			return
+		}
+		p1 = p1More
+	}
+
+	if len(response) != len(sig) {
+		err = errWrongSignatureLen
+		return
+	}
+	copy(sig[:], response)
+	if !ed25519.Verify(addr[:], crypto.TransactionBytesToSign(tx), sig[:]) {
+		err = errInvalidSignature
+	}
+	return
+}
+
+// SignTransaction signs tx with the key at accountIndex, returning the txid
+// and the encoded signed transaction. If the address of the key is not the
+// sender, it is set as AuthAddr.
+func (d Device) SignTransaction(accountIndex uint32, tx types.Transaction) (txid string, stxBytes []byte, err error) {
+	sig, addr, err := d.sign(accountIndex, tx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	stx := types.SignedTxn{Sig: sig, Txn: tx}
+	if tx.Sender != addr {
+		stx.AuthAddr = addr
+	}
+	return crypto.GetTxID(tx), msgpack.Encode(stx), nil
+}
+
+// TransactionSigner is a transaction.TransactionSigner signing with the key at
+// AccountIndex of a Ledger device. Each transaction must be approved on the
+// device.
+type TransactionSigner struct {
+	Device       Device
+	AccountIndex uint32
+}
+
+// SignTransactions signs the provided transactions with the device.
+func (s TransactionSigner) SignTransactions(txGroup []types.Transaction, indexesToSign []int) ([][]byte, error) {
+	stxs := make([][]byte, len(indexesToSign))
+	for i, pos := range indexesToSign {
+		if pos < 0 || pos >= len(txGroup) {
+			return nil, fmt.Errorf("index to sign %d is out of range for a group of %d transactions", pos, len(txGroup))
+		}
+		_, stxBytes, err := s.Device.SignTransaction(s.AccountIndex, txGroup[pos])
+		if err != nil {
+			return nil, err
+		}
+		stxs[i] = stxBytes
+	}
+	return stxs, nil
+}
+
+// Equals returns true if the other TransactionSigner signs with the same key
+// of the same device.
+func (s TransactionSigner) Equals(other transaction.TransactionSigner) bool {
+	o, ok := other.(TransactionSigner)
+	if !ok || o.AccountIndex != s.AccountIndex {
+		return false
+	}
+	// comparing transports of a type that is not comparable would panic
+	if t := reflect.TypeOf(s.Device.Transport); t == nil || !t.Comparable() {
+		return false
+	}
+	return o.Device.Transport == s.Device.Transport
+}
@@ -0,0 +1,117 @@
+package ledger
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// fakeApp emulates the Algorand app with one key per account index.
+type fakeApp struct {
+	keys     map[uint32]ed25519.PrivateKey
+	reject   bool
+	pending  []byte
+	commands int
+}
+
+func (a *fakeApp) Exchange(apdu []byte) ([]byte, error) {
+	a.commands++
+	ins, p1, p2, data := apdu[1], apdu[2], apdu[3], apdu[5:]
+	if int(apdu[4]) != len(data) {
+		return []byte{0x67, 0x00}, nil
+	}
+	switch ins {
+	case insGetPublicKey:
+		key := a.keys[binary.BigEndian.Uint32(data)]
+		return append(append([]byte{}, key.Public().(ed25519.PublicKey)...), 0x90, 0x00), nil
+	case insSignMsgpack:
+		if p1 == p1FirstAccountID {
+			a.pending = nil
+		}
+		a.pending = append(a.pending, data...)
+		if p2 == p2More {
+			return []byte{0x90, 0x00}, nil
+		}
+		if a.reject {
+			return []byte{0x69, 0x85}, nil
+		}
+		key := a.keys[binary.BigEndian.Uint32(a.pending)]
+		sig := ed25519.Sign(key, append([]byte("TX"), a.pending[4:]...))
+		return append(sig, 0x90, 0x00), nil
+	}
+	return []byte{0x6d, 0x00}, nil
+}
+
+func TestDevice(t *testing.T) {
+	first, second := crypto.GenerateAccount(), crypto.GenerateAccount()
+	app := &fakeApp{keys: map[uint32]ed25519.PrivateKey{0: first.PrivateKey, 1: second.PrivateKey}}
+	device := Device{Transport: app}
+
+	addr, err := device.GetAddress(1)
+	require.NoError(t, err)
+	require.Equal(t, second.Address, addr)
+
+	// a long note spreads the transaction over several chunks
+	params := types.SuggestedParams{Fee: 1000, FirstRoundValid: 1, LastRoundValid: 100, GenesisHash: make([]byte, 32), FlatFee: true}
+	tx, err := transaction.MakePaymentTxn(first.Address.String(), second.Address.String(), 1, make([]byte, 600), "", params)
+	require.NoError(t, err)
+
+	app.commands = 0
+	txid, stxBytes, err := device.SignTransaction(0, tx)
+	require.NoError(t, err)
+	require.Equal(t, 1+(4+len(msgpack.Encode(tx))+chunkSize-1)/chunkSize, app.commands)
+	require.Equal(t, crypto.GetTxID(tx), txid)
+	_, expected, err := crypto.SignTransaction(first.PrivateKey, tx)
+	require.NoError(t, err)
+	require.Equal(t, expected, stxBytes)
+
+	// a key that is not the sender signs as AuthAddr
+	_, stxBytes, err = device.SignTransaction(1, tx)
+	require.NoError(t, err)
+	var stx types.SignedTxn
+	require.NoError(t, msgpack.Decode(stxBytes, &stx))
+	require.Equal(t, second.Address, stx.AuthAddr)
+	require.True(t, crypto.VerifySignedTxn(stx))
+
+	app.reject = true
+	_, _, err = device.SignTransaction(0, tx)
+	require.ErrorIs(t, err, StatusError{Status: StatusUserRejected})
+
+	signer := TransactionSigner{Device: device, AccountIndex: 0}
+	require.True(t, signer.Equals(TransactionSigner{Device: Device{Transport: app}}))
+	require.False(t, signer.Equals(TransactionSigner{Device: device, AccountIndex: 1}))
+	var _ transaction.TransactionSigner = signer
+}
+
+func TestHIDFraming(t *testing.T) {
+	apdu := make([]byte, 200)
+	for i := range apdu {
+		apdu[i] = byte(i)
+	}
+	reports := wrapAPDU(apdu)
+	require.Len(t, reports, 4)
+
+	var r apduReader
+	var out []byte
+	for i, report := range reports {
+		require.Len(t, report, hidReportSize)
+		var err error
+		out, err = r.add(report)
+		require.NoError(t, err)
+		if i < len(reports)-1 {
+			require.Nil(t, out)
+		}
+	}
+	require.Equal(t, apdu, out)
+
+	var outOfOrder apduReader
+	_, err := outOfOrder.add(reports[1])
+	require.ErrorIs(t, err, errFrameSequence)
+}
@@ -0,0 +1,9 @@
+package localnet
+
+import (
+	"errors"
+)
+
+var errNotRunning = errors.New("no local network is running")
+
+var errNoDispenser = errors.New("the wallet has no funded account")
@@ -0,0 +1,275 @@
+// Package localnet connects to a local Algorand network, such as AlgoKit
+// LocalNet or the sandbox, for integration tests: it provides the funded
+// accounts of the default KMD wallet, funds test accounts from them, and
+// advances rounds on networks in dev mode.
+package localnet
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/kmd"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/indexer"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// The endpoints and wallet of AlgoKit LocalNet and the sandbox.
+const (
+	DefaultAlgodURL   = "http://localhost:4001"
+	DefaultIndexerURL = "http://localhost:8980"
+	DefaultKMDURL     = "http://localhost:4002"
+	DefaultWallet     = "unencrypted-default-wallet"
+)
+
+// DefaultToken is the API token of algod, indexer and KMD.
+var DefaultToken = strings.Repeat("a", 64)
+
+// Config configures the connection to a local network.
+type Config struct {
+	AlgodURL   string
+	IndexerURL string
+	KMDURL     string
+	Token      string
+
+	// Wallet is the name of the KMD wallet whose accounts are funded, and
+	// WalletPassword its password.
+	Wallet         string
+	WalletPassword string
+}
+
+// DefaultConfig returns the configuration of AlgoKit LocalNet and the
+// sandbox, whose ports may be changed by the ALGOD_PORT, INDEXER_PORT and
+// KMD_PORT environment variables.
+func DefaultConfig() Config {
+	config := Config{
+		AlgodURL:   DefaultAlgodURL,
+		IndexerURL: DefaultIndexerURL,
+		KMDURL:     DefaultKMDURL,
+		Token:      DefaultToken,
+		Wallet:     DefaultWallet,
+	}
+	if port, ok := os.LookupEnv("ALGOD_PORT"); ok {
+		config.AlgodURL = "http://localhost:" + port
+	}
+	if port, ok := os.LookupEnv("INDEXER_PORT"); ok {
+		config.IndexerURL = "http://localhost:" + port
+	}
+	if port, ok := os.LookupEnv("KMD_PORT"); ok {
+		config.KMDURL = "http://localhost:" + port
+	}
+	return config
+}
+
+// LocalNet is a connection to a running local network.
+type LocalNet struct {
+	Algod   *algod.Client
+	Indexer *indexer.Client
+	KMD     kmd.Client
+
+	config Config
+
+	mu        sync.Mutex
+	dispenser *crypto.Account
+}
+
+// Connect returns a connection to the local network of config, or an error
+// if its algod or KMD does not answer.
+func Connect(ctx context.Context, config Config) (*LocalNet, error) {
+	algodClient, err := algod.MakeClient(config.AlgodURL, config.Token)
+	if err != nil {
+		return nil, err
+	}
+	indexerClient, err := indexer.MakeClient(config.IndexerURL, config.Token)
+	if err != nil {
+		return nil, err
+	}
+	kmdClient, err := kmd.MakeClient(config.KMDURL, config.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := algodClient.HealthCheck().Do(ctx); err != nil {
+		return nil, fmt.Errorf("%w: algod at %s: %v", errNotRunning, config.AlgodURL, err)
+	}
+	if _, err := kmdClient.VersionWithContext(ctx); err != nil {
+		return nil, fmt.Errorf("%w: kmd at %s: %v", errNotRunning, config.KMDURL, err)
+	}
+	return &LocalNet{Algod: algodClient, Indexer: indexerClient, KMD: kmdClient, config: config}, nil
+}
+
+// ForTest returns a connection to the local network of DefaultConfig, and
+// skips t if none is running.
+func ForTest(t testing.TB) *LocalNet {
+	t.Helper()
+	n, err := Connect(context.Background(), DefaultConfig())
+	if err != nil {
+		t.Skipf("skipping test needing a local network: %v", err)
+	}
+	return n
+}
+
+// Accounts returns the accounts of the KMD wallet, funded on creation of the
+// network.
+func (n *LocalNet) Accounts(ctx context.Context) ([]crypto.Account, error) {
+	wallets, err := n.KMD.ListWalletsWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list wallets: %w", err)
+	}
+	var walletID string
+	for _, wallet := range wallets.Wallets {
+		if wallet.Name == n.config.Wallet {
+			walletID = wallet.ID
+		}
+	}
+	if walletID == "" {
+		return nil, fmt.Errorf("no wallet named %s", n.config.Wallet)
+	}
+
+	handle, err := n.KMD.InitWalletHandleWithContext(ctx, walletID, n.config.WalletPassword)
+	if err != nil {
+		return nil, fmt.Errorf("could not open wallet %s: %w", n.config.Wallet, err)
+	}
+	defer n.KMD.ReleaseWalletHandleWithContext(ctx, handle.WalletHandleToken)
+
+	keys, err := n.KMD.ListKeysWithContext(ctx, handle.WalletHandleToken)
+	if err != nil {
+		return nil, fmt.Errorf("could not list keys: %w", err)
+	}
+	var accounts []crypto.Account
+	for _, address := range keys.Addresses {
+		key, err := n.KMD.ExportKeyWithContext(ctx, handle.WalletHandleToken, n.config.WalletPassword, address)
+		if err != nil {
+			return nil, fmt.Errorf("could not export key of %s: %w", address, err)
+		}
+		account, err := crypto.AccountFromPrivateKey(key.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+// Dispenser returns the account of the wallet with the most algos, which
+// funds the other accounts.
+func (n *LocalNet) Dispenser(ctx context.Context) (crypto.Account, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.dispenser != nil {
+		return *n.dispenser, nil
+	}
+
+	accounts, err := n.Accounts(ctx)
+	if err != nil {
+		return crypto.Account{}, err
+	}
+	var richest uint64
+	for i := range accounts {
+		info, err := n.Algod.AccountInformation(accounts[i].Address.String()).Do(ctx)
+		if err != nil {
+			return crypto.Account{}, err
+		}
+		if info.Amount > richest {
+			richest = info.Amount
+			n.dispenser = &accounts[i]
+		}
+	}
+	if n.dispenser == nil {
+		return crypto.Account{}, errNoDispenser
+	}
+	return *n.dispenser, nil
+}
+
+// NewAccount returns a new account funded with amount microAlgos.
+func (n *LocalNet) NewAccount(ctx context.Context, amount uint64) (crypto.Account, error) {
+	account := crypto.GenerateAccount()
+	if err := n.EnsureFunded(ctx, account.Address, amount); err != nil {
+		return crypto.Account{}, err
+	}
+	return account, nil
+}
+
+// EnsureFunded pays address from the dispenser for its balance to be at
+// least amount microAlgos, and waits for the payment to be confirmed.
+func (n *LocalNet) EnsureFunded(ctx context.Context, address types.Address, amount uint64) error {
+	info, err := n.Algod.AccountInformation(address.String()).Do(ctx)
+	if err != nil {
+		return err
+	}
+	if info.Amount >= amount {
+		return nil
+	}
+	dispenser, err := n.Dispenser(ctx)
+	if err != nil {
+		return err
+	}
+	return n.pay(ctx, dispenser, address, amount-info.Amount, nil)
+}
+
+// AdvanceRounds makes the network advance by rounds rounds. On a network in
+// dev mode, which makes a block per transaction, it sends as many payments of
+// the dispenser to itself; otherwise it waits for the rounds to pass.
+func (n *LocalNet) AdvanceRounds(ctx context.Context, rounds uint64) error {
+	status, err := n.Algod.Status().Do(ctx)
+	if err != nil {
+		return err
+	}
+	target := status.LastRound + rounds
+
+	encoded, err := n.Algod.GetGenesis().Do(ctx)
+	if err != nil {
+		return err
+	}
+	genesis, err := types.ParseGenesis([]byte(encoded))
+	if err != nil {
+		return err
+	}
+	if genesis.DevMode {
+		dispenser, err := n.Dispenser(ctx)
+		if err != nil {
+			return err
+		}
+		for i := uint64(0); i < rounds; i++ {
+			note := []byte(fmt.Sprintf("advance %d", target-rounds+i+1))
+			if err := n.pay(ctx, dispenser, dispenser.Address, 0, note); err != nil {
+				return err
+			}
+		}
+	}
+
+	for status.LastRound < target {
+		if status, err = n.Algod.StatusAfterBlock(status.LastRound).Do(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pay sends amount from from to to, and waits for the payment to be
+// confirmed.
+func (n *LocalNet) pay(ctx context.Context, from crypto.Account, to types.Address, amount uint64, note []byte) error {
+	params, err := n.Algod.SuggestedParams().Do(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := transaction.MakePaymentTxn(from.Address.String(), to.String(), amount, note, "", params)
+	if err != nil {
+		return err
+	}
+	txid, stx, err := crypto.SignTransaction(from.PrivateKey, tx)
+	if err != nil {
+		return err
+	}
+	if _, err := n.Algod.SendRawTransaction(stx).Do(ctx); err != nil {
+		return fmt.Errorf("could not send payment to %s: %w", to, err)
+	}
+	_, err = transaction.WaitForConfirmation(n.Algod, txid, 10, ctx)
+	return err
+}
@@ -0,0 +1,149 @@
+package localnet
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/kmd"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// mockNet is an algod and KMD mock of a network in dev mode, confirming each
+// payment in a block of its own.
+type mockNet struct {
+	t         *testing.T
+	mu        sync.Mutex
+	accounts  []crypto.Account
+	balances  map[types.Address]uint64
+	round     uint64
+	confirmed map[string]uint64
+}
+
+func newMockNet(t *testing.T) *mockNet {
+	n := &mockNet{t: t, balances: make(map[types.Address]uint64), round: 1, confirmed: make(map[string]uint64)}
+	for i, amount := range []uint64{1000, 4000000, 2000} {
+		n.accounts = append(n.accounts, crypto.GenerateAccount())
+		n.balances[n.accounts[i].Address] = amount
+	}
+	return n
+}
+
+func (n *mockNet) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	path := r.URL.Path
+	switch {
+	case path == "/health":
+		w.Write([]byte("null"))
+	case path == "/versions":
+		w.Write(json.Encode(kmd.VersionsResponse{Versions: []string{"v1"}}))
+	case path == "/v1/wallets":
+		w.Write(json.Encode(kmd.ListWalletsResponse{Wallets: []kmd.APIV1Wallet{{ID: "other", Name: "other"}, {ID: "default", Name: DefaultWallet}}}))
+	case path == "/v1/wallet/init":
+		var req kmd.InitWalletHandleRequest
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(n.t, json.Decode(body, &req))
+		require.Equal(n.t, "default", req.WalletID)
+		w.Write(json.Encode(kmd.InitWalletHandleResponse{WalletHandleToken: "handle"}))
+	case path == "/v1/wallet/release":
+		w.Write(json.Encode(kmd.ReleaseWalletHandleResponse{}))
+	case path == "/v1/key/list":
+		var addresses []string
+		for _, account := range n.accounts {
+			addresses = append(addresses, account.Address.String())
+		}
+		w.Write(json.Encode(kmd.ListKeysResponse{Addresses: addresses}))
+	case path == "/v1/key/export":
+		var req kmd.ExportKeyRequest
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(n.t, json.Decode(body, &req))
+		for _, account := range n.accounts {
+			if account.Address.String() == req.Address {
+				w.Write(json.Encode(kmd.ExportKeyResponse{PrivateKey: account.PrivateKey}))
+			}
+		}
+	case path == "/genesis":
+		w.Write(json.Encode(types.Genesis{Network: "dockernet", DevMode: true}))
+	case path == "/v2/status":
+		w.Write(json.Encode(models.NodeStatus{LastRound: n.round}))
+	case strings.HasPrefix(path, "/v2/status/wait-for-block-after/"):
+		w.Write(json.Encode(models.NodeStatus{LastRound: n.round}))
+	case strings.HasPrefix(path, "/v2/accounts/"):
+		address, err := types.DecodeAddress(strings.TrimPrefix(path, "/v2/accounts/"))
+		require.NoError(n.t, err)
+		w.Write(json.Encode(models.Account{Address: address.String(), Amount: n.balances[address]}))
+	case path == "/v2/transactions/params":
+		w.Write(json.Encode(models.TransactionParametersResponse{GenesisId: "dockernet-v1", GenesisHash: make([]byte, 32), LastRound: n.round, MinFee: 1000}))
+	case path == "/v2/transactions":
+		var stx types.SignedTxn
+		body, _ := io.ReadAll(r.Body)
+		require.NoError(n.t, msgpack.Decode(body, &stx))
+		n.balances[stx.Txn.Sender] -= uint64(stx.Txn.Amount) + uint64(stx.Txn.Fee)
+		n.balances[stx.Txn.Receiver] += uint64(stx.Txn.Amount)
+		n.round++
+		txid := crypto.GetTxID(stx.Txn)
+		n.confirmed[txid] = n.round
+		w.Write(json.Encode(models.PostTransactionsResponse{Txid: txid}))
+	case strings.HasPrefix(path, "/v2/transactions/pending/"):
+		txid := strings.TrimPrefix(path, "/v2/transactions/pending/")
+		w.Write(msgpack.Encode(models.PendingTransactionInfoResponse{ConfirmedRound: n.confirmed[txid]}))
+	default:
+		n.t.Errorf("unexpected request %s", path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestLocalNet(t *testing.T) {
+	mock := newMockNet(t)
+	server := httptest.NewServer(mock)
+	defer server.Close()
+	config := DefaultConfig()
+	config.AlgodURL, config.IndexerURL, config.KMDURL = server.URL, server.URL, server.URL
+
+	ctx := context.Background()
+	n, err := Connect(ctx, config)
+	require.NoError(t, err)
+
+	accounts, err := n.Accounts(ctx)
+	require.NoError(t, err)
+	require.Equal(t, mock.accounts, accounts)
+	dispenser, err := n.Dispenser(ctx)
+	require.NoError(t, err)
+	require.Equal(t, mock.accounts[1], dispenser)
+
+	account, err := n.NewAccount(ctx, 500000)
+	require.NoError(t, err)
+	require.Equal(t, uint64(500000), mock.balances[account.Address])
+	require.Equal(t, uint64(2), mock.round)
+
+	// Only the missing amount is paid, and nothing once funded.
+	require.NoError(t, n.EnsureFunded(ctx, mock.accounts[0].Address, 300000))
+	require.Equal(t, uint64(300000), mock.balances[mock.accounts[0].Address])
+	require.NoError(t, n.EnsureFunded(ctx, mock.accounts[0].Address, 300000))
+	require.Equal(t, uint64(3), mock.round)
+
+	require.NoError(t, n.AdvanceRounds(ctx, 4))
+	require.Equal(t, uint64(7), mock.round)
+	require.Equal(t, uint64(4000000-500000-299000-6*1000), mock.balances[dispenser.Address])
+}
+
+func TestConnectNotRunning(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	config := DefaultConfig()
+	config.AlgodURL = server.URL
+	_, err := Connect(context.Background(), config)
+	require.ErrorIs(t, err, errNotRunning)
+	require.ErrorContains(t, err, "algod at "+server.URL)
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
 )
 
 // SourceMap provides a mapping of the source to assembled program
@@ -74,6 +76,21 @@ func (s *SourceMap) GetPcsForLine(line int) []int {
 	return s.LineToPc[line]
 }
 
+// GetLinesForTrace returns the source line of every opcode in a simulate exec
+// trace, or -1 for an opcode the source map does not cover. When the program
+// failed, the last line is the one that failed.
+func (s *SourceMap) GetLinesForTrace(trace []models.SimulationOpcodeTraceUnit) []int {
+	lines := make([]int, len(trace))
+	for i, unit := range trace {
+		line, ok := s.GetLineForPc(int(unit.Pc))
+		if !ok {
+			line = -1
+		}
+		lines[i] = line
+	}
+	return lines
+}
+
 const (
 	// consts used for vlq encoding/decoding
 	b64table     string = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
@@ -0,0 +1,35 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+)
+
+func TestSourceMap(t *testing.T) {
+	sm, err := DecodeSourceMap(map[string]interface{}{
+		"version":  3,
+		"sources":  []string{"approval.teal"},
+		"names":    []string{},
+		"mappings": "AAAA;;AACA;AAAA;AACA",
+	})
+	require.NoError(t, err)
+
+	line, ok := sm.GetLineForPc(3)
+	require.True(t, ok)
+	require.Equal(t, 1, line)
+	_, ok = sm.GetLineForPc(10)
+	require.False(t, ok)
+
+	require.Equal(t, []int{0, 1}, sm.GetPcsForLine(0))
+	require.Equal(t, []int{2, 3}, sm.GetPcsForLine(1))
+	require.Equal(t, []int{4}, sm.GetPcsForLine(2))
+
+	trace := []models.SimulationOpcodeTraceUnit{{Pc: 0}, {Pc: 2}, {Pc: 4}, {Pc: 10}}
+	require.Equal(t, []int{0, 1, 2, -1}, sm.GetLinesForTrace(trace))
+
+	_, err = DecodeSourceMap(map[string]interface{}{"version": 2, "mappings": "AAAA"})
+	require.Error(t, err)
+}
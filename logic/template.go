@@ -0,0 +1,166 @@
+package logic
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// TemplatePrefix starts the name of the placeholders of TEAL templates.
+const TemplatePrefix = "TMPL_"
+
+// TemplatePlaceholders returns the names of the TMPL_ placeholders of TEAL
+// source, sorted and without duplicates. Comments and string literals are
+// ignored.
+func TemplatePlaceholders(source string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	forEachTemplateToken(source, func(token, _ string) string {
+		if !seen[token] {
+			seen[token] = true
+			names = append(names, token)
+		}
+		return token
+	})
+	sort.Strings(names)
+	return names
+}
+
+// SubstituteTemplate replaces the TMPL_ placeholders of TEAL source with
+// values, keyed by the name of the placeholder with or without its TMPL_
+// prefix. Values are typed:
+//
+//   - unsigned integers, non-negative ints and bools, as 1 or 0, become
+//     integer constants
+//   - []byte and string become 0x byte constants
+//   - a types.Address becomes its base32 form following the addr pseudo-op,
+//     and a 0x byte constant elsewhere
+//
+// Placeholders in comments and string literals are left alone. It returns an
+// error if a placeholder has no value, or a value is of an unsupported type.
+// Values with no placeholder are ignored.
+func SubstituteTemplate(source string, values map[string]interface{}) (string, error) {
+	normalized := make(map[string]interface{}, len(values))
+	for name, value := range values {
+		if !strings.HasPrefix(name, TemplatePrefix) {
+			name = TemplatePrefix + name
+		}
+		normalized[name] = value
+	}
+
+	var missing []string
+	var err error
+	result := forEachTemplateToken(source, func(token, previous string) string {
+		value, ok := normalized[token]
+		if !ok {
+			missing = append(missing, token)
+			return token
+		}
+		substituted, valueErr := templateValue(value, previous == "addr")
+		if valueErr != nil && err == nil {
+			err = fmt.Errorf("template value of %s: %w", token, valueErr)
+		}
+		return substituted
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("template placeholders without a value: %s", strings.Join(missing, ", "))
+	}
+	return result, nil
+}
+
+// templateValue returns the TEAL form of a template value.
+func templateValue(value interface{}, afterAddr bool) (string, error) {
+	switch v := value.(type) {
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case int:
+		if v < 0 {
+			return "", fmt.Errorf("negative integer %d", v)
+		}
+		return strconv.Itoa(v), nil
+	case bool:
+		if v {
+			return "1", nil
+		}
+		return "0", nil
+	case []byte:
+		return "0x" + hex.EncodeToString(v), nil
+	case string:
+		return "0x" + hex.EncodeToString([]byte(v)), nil
+	case types.Address:
+		if afterAddr {
+			return v.String(), nil
+		}
+		return "0x" + hex.EncodeToString(v[:]), nil
+	default:
+		return "", fmt.Errorf("unsupported type %T", value)
+	}
+}
+
+// forEachTemplateToken calls replace for every TMPL_ token of source outside
+// comments and string literals, along with the token before it on the line,
+// and returns source with each token replaced by the result.
+func forEachTemplateToken(source string, replace func(token, previous string) string) string {
+	var out strings.Builder
+	out.Grow(len(source))
+
+	lines := strings.SplitAfter(source, "\n")
+	for _, line := range lines {
+		previous := ""
+		i := 0
+		for i < len(line) {
+			c := line[i]
+			switch {
+			case c == '"':
+				// copy the string literal, escapes included
+				j := i + 1
+				for j < len(line) && line[j] != '"' {
+					if line[j] == '\\' {
+						j++
+					}
+					j++
+				}
+				if j > len(line) {
+					// an unterminated literal ending with a backslash
+					j = len(line)
+				} else if j < len(line) {
+					j++
+				}
+				out.WriteString(line[i:j])
+				previous = line[i:j]
+				i = j
+			case c == '/' && strings.HasPrefix(line[i:], "//"):
+				out.WriteString(line[i:])
+				i = len(line)
+			case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+				out.WriteByte(c)
+				i++
+			default:
+				j := i
+				for j < len(line) && !strings.ContainsRune(" \t\r\n\"", rune(line[j])) && !strings.HasPrefix(line[j:], "//") {
+					j++
+				}
+				token := line[i:j]
+				if strings.HasPrefix(token, TemplatePrefix) && len(token) > len(TemplatePrefix) {
+					out.WriteString(replace(token, previous))
+				} else {
+					out.WriteString(token)
+				}
+				previous = token
+				i = j
+			}
+		}
+	}
+	return out.String()
+}
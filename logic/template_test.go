@@ -0,0 +1,66 @@
+package logic
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestSubstituteTemplate(t *testing.T) {
+	var owner types.Address
+	owner[0] = 1
+	source := `#pragma version 8
+int TMPL_FEE // TMPL_IN_COMMENT
+addr TMPL_OWNER
+byte TMPL_OWNER
+byte "TMPL_IN_STRING \" TMPL_STILL_IN_STRING"
+pushbytes TMPL_NOTE
+int TMPL_ENABLED//comment
+`
+	require.Equal(t, []string{"TMPL_ENABLED", "TMPL_FEE", "TMPL_NOTE", "TMPL_OWNER"}, TemplatePlaceholders(source))
+
+	values := map[string]interface{}{
+		"TMPL_FEE": uint64(1000),
+		"OWNER":    owner,
+		"NOTE":     []byte("hi"),
+		"ENABLED":  true,
+		"UNUSED":   1,
+	}
+	result, err := SubstituteTemplate(source, values)
+	require.NoError(t, err)
+	require.Equal(t, `#pragma version 8
+int 1000 // TMPL_IN_COMMENT
+addr `+owner.String()+`
+byte 0x`+hex.EncodeToString(owner[:])+`
+byte "TMPL_IN_STRING \" TMPL_STILL_IN_STRING"
+pushbytes 0x6869
+int 1//comment
+`, result)
+
+	delete(values, "NOTE")
+	_, err = SubstituteTemplate(source, values)
+	require.ErrorContains(t, err, "TMPL_NOTE")
+
+	values["NOTE"] = -1
+	_, err = SubstituteTemplate(source, values)
+	require.ErrorContains(t, err, "negative")
+
+	values["NOTE"] = 1.5
+	_, err = SubstituteTemplate(source, values)
+	require.ErrorContains(t, err, "unsupported type float64")
+}
+
+func TestSubstituteTemplateUnterminatedString(t *testing.T) {
+	// the string literal runs to the end of the line, ending with an escape
+	for _, source := range []string{"byte \"abc\\", "byte \"abc\\\nint TMPL_FEE\n"} {
+		result, err := SubstituteTemplate(source, map[string]interface{}{"FEE": 1})
+		require.NoError(t, err)
+		require.Equal(t, strings.Replace(source, "TMPL_FEE", "1", 1), result)
+	}
+	require.Equal(t, []string{"TMPL_FEE"}, TemplatePlaceholders("byte \"abc\\\nint TMPL_FEE"))
+	require.Empty(t, TemplatePlaceholders("byte \"abc\\"))
+}
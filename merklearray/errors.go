@@ -0,0 +1,11 @@
+package merklearray
+
+import (
+	"errors"
+)
+
+var errUnsupportedHash = errors.New("unsupported hash type")
+var errTreeTooDeep = errors.New("proof tree depth exceeds the maximum encoded tree depth")
+var errProofLength = errors.New("proof length does not match its tree depth")
+var errRootMismatch = errors.New("proof does not lead to the expected root")
+var errEmptyTree = errors.New("cannot build a tree without leaves")
@@ -0,0 +1,216 @@
+// Package merklearray builds and verifies the Merkle trees used by Algorand to
+// commit to arrays, such as the transactions of a block, with proofs
+// serialized as by go-algorand and algod.
+//
+// A tree is built over leaf hashes, each the hash of a domain separation prefix
+// and the element. Inner nodes hash the prefix "MA" and their two children, a
+// missing right child counting as a digest of zeros. A proof of one leaf is the
+// concatenation of the siblings of the leaf and of its ancestors, from the
+// bottom up, and the tree depth is the length of that path.
+//
+// A vector commitment is a tree padded to a power of two leaves in which
+// element i is stored at the leaf whose position is i with its depth bits
+// reversed. Padding leaves are the hash of no data.
+package merklearray
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"math/bits"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// nodePrefix is prepended to the two children of an inner node when hashing
+// it.
+var nodePrefix = []byte("MA")
+
+// NewHash returns a new hash of the given type. Only SHA-512/256 and SHA-256
+// are supported.
+func NewHash(hashType types.HashType) (hash.Hash, error) {
+	switch hashType {
+	case types.Sha512_256:
+		return sha512.New512_256(), nil
+	case types.Sha256:
+		return sha256.New(), nil
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnsupportedHash, hashType)
+	}
+}
+
+// HashLeaf returns the hash of prefix and data, the leaf of an element
+// encoded as data in a tree using the domain separation prefix.
+func HashLeaf(hashType types.HashType, prefix string, data []byte) ([]byte, error) {
+	h, err := NewHash(hashType)
+	if err != nil {
+		return nil, err
+	}
+	h.Write([]byte(prefix))
+	h.Write(data)
+	return h.Sum(nil), nil
+}
+
+// Tree is a Merkle tree built over leaf hashes.
+type Tree struct {
+	hashType         types.HashType
+	vectorCommitment bool
+
+	// levels holds the nodes of each level, leaves first and root last
+	levels [][][]byte
+}
+
+// Build returns the tree over leaves, in order.
+func Build(hashType types.HashType, leaves [][]byte) (*Tree, error) {
+	return build(hashType, leaves, false)
+}
+
+// BuildVectorCommitment returns the vector commitment tree over leaves.
+func BuildVectorCommitment(hashType types.HashType, leaves [][]byte) (*Tree, error) {
+	return build(hashType, leaves, true)
+}
+
+func build(hashType types.HashType, leaves [][]byte, vectorCommitment bool) (*Tree, error) {
+	h, err := NewHash(hashType)
+	if err != nil {
+		return nil, err
+	}
+	if len(leaves) == 0 {
+		return nil, errEmptyTree
+	}
+
+	level := make([][]byte, len(leaves))
+	copy(level, leaves)
+	if vectorCommitment {
+		depth := uint64(bits.Len64(uint64(len(leaves) - 1)))
+		level = make([][]byte, 1<<depth)
+		bottom := h.Sum(nil)
+		for pos := range level {
+			index := reverseIndex(uint64(pos), depth)
+			if index < uint64(len(leaves)) {
+				level[pos] = leaves[index]
+			} else {
+				level[pos] = bottom
+			}
+		}
+	}
+
+	t := &Tree{hashType: hashType, vectorCommitment: vectorCommitment, levels: [][][]byte{level}}
+	zero := make([]byte, h.Size())
+	for len(level) > 1 {
+		up := make([][]byte, (len(level)+1)/2)
+		for i := range up {
+			right := zero
+			if 2*i+1 < len(level) {
+				right = level[2*i+1]
+			}
+			up[i] = hashNode(h, level[2*i], right)
+		}
+		t.levels = append(t.levels, up)
+		level = up
+	}
+	return t, nil
+}
+
+// Root returns the root of t, which commits to all its leaves.
+func (t *Tree) Root() []byte {
+	return t.levels[len(t.levels)-1][0]
+}
+
+// Depth returns the number of edges from a leaf of t to its root.
+func (t *Tree) Depth() uint64 {
+	return uint64(len(t.levels) - 1)
+}
+
+// Prove returns the proof of the leaf at index, the index of the element for
+// a vector commitment.
+func (t *Tree) Prove(index uint64) ([]byte, error) {
+	pos := index
+	if t.vectorCommitment && index < uint64(len(t.levels[0])) {
+		pos = reverseIndex(index, t.Depth())
+	}
+	if pos >= uint64(len(t.levels[0])) {
+		return nil, fmt.Errorf("index %d is out of range for a tree of %d leaves", index, len(t.levels[0]))
+	}
+
+	var proof []byte
+	size := len(t.Root())
+	for _, level := range t.levels[:t.Depth()] {
+		if sibling := pos ^ 1; sibling < uint64(len(level)) {
+			proof = append(proof, level[sibling]...)
+		} else {
+			proof = append(proof, make([]byte, size)...)
+		}
+		pos >>= 1
+	}
+	return proof, nil
+}
+
+// Verify checks that proof, as returned by Prove, shows that leaf is at
+// index in the tree of the given depth with the given root.
+func Verify(hashType types.HashType, root, leaf []byte, index uint64, proof []byte, depth uint64) error {
+	return verify(hashType, root, leaf, index, proof, depth)
+}
+
+// VerifyVectorCommitment checks that proof, as returned by Prove, shows that
+// leaf is the element at index in the vector commitment of the given depth
+// with the given root.
+func VerifyVectorCommitment(hashType types.HashType, root, leaf []byte, index uint64, proof []byte, depth uint64) error {
+	if depth > types.MaxEncodedTreeDepth {
+		return errTreeTooDeep
+	}
+	if index >= 1<<depth {
+		return fmt.Errorf("index %d is out of range for a tree of depth %d", index, depth)
+	}
+	return verify(hashType, root, leaf, reverseIndex(index, depth), proof, depth)
+}
+
+func verify(hashType types.HashType, root, leaf []byte, pos uint64, proof []byte, depth uint64) error {
+	h, err := NewHash(hashType)
+	if err != nil {
+		return err
+	}
+	if depth > types.MaxEncodedTreeDepth {
+		return errTreeTooDeep
+	}
+	size := uint64(h.Size())
+	if uint64(len(proof)) != depth*size {
+		return errProofLength
+	}
+	if pos >= 1<<depth {
+		return fmt.Errorf("position %d is out of range for a tree of depth %d", pos, depth)
+	}
+
+	node := leaf
+	for level := uint64(0); level < depth; level++ {
+		sibling := proof[level*size : (level+1)*size]
+		if pos&1 == 0 {
+			node = hashNode(h, node, sibling)
+		} else {
+			node = hashNode(h, sibling, node)
+		}
+		pos >>= 1
+	}
+	if string(node) != string(root) {
+		return errRootMismatch
+	}
+	return nil
+}
+
+func hashNode(h hash.Hash, left, right []byte) []byte {
+	h.Reset()
+	h.Write(nodePrefix)
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// reverseIndex reverses the depth low bits of index, mapping an element of a
+// vector commitment to its leaf and back.
+func reverseIndex(index, depth uint64) uint64 {
+	if depth == 0 {
+		return index
+	}
+	return bits.Reverse64(index) >> (64 - depth)
+}
@@ -0,0 +1,224 @@
+package merklearray
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func makeLeaves(t *testing.T, hashType types.HashType, n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := range leaves {
+		leaf, err := HashLeaf(hashType, "test", []byte{byte(i)})
+		require.NoError(t, err)
+		leaves[i] = leaf
+	}
+	return leaves
+}
+
+func TestBuild(t *testing.T) {
+	leaves := makeLeaves(t, types.Sha512_256, 3)
+	tree, err := Build(types.Sha512_256, leaves)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), tree.Depth())
+
+	node := func(left, right []byte) []byte {
+		sum := sha512.Sum512_256(append(append([]byte("MA"), left...), right...))
+		return sum[:]
+	}
+	zero := make([]byte, sha512.Size256)
+	require.Equal(t, node(node(leaves[0], leaves[1]), node(leaves[2], zero)), tree.Root())
+
+	single, err := Build(types.Sha512_256, leaves[:1])
+	require.NoError(t, err)
+	require.Equal(t, leaves[0], single.Root())
+	require.Zero(t, single.Depth())
+
+	_, err = Build(types.Sha512_256, nil)
+	require.ErrorIs(t, err, errEmptyTree)
+	_, err = Build(types.Sumhash, leaves)
+	require.ErrorIs(t, err, errUnsupportedHash)
+}
+
+func TestBuildVectorCommitment(t *testing.T) {
+	leaves := makeLeaves(t, types.Sha256, 3)
+	tree, err := BuildVectorCommitment(types.Sha256, leaves)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), tree.Depth())
+
+	node := func(left, right []byte) []byte {
+		sum := sha256.Sum256(append(append([]byte("MA"), left...), right...))
+		return sum[:]
+	}
+	// element i is at leaf reverse(i): 0, 2, 1, 3
+	bottom := sha256.Sum256(nil)
+	require.Equal(t, node(node(leaves[0], leaves[2]), node(leaves[1], bottom[:])), tree.Root())
+}
+
+func TestProveAndVerify(t *testing.T) {
+	for _, hashType := range []types.HashType{types.Sha512_256, types.Sha256} {
+		for _, n := range []int{1, 2, 5, 8, 13} {
+			leaves := makeLeaves(t, hashType, n)
+			for _, vc := range []bool{false, true} {
+				build, verify := Build, Verify
+				if vc {
+					build, verify = BuildVectorCommitment, VerifyVectorCommitment
+				}
+				tree, err := build(hashType, leaves)
+				require.NoError(t, err)
+
+				for i, leaf := range leaves {
+					proof, err := tree.Prove(uint64(i))
+					require.NoError(t, err)
+					require.NoError(t, verify(hashType, tree.Root(), leaf, uint64(i), proof, tree.Depth()))
+
+					other := leaves[(i+1)%n]
+					if n > 1 {
+						require.ErrorIs(t, verify(hashType, tree.Root(), other, uint64(i), proof, tree.Depth()), errRootMismatch)
+					}
+					if tree.Depth() > 0 {
+						require.ErrorIs(t, verify(hashType, tree.Root(), leaf, uint64(i), proof[1:], tree.Depth()), errProofLength)
+					}
+				}
+				_, err = tree.Prove(1 << tree.Depth())
+				require.Error(t, err)
+			}
+		}
+	}
+}
+
+func TestVerifyTransaction(t *testing.T) {
+	txns := make([]types.Transaction, 3)
+	stibs := make([][]byte, len(txns))
+	for i := range txns {
+		txns[i] = types.Transaction{Type: types.PaymentTx, Header: types.Header{FirstValid: types.Round(i + 1), LastValid: 1000}}
+		stibs[i] = []byte{byte(i)}
+	}
+
+	var commitments types.TxnCommitments
+	proofs := map[string][]models.TransactionProofResponse{}
+	for name, hashType := range map[string]types.HashType{"sha512_256": types.Sha512_256, "sha256": types.Sha256} {
+		leaves := make([][]byte, len(txns))
+		for i, txn := range txns {
+			stib, err := HashLeaf(hashType, "STIB", stibs[i])
+			require.NoError(t, err)
+			stibs[i] = stib
+			leaves[i], err = TransactionLeaf(hashType, txn, stib)
+			require.NoError(t, err)
+		}
+
+		build := Build
+		if hashType == types.Sha256 {
+			build = BuildVectorCommitment
+		}
+		tree, err := build(hashType, leaves)
+		require.NoError(t, err)
+		if hashType == types.Sha256 {
+			copy(commitments.Sha256Commitment[:], tree.Root())
+		} else {
+			copy(commitments.NativeSha512_256Commitment[:], tree.Root())
+		}
+
+		for i := range txns {
+			proof, err := tree.Prove(uint64(i))
+			require.NoError(t, err)
+			proofs[name] = append(proofs[name], models.TransactionProofResponse{
+				Hashtype:  name,
+				Idx:       uint64(i),
+				Proof:     proof,
+				Stibhash:  stibs[i],
+				Treedepth: tree.Depth(),
+			})
+		}
+	}
+
+	for _, byType := range proofs {
+		for i, proof := range byType {
+			require.NoError(t, VerifyTransaction(txns[i], proof, commitments))
+			require.Error(t, VerifyTransaction(txns[(i+1)%len(txns)], proof, commitments))
+		}
+	}
+
+	// the SHA-512/256 leaf uses the usual txid
+	leaf, err := TransactionLeaf(types.Sha512_256, txns[0], stibs[0])
+	require.NoError(t, err)
+	expected := sha512.Sum512_256(append(append([]byte("TL"), crypto.TransactionID(txns[0])...), stibs[0]...))
+	require.Equal(t, expected[:], leaf)
+
+	proof := proofs["sha256"][0]
+	proof.Hashtype = "sumhash"
+	require.ErrorIs(t, VerifyTransaction(txns[0], proof, commitments), errUnsupportedHash)
+}
+
+func TestVerifyTransactionProof(t *testing.T) {
+	var block types.Block
+	block.GenesisID = "testnet-v1.0"
+	block.GenesisHash[0] = 1
+	txns := make([]types.Transaction, 3)
+	for i := range txns {
+		txns[i] = types.Transaction{
+			Type:   types.PaymentTx,
+			Header: types.Header{FirstValid: types.Round(i + 1), LastValid: 1000, GenesisID: block.GenesisID, GenesisHash: block.GenesisHash},
+		}
+		// blocks store transactions without the genesis fields
+		stib := types.SignedTxnInBlock{HasGenesisID: true, HasGenesisHash: true}
+		stib.Txn = txns[i]
+		stib.Txn.GenesisID = ""
+		stib.Txn.GenesisHash = types.Digest{}
+		block.Payset = append(block.Payset, stib)
+	}
+
+	for _, hashType := range []types.HashType{types.Sha512_256, types.Sha256} {
+		leaves := make([][]byte, len(txns))
+		for i, stib := range block.Payset {
+			stibHash, err := HashLeaf(hashType, "STIB", msgpack.Encode(stib))
+			require.NoError(t, err)
+			leaves[i], err = TransactionLeaf(hashType, txns[i], stibHash)
+			require.NoError(t, err)
+		}
+
+		name, build := "sha512_256", Build
+		if hashType == types.Sha256 {
+			name, build = "sha256", BuildVectorCommitment
+		}
+		tree, err := build(hashType, leaves)
+		require.NoError(t, err)
+		if hashType == types.Sha256 {
+			copy(block.Sha256Commitment[:], tree.Root())
+		} else {
+			copy(block.NativeSha512_256Commitment[:], tree.Root())
+		}
+
+		for i, txn := range txns {
+			path, err := tree.Prove(uint64(i))
+			require.NoError(t, err)
+			proof := models.TransactionProofResponse{Hashtype: name, Idx: uint64(i), Proof: path, Treedepth: tree.Depth()}
+			require.NoError(t, VerifyTransactionProof(block, crypto.GetTxID(txn), proof))
+
+			// the stibhash of the proof is not trusted
+			proof.Stibhash = []byte("forged")
+			require.NoError(t, VerifyTransactionProof(block, crypto.GetTxID(txn), proof))
+
+			other := crypto.GetTxID(txns[(i+1)%len(txns)])
+			require.Error(t, VerifyTransactionProof(block, other, proof))
+		}
+
+		tampered := block
+		tampered.Payset = append(types.Payset{}, block.Payset...)
+		tampered.Payset[0].ApplyData.ClosingAmount = 1
+		path, err := tree.Prove(0)
+		require.NoError(t, err)
+		proof := models.TransactionProofResponse{Hashtype: name, Proof: path, Treedepth: tree.Depth()}
+		require.ErrorIs(t, VerifyTransactionProof(tampered, crypto.GetTxID(txns[0]), proof), errRootMismatch)
+
+		proof.Idx = 3
+		require.Error(t, VerifyTransactionProof(block, crypto.GetTxID(txns[0]), proof))
+	}
+}
@@ -0,0 +1,95 @@
+package merklearray
+
+import (
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// txnLeafPrefix is prepended to the leaves of the transaction trees of blocks.
+const txnLeafPrefix = "TL"
+
+// stibPrefix is prepended to a SignedTxnInBlock when hashing it.
+const stibPrefix = "STIB"
+
+// TransactionLeaf returns the leaf of tx in a transaction tree of a block,
+// the hash of its txid and of its SignedTxnInBlock, which algod returns as the
+// stibhash of a transaction proof. The txid is computed with the hash of the
+// tree, so for SHA-256 trees it is not the usual SHA-512/256 txid.
+func TransactionLeaf(hashType types.HashType, tx types.Transaction, stibHash []byte) ([]byte, error) {
+	txid, err := HashLeaf(hashType, "", crypto.TransactionBytesToSign(tx))
+	if err != nil {
+		return nil, err
+	}
+	return HashLeaf(hashType, txnLeafPrefix, append(txid, stibHash...))
+}
+
+// VerifyTransaction checks that proof, as returned by algod's
+// GetTransactionProof, shows that tx is in the block whose header holds
+// commitments. SHA-512/256 proofs are checked against the transaction tree
+// root, and SHA-256 proofs against the transaction vector commitment root.
+func VerifyTransaction(tx types.Transaction, proof models.TransactionProofResponse, commitments types.TxnCommitments) error {
+	hashType, err := proofHashType(proof.Hashtype)
+	if err != nil {
+		return err
+	}
+	leaf, err := TransactionLeaf(hashType, tx, proof.Stibhash)
+	if err != nil {
+		return err
+	}
+	if hashType == types.Sha256 {
+		return VerifyVectorCommitment(hashType, commitments.Sha256Commitment[:], leaf, proof.Idx, proof.Proof, proof.Treedepth)
+	}
+	return Verify(hashType, commitments.NativeSha512_256Commitment[:], leaf, proof.Idx, proof.Proof, proof.Treedepth)
+}
+
+// proofHashType returns the hash type named by the hashtype of a transaction
+// proof, which defaults to SHA-512/256.
+func proofHashType(hashtype string) (types.HashType, error) {
+	switch hashtype {
+	case "", "sha512_256":
+		return types.Sha512_256, nil
+	case "sha256":
+		return types.Sha256, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", errUnsupportedHash, hashtype)
+	}
+}
+
+// VerifyTransactionProof checks that the transaction txid is in block using
+// proof, as returned by algod's GetTransactionProof. Unlike VerifyTransaction,
+// it trusts nothing from the proof but the path: the leaf is recomputed from
+// the SignedTxnInBlock at the proven index of the payset, so block must be the
+// full block, as returned by algod's GetBlock.
+func VerifyTransactionProof(block types.Block, txid string, proof models.TransactionProofResponse) error {
+	hashType, err := proofHashType(proof.Hashtype)
+	if err != nil {
+		return err
+	}
+	if proof.Idx >= uint64(len(block.Payset)) {
+		return fmt.Errorf("proof index %d is out of range for a payset of %d transactions", proof.Idx, len(block.Payset))
+	}
+
+	// Transactions are stored without the genesis fields of the block.
+	stib := block.Payset[proof.Idx]
+	tx := stib.Txn
+	if stib.HasGenesisID {
+		tx.GenesisID = block.GenesisID
+	}
+	if stib.HasGenesisHash {
+		tx.GenesisHash = block.GenesisHash
+	}
+	if id := crypto.GetTxID(tx); id != txid {
+		return fmt.Errorf("transaction at index %d is %s, not %s", proof.Idx, id, txid)
+	}
+
+	stibHash, err := HashLeaf(hashType, stibPrefix, msgpack.Encode(stib))
+	if err != nil {
+		return err
+	}
+	proof.Stibhash = stibHash
+	return VerifyTransaction(tx, proof, block.TxnCommitments)
+}
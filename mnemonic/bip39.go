@@ -0,0 +1,124 @@
+package mnemonic
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	bip39SeedLenBytes   = 64
+	bip39PBKDF2Rounds   = 2048
+	bip39SaltPrefix     = "mnemonic"
+	bip39MinEntropyBits = 128
+	bip39MaxEntropyBits = 256
+)
+
+// GenerateBIP39 returns a new 24 word BIP-39 mnemonic encoding 256 bits of
+// random entropy.
+func GenerateBIP39() (string, error) {
+	entropy := make([]byte, bip39MaxEntropyBits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	return FromEntropyBIP39(entropy)
+}
+
+// FromEntropyBIP39 converts entropy into a BIP-39 mnemonic. The entropy must be
+// 128 to 256 bits long, in steps of 32 bits, giving a mnemonic of 12 to 24
+// words. Unlike FromKey, the checksum is spread over the last word.
+func FromEntropyBIP39(entropy []byte) (string, error) {
+	return FromEntropyBIP39WithWordlist(entropy, English)
+}
+
+// FromEntropyBIP39WithWordlist is FromEntropyBIP39 with the words taken from wl.
+func FromEntropyBIP39WithWordlist(entropy []byte, wl Wordlist) (string, error) {
+	entropyBits := len(entropy) * 8
+	if entropyBits < bip39MinEntropyBits || entropyBits > bip39MaxEntropyBits || entropyBits%32 != 0 {
+		return "", errWrongBIP39EntropyLen
+	}
+
+	// The checksum is the first entropyBits/32 bits of the SHA-256 of the
+	// entropy, appended to the entropy before splitting it into 11 bit words.
+	hash := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), hash[0])
+	checksumBits := entropyBits / 32
+
+	words := make([]string, (entropyBits+checksumBits)/bitsPerWord)
+	for i := range words {
+		var index int
+		for bit := i * bitsPerWord; bit < (i+1)*bitsPerWord; bit++ {
+			index = index<<1 | int(data[bit/8]>>(7-bit%8)&1)
+		}
+		words[i] = wl.Word(index)
+	}
+	return strings.Join(words, wl.Separator()), nil
+}
+
+// ToEntropyBIP39 converts a BIP-39 mnemonic back into the entropy it encodes.
+// It returns an error if a word is not in the words list, the number of words
+// is unexpected, or the checksum does not match.
+func ToEntropyBIP39(mnemonic string) ([]byte, error) {
+	return ToEntropyBIP39WithWordlist(mnemonic, English)
+}
+
+// ToEntropyBIP39WithWordlist is ToEntropyBIP39 for a mnemonic made of words
+// from wl.
+func ToEntropyBIP39WithWordlist(mnemonic string, wl Wordlist) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+
+	totalBits := len(words) * bitsPerWord
+	checksumBits := totalBits / 33
+	entropyBits := totalBits - checksumBits
+	if len(words)%3 != 0 || entropyBits < bip39MinEntropyBits || entropyBits > bip39MaxEntropyBits {
+		return nil, errWrongBIP39MnemonicLen
+	}
+
+	data := make([]byte, (totalBits+7)/8)
+	for i, w := range words {
+		index := wl.Index(w)
+		if index == -1 {
+			return nil, &UnknownWordError{Index: i, Word: w, Suggestions: suggestWords(w, wl)}
+		}
+		for b := 0; b < bitsPerWord; b++ {
+			if index>>(bitsPerWord-1-b)&1 == 1 {
+				bit := i*bitsPerWord + b
+				data[bit/8] |= 1 << (7 - bit%8)
+			}
+		}
+	}
+
+	entropy := data[:entropyBits/8]
+	hash := sha256.Sum256(entropy)
+	mask := byte(0xFF) << (8 - checksumBits)
+	if data[entropyBits/8]&mask != hash[0]&mask {
+		// the checksum takes the low bits of the last word
+		lastWord := words[len(words)-1]
+		expected := wl.Index(lastWord)&^(1<<checksumBits-1) | int(hash[0]>>(8-checksumBits))
+		return nil, &ChecksumError{Word: lastWord, Expected: wl.Word(expected)}
+	}
+	return entropy, nil
+}
+
+// SeedFromBIP39 validates a BIP-39 mnemonic and derives the 64 byte seed it
+// stands for, protected by an optional passphrase. Both are NFKD normalized
+// first, as BIP-39 requires.
+func SeedFromBIP39(mnemonic, passphrase string) ([]byte, error) {
+	return SeedFromBIP39WithWordlist(mnemonic, passphrase, English)
+}
+
+// SeedFromBIP39WithWordlist is SeedFromBIP39 for a mnemonic made of words from
+// wl. As NFKD turns every separator into a plain space, the words are joined
+// with a space whatever the separator of wl.
+func SeedFromBIP39WithWordlist(mnemonic, passphrase string, wl Wordlist) ([]byte, error) {
+	if _, err := ToEntropyBIP39WithWordlist(mnemonic, wl); err != nil {
+		return nil, err
+	}
+	normalized := norm.NFKD.String(strings.Join(strings.Fields(mnemonic), sepStr))
+	salt := bip39SaltPrefix + norm.NFKD.String(passphrase)
+	return pbkdf2.Key([]byte(normalized), []byte(salt), bip39PBKDF2Rounds, bip39SeedLenBytes, sha512.New), nil
+}
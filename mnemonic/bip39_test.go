@@ -0,0 +1,85 @@
+package mnemonic
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBIP39Vectors(t *testing.T) {
+	// Test vectors from the BIP-39 reference implementation, all with the
+	// passphrase "TREZOR".
+	testcases := []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{
+		{
+			entropy:  "00000000000000000000000000000000",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			seed:     "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+			seed:     "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			entropy:  "0000000000000000000000000000000000000000000000000000000000000000",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			seed:     "bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+		},
+	}
+
+	for _, tc := range testcases {
+		entropy, err := hex.DecodeString(tc.entropy)
+		require.NoError(t, err)
+
+		m, err := FromEntropyBIP39(entropy)
+		require.NoError(t, err)
+		require.Equal(t, tc.mnemonic, m)
+
+		recovered, err := ToEntropyBIP39(m)
+		require.NoError(t, err)
+		require.Equal(t, entropy, recovered)
+
+		seed, err := SeedFromBIP39(m, "TREZOR")
+		require.NoError(t, err)
+		require.Equal(t, tc.seed, hex.EncodeToString(seed))
+	}
+}
+
+func TestGenerateBIP39(t *testing.T) {
+	m, err := GenerateBIP39()
+	require.NoError(t, err)
+	require.Len(t, strings.Fields(m), 24)
+
+	entropy, err := ToEntropyBIP39(m)
+	require.NoError(t, err)
+	require.Len(t, entropy, 32)
+
+	withPassphrase, err := SeedFromBIP39(m, "passphrase")
+	require.NoError(t, err)
+	withoutPassphrase, err := SeedFromBIP39(m, "")
+	require.NoError(t, err)
+	require.NotEqual(t, withPassphrase, withoutPassphrase)
+}
+
+func TestBIP39Errors(t *testing.T) {
+	_, err := FromEntropyBIP39(make([]byte, 15))
+	require.ErrorIs(t, err, errWrongBIP39EntropyLen)
+
+	_, err = ToEntropyBIP39("abandon abandon abandon")
+	require.ErrorIs(t, err, errWrongBIP39MnemonicLen)
+
+	_, err = ToEntropyBIP39("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon")
+	require.ErrorIs(t, err, errWrongChecksum)
+	var checksumErr *ChecksumError
+	require.ErrorAs(t, err, &checksumErr)
+	require.Equal(t, "about", checksumErr.Expected)
+
+	_, err = SeedFromBIP39("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon zzz", "")
+	require.ErrorContains(t, err, "zzz")
+}
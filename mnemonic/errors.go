@@ -2,8 +2,59 @@ package mnemonic
 
 import (
 	"fmt"
+	"strings"
 )
 
 var errWrongKeyLen = fmt.Errorf("key length must be %d bytes", keyLenBytes)
 var errWrongMnemonicLen = fmt.Errorf("mnemonic must be %d words", mnemonicLenWords)
 var errWrongChecksum = fmt.Errorf("checksum failed to validate")
+var errWrongBIP39EntropyLen = fmt.Errorf("BIP-39 entropy must be %d to %d bits, in steps of 32", bip39MinEntropyBits, bip39MaxEntropyBits)
+var errWrongBIP39MnemonicLen = fmt.Errorf("BIP-39 mnemonic must be 12, 15, 18, 21 or 24 words")
+
+// maxSuggestions is the most suggestions an UnknownWordError carries.
+const maxSuggestions = 5
+
+// UnknownWordError is returned when a word of a mnemonic is not in the words
+// list, such as after a typo while copying it.
+type UnknownWordError struct {
+	// Index is the position of Word in the mnemonic, starting at 0.
+	Index int
+
+	// Word is the word that was not found.
+	Word string
+
+	// Suggestions are the words of the list at most two edits away from Word,
+	// closest first.
+	Suggestions []string
+}
+
+// Error satisfies builtin interface `error`
+func (err *UnknownWordError) Error() string {
+	msg := fmt.Sprintf("%s is not in the words list (word %d)", err.Word, err.Index+1)
+	if len(err.Suggestions) > 0 {
+		msg += fmt.Sprintf(", did you mean %s?", strings.Join(err.Suggestions, " or "))
+	}
+	return msg
+}
+
+// ChecksumError is returned when every word of a mnemonic is in the words list
+// but its checksum does not match. It matches errors.Is against the checksum
+// error returned before it was introduced.
+type ChecksumError struct {
+	// Word is the last word of the mnemonic, which holds the checksum.
+	Word string
+
+	// Expected is the last word the other words call for. A different value
+	// usually means one of the other words was copied wrong.
+	Expected string
+}
+
+// Error satisfies builtin interface `error`
+func (err *ChecksumError) Error() string {
+	return fmt.Sprintf("%s: last word is %s, expected %s", errWrongChecksum, err.Word, err.Expected)
+}
+
+// Unwrap returns the generic checksum error.
+func (err *ChecksumError) Unwrap() error {
+	return errWrongChecksum
+}
@@ -2,7 +2,6 @@ package mnemonic
 
 import (
 	"crypto/sha512"
-	"fmt"
 	"strings"
 )
 
@@ -28,33 +27,36 @@ func init() {
 // mnemonic includes a checksum. Each word in the mnemonic represents 11 bits
 // of data, and the last 11 bits are reserved for the checksum.
 func FromKey(key []byte) (string, error) {
+	return FromKeyWithWordlist(key, English)
+}
+
+// FromKeyWithWordlist is FromKey with the words taken from wl.
+func FromKeyWithWordlist(key []byte, wl Wordlist) (string, error) {
 	// Ensure the key we are passed is the expected length
 	if len(key) != keyLenBytes {
 		return "", errWrongKeyLen
 	}
 
 	// Compute the checksum of these bytes
-	chk := checksum(key)
+	chk := checksumIndex(key)
 	uint11Array := toUint11Array(key)
-	words := applyWords(uint11Array, wordlist)
-	return fmt.Sprintf("%s %s", strings.Join(words, " "), chk), nil
+	words := applyWords(append(uint11Array, chk), wl)
+	return strings.Join(words, wl.Separator()), nil
 }
 
 // ToKey converts a mnemonic generated using this library into the source
 // key used to create it. It returns an error if the passed mnemonic has an
 // incorrect checksum, if the number of words is unexpected, or if one
-// of the passed words is not found in the words list.
+// of the passed words is not found in the words list. The last two cases
+// return an *UnknownWordError or a *ChecksumError describing the problem.
 func ToKey(mnemonic string) ([]byte, error) {
-	// Split input on whitespace
-	wordsRaw := strings.Split(mnemonic, sepStr)
+	return ToKeyWithWordlist(mnemonic, English)
+}
 
-	// Strip out extra whitespace
-	var words []string
-	for _, word := range wordsRaw {
-		if word != "" {
-			words = append(words, word)
-		}
-	}
+// ToKeyWithWordlist is ToKey for a mnemonic made of words from wl.
+func ToKeyWithWordlist(mnemonic string, wl Wordlist) ([]byte, error) {
+	// Split input on whitespace
+	words := strings.Fields(mnemonic)
 
 	// Ensure the mnemonic is the correct length
 	if len(words) != mnemonicLenWords {
@@ -62,16 +64,16 @@ func ToKey(mnemonic string) ([]byte, error) {
 	}
 
 	// Check that all words are in list
-	for _, w := range words {
-		if indexOf(wordlist, w) == -1 {
-			return nil, fmt.Errorf("%s is not in the words list", w)
+	for i, w := range words {
+		if wl.Index(w) == -1 {
+			return nil, &UnknownWordError{Index: i, Word: w, Suggestions: suggestWords(w, wl)}
 		}
 	}
 
 	// convert words to uin11array (Excluding the checksum word)
 	var uint11Array []uint32
 	for i := 0; i < len(words)-1; i++ {
-		uint11Array = append(uint11Array, uint32(indexOf(wordlist, words[i])))
+		uint11Array = append(uint11Array, uint32(wl.Index(words[i])))
 	}
 
 	// convert t the key back to byte array
@@ -96,11 +98,12 @@ func ToKey(mnemonic string) ([]byte, error) {
 	byteArr = byteArr[0:keyLenBytes]
 
 	// Pull out the checksum
-	mnemonicChecksum := checksum(byteArr)
+	mnemonicChecksum := checksumIndex(byteArr)
 
 	// Verify the checksum
-	if mnemonicChecksum != words[len(words)-1] {
-		return nil, errWrongChecksum
+	lastWord := words[len(words)-1]
+	if mnemonicChecksum != uint32(wl.Index(lastWord)) {
+		return nil, &ChecksumError{Word: lastWord, Expected: wl.Word(int(mnemonicChecksum))}
 	}
 
 	// Verify that we recovered the correct amount of data
@@ -165,31 +168,25 @@ func toByteArray(arr []uint32) []byte {
 	return output
 }
 
-func applyWords(arr []uint32, words []string) []string {
+func applyWords(arr []uint32, wl Wordlist) []string {
 	res := make([]string, len(arr))
 	for i := 0; i < len(arr); i++ {
-		res[i] = words[arr[i]]
+		res[i] = wl.Word(int(arr[i]))
 	}
 	return res
 }
 
-func indexOf(arr []string, s string) int {
-	for i, w := range arr {
-		if w == s {
-			return i
-		}
-	}
-	return -1
-}
-
-// Checksum returns a word that represents the 11 bit checksum of the data
-func checksum(data []byte) string {
+// checksumIndex returns the 11 bit checksum of the data
+func checksumIndex(data []byte) uint32 {
 	// Compute the full hash of the data to checksum
 	fullHash := sha512.Sum512_256(data)
 
 	// Convert to 11 bits array
 	temp := fullHash[0:2]
-	chkBytes := toUint11Array(temp)
+	return toUint11Array(temp)[0]
+}
 
-	return applyWords(chkBytes, wordlist)[0]
+// Checksum returns a word that represents the 11 bit checksum of the data
+func checksum(data []byte) string {
+	return wordlist[checksumIndex(data)]
 }
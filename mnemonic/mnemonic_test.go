@@ -65,9 +65,36 @@ func TestWordNotInList(t *testing.T) {
 	mn := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon zzz invest"
 	_, err := ToKey(mn)
 	require.Error(t, err)
+
+	var wordErr *UnknownWordError
+	require.ErrorAs(t, err, &wordErr)
+	require.Equal(t, 23, wordErr.Index)
+	require.Equal(t, "zzz", wordErr.Word)
+
+	typo := strings.Replace(mn, "zzz", "abandn", 1)
+	_, err = ToKey(typo)
+	require.ErrorAs(t, err, &wordErr)
+	require.Equal(t, "abandn", wordErr.Word)
+	require.Equal(t, "abandon", wordErr.Suggestions[0])
+	require.LessOrEqual(t, len(wordErr.Suggestions), maxSuggestions)
+	require.ErrorContains(t, err, "did you mean abandon")
 	return
 }
 
+func TestSuggestWords(t *testing.T) {
+	require.Equal(t, 0, editDistance("zoo", "zoo"))
+	require.Equal(t, 1, editDistance("zo", "zoo"))
+	require.Equal(t, 1, editDistance("ozo", "zoo"))
+	require.Equal(t, 2, editDistance("oz", "zoo"))
+	require.Equal(t, 3, editDistance("", "zoo"))
+
+	for _, s := range suggestWords("zeor", English) {
+		require.LessOrEqual(t, editDistance("zeor", s), maxSuggestionDistance)
+	}
+	require.Equal(t, "zero", suggestWords("zeor", English)[0])
+	require.Empty(t, suggestWords("xxxxxxxx", English))
+}
+
 func TestCorruptedChecksum(t *testing.T) {
 	key := make([]byte, 32)
 	_, err := rand.Read(key)
@@ -76,10 +103,15 @@ func TestCorruptedChecksum(t *testing.T) {
 	wl := strings.Split(m, sepStr)
 	lastWord := wl[len(wl)-1]
 	// Shuffle the last word (last 11 bits of checksum)
-	wl[len(wl)-1] = wordlist[(indexOf(wordlist, lastWord)+1)%len(wordlist)]
+	wl[len(wl)-1] = wordlist[(English.Index(lastWord)+1)%len(wordlist)]
 	recovered, err := ToKey(strings.Join(wl, sepStr))
 	require.Error(t, err)
 	require.Empty(t, recovered)
+	require.ErrorIs(t, err, errWrongChecksum)
+
+	var checksumErr *ChecksumError
+	require.ErrorAs(t, err, &checksumErr)
+	require.Equal(t, lastWord, checksumErr.Expected)
 }
 
 func TestInvalidKeyLen(t *testing.T) {
@@ -0,0 +1,181 @@
+package mnemonic
+
+import (
+	"embed"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// wordlistLen is the number of words in a wordlist, one per 11 bit value.
+const wordlistLen = 1 << bitsPerWord
+
+// Wordlist maps between the words of a mnemonic language and the 11 bit
+// values they encode.
+type Wordlist interface {
+	// Word returns the word encoding index, which must be below 2048.
+	Word(index int) string
+
+	// Index returns the value encoded by word, or -1 if word is not in the
+	// list.
+	Index(word string) int
+
+	// Separator returns the string placed between the words of a mnemonic.
+	Separator() string
+}
+
+// English is the BIP-39 English wordlist. It is the only wordlist used by
+// FromKey, ToKey and the BIP-39 functions without a Wordlist argument.
+var English Wordlist = mustNewWordlist(wordlist[:wordlistLen], sepStr)
+
+// The other BIP-39 wordlists, as published with the BIP. Japanese mnemonics
+// are joined with the ideographic space.
+var (
+	ChineseSimplified  Wordlist = &embeddedWordlist{file: "chinese_simplified.txt", separator: sepStr}
+	ChineseTraditional Wordlist = &embeddedWordlist{file: "chinese_traditional.txt", separator: sepStr}
+	Czech              Wordlist = &embeddedWordlist{file: "czech.txt", separator: sepStr}
+	French             Wordlist = &embeddedWordlist{file: "french.txt", separator: sepStr}
+	Italian            Wordlist = &embeddedWordlist{file: "italian.txt", separator: sepStr}
+	Japanese           Wordlist = &embeddedWordlist{file: "japanese.txt", separator: "\u3000"}
+	Korean             Wordlist = &embeddedWordlist{file: "korean.txt", separator: sepStr}
+	Spanish            Wordlist = &embeddedWordlist{file: "spanish.txt", separator: sepStr}
+)
+
+//go:embed wordlists/*.txt
+var wordlistFiles embed.FS
+
+// embeddedWordlist is a wordlist read from wordlistFiles the first time it is
+// used, so that programs only pay for the languages they use.
+type embeddedWordlist struct {
+	file      string
+	separator string
+
+	once sync.Once
+	wl   Wordlist
+}
+
+func (e *embeddedWordlist) load() Wordlist {
+	e.once.Do(func() {
+		data, err := wordlistFiles.ReadFile("wordlists/" + e.file)
+		if err != nil {
+			panic(err)
+		}
+		e.wl = mustNewWordlist(strings.Split(strings.TrimSpace(string(data)), "\n"), e.separator)
+	})
+	return e.wl
+}
+
+func (e *embeddedWordlist) Word(index int) string { return e.load().Word(index) }
+func (e *embeddedWordlist) Index(word string) int { return e.load().Index(word) }
+func (e *embeddedWordlist) Separator() string     { return e.separator }
+
+type stringWordlist struct {
+	words     []string
+	indexes   map[string]int
+	separator string
+}
+
+// NewWordlist creates a Wordlist from 2048 distinct words. Words are compared
+// in their NFKD normalization, as BIP-39 specifies, so mnemonics typed with
+// precomposed or decomposed accents and kana are both found. Mnemonics are
+// split on any whitespace, and separator is used to join the words of generated
+// mnemonics, for example the ideographic space for Japanese.
+func NewWordlist(words []string, separator string) (Wordlist, error) {
+	if len(words) != wordlistLen {
+		return nil, fmt.Errorf("wordlist must have %d words, got %d", wordlistLen, len(words))
+	}
+	wl := stringWordlist{
+		words:     append([]string{}, words...),
+		indexes:   make(map[string]int, len(words)),
+		separator: separator,
+	}
+	for i, w := range words {
+		if w == "" {
+			return nil, fmt.Errorf("wordlist has an empty word at index %d", i)
+		}
+		normalized := norm.NFKD.String(w)
+		if _, ok := wl.indexes[normalized]; ok {
+			return nil, fmt.Errorf("wordlist has %s more than once", w)
+		}
+		wl.indexes[normalized] = i
+	}
+	return wl, nil
+}
+
+func mustNewWordlist(words []string, separator string) Wordlist {
+	wl, err := NewWordlist(words, separator)
+	if err != nil {
+		panic(err)
+	}
+	return wl
+}
+
+func (wl stringWordlist) Word(index int) string {
+	return wl.words[index]
+}
+
+func (wl stringWordlist) Index(word string) int {
+	if index, ok := wl.indexes[norm.NFKD.String(word)]; ok {
+		return index
+	}
+	return -1
+}
+
+func (wl stringWordlist) Separator() string {
+	return wl.separator
+}
+
+// maxSuggestionDistance is the largest edit distance between a mistyped word
+// and the words suggested for it.
+const maxSuggestionDistance = 2
+
+// suggestWords returns up to maxSuggestions words of wl within
+// maxSuggestionDistance edits of word, closest first and then in list order.
+func suggestWords(word string, wl Wordlist) []string {
+	word = norm.NFKD.String(word)
+	var byDistance [maxSuggestionDistance + 1][]string
+	for i := 0; i < wordlistLen; i++ {
+		candidate := wl.Word(i)
+		if d := editDistance(word, norm.NFKD.String(candidate)); d <= maxSuggestionDistance {
+			byDistance[d] = append(byDistance[d], candidate)
+		}
+	}
+
+	var suggestions []string
+	for _, words := range byDistance {
+		suggestions = append(suggestions, words...)
+	}
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	return suggestions
+}
+
+// editDistance returns the number of rune insertions, deletions,
+// substitutions and transpositions of adjacent runes needed to turn a into b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
@@ -0,0 +1,124 @@
+package mnemonic
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/unicode/norm"
+)
+
+func makeTestWordlist(t *testing.T, separator string) Wordlist {
+	words := make([]string, wordlistLen)
+	for i := range words {
+		words[i] = fmt.Sprintf("mot%04d", i)
+	}
+	wl, err := NewWordlist(words, separator)
+	require.NoError(t, err)
+	return wl
+}
+
+func TestCustomWordlist(t *testing.T) {
+	wl := makeTestWordlist(t, "　")
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	m, err := FromKeyWithWordlist(key, wl)
+	require.NoError(t, err)
+	require.Len(t, strings.Split(m, "　"), mnemonicLenWords)
+	recovered, err := ToKeyWithWordlist(m, wl)
+	require.NoError(t, err)
+	require.Equal(t, key, recovered)
+
+	// the same key encodes to the same indexes in every language
+	english, err := FromKey(key)
+	require.NoError(t, err)
+	englishWords := strings.Fields(english)
+	for i, w := range strings.Fields(m) {
+		require.Equal(t, English.Index(englishWords[i]), wl.Index(w))
+	}
+
+	_, err = ToKey(m)
+	require.Error(t, err)
+
+	bip39, err := FromEntropyBIP39WithWordlist(key, wl)
+	require.NoError(t, err)
+	entropy, err := ToEntropyBIP39WithWordlist(bip39, wl)
+	require.NoError(t, err)
+	require.Equal(t, key, entropy)
+
+	// seeds are computed over space separated words
+	seed, err := SeedFromBIP39WithWordlist(bip39, "", wl)
+	require.NoError(t, err)
+	spaced, err := SeedFromBIP39WithWordlist(strings.ReplaceAll(bip39, "　", " "), "", wl)
+	require.NoError(t, err)
+	require.Equal(t, seed, spaced)
+}
+
+func TestNewWordlistErrors(t *testing.T) {
+	_, err := NewWordlist([]string{"one", "two"}, " ")
+	require.Error(t, err)
+
+	words := make([]string, wordlistLen)
+	for i := range words {
+		words[i] = fmt.Sprintf("w%d", i)
+	}
+	words[10] = words[9]
+	_, err = NewWordlist(words, " ")
+	require.ErrorContains(t, err, "more than once")
+}
+
+func TestBIP39Wordlists(t *testing.T) {
+	entropy := make([]byte, 32)
+	_, err := rand.Read(entropy)
+	require.NoError(t, err)
+
+	for name, wl := range map[string]Wordlist{
+		"chinese_simplified":  ChineseSimplified,
+		"chinese_traditional": ChineseTraditional,
+		"czech":               Czech,
+		"french":              French,
+		"italian":             Italian,
+		"japanese":            Japanese,
+		"korean":              Korean,
+		"spanish":             Spanish,
+	} {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < wordlistLen; i++ {
+				require.Equal(t, i, wl.Index(wl.Word(i)))
+			}
+			m, err := FromEntropyBIP39WithWordlist(entropy, wl)
+			require.NoError(t, err)
+			require.Len(t, strings.Split(m, wl.Separator()), 24)
+			recovered, err := ToEntropyBIP39WithWordlist(m, wl)
+			require.NoError(t, err)
+			require.Equal(t, entropy, recovered)
+		})
+	}
+}
+
+func TestBIP39Normalization(t *testing.T) {
+	// From the Japanese BIP-39 test vectors, whose passphrase only matches
+	// once NFKD normalized.
+	m := "あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら"
+	entropy, err := ToEntropyBIP39WithWordlist(m, Japanese)
+	require.NoError(t, err)
+	require.Equal(t, make([]byte, 16), entropy)
+	generated, err := FromEntropyBIP39WithWordlist(entropy, Japanese)
+	require.NoError(t, err)
+	require.Equal(t, norm.NFKD.String(m), norm.NFKD.String(generated))
+	seed, err := SeedFromBIP39WithWordlist(m, "㍍ガバヴァぱばぐゞちぢ十人十色", Japanese)
+	require.NoError(t, err)
+	require.Equal(t, "a262d6fb6122ecf45be09c50492b31f92e9beb7d9a845987a02cefda57a15f9c467a17872029a9e92299b5cbdf306e3a0ee620245cbd508959b6cb7ca637bd55", hex.EncodeToString(seed))
+
+	// Accented words are found whether typed precomposed or decomposed.
+	word := Spanish.Word(0)
+	require.Equal(t, "ábaco", norm.NFC.String(word))
+	require.Equal(t, 0, Spanish.Index(norm.NFC.String(word)))
+	require.Equal(t, 0, Spanish.Index(norm.NFD.String(word)))
+}
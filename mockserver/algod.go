@@ -0,0 +1,48 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// ExpectSendTransaction expects a send of transactions, answered with txid.
+func (s *Server) ExpectSendTransaction(txid string) *Expectation {
+	return s.Expect(http.MethodPost, "/v2/transactions").ReturnJSON(models.PostTransactionsResponse{Txid: txid})
+}
+
+// ExpectStatus expects a request of the status of the node.
+func (s *Server) ExpectStatus(status models.NodeStatus) *Expectation {
+	return s.Expect(http.MethodGet, "/v2/status").ReturnJSON(status)
+}
+
+// ExpectStatusAfterBlock expects a wait for the block after round, answered
+// with status.
+func (s *Server) ExpectStatusAfterBlock(round uint64, status models.NodeStatus) *Expectation {
+	return s.Expect(http.MethodGet, fmt.Sprintf("/v2/status/wait-for-block-after/%d", round)).ReturnJSON(status)
+}
+
+// ExpectSuggestedParams expects a request of the suggested transaction
+// parameters.
+func (s *Server) ExpectSuggestedParams(params models.TransactionParametersResponse) *Expectation {
+	return s.Expect(http.MethodGet, "/v2/transactions/params").ReturnJSON(params)
+}
+
+// ExpectPendingTransaction expects a request of the pending transaction
+// txid.
+func (s *Server) ExpectPendingTransaction(txid string, info models.PendingTransactionInfoResponse) *Expectation {
+	return s.Expect(http.MethodGet, "/v2/transactions/pending/"+txid).ReturnMsgpack(info)
+}
+
+// ExpectBlock expects a request of the block of round block.Round.
+func (s *Server) ExpectBlock(block types.Block) *Expectation {
+	return s.Expect(http.MethodGet, fmt.Sprintf("/v2/blocks/%d", block.Round)).ReturnMsgpack(models.BlockResponse{Block: block})
+}
+
+// ExpectAccountInformation expects a request of the information of
+// account.Address.
+func (s *Server) ExpectAccountInformation(account models.Account) *Expectation {
+	return s.Expect(http.MethodGet, "/v2/accounts/"+account.Address).ReturnJSON(account)
+}
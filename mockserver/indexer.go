@@ -0,0 +1,23 @@
+package mockserver
+
+import (
+	"net/http"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+)
+
+// ExpectSearchForTransactions expects an indexer search of transactions.
+func (s *Server) ExpectSearchForTransactions(response models.TransactionsResponse) *Expectation {
+	return s.Expect(http.MethodGet, "/v2/transactions").ReturnJSON(response)
+}
+
+// ExpectLookupAccount expects an indexer lookup of the account
+// response.Account.Address.
+func (s *Server) ExpectLookupAccount(response models.AccountResponse) *Expectation {
+	return s.Expect(http.MethodGet, "/v2/accounts/"+response.Account.Address).ReturnJSON(response)
+}
+
+// ExpectHealth expects a request of the health of the indexer.
+func (s *Server) ExpectHealth(health models.HealthCheckResponse) *Expectation {
+	return s.Expect(http.MethodGet, "/health").ReturnJSON(health)
+}
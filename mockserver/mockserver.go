@@ -0,0 +1,187 @@
+// Package mockserver provides an HTTP server mocking algod and indexer for
+// the unit tests of programs using their clients: requests are answered
+// following expectations, such as a send of transactions returning a
+// transaction ID, a block, or an error, and the expectations not met fail the
+// test.
+package mockserver
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/indexer"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+)
+
+// Server is a mock algod and indexer server.
+type Server struct {
+	*httptest.Server
+
+	t            testing.TB
+	mu           sync.Mutex
+	expectations []*Expectation
+}
+
+// New starts a Server, closed when the test ends, after checking its
+// expectations are met. Requests no expectation matches fail the test, and
+// are answered with a 404.
+func New(t testing.TB) *Server {
+	s := &Server{t: t}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(func() {
+		s.Close()
+		s.AssertExpectations()
+	})
+	return s
+}
+
+// Algod returns an algod client of the server.
+func (s *Server) Algod() *algod.Client {
+	c, err := algod.MakeClient(s.URL, "")
+	if err != nil {
+		s.t.Fatalf("could not make algod client: %v", err)
+	}
+	return c
+}
+
+// Indexer returns an indexer client of the server.
+func (s *Server) Indexer() *indexer.Client {
+	c, err := indexer.MakeClient(s.URL, "")
+	if err != nil {
+		s.t.Fatalf("could not make indexer client: %v", err)
+	}
+	return c
+}
+
+// Expect adds an expectation of a request of method to path, answered with
+// an empty JSON object once, unless configured otherwise.
+func (s *Server) Expect(method, path string) *Expectation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e := &Expectation{s: s, method: method, path: path, status: http.StatusOK, body: []byte("{}"), contentType: "application/json", times: 1}
+	s.expectations = append(s.expectations, e)
+	return e
+}
+
+// AssertExpectations fails the test for each expectation not met.
+func (s *Server) AssertExpectations() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range s.expectations {
+		if e.times > 0 && e.calls < e.times {
+			s.t.Errorf("expected %s %s %d times, got %d", e.method, e.path, e.times, e.calls)
+		}
+	}
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.t.Errorf("could not read request %s %s: %v", r.Method, r.URL.Path, err)
+		return
+	}
+
+	s.mu.Lock()
+	var matched *Expectation
+	for _, e := range s.expectations {
+		if e.method == r.Method && e.path == r.URL.Path && (e.times == 0 || e.calls < e.times) {
+			matched = e
+			matched.calls++
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if matched == nil {
+		s.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unexpected request %s %s", r.Method, r.URL.Path))
+		return
+	}
+	if matched.check != nil {
+		if err := matched.check(r, body); err != nil {
+			s.t.Errorf("request %s %s: %v", r.Method, r.URL.Path, err)
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	w.Header().Set("Content-Type", matched.contentType)
+	w.WriteHeader(matched.status)
+	w.Write(matched.body)
+}
+
+// Expectation is an expected request and its response.
+type Expectation struct {
+	s      *Server
+	method string
+	path   string
+	check  func(*http.Request, []byte) error
+
+	status      int
+	body        []byte
+	contentType string
+
+	times int
+	calls int
+}
+
+// ReturnJSON answers the request with the JSON encoding of v.
+func (e *Expectation) ReturnJSON(v interface{}) *Expectation {
+	e.body, e.contentType = json.Encode(v), "application/json"
+	return e
+}
+
+// ReturnMsgpack answers the request with the msgpack encoding of v, as
+// algod does for blocks and pending transactions.
+func (e *Expectation) ReturnMsgpack(v interface{}) *Expectation {
+	e.body, e.contentType = msgpack.Encode(v), "application/msgpack"
+	return e
+}
+
+// ReturnError answers the request with status and an error message, as
+// algod and indexer do.
+func (e *Expectation) ReturnError(status int, message string) *Expectation {
+	e.status = status
+	e.body, e.contentType = json.Encode(errorResponse{Message: message}), "application/json"
+	return e
+}
+
+// Times expects the request n times; zero allows any number of times.
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+// AnyTimes allows the request any number of times, including none.
+func (e *Expectation) AnyTimes() *Expectation {
+	return e.Times(0)
+}
+
+// Check makes the request fail the test, and be answered with a 400, if
+// check returns an error for it. body is the body of the request.
+func (e *Expectation) Check(check func(r *http.Request, body []byte) error) *Expectation {
+	e.check = check
+	return e
+}
+
+// Calls returns the number of requests the expectation has answered.
+func (e *Expectation) Calls() int {
+	e.s.mu.Lock()
+	defer e.s.mu.Unlock()
+	return e.calls
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(json.Encode(errorResponse{Message: message}))
+}
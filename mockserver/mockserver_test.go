@@ -0,0 +1,93 @@
+package mockserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// recorder records the errors of a test expected to fail.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestServer(t *testing.T) {
+	s := New(t)
+	ctx := context.Background()
+
+	s.ExpectSendTransaction("TXID").Check(func(r *http.Request, body []byte) error {
+		if string(body) != "signed" {
+			return errors.New("unexpected transaction")
+		}
+		return nil
+	})
+	txid, err := s.Algod().SendRawTransaction([]byte("signed")).Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "TXID", txid)
+
+	var block types.Block
+	block.Round = 5
+	block.GenesisID = "mocknet"
+	s.ExpectBlock(block).Times(2)
+	for i := 0; i < 2; i++ {
+		got, err := s.Algod().Block(5).Do(ctx)
+		require.NoError(t, err)
+		require.Equal(t, block, got)
+	}
+
+	status := s.ExpectStatus(models.NodeStatus{LastRound: 9}).AnyTimes()
+	got, err := s.Algod().Status().Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(9), got.LastRound)
+	require.Equal(t, 1, status.Calls())
+
+	// Errors are injected, then the next expectation answers.
+	s.ExpectSuggestedParams(models.TransactionParametersResponse{}).ReturnError(http.StatusServiceUnavailable, "overloaded")
+	s.ExpectSuggestedParams(models.TransactionParametersResponse{MinFee: 1000, LastRound: 9})
+	_, err = s.Algod().SuggestedParams().Do(ctx)
+	var httpErr *common.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, "overloaded", httpErr.Message)
+	params, err := s.Algod().SuggestedParams().Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), params.MinFee)
+
+	s.ExpectHealth(models.HealthCheckResponse{Round: 9})
+	health, err := s.Indexer().HealthCheck().Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(9), health.Round)
+}
+
+func TestServerFailures(t *testing.T) {
+	r := &recorder{TB: t}
+	s := New(r)
+	s.ExpectSendTransaction("TXID").Check(func(*http.Request, []byte) error {
+		return errors.New("unexpected transaction")
+	})
+	s.ExpectStatus(models.NodeStatus{})
+
+	_, err := s.Algod().SendRawTransaction([]byte("signed")).Do(context.Background())
+	require.ErrorContains(t, err, "unexpected transaction")
+	_, err = s.Algod().Block(1).Do(context.Background())
+	require.ErrorContains(t, err, "unexpected request GET /v2/blocks/1")
+	s.AssertExpectations()
+
+	require.Equal(t, []string{
+		"request POST /v2/transactions: unexpected transaction",
+		"unexpected request GET /v2/blocks/1",
+		"expected GET /v2/status 1 times, got 0",
+	}, r.errors)
+}
@@ -0,0 +1,7 @@
+package subscriber
+
+import (
+	"errors"
+)
+
+var errReorgTooDeep = errors.New("the chain of the node diverges before the oldest round whose hash is kept")
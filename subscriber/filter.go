@@ -0,0 +1,93 @@
+package subscriber
+
+import (
+	"bytes"
+
+	"github.com/algorand/go-algorand-sdk/v2/events"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// Filter selects transactions. A transaction matches if it matches every
+// field that is set.
+type Filter struct {
+	// Sender is the sender of the transaction.
+	Sender types.Address
+	// Receiver is the receiver of a payment or asset transfer.
+	Receiver types.Address
+	// AppID is the application called, or created, by the transaction.
+	AppID uint64
+	// AssetID is the asset transferred, configured, created or frozen by the
+	// transaction.
+	AssetID uint64
+	// NotePrefix starts the note of the transaction.
+	NotePrefix []byte
+	// Events has the ARC-28 events of which the transaction must log at
+	// least one. The events it logs are returned with it.
+	Events *events.Decoder
+	// Match is a custom condition on the transaction.
+	Match func(types.SignedTxnWithAD) bool
+
+	// Inner, if true, also matches the inner transactions of top-level
+	// transactions, which are otherwise ignored.
+	Inner bool
+}
+
+// Match is a transaction matching a Filter.
+type Match struct {
+	// Round is the round of the block of the transaction.
+	Round uint64
+	// Intra is the index in the block of the transaction, or of the
+	// top-level transaction it is an inner transaction of.
+	Intra int
+	// Path has the index of an inner transaction among those issued by its
+	// parent, preceded by the indexes of its ancestors, as in
+	// transaction.InnerTransaction. It is empty for a top-level transaction.
+	Path []int
+
+	// TxID is the ID of the transaction, and ParentID the ID of the
+	// transaction that issued it if it is an inner transaction.
+	TxID     string
+	ParentID string
+
+	// Txn is the transaction, with what it applied.
+	Txn types.SignedTxnWithAD
+	// Events are the events of Filter.Events the transaction logged.
+	Events []events.DecodedEvent
+}
+
+// match returns whether txn matches f, and the events it logged if f has
+// some.
+func (f Filter) match(txn types.SignedTxnWithAD) ([]events.DecodedEvent, bool) {
+	tx := txn.Txn
+	if !f.Sender.IsZero() && tx.Sender != f.Sender {
+		return nil, false
+	}
+	if !f.Receiver.IsZero() && tx.Receiver != f.Receiver && tx.AssetReceiver != f.Receiver {
+		return nil, false
+	}
+	if f.AppID != 0 && uint64(tx.ApplicationID) != f.AppID && txn.ApplyData.ApplicationID != f.AppID {
+		return nil, false
+	}
+	if f.AssetID != 0 && uint64(tx.XferAsset) != f.AssetID && uint64(tx.ConfigAsset) != f.AssetID &&
+		uint64(tx.FreezeAsset) != f.AssetID && txn.ApplyData.ConfigAsset != f.AssetID {
+		return nil, false
+	}
+	if f.NotePrefix != nil && !bytes.HasPrefix(tx.Note, f.NotePrefix) {
+		return nil, false
+	}
+	if f.Match != nil && !f.Match(txn) {
+		return nil, false
+	}
+	if f.Events == nil {
+		return nil, true
+	}
+
+	var logged []events.DecodedEvent
+	for _, log := range txn.EvalDelta.Logs {
+		// logs of other events, or that are not events, are skipped
+		if event, err := f.Events.Decode([]byte(log)); err == nil {
+			logged = append(logged, event)
+		}
+	}
+	return logged, len(logged) > 0
+}
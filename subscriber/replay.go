@@ -0,0 +1,283 @@
+package subscriber
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// replayWindow is the number of rounds searched at once when replaying, whose
+// matches are delivered in order once all found.
+const replayWindow = 1000
+
+// replayPageSize is the number of transactions fetched per indexer request.
+const replayPageSize = 1000
+
+type replayed struct {
+	sub   subscription
+	match Match
+}
+
+// replay delivers the matches of the rounds from next to the last one both
+// the node and the indexer have, found with the indexer, and returns the
+// round following them.
+func (s *Subscriber) replay(ctx context.Context, next uint64) (uint64, error) {
+	status, err := s.client.Status().Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	health, err := s.config.Indexer.HealthCheck().Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	last := status.LastRound
+	if health.Round < last {
+		last = health.Round
+	}
+
+	for next <= last {
+		end := next + replayWindow - 1
+		if end > last {
+			end = last
+		}
+		var matches []replayed
+		for _, sub := range s.subscriptions {
+			found, err := s.search(ctx, sub, next, end)
+			if err != nil {
+				return 0, err
+			}
+			matches = append(matches, found...)
+		}
+		sort.SliceStable(matches, func(i, j int) bool {
+			a, b := matches[i].match, matches[j].match
+			if a.Round != b.Round {
+				return a.Round < b.Round
+			}
+			return a.Intra < b.Intra
+		})
+		for _, m := range matches {
+			select {
+			case m.sub.c <- m.match:
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}
+		if err := s.config.Watermark.Save(end); err != nil {
+			return 0, fmt.Errorf("could not save the watermark: %w", err)
+		}
+		next = end + 1
+	}
+	return next, nil
+}
+
+// search returns the matches of sub from round first to last. The indexer is
+// searched for the fields of the filter it supports, and the filter then
+// applied to the transactions it returns.
+func (s *Subscriber) search(ctx context.Context, sub subscription, first, last uint64) ([]replayed, error) {
+	f := sub.filter
+	search := s.config.Indexer.SearchForTransactions().MinRound(first).MaxRound(last).Limit(replayPageSize)
+	switch {
+	case !f.Sender.IsZero():
+		search.AddressString(f.Sender.String()).AddressRole("sender")
+	case !f.Receiver.IsZero():
+		search.AddressString(f.Receiver.String()).AddressRole("receiver")
+	}
+	if f.AppID != 0 {
+		search.ApplicationId(f.AppID)
+	}
+	if f.AssetID != 0 {
+		search.AssetID(f.AssetID)
+	}
+	if f.NotePrefix != nil {
+		search.NotePrefix(f.NotePrefix)
+	}
+
+	var found []replayed
+	// the indexer returns the top-level transaction of matching inner
+	// transactions, possibly more than once
+	seen := make(map[string]bool)
+	it := search.Iterator(ctx)
+	for it.Next() {
+		txn := it.Item()
+		if seen[txn.Id] {
+			continue
+		}
+		seen[txn.Id] = true
+		converted, err := fromIndexerTransaction(txn)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert transaction %s: %w", txn.Id, err)
+		}
+		root := Match{Round: txn.ConfirmedRound, Intra: int(txn.IntraRoundOffset), TxID: txn.Id, Txn: converted}
+		err = walk(root, []subscription{sub}, func(sub subscription, m Match) error {
+			found = append(found, replayed{sub: sub, match: m})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return found, it.Err()
+}
+
+// fromIndexerTransaction converts a transaction returned by the indexer. The
+// state deltas of application calls, and the fields of state proof and
+// heartbeat transactions, are left out.
+func fromIndexerTransaction(txn models.Transaction) (types.SignedTxnWithAD, error) {
+	var stxn types.SignedTxnWithAD
+	var err error
+	addresses := []struct {
+		encoded string
+		decoded *types.Address
+	}{
+		{txn.Sender, &stxn.Txn.Sender},
+		{txn.RekeyTo, &stxn.Txn.RekeyTo},
+		{txn.AuthAddr, &stxn.AuthAddr},
+		{txn.PaymentTransaction.Receiver, &stxn.Txn.Receiver},
+		{txn.PaymentTransaction.CloseRemainderTo, &stxn.Txn.CloseRemainderTo},
+		{txn.AssetTransferTransaction.Receiver, &stxn.Txn.AssetReceiver},
+		{txn.AssetTransferTransaction.Sender, &stxn.Txn.AssetSender},
+		{txn.AssetTransferTransaction.CloseTo, &stxn.Txn.AssetCloseTo},
+		{txn.AssetFreezeTransaction.Address, &stxn.Txn.FreezeAccount},
+		{txn.AssetConfigTransaction.Params.Manager, &stxn.Txn.AssetParams.Manager},
+		{txn.AssetConfigTransaction.Params.Reserve, &stxn.Txn.AssetParams.Reserve},
+		{txn.AssetConfigTransaction.Params.Freeze, &stxn.Txn.AssetParams.Freeze},
+		{txn.AssetConfigTransaction.Params.Clawback, &stxn.Txn.AssetParams.Clawback},
+	}
+	for _, a := range addresses {
+		if a.encoded == "" {
+			continue
+		}
+		if *a.decoded, err = types.DecodeAddress(a.encoded); err != nil {
+			return types.SignedTxnWithAD{}, err
+		}
+	}
+
+	tx := &stxn.Txn
+	tx.Type = types.TxType(txn.Type)
+	tx.Fee = types.MicroAlgos(txn.Fee)
+	tx.FirstValid = types.Round(txn.FirstValid)
+	tx.LastValid = types.Round(txn.LastValid)
+	tx.Note = txn.Note
+	tx.GenesisID = txn.GenesisId
+	copy(tx.GenesisHash[:], txn.GenesisHash)
+	copy(tx.Group[:], txn.Group)
+	copy(tx.Lease[:], txn.Lease)
+
+	tx.Amount = types.MicroAlgos(txn.PaymentTransaction.Amount)
+
+	keyreg := txn.KeyregTransaction
+	copy(tx.VotePK[:], keyreg.VoteParticipationKey)
+	copy(tx.SelectionPK[:], keyreg.SelectionParticipationKey)
+	copy(tx.StateProofPK[:], keyreg.StateProofKey)
+	tx.VoteFirst = types.Round(keyreg.VoteFirstValid)
+	tx.VoteLast = types.Round(keyreg.VoteLastValid)
+	tx.VoteKeyDilution = keyreg.VoteKeyDilution
+	tx.Nonparticipation = keyreg.NonParticipation
+
+	tx.XferAsset = types.AssetIndex(txn.AssetTransferTransaction.AssetId)
+	tx.AssetAmount = txn.AssetTransferTransaction.Amount
+	tx.FreezeAsset = types.AssetIndex(txn.AssetFreezeTransaction.AssetId)
+	tx.AssetFrozen = txn.AssetFreezeTransaction.NewFreezeStatus
+
+	config := txn.AssetConfigTransaction
+	tx.ConfigAsset = types.AssetIndex(config.AssetId)
+	tx.AssetParams.Total = config.Params.Total
+	tx.AssetParams.Decimals = uint32(config.Params.Decimals)
+	tx.AssetParams.DefaultFrozen = config.Params.DefaultFrozen
+	tx.AssetParams.UnitName = config.Params.UnitName
+	tx.AssetParams.AssetName = config.Params.Name
+	tx.AssetParams.URL = config.Params.Url
+	copy(tx.AssetParams.MetadataHash[:], config.Params.MetadataHash)
+
+	app := txn.ApplicationTransaction
+	tx.ApplicationID = types.AppIndex(app.ApplicationId)
+	if tx.OnCompletion, err = onCompletion(app.OnCompletion); err != nil {
+		return types.SignedTxnWithAD{}, err
+	}
+	tx.ApplicationArgs = app.ApplicationArgs
+	for _, account := range app.Accounts {
+		address, err := types.DecodeAddress(account)
+		if err != nil {
+			return types.SignedTxnWithAD{}, err
+		}
+		tx.Accounts = append(tx.Accounts, address)
+	}
+	for _, id := range app.ForeignApps {
+		tx.ForeignApps = append(tx.ForeignApps, types.AppIndex(id))
+	}
+	for _, id := range app.ForeignAssets {
+		tx.ForeignAssets = append(tx.ForeignAssets, types.AssetIndex(id))
+	}
+	tx.GlobalStateSchema = types.StateSchema{NumUint: app.GlobalStateSchema.NumUint, NumByteSlice: app.GlobalStateSchema.NumByteSlice}
+	tx.LocalStateSchema = types.StateSchema{NumUint: app.LocalStateSchema.NumUint, NumByteSlice: app.LocalStateSchema.NumByteSlice}
+	tx.ApprovalProgram = app.ApprovalProgram
+	tx.ClearStateProgram = app.ClearStateProgram
+	tx.ExtraProgramPages = uint32(app.ExtraProgramPages)
+
+	signature := txn.Signature
+	copy(stxn.Sig[:], signature.Sig)
+	stxn.Msig = fromIndexerMultisig(signature.Multisig)
+	if len(signature.Logicsig.Logic) > 0 {
+		stxn.Lsig.Logic = signature.Logicsig.Logic
+		stxn.Lsig.Args = signature.Logicsig.Args
+		copy(stxn.Lsig.Sig[:], signature.Logicsig.Signature)
+		stxn.Lsig.Msig = fromIndexerMultisig(signature.Logicsig.MultisigSignature)
+	}
+
+	ad := &stxn.ApplyData
+	ad.ClosingAmount = types.MicroAlgos(txn.ClosingAmount)
+	ad.AssetClosingAmount = txn.AssetTransferTransaction.CloseAmount
+	ad.SenderRewards = types.MicroAlgos(txn.SenderRewards)
+	ad.ReceiverRewards = types.MicroAlgos(txn.ReceiverRewards)
+	ad.CloseRewards = types.MicroAlgos(txn.CloseRewards)
+	ad.ConfigAsset = txn.CreatedAssetIndex
+	ad.ApplicationID = txn.CreatedApplicationIndex
+	for _, log := range txn.Logs {
+		ad.EvalDelta.Logs = append(ad.EvalDelta.Logs, string(log))
+	}
+	for _, inner := range txn.InnerTxns {
+		converted, err := fromIndexerTransaction(inner)
+		if err != nil {
+			return types.SignedTxnWithAD{}, err
+		}
+		ad.EvalDelta.InnerTxns = append(ad.EvalDelta.InnerTxns, converted)
+	}
+	return stxn, nil
+}
+
+func fromIndexerMultisig(msig models.TransactionSignatureMultisig) types.MultisigSig {
+	if msig.Version == 0 {
+		return types.MultisigSig{}
+	}
+	converted := types.MultisigSig{Version: uint8(msig.Version), Threshold: uint8(msig.Threshold)}
+	for _, subsig := range msig.Subsignature {
+		var sig types.Signature
+		copy(sig[:], subsig.Signature)
+		converted.Subsigs = append(converted.Subsigs, types.MultisigSubsig{Key: subsig.PublicKey, Sig: sig})
+	}
+	return converted
+}
+
+// onCompletion converts the on-completion of an application call as named by
+// the indexer.
+func onCompletion(name string) (types.OnCompletion, error) {
+	switch name {
+	case "", "noop":
+		return types.NoOpOC, nil
+	case "optin":
+		return types.OptInOC, nil
+	case "closeout":
+		return types.CloseOutOC, nil
+	case "clear":
+		return types.ClearStateOC, nil
+	case "update":
+		return types.UpdateApplicationOC, nil
+	case "delete":
+		return types.DeleteApplicationOC, nil
+	default:
+		return 0, fmt.Errorf("unknown on-completion %q", name)
+	}
+}
@@ -0,0 +1,266 @@
+// Package subscriber follows the blocks of an algod node as they are made,
+// and delivers the transactions matching filters over channels. The last
+// processed round is persisted so that a restarted subscriber resumes where
+// it stopped.
+package subscriber
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/indexer"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// Config configures a Subscriber.
+type Config struct {
+	// StartRound is the first round to process when Watermark has none. If
+	// zero, the subscriber starts after the last round of the node.
+	StartRound uint64
+	// Watermark persists the last processed round, a MemoryWatermark if nil.
+	Watermark Watermark
+
+	// Indexer, if set, is searched for the matches of the rounds from the
+	// start up to the last one of the node, before following its new blocks.
+	// This bootstraps from rounds the node no longer has, unless archival.
+	// Indexer transactions lack the state deltas of application calls.
+	Indexer *indexer.Client
+
+	// ReorgDepth is the number of processed rounds whose block hash is kept
+	// to detect the node switching to another chain, as a follower node
+	// restored from another catchpoint may. The subscriber then goes back to
+	// the last round the chains share, calls OnReorg with it, and processes
+	// the following rounds again. Zero disables the detection, which costs a
+	// request per round.
+	ReorgDepth int
+	// OnReorg is called with the last round kept when the subscriber goes
+	// back because of a change of chain.
+	OnReorg func(round uint64)
+
+	// Follower must be true if the node runs in follower mode: the sync
+	// round of the node is then moved past each processed round, for it to
+	// keep fetching blocks.
+	Follower bool
+}
+
+type subscription struct {
+	filter Filter
+	c      chan Match
+}
+
+// Subscriber delivers the transactions of new blocks matching filters.
+type Subscriber struct {
+	client        *algod.Client
+	config        Config
+	subscriptions []subscription
+
+	// hashes are the block hashes of the last processed rounds, when
+	// detecting changes of chain.
+	hashes map[uint64]types.BlockHash
+}
+
+// New returns a Subscriber following the blocks of the node of client.
+func New(client *algod.Client, config Config) *Subscriber {
+	if config.Watermark == nil {
+		config.Watermark = &MemoryWatermark{}
+	}
+	return &Subscriber{client: client, config: config, hashes: make(map[uint64]types.BlockHash)}
+}
+
+// Subscribe returns a channel receiving the transactions matching filter,
+// buffering up to buffer of them. It must be called before Run, which closes
+// the channel when it returns. Rounds are only processed once their matches
+// are received from every channel.
+func (s *Subscriber) Subscribe(filter Filter, buffer int) <-chan Match {
+	c := make(chan Match, buffer)
+	s.subscriptions = append(s.subscriptions, subscription{filter: filter, c: c})
+	return c
+}
+
+// Run processes the blocks of the node, from the round after the watermark,
+// waiting for new ones once caught up, until ctx is cancelled or an error
+// occurs, which it returns.
+func (s *Subscriber) Run(ctx context.Context) error {
+	defer func() {
+		for _, sub := range s.subscriptions {
+			close(sub.c)
+		}
+	}()
+
+	next, err := s.startRound(ctx)
+	if err != nil {
+		return err
+	}
+	if s.config.Indexer != nil {
+		if next, err = s.replay(ctx, next); err != nil {
+			return err
+		}
+	}
+	if s.config.Follower {
+		if _, err := s.client.SetSyncRound(next).Do(ctx); err != nil {
+			return err
+		}
+	}
+	for {
+		status, err := s.client.StatusAfterBlock(next - 1).Do(ctx)
+		if err != nil {
+			return err
+		}
+		for next <= status.LastRound {
+			if next, err = s.process(ctx, next); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// startRound returns the first round to process.
+func (s *Subscriber) startRound(ctx context.Context) (uint64, error) {
+	round, err := s.config.Watermark.Load()
+	if err != nil {
+		return 0, fmt.Errorf("could not load the watermark: %w", err)
+	}
+	if round > 0 {
+		return round + 1, nil
+	}
+	if s.config.StartRound > 0 {
+		return s.config.StartRound, nil
+	}
+	status, err := s.client.Status().Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return status.LastRound + 1, nil
+}
+
+// process delivers the matches of round, and returns the next round to
+// process.
+func (s *Subscriber) process(ctx context.Context, round uint64) (uint64, error) {
+	block, err := s.client.Block(round).Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if previous, ok := s.hashes[round-1]; ok && block.Branch != previous {
+		return s.reorg(ctx, round-1)
+	}
+
+	for intra, stib := range block.Payset {
+		txn := stib.SignedTxnWithAD
+		if stib.HasGenesisID {
+			txn.Txn.GenesisID = block.GenesisID
+		}
+		if stib.HasGenesisHash {
+			txn.Txn.GenesisHash = block.GenesisHash
+		}
+		top := Match{Round: round, Intra: intra, TxID: crypto.GetTxID(txn.Txn), Txn: txn}
+		if err := s.deliver(ctx, top); err != nil {
+			return 0, err
+		}
+	}
+
+	if s.config.ReorgDepth > 0 {
+		response, err := s.client.GetBlockHash(round).Do(ctx)
+		if err != nil {
+			return 0, err
+		}
+		var hash types.BlockHash
+		if err := hash.UnmarshalText([]byte(response.Blockhash)); err != nil {
+			return 0, err
+		}
+		s.hashes[round] = hash
+		delete(s.hashes, round-uint64(s.config.ReorgDepth))
+	}
+	if err := s.config.Watermark.Save(round); err != nil {
+		return 0, fmt.Errorf("could not save the watermark: %w", err)
+	}
+	if s.config.Follower {
+		if _, err := s.client.SetSyncRound(round + 1).Do(ctx); err != nil {
+			return 0, err
+		}
+	}
+	return round + 1, nil
+}
+
+// deliver sends m, and its inner transactions, to the subscriptions whose
+// filter they match.
+func (s *Subscriber) deliver(ctx context.Context, m Match) error {
+	return walk(m, s.subscriptions, func(sub subscription, matched Match) error {
+		select {
+		case sub.c <- matched:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// walk calls fn with m, and then its inner transactions, for each of subs
+// whose filter they match, with the events they logged.
+func walk(m Match, subs []subscription, fn func(subscription, Match) error) error {
+	for _, sub := range subs {
+		if len(m.Path) > 0 && !sub.filter.Inner {
+			continue
+		}
+		logged, ok := sub.filter.match(m.Txn)
+		if !ok {
+			continue
+		}
+		matched := m
+		matched.Events = logged
+		if err := fn(sub, matched); err != nil {
+			return err
+		}
+	}
+
+	// the IDs of inner transactions derive from the plain ID of their caller,
+	// see crypto.InnerTransactionID
+	callerID := m.TxID
+	if len(m.Path) > 0 {
+		callerID = crypto.GetTxID(m.Txn.Txn)
+	}
+	for i, inner := range m.Txn.EvalDelta.InnerTxns {
+		id, err := crypto.InnerTransactionID(callerID, i, inner.Txn)
+		if err != nil {
+			return err
+		}
+		path := append(append(make([]int, 0, len(m.Path)+1), m.Path...), i)
+		err = walk(Match{Round: m.Round, Intra: m.Intra, Path: path, TxID: id, ParentID: m.TxID, Txn: inner}, subs, fn)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reorg finds the last round, from round down, whose hash the node agrees
+// with, forgets the rounds after it, and returns the round following it.
+func (s *Subscriber) reorg(ctx context.Context, round uint64) (uint64, error) {
+	for ; ; round-- {
+		kept, ok := s.hashes[round]
+		if !ok {
+			return 0, errReorgTooDeep
+		}
+		response, err := s.client.GetBlockHash(round).Do(ctx)
+		if err != nil {
+			return 0, err
+		}
+		var hash types.BlockHash
+		if err := hash.UnmarshalText([]byte(response.Blockhash)); err != nil {
+			return 0, err
+		}
+		if hash == kept {
+			break
+		}
+		delete(s.hashes, round)
+	}
+
+	if err := s.config.Watermark.Save(round); err != nil {
+		return 0, fmt.Errorf("could not save the watermark: %w", err)
+	}
+	if s.config.OnReorg != nil {
+		s.config.OnReorg(round)
+	}
+	return round + 1, nil
+}
@@ -0,0 +1,464 @@
+package subscriber
+
+import (
+	"context"
+	"encoding/base32"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/abi"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/indexer"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/events"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+var (
+	alice = types.Address{1}
+	bob   = types.Address{2}
+	carol = types.Address{3}
+)
+
+var swapped = events.Event{Name: "Swapped", Args: []abi.Arg{{Type: "uint64", Name: "in"}}}
+
+func swappedLog(t *testing.T, in uint64) string {
+	typ, err := abi.TypeOf("(uint64)")
+	require.NoError(t, err)
+	encoded, err := typ.Encode([]interface{}{in})
+	require.NoError(t, err)
+	return string(append(swapped.GetSelector(), encoded...))
+}
+
+// mockNode is an algod mock making a block with a payment from alice to bob
+// and a call of application 7 paying carol and logging Swapped, each time
+// asked to wait for a block. Once at forkRound, if set, it switches to
+// another chain from round 12.
+type mockNode struct {
+	t         *testing.T
+	mu        sync.Mutex
+	round     uint64
+	forkRound uint64
+	forked    bool
+	syncRound uint64
+}
+
+func (n *mockNode) hash(round uint64) types.BlockHash {
+	hash := types.BlockHash{byte(round)}
+	if n.forked && round >= 12 {
+		hash[1] = 1
+	}
+	return hash
+}
+
+func (n *mockNode) block(round uint64) types.Block {
+	var block types.Block
+	block.Round = types.Round(round)
+	block.Branch = n.hash(round - 1)
+	block.GenesisID = "mock"
+
+	var pay types.SignedTxnInBlock
+	pay.Txn.Type = types.PaymentTx
+	pay.Txn.Sender = alice
+	pay.Txn.Receiver = bob
+	pay.Txn.Note = []byte(fmt.Sprintf("round %d", round))
+	pay.HasGenesisID = true
+
+	var call types.SignedTxnInBlock
+	call.Txn.Type = types.ApplicationCallTx
+	call.Txn.Sender = alice
+	call.Txn.ApplicationID = 7
+	call.EvalDelta.Logs = []string{"not an event", swappedLog(n.t, round)}
+	var inner types.SignedTxnWithAD
+	inner.Txn.Type = types.PaymentTx
+	inner.Txn.Sender = crypto.GetApplicationAddress(7)
+	inner.Txn.Receiver = carol
+	call.EvalDelta.InnerTxns = []types.SignedTxnWithAD{inner}
+
+	block.Payset = types.Payset{pay, call}
+	return block
+}
+
+func (n *mockNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	path := r.URL.Path
+	switch {
+	case path == "/v2/status":
+		w.Write(json.Encode(models.NodeStatus{LastRound: n.round}))
+	case strings.HasPrefix(path, "/v2/status/wait-for-block-after/"):
+		after, _ := strconv.ParseUint(strings.TrimPrefix(path, "/v2/status/wait-for-block-after/"), 10, 64)
+		if n.round <= after {
+			n.round = after + 1
+		}
+		if n.forkRound != 0 && n.round >= n.forkRound {
+			n.forked = true
+		}
+		w.Write(json.Encode(models.NodeStatus{LastRound: n.round}))
+	case strings.HasPrefix(path, "/v2/ledger/sync/"):
+		n.syncRound, _ = strconv.ParseUint(strings.TrimPrefix(path, "/v2/ledger/sync/"), 10, 64)
+	case strings.HasPrefix(path, "/v2/blocks/") && strings.HasSuffix(path, "/hash"):
+		round, _ := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(path, "/v2/blocks/"), "/hash"), 10, 64)
+		hash := n.hash(round)
+		w.Write(json.Encode(models.BlockHashResponse{Blockhash: base64.StdEncoding.EncodeToString(hash[:])}))
+	case strings.HasPrefix(path, "/v2/blocks/"):
+		round, _ := strconv.ParseUint(strings.TrimPrefix(path, "/v2/blocks/"), 10, 64)
+		if round > n.round {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(msgpack.Encode(models.BlockResponse{Block: n.block(round)}))
+	default:
+		n.t.Errorf("unexpected request %s", path)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func makeMockClient(t *testing.T, node *mockNode) *algod.Client {
+	node.t = t
+	server := httptest.NewServer(node)
+	t.Cleanup(server.Close)
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+	return client
+}
+
+func TestSubscriber(t *testing.T) {
+	node := &mockNode{round: 10}
+	watermark := FileWatermark(filepath.Join(t.TempDir(), "watermark"))
+	s := New(makeMockClient(t, node), Config{Watermark: watermark, Follower: true})
+
+	decoder, err := events.MakeDecoder([]events.Event{swapped})
+	require.NoError(t, err)
+	payments := s.Subscribe(Filter{Sender: alice, Receiver: bob, NotePrefix: []byte("round")}, 0)
+	inner := s.Subscribe(Filter{Receiver: carol, Inner: true}, 0)
+	swaps := s.Subscribe(Filter{AppID: 7, Events: &decoder}, 0)
+	notInner := s.Subscribe(Filter{Receiver: carol}, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	for round := uint64(11); round <= 13; round++ {
+		payment := <-payments
+		require.Equal(t, round, payment.Round)
+		require.Equal(t, 0, payment.Intra)
+		require.Equal(t, "mock", payment.Txn.Txn.GenesisID)
+		require.Equal(t, crypto.GetTxID(payment.Txn.Txn), payment.TxID)
+		require.Equal(t, fmt.Sprintf("round %d", round), string(payment.Txn.Txn.Note))
+
+		swap := <-swaps
+		require.Equal(t, 1, swap.Intra)
+		require.Len(t, swap.Events, 1)
+		require.Equal(t, []interface{}{round}, swap.Events[0].Values)
+
+		paid := <-inner
+		require.Equal(t, round, paid.Round)
+		require.Equal(t, []int{0}, paid.Path)
+		require.Equal(t, swap.TxID, paid.ParentID)
+		id, err := crypto.InnerTransactionID(swap.TxID, 0, paid.Txn.Txn)
+		require.NoError(t, err)
+		require.Equal(t, id, paid.TxID)
+	}
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+	_, open := <-notInner
+	require.False(t, open)
+
+	last, err := watermark.Load()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, last, uint64(12))
+	// The sync round follows the watermark, unless cancelled in between.
+	node.mu.Lock()
+	require.Contains(t, []uint64{last, last + 1}, node.syncRound)
+	node.mu.Unlock()
+
+	// A new subscriber resumes after the watermark.
+	s = New(makeMockClient(t, node), Config{Watermark: watermark})
+	payments = s.Subscribe(Filter{Sender: alice, Receiver: bob}, 0)
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	go func() { done <- s.Run(ctx) }()
+	require.Equal(t, last+1, (<-payments).Round)
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestSubscriberReorg(t *testing.T) {
+	node := &mockNode{round: 10, forkRound: 14}
+	var reorgs []uint64
+	s := New(makeMockClient(t, node), Config{
+		StartRound: 11,
+		ReorgDepth: 5,
+		OnReorg:    func(round uint64) { reorgs = append(reorgs, round) },
+	})
+	payments := s.Subscribe(Filter{Sender: alice, Receiver: bob}, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	var rounds []uint64
+	for len(rounds) < 6 {
+		rounds = append(rounds, (<-payments).Round)
+	}
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+
+	// Rounds 12 and 13 are processed again on the new chain.
+	require.Equal(t, []uint64{11, 12, 13, 12, 13, 14}, rounds)
+	require.Equal(t, []uint64{11}, reorgs)
+}
+
+func TestSubscriberReorgTooDeep(t *testing.T) {
+	node := &mockNode{round: 10, forkRound: 14}
+	s := New(makeMockClient(t, node), Config{StartRound: 11, ReorgDepth: 2})
+	require.ErrorIs(t, s.Run(context.Background()), errReorgTooDeep)
+}
+
+func TestFilter(t *testing.T) {
+	var txn types.SignedTxnWithAD
+	txn.Txn.Sender = alice
+	txn.Txn.AssetReceiver = bob
+	txn.Txn.XferAsset = 5
+	txn.Txn.Note = []byte("hello")
+
+	match := func(f Filter) bool {
+		_, ok := f.match(txn)
+		return ok
+	}
+	require.True(t, match(Filter{}))
+	require.True(t, match(Filter{Sender: alice, Receiver: bob, AssetID: 5, NotePrefix: []byte("he")}))
+	require.False(t, match(Filter{Sender: bob}))
+	require.False(t, match(Filter{Receiver: alice}))
+	require.False(t, match(Filter{AssetID: 6}))
+	require.False(t, match(Filter{AppID: 5}))
+	require.False(t, match(Filter{NotePrefix: []byte("world")}))
+	require.False(t, match(Filter{Match: func(types.SignedTxnWithAD) bool { return false }}))
+
+	decoder, err := events.MakeDecoder([]events.Event{swapped})
+	require.NoError(t, err)
+	require.False(t, match(Filter{Events: &decoder}))
+
+	// Created applications and assets match their ID.
+	txn = types.SignedTxnWithAD{}
+	txn.ApplyData.ApplicationID = 8
+	require.True(t, match(Filter{AppID: 8}))
+	txn.ApplyData.ConfigAsset = 9
+	require.True(t, match(Filter{AssetID: 9}))
+}
+
+func TestWalkInnerTransactionIDs(t *testing.T) {
+	var top, call, paid types.SignedTxnWithAD
+	top.Txn = types.Transaction{Type: types.ApplicationCallTx, Header: types.Header{Sender: alice, GenesisID: "mock"}}
+	call.Txn = types.Transaction{Type: types.ApplicationCallTx, Header: types.Header{Sender: bob}}
+	paid.Txn = types.Transaction{Type: types.PaymentTx, Header: types.Header{Sender: bob}, PaymentTxnFields: types.PaymentTxnFields{Receiver: carol}}
+	call.EvalDelta.InnerTxns = []types.SignedTxnWithAD{paid}
+	top.EvalDelta.InnerTxns = []types.SignedTxnWithAD{paid, call}
+
+	var matched []Match
+	root := Match{Round: 3, TxID: crypto.GetTxID(top.Txn), Txn: top}
+	err := walk(root, []subscription{{filter: Filter{Inner: true}}}, func(_ subscription, m Match) error {
+		matched = append(matched, m)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, matched, 4)
+
+	callID, err := crypto.InnerTransactionID(root.TxID, 1, call.Txn)
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, matched[2].Path)
+	require.Equal(t, callID, matched[2].TxID)
+
+	// the nested payment derives from the plain ID of the call that issued it
+	nestedID, err := crypto.InnerTransactionID(crypto.GetTxID(call.Txn), 0, paid.Txn)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 0}, matched[3].Path)
+	require.Equal(t, nestedID, matched[3].TxID)
+	require.Equal(t, callID, matched[3].ParentID)
+}
+
+func TestMemoryWatermark(t *testing.T) {
+	var w MemoryWatermark
+	round, err := w.Load()
+	require.NoError(t, err)
+	require.Zero(t, round)
+	require.NoError(t, w.Save(42))
+	round, err = w.Load()
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), round)
+}
+
+// indexerTxID returns an ID of the transaction at intra in round.
+func indexerTxID(round uint64, intra byte) string {
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte{31: intra, 0: byte(round), 1: byte(round >> 8)})
+}
+
+// indexerTransactions returns the transactions of mockNode blocks as returned
+// by the indexer.
+func indexerTransactions(t *testing.T, round uint64) []models.Transaction {
+	pay := models.Transaction{
+		Id:                 indexerTxID(round, 0),
+		Type:               "pay",
+		ConfirmedRound:     round,
+		Sender:             alice.String(),
+		Note:               []byte(fmt.Sprintf("round %d", round)),
+		PaymentTransaction: models.TransactionPayment{Receiver: bob.String(), Amount: 5},
+	}
+	call := models.Transaction{
+		Id:                     indexerTxID(round, 1),
+		Type:                   "appl",
+		ConfirmedRound:         round,
+		IntraRoundOffset:       1,
+		Sender:                 alice.String(),
+		ApplicationTransaction: models.TransactionApplication{ApplicationId: 7, OnCompletion: "noop"},
+		Logs:                   [][]byte{[]byte(swappedLog(t, round))},
+		InnerTxns: []models.Transaction{{
+			Type:               "pay",
+			Sender:             crypto.GetApplicationAddress(7).String(),
+			PaymentTransaction: models.TransactionPayment{Receiver: carol.String()},
+		}},
+	}
+	return []models.Transaction{pay, call}
+}
+
+func TestSubscriberReplay(t *testing.T) {
+	var mu sync.Mutex
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			w.Write(json.Encode(models.HealthCheck{Round: 1008}))
+		case "/v2/transactions":
+			mu.Lock()
+			queries = append(queries, r.URL.RawQuery)
+			mu.Unlock()
+			query := r.URL.Query()
+			if query.Get("next") != "" {
+				w.Write(json.Encode(models.TransactionsResponse{}))
+				return
+			}
+			first, _ := strconv.ParseUint(query.Get("min-round"), 10, 64)
+			last, _ := strconv.ParseUint(query.Get("max-round"), 10, 64)
+			var response models.TransactionsResponse
+			for round := first; round <= last; round++ {
+				response.Transactions = append(response.Transactions, indexerTransactions(t, round)...)
+			}
+			response.NextToken = "more"
+			w.Write(json.Encode(response))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	indexerClient, err := indexer.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	node := &mockNode{round: 1010}
+	watermark := &MemoryWatermark{}
+	s := New(makeMockClient(t, node), Config{StartRound: 5, Watermark: watermark, Indexer: indexerClient})
+	payments := s.Subscribe(Filter{Sender: alice, Receiver: bob}, 0)
+	inner := s.Subscribe(Filter{Receiver: carol, Inner: true}, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	// Rounds up to 1008, the last of the indexer, are replayed from it, and
+	// the following ones followed on the node.
+	for round := uint64(5); round <= 1010; round++ {
+		payment := <-payments
+		require.Equal(t, round, payment.Round)
+		require.Equal(t, fmt.Sprintf("round %d", round), string(payment.Txn.Txn.Note))
+		if round <= 1008 {
+			require.Equal(t, indexerTxID(round, 0), payment.TxID)
+			require.Equal(t, types.MicroAlgos(5), payment.Txn.Txn.Amount)
+		}
+
+		paid := <-inner
+		require.Equal(t, round, paid.Round)
+		require.Equal(t, 1, paid.Intra)
+		require.Equal(t, []int{0}, paid.Path)
+		require.Equal(t, carol, paid.Txn.Txn.Receiver)
+		if round == 1008 {
+			mark, err := watermark.Load()
+			require.NoError(t, err)
+			require.Equal(t, uint64(1004), mark)
+		}
+	}
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Contains(t, queries, fmt.Sprintf("address=%s&address-role=sender&limit=1000&max-round=1004&min-round=5", alice))
+	require.Contains(t, queries, fmt.Sprintf("address=%s&address-role=receiver&limit=1000&max-round=1008&min-round=1005", carol))
+}
+
+func TestFromIndexerTransaction(t *testing.T) {
+	converted, err := fromIndexerTransaction(models.Transaction{
+		Type:        "acfg",
+		Sender:      alice.String(),
+		Fee:         1000,
+		FirstValid:  1,
+		LastValid:   1001,
+		GenesisHash: []byte{1, 2},
+		Lease:       []byte{3},
+		AssetConfigTransaction: models.TransactionAssetConfig{Params: models.AssetParams{
+			Total:    100,
+			Decimals: 2,
+			UnitName: "U",
+			Name:     "Unit",
+			Manager:  bob.String(),
+		}},
+		CreatedAssetIndex: 12,
+		Signature: models.TransactionSignature{Multisig: models.TransactionSignatureMultisig{
+			Version:      1,
+			Threshold:    1,
+			Subsignature: []models.TransactionSignatureMultisigSubsignature{{PublicKey: bob[:], Signature: []byte{4}}},
+		}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.AssetConfigTx, converted.Txn.Type)
+	require.Equal(t, alice, converted.Txn.Sender)
+	require.Equal(t, types.MicroAlgos(1000), converted.Txn.Fee)
+	require.Equal(t, byte(2), converted.Txn.GenesisHash[1])
+	require.Equal(t, byte(3), converted.Txn.Lease[0])
+	require.Equal(t, uint64(100), converted.Txn.AssetParams.Total)
+	require.Equal(t, uint32(2), converted.Txn.AssetParams.Decimals)
+	require.Equal(t, "Unit", converted.Txn.AssetParams.AssetName)
+	require.Equal(t, bob, converted.Txn.AssetParams.Manager)
+	require.Equal(t, uint64(12), converted.ConfigAsset)
+	require.Equal(t, uint8(1), converted.Msig.Version)
+	require.Equal(t, byte(4), converted.Msig.Subsigs[0].Sig[0])
+
+	converted, err = fromIndexerTransaction(models.Transaction{
+		Type:                   "appl",
+		Sender:                 alice.String(),
+		ApplicationTransaction: models.TransactionApplication{ApplicationId: 7, OnCompletion: "optin", Accounts: []string{bob.String()}, ForeignAssets: []uint64{3}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, types.OptInOC, converted.Txn.OnCompletion)
+	require.Equal(t, []types.Address{bob}, converted.Txn.Accounts)
+	require.Equal(t, []types.AssetIndex{3}, converted.Txn.ForeignAssets)
+
+	_, err = fromIndexerTransaction(models.Transaction{Sender: "invalid"})
+	require.Error(t, err)
+	_, err = fromIndexerTransaction(models.Transaction{Sender: alice.String(), ApplicationTransaction: models.TransactionApplication{OnCompletion: "unknown"}})
+	require.ErrorContains(t, err, "unknown on-completion")
+}
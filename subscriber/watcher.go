@@ -0,0 +1,168 @@
+package subscriber
+
+import (
+	"sync"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// AccountEventType is the type of an AccountEvent.
+type AccountEventType string
+
+const (
+	// AlgoIn is a payment, or the remainder of a closed account, received.
+	AlgoIn AccountEventType = "algo-in"
+	// AlgoOut is a payment sent, or the remainder of the account on closing.
+	AlgoOut AccountEventType = "algo-out"
+	// AssetIn is an amount of an asset received.
+	AssetIn AccountEventType = "asset-in"
+	// AssetOut is an amount of an asset sent, clawed back or closed out.
+	AssetOut AccountEventType = "asset-out"
+	// AssetSelfTransfer is a transfer of no units of an asset from the
+	// account to itself, which is how an account opts in to an asset. An
+	// account already opted in may make one too, so it is not necessarily an
+	// opt-in: the transaction does not tell whether the account held the
+	// asset before.
+	AssetSelfTransfer AccountEventType = "asset-self-transfer"
+	// AppOptIn is an opt-in to an application.
+	AppOptIn AccountEventType = "app-opt-in"
+	// Rekey is a change of the authorized address of the account.
+	Rekey AccountEventType = "rekey"
+)
+
+// AccountEvent is a change of a watched account.
+type AccountEvent struct {
+	Type AccountEventType
+	// Address is the watched account.
+	Address types.Address
+	// Counterparty is the other account of a transfer, the sender for
+	// incoming ones and the receiver for outgoing ones, or the new
+	// authorized address of a rekey.
+	Counterparty types.Address
+	// AssetID is the asset of asset events, AppID the application of
+	// AppOptIn.
+	AssetID uint64
+	AppID   uint64
+	// Amount is the amount transferred, in microAlgos or base units of the
+	// asset.
+	Amount uint64
+
+	// Match is the transaction making the change.
+	Match Match
+}
+
+// Watcher reports the changes of a set of accounts: transfers in and out,
+// application opt-ins, asset self-transfers and rekeys, by top-level and
+// inner transactions. Fees and rewards are not reported.
+type Watcher struct {
+	mu        sync.RWMutex
+	addresses map[types.Address]bool
+	events    chan AccountEvent
+}
+
+// NewWatcher returns a Watcher of addresses, subscribed to s, whose events
+// are buffered up to buffer of them. It must be called before s.Run, and its
+// events are closed once s.Run returns.
+func NewWatcher(s *Subscriber, addresses []types.Address, buffer int) *Watcher {
+	w := &Watcher{addresses: make(map[types.Address]bool), events: make(chan AccountEvent, buffer)}
+	for _, address := range addresses {
+		w.addresses[address] = true
+	}
+	matches := s.Subscribe(Filter{Match: w.involves, Inner: true}, buffer)
+	go func() {
+		defer close(w.events)
+		for m := range matches {
+			for _, event := range w.accountEvents(m) {
+				w.events <- event
+			}
+		}
+	}()
+	return w
+}
+
+// Events returns the channel of the events of the watched accounts.
+func (w *Watcher) Events() <-chan AccountEvent {
+	return w.events
+}
+
+// Add starts watching address, from the round being processed.
+func (w *Watcher) Add(address types.Address) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.addresses[address] = true
+}
+
+// Remove stops watching address.
+func (w *Watcher) Remove(address types.Address) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.addresses, address)
+}
+
+func (w *Watcher) watched(address types.Address) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return !address.IsZero() && w.addresses[address]
+}
+
+// involves returns whether txn may change a watched account.
+func (w *Watcher) involves(txn types.SignedTxnWithAD) bool {
+	tx := txn.Txn
+	for _, address := range []types.Address{tx.Sender, tx.Receiver, tx.CloseRemainderTo, tx.AssetSender, tx.AssetReceiver, tx.AssetCloseTo} {
+		if w.watched(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// accountEvents returns the events of the watched accounts changed by m.
+func (w *Watcher) accountEvents(m Match) []AccountEvent {
+	var events []AccountEvent
+	transfer := func(from, to types.Address, amount uint64, in, out AccountEventType, assetID uint64) {
+		if w.watched(from) {
+			events = append(events, AccountEvent{Type: out, Address: from, Counterparty: to, AssetID: assetID, Amount: amount, Match: m})
+		}
+		if w.watched(to) {
+			events = append(events, AccountEvent{Type: in, Address: to, Counterparty: from, AssetID: assetID, Amount: amount, Match: m})
+		}
+	}
+
+	tx := m.Txn.Txn
+	switch tx.Type {
+	case types.PaymentTx:
+		transfer(tx.Sender, tx.Receiver, uint64(tx.Amount), AlgoIn, AlgoOut, 0)
+		if !tx.CloseRemainderTo.IsZero() {
+			transfer(tx.Sender, tx.CloseRemainderTo, uint64(m.Txn.ClosingAmount), AlgoIn, AlgoOut, 0)
+		}
+	case types.AssetTransferTx:
+		assetID := uint64(tx.XferAsset)
+		from := tx.Sender
+		if !tx.AssetSender.IsZero() {
+			from = tx.AssetSender
+		}
+		if tx.AssetSender.IsZero() && tx.AssetReceiver == tx.Sender && tx.AssetAmount == 0 {
+			if w.watched(tx.Sender) {
+				events = append(events, AccountEvent{Type: AssetSelfTransfer, Address: tx.Sender, AssetID: assetID, Match: m})
+			}
+			break
+		}
+		transfer(from, tx.AssetReceiver, tx.AssetAmount, AssetIn, AssetOut, assetID)
+		if !tx.AssetCloseTo.IsZero() {
+			transfer(from, tx.AssetCloseTo, m.Txn.AssetClosingAmount, AssetIn, AssetOut, assetID)
+		}
+	case types.ApplicationCallTx:
+		if tx.OnCompletion == types.OptInOC && w.watched(tx.Sender) {
+			appID := uint64(tx.ApplicationID)
+			if appID == 0 {
+				appID = m.Txn.ApplicationID
+			}
+			events = append(events, AccountEvent{Type: AppOptIn, Address: tx.Sender, AppID: appID, Match: m})
+		}
+	}
+
+	if !tx.RekeyTo.IsZero() && w.watched(tx.Sender) {
+		events = append(events, AccountEvent{Type: Rekey, Address: tx.Sender, Counterparty: tx.RekeyTo, Match: m})
+	}
+	return events
+}
@@ -0,0 +1,102 @@
+package subscriber
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestWatcher(t *testing.T) {
+	node := &mockNode{round: 10}
+	s := New(makeMockClient(t, node), Config{StartRound: 11})
+	w := NewWatcher(s, []types.Address{bob}, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	event := <-w.Events()
+	require.Equal(t, AlgoIn, event.Type)
+	require.Equal(t, bob, event.Address)
+	require.Equal(t, alice, event.Counterparty)
+	require.Equal(t, uint64(11), event.Match.Round)
+
+	// Inner transactions are watched too.
+	w.Add(carol)
+	w.Remove(bob)
+	for event = range w.Events() {
+		if event.Address == carol {
+			break
+		}
+		require.Equal(t, bob, event.Address)
+	}
+	require.Equal(t, AlgoIn, event.Type)
+	require.Equal(t, []int{0}, event.Match.Path)
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+	for range w.Events() {
+	}
+}
+
+func TestAccountEvents(t *testing.T) {
+	w := &Watcher{addresses: map[types.Address]bool{alice: true, bob: true}}
+	events := func(tx types.Transaction, ad types.ApplyData) []AccountEvent {
+		m := Match{Txn: types.SignedTxnWithAD{SignedTxn: types.SignedTxn{Txn: tx}, ApplyData: ad}}
+		found := w.accountEvents(m)
+		for i := range found {
+			require.Equal(t, m, found[i].Match)
+			found[i].Match = Match{}
+		}
+		return found
+	}
+
+	var pay types.Transaction
+	pay.Type = types.PaymentTx
+	pay.Sender = alice
+	pay.Receiver = carol
+	pay.Amount = 10
+	pay.CloseRemainderTo = bob
+	require.Equal(t, []AccountEvent{
+		{Type: AlgoOut, Address: alice, Counterparty: carol, Amount: 10},
+		{Type: AlgoOut, Address: alice, Counterparty: bob, Amount: 90},
+		{Type: AlgoIn, Address: bob, Counterparty: alice, Amount: 90},
+	}, events(pay, types.ApplyData{ClosingAmount: 90}))
+
+	var xfer types.Transaction
+	xfer.Type = types.AssetTransferTx
+	xfer.Sender = carol
+	xfer.AssetSender = alice
+	xfer.AssetReceiver = carol
+	xfer.XferAsset = 5
+	xfer.AssetAmount = 3
+	require.Equal(t, []AccountEvent{
+		{Type: AssetOut, Address: alice, Counterparty: carol, AssetID: 5, Amount: 3},
+	}, events(xfer, types.ApplyData{}))
+
+	var optIn types.Transaction
+	optIn.Type = types.AssetTransferTx
+	optIn.Sender = bob
+	optIn.AssetReceiver = bob
+	optIn.XferAsset = 5
+	optIn.RekeyTo = carol
+	require.Equal(t, []AccountEvent{
+		{Type: AssetSelfTransfer, Address: bob, AssetID: 5},
+		{Type: Rekey, Address: bob, Counterparty: carol},
+	}, events(optIn, types.ApplyData{}))
+
+	var appOptIn types.Transaction
+	appOptIn.Type = types.ApplicationCallTx
+	appOptIn.Sender = alice
+	appOptIn.OnCompletion = types.OptInOC
+	appOptIn.ApplicationID = 7
+	require.Equal(t, []AccountEvent{{Type: AppOptIn, Address: alice, AppID: 7}}, events(appOptIn, types.ApplyData{}))
+
+	require.True(t, w.involves(types.SignedTxnWithAD{SignedTxn: types.SignedTxn{Txn: xfer}}))
+	pay.Sender = carol
+	pay.CloseRemainderTo = types.Address{}
+	require.False(t, w.involves(types.SignedTxnWithAD{SignedTxn: types.SignedTxn{Txn: pay}}))
+}
@@ -0,0 +1,75 @@
+package subscriber
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Watermark persists the last round a Subscriber processed, for it to resume
+// from the next one.
+type Watermark interface {
+	// Load returns the last processed round, zero if none.
+	Load() (uint64, error)
+	// Save records round as the last processed one.
+	Save(round uint64) error
+}
+
+// MemoryWatermark keeps the last processed round in memory.
+type MemoryWatermark struct {
+	mu    sync.Mutex
+	round uint64
+}
+
+// Load returns the last saved round.
+func (w *MemoryWatermark) Load() (uint64, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.round, nil
+}
+
+// Save records round.
+func (w *MemoryWatermark) Save(round uint64) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.round = round
+	return nil
+}
+
+// FileWatermark keeps the last processed round in the file at its path, in
+// decimal.
+type FileWatermark string
+
+// Load reads the round from the file, zero if it does not exist.
+func (w FileWatermark) Load() (uint64, error) {
+	data, err := os.ReadFile(string(w))
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// Save writes round to a temporary file, which then replaces the file so that
+// it is never left partially written.
+func (w FileWatermark) Save(round uint64) error {
+	tmp, err := os.CreateTemp(filepath.Dir(string(w)), filepath.Base(string(w))+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(strconv.FormatUint(round, 10) + "\n"); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), string(w))
+}
@@ -0,0 +1,15 @@
+package templates
+
+import (
+	"errors"
+)
+
+var errZeroReceiver = errors.New("the receiver of a contract cannot be the zero address")
+var errZeroOwner = errors.New("the owner of a contract cannot be the zero address")
+var errHashImageLen = errors.New("the hash image of a hash time-locked contract must be 32 bytes")
+var errPreimageMismatch = errors.New("the preimage does not hash to the hash image of the contract")
+var errZeroPeriod = errors.New("the period of a periodic payment must be positive")
+var errWindowTooLong = errors.New("the withdrawal window of a periodic payment cannot be longer than its period")
+var errZeroAmount = errors.New("the amount of a periodic payment must be positive")
+var errExpired = errors.New("the contract has expired")
+var errNotExpired = errors.New("the contract has not expired yet")
@@ -0,0 +1,192 @@
+package templates
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// HashFunction is the hash function of a hash time-locked contract.
+type HashFunction string
+
+const (
+	// SHA256 hashes the preimage with SHA-256.
+	SHA256 HashFunction = "sha256"
+	// SHA512T256 hashes the preimage with SHA-512/256.
+	SHA512T256 HashFunction = "sha512_256"
+	// Keccak256 hashes the preimage with the original Keccak-256, as Ethereum
+	// does, rather than the standardized SHA3-256.
+	Keccak256 HashFunction = "keccak256"
+)
+
+// Hash returns the hash of preimage with f.
+func (f HashFunction) Hash(preimage []byte) ([]byte, error) {
+	switch f {
+	case SHA256:
+		sum := sha256.Sum256(preimage)
+		return sum[:], nil
+	case SHA512T256:
+		sum := sha512.Sum512_256(preimage)
+		return sum[:], nil
+	case Keccak256:
+		h := sha3.NewLegacyKeccak256()
+		h.Write(preimage)
+		return h.Sum(nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported hash function %q", f)
+	}
+}
+
+// htlcTemplate closes the account to the receiver if the first argument is
+// the preimage of the hash image, or to the owner after the expiry round.
+const htlcTemplate = tealVersion + `txn TypeEnum
+int pay
+==
+txn Fee
+int TMPL_MAX_FEE
+<=
+&&
+txn RekeyTo
+global ZeroAddress
+==
+&&
+txn Amount
+int 0
+==
+&&
+txn CloseRemainderTo
+addr TMPL_RECEIVER
+==
+arg 0
+%s
+byte TMPL_HASH_IMAGE
+==
+&&
+txn CloseRemainderTo
+addr TMPL_OWNER
+==
+txn FirstValid
+int TMPL_EXPIRY_ROUND
+>
+&&
+||
+&&
+`
+
+// HTLC is a hash time-locked contract: its receiver gets its whole balance by
+// revealing the preimage of HashImage, and once ExpiryRound is passed its
+// owner gets it back.
+type HTLC struct {
+	Owner    types.Address
+	Receiver types.Address
+
+	HashFunction HashFunction
+	HashImage    []byte
+
+	ExpiryRound uint64
+
+	// MaxFee is the largest fee the transactions of the contract may pay.
+	MaxFee uint64
+}
+
+// Validate checks the parameters of h.
+func (h HTLC) Validate() error {
+	if h.Owner == (types.Address{}) {
+		return errZeroOwner
+	}
+	if h.Receiver == (types.Address{}) {
+		return errZeroReceiver
+	}
+	if _, err := h.HashFunction.Hash(nil); err != nil {
+		return err
+	}
+	if len(h.HashImage) != 32 {
+		return errHashImageLen
+	}
+	return nil
+}
+
+// Source returns the TEAL source of the contract.
+func (h HTLC) Source() (string, error) {
+	if err := h.Validate(); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(htlcTemplate, h.HashFunction), nil
+}
+
+func (h HTLC) templateValues() map[string]interface{} {
+	return map[string]interface{}{
+		"OWNER":        h.Owner,
+		"RECEIVER":     h.Receiver,
+		"HASH_IMAGE":   h.HashImage,
+		"EXPIRY_ROUND": h.ExpiryRound,
+		"MAX_FEE":      h.MaxFee,
+	}
+}
+
+// LogicSigAccount compiles the contract with algod into its escrow account,
+// which is to be funded with the amount to pay.
+func (h HTLC) LogicSigAccount(ctx context.Context, client *algod.Client) (crypto.LogicSigAccount, error) {
+	source, err := h.Source()
+	if err != nil {
+		return crypto.LogicSigAccount{}, err
+	}
+	return compileEscrow(ctx, client, source, h.templateValues())
+}
+
+// ClaimTransaction returns the transaction closing the escrow account to the
+// receiver, which must be valid before the expiry round. The fee of sp must not
+// exceed MaxFee.
+func (h HTLC) ClaimTransaction(escrow crypto.LogicSigAccount, sp types.SuggestedParams) (types.Transaction, error) {
+	if sp.FirstRoundValid > types.Round(h.ExpiryRound) {
+		return types.Transaction{}, errExpired
+	}
+	return h.closeTransaction(escrow, h.Receiver, sp)
+}
+
+// ClaimSigner returns the signer of the claim transaction, revealing preimage.
+func (h HTLC) ClaimSigner(escrow crypto.LogicSigAccount, preimage []byte) (transaction.LogicSigAccountTransactionSigner, error) {
+	hash, err := h.HashFunction.Hash(preimage)
+	if err != nil {
+		return transaction.LogicSigAccountTransactionSigner{}, err
+	}
+	if !bytes.Equal(hash, h.HashImage) {
+		return transaction.LogicSigAccountTransactionSigner{}, errPreimageMismatch
+	}
+	escrow.Lsig.Args = [][]byte{preimage}
+	return transaction.LogicSigAccountTransactionSigner{LogicSigAccount: escrow}, nil
+}
+
+// RefundTransaction returns the transaction closing the escrow account to the
+// owner, which must be valid after the expiry round. It is signed by the
+// escrow account without arguments. The fee of sp must not exceed MaxFee.
+func (h HTLC) RefundTransaction(escrow crypto.LogicSigAccount, sp types.SuggestedParams) (types.Transaction, error) {
+	if sp.FirstRoundValid <= types.Round(h.ExpiryRound) {
+		return types.Transaction{}, errNotExpired
+	}
+	return h.closeTransaction(escrow, h.Owner, sp)
+}
+
+func (h HTLC) closeTransaction(escrow crypto.LogicSigAccount, to types.Address, sp types.SuggestedParams) (types.Transaction, error) {
+	from, err := escrow.Address()
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	tx, err := transaction.MakePaymentTxn(from.String(), to.String(), 0, nil, to.String(), sp)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	if tx.Fee > types.MicroAlgos(h.MaxFee) {
+		return types.Transaction{}, fmt.Errorf("fee %d is more than the contract allows, %d", tx.Fee, h.MaxFee)
+	}
+	return tx, nil
+}
@@ -0,0 +1,183 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// periodicPaymentTemplate pays the amount to the receiver in transactions
+// valid from a multiple of the period for the window, with the lease, or
+// closes the account to the receiver after the expiry round.
+const periodicPaymentTemplate = tealVersion + `txn TypeEnum
+int pay
+==
+txn Fee
+int TMPL_MAX_FEE
+<=
+&&
+txn RekeyTo
+global ZeroAddress
+==
+&&
+txn FirstValid
+int TMPL_PERIOD
+%
+int 0
+==
+&&
+txn LastValid
+txn FirstValid
+int TMPL_WINDOW
++
+==
+&&
+txn Lease
+byte TMPL_LEASE
+==
+&&
+txn CloseRemainderTo
+global ZeroAddress
+==
+txn Receiver
+addr TMPL_RECEIVER
+==
+&&
+txn Amount
+int TMPL_AMOUNT
+==
+&&
+txn CloseRemainderTo
+addr TMPL_RECEIVER
+==
+txn FirstValid
+int TMPL_EXPIRY_ROUND
+>
+&&
+txn Amount
+int 0
+==
+&&
+||
+&&
+`
+
+// PeriodicPayment is a contract letting its receiver withdraw Amount once
+// every Period rounds, in a transaction valid for the Window rounds starting
+// at a multiple of the period. The lease of the withdrawals prevents a second
+// one in the same period. After ExpiryRound, the receiver may close the
+// account.
+//
+// A withdrawal cannot take the escrow account below the minimum balance,
+// which the account must hold on top of the amounts to pay; closing the
+// account gets it back.
+type PeriodicPayment struct {
+	Receiver types.Address
+	Amount   uint64
+
+	Period uint64
+	Window uint64
+
+	ExpiryRound uint64
+
+	// Lease identifies the withdrawals of the contract.
+	Lease [32]byte
+
+	// MaxFee is the largest fee the transactions of the contract may pay.
+	MaxFee uint64
+}
+
+// Validate checks the parameters of p.
+func (p PeriodicPayment) Validate() error {
+	if p.Receiver == (types.Address{}) {
+		return errZeroReceiver
+	}
+	if p.Amount == 0 {
+		return errZeroAmount
+	}
+	if p.Period == 0 {
+		return errZeroPeriod
+	}
+	if p.Window > p.Period {
+		return errWindowTooLong
+	}
+	return nil
+}
+
+func (p PeriodicPayment) templateValues() map[string]interface{} {
+	return map[string]interface{}{
+		"RECEIVER":     p.Receiver,
+		"AMOUNT":       p.Amount,
+		"PERIOD":       p.Period,
+		"WINDOW":       p.Window,
+		"EXPIRY_ROUND": p.ExpiryRound,
+		"LEASE":        p.Lease[:],
+		"MAX_FEE":      p.MaxFee,
+	}
+}
+
+// LogicSigAccount compiles the contract with algod into its escrow account,
+// which is to be funded with the amounts to pay and the minimum balance.
+func (p PeriodicPayment) LogicSigAccount(ctx context.Context, client *algod.Client) (crypto.LogicSigAccount, error) {
+	if err := p.Validate(); err != nil {
+		return crypto.LogicSigAccount{}, err
+	}
+	return compileEscrow(ctx, client, periodicPaymentTemplate, p.templateValues())
+}
+
+// WithdrawalTransaction returns the transaction withdrawing the amount of the
+// first period starting at or after the first round of sp. Its validity is set
+// by the contract, and the fee of sp must not exceed MaxFee. It is signed by
+// the escrow account without arguments.
+func (p PeriodicPayment) WithdrawalTransaction(escrow crypto.LogicSigAccount, sp types.SuggestedParams) (types.Transaction, error) {
+	if err := p.Validate(); err != nil {
+		return types.Transaction{}, err
+	}
+	first := (uint64(sp.FirstRoundValid) + p.Period - 1) / p.Period * p.Period
+	sp.FirstRoundValid = types.Round(first)
+	sp.LastRoundValid = types.Round(first + p.Window)
+	tx, err := p.transaction(escrow, p.Amount, sp)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	tx.Lease = p.Lease
+	return tx, nil
+}
+
+// CloseTransaction returns the transaction closing the escrow account to the
+// receiver, which must be valid after the expiry round. It is signed by the
+// escrow account without arguments. The fee of sp must not exceed MaxFee.
+//
+// The contract also requires the validity of the transaction to match a
+// withdrawal window, which CloseTransaction sets like WithdrawalTransaction.
+func (p PeriodicPayment) CloseTransaction(escrow crypto.LogicSigAccount, sp types.SuggestedParams) (types.Transaction, error) {
+	tx, err := p.WithdrawalTransaction(escrow, sp)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	if uint64(tx.FirstValid) <= p.ExpiryRound {
+		return types.Transaction{}, errNotExpired
+	}
+	tx.Amount = 0
+	tx.CloseRemainderTo = p.Receiver
+	return tx, nil
+}
+
+func (p PeriodicPayment) transaction(escrow crypto.LogicSigAccount, amount uint64, sp types.SuggestedParams) (types.Transaction, error) {
+	from, err := escrow.Address()
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	tx, err := transaction.MakePaymentTxn(from.String(), p.Receiver.String(), amount, nil, "", sp)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	if tx.Fee > types.MicroAlgos(p.MaxFee) {
+		return types.Transaction{}, fmt.Errorf("fee %d is more than the contract allows, %d", tx.Fee, p.MaxFee)
+	}
+	return tx, nil
+}
@@ -0,0 +1,42 @@
+// Package templates provides ready-made stateless contracts, escrow accounts
+// whose logic is a TEAL template filled with the parameters of the contract:
+//
+//   - HTLC, a hash time-locked contract paying its receiver the holder of a
+//     secret, or refunding its owner once expired
+//   - PeriodicPayment, letting its receiver withdraw a fixed amount every
+//     period, and close the account once expired
+//
+// Programs are compiled by algod. Their transactions pay a fee of at most the
+// MaxFee of the contract, which may be zero: fee pooling lets another
+// transaction of the group pay for them.
+package templates
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/logic"
+)
+
+// tealVersion is the TEAL version of the templates.
+const tealVersion = "#pragma version 8\n"
+
+// compileEscrow fills a TEAL template with values and compiles it with algod
+// into an escrow account.
+func compileEscrow(ctx context.Context, client *algod.Client, template string, values map[string]interface{}) (crypto.LogicSigAccount, error) {
+	source, err := logic.SubstituteTemplate(template, values)
+	if err != nil {
+		return crypto.LogicSigAccount{}, err
+	}
+	response, err := client.TealCompile([]byte(source)).Do(ctx)
+	if err != nil {
+		return crypto.LogicSigAccount{}, err
+	}
+	program, err := base64.StdEncoding.DecodeString(response.Result)
+	if err != nil {
+		return crypto.LogicSigAccount{}, err
+	}
+	return crypto.MakeLogicSigAccountEscrowChecked(program, nil)
+}
@@ -0,0 +1,143 @@
+package templates
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// compileServer returns an algod client whose compiler records the source and
+// returns a program derived from it.
+func compileServer(t *testing.T, source *string) *algod.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/teal/compile", r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		*source = string(body)
+		sum := sha256.Sum256(body)
+		program := append([]byte{0x08}, sum[:]...)
+		w.Write(json.Encode(models.CompileResponse{Result: base64.StdEncoding.EncodeToString(program)}))
+	}))
+	t.Cleanup(server.Close)
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+	return client
+}
+
+func testParams(first uint64, fee uint64) types.SuggestedParams {
+	return types.SuggestedParams{Fee: types.MicroAlgos(fee), FlatFee: true, FirstRoundValid: types.Round(first), LastRoundValid: types.Round(first + 1000), GenesisHash: make([]byte, 32)}
+}
+
+func TestHTLC(t *testing.T) {
+	owner, receiver := crypto.GenerateAccount().Address, crypto.GenerateAccount().Address
+	preimage := []byte("secret")
+	image := sha256.Sum256(preimage)
+	h := HTLC{Owner: owner, Receiver: receiver, HashFunction: SHA256, HashImage: image[:], ExpiryRound: 1000, MaxFee: 2000}
+
+	var source string
+	escrow, err := h.LogicSigAccount(context.Background(), compileServer(t, &source))
+	require.NoError(t, err)
+	require.Contains(t, source, "addr "+receiver.String()+"\n")
+	require.Contains(t, source, "addr "+owner.String()+"\n")
+	require.Contains(t, source, "arg 0\nsha256\n")
+	require.Contains(t, source, "int 1000\n>")
+	require.NotContains(t, source, "TMPL_")
+	escrowAddr, err := escrow.Address()
+	require.NoError(t, err)
+
+	claim, err := h.ClaimTransaction(escrow, testParams(500, 0))
+	require.NoError(t, err)
+	require.Equal(t, escrowAddr, claim.Sender)
+	require.Equal(t, receiver, claim.CloseRemainderTo)
+	require.Zero(t, claim.Amount)
+	_, err = h.ClaimTransaction(escrow, testParams(1001, 0))
+	require.ErrorIs(t, err, errExpired)
+	_, err = h.ClaimTransaction(escrow, testParams(500, 3000))
+	require.Error(t, err)
+
+	signer, err := h.ClaimSigner(escrow, preimage)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{preimage}, signer.LogicSigAccount.Lsig.Args)
+	require.Empty(t, escrow.Lsig.Args)
+	_, err = h.ClaimSigner(escrow, []byte("wrong"))
+	require.ErrorIs(t, err, errPreimageMismatch)
+
+	refund, err := h.RefundTransaction(escrow, testParams(1001, 1000))
+	require.NoError(t, err)
+	require.Equal(t, owner, refund.CloseRemainderTo)
+	_, err = h.RefundTransaction(escrow, testParams(1000, 1000))
+	require.ErrorIs(t, err, errNotExpired)
+
+	h.HashImage = image[:31]
+	require.ErrorIs(t, h.Validate(), errHashImageLen)
+	h.HashImage, h.HashFunction = image[:], "md5"
+	require.Error(t, h.Validate())
+}
+
+func TestHTLCKeccak256(t *testing.T) {
+	empty, err := Keccak256.Hash(nil)
+	require.NoError(t, err)
+	require.Equal(t, "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470", hex.EncodeToString(empty))
+
+	preimage := []byte("secret")
+	image, err := Keccak256.Hash(preimage)
+	require.NoError(t, err)
+	h := HTLC{Owner: crypto.GenerateAccount().Address, Receiver: crypto.GenerateAccount().Address, HashFunction: Keccak256, HashImage: image, ExpiryRound: 1000, MaxFee: 2000}
+	require.NoError(t, h.Validate())
+
+	var source string
+	escrow, err := h.LogicSigAccount(context.Background(), compileServer(t, &source))
+	require.NoError(t, err)
+	require.Contains(t, source, "arg 0\nkeccak256\n")
+	_, err = h.ClaimSigner(escrow, preimage)
+	require.NoError(t, err)
+}
+
+func TestPeriodicPayment(t *testing.T) {
+	receiver := crypto.GenerateAccount().Address
+	p := PeriodicPayment{Receiver: receiver, Amount: 5000, Period: 100, Window: 10, ExpiryRound: 1000, Lease: [32]byte{1}, MaxFee: 1000}
+
+	var source string
+	escrow, err := p.LogicSigAccount(context.Background(), compileServer(t, &source))
+	require.NoError(t, err)
+	require.Contains(t, source, "byte 0x01"+strings.Repeat("00", 31)+"\n")
+	require.Contains(t, source, "int 5000\n")
+	require.NotContains(t, source, "TMPL_")
+
+	tx, err := p.WithdrawalTransaction(escrow, testParams(250, 1000))
+	require.NoError(t, err)
+	require.Equal(t, types.Round(300), tx.FirstValid)
+	require.Equal(t, types.Round(310), tx.LastValid)
+	require.Equal(t, p.Lease, tx.Lease)
+	require.Equal(t, types.MicroAlgos(5000), tx.Amount)
+	require.Equal(t, receiver, tx.Receiver)
+
+	tx, err = p.WithdrawalTransaction(escrow, testParams(300, 1000))
+	require.NoError(t, err)
+	require.Equal(t, types.Round(300), tx.FirstValid)
+
+	_, err = p.CloseTransaction(escrow, testParams(950, 1000))
+	require.ErrorIs(t, err, errNotExpired)
+	tx, err = p.CloseTransaction(escrow, testParams(1001, 1000))
+	require.NoError(t, err)
+	require.Equal(t, types.Round(1100), tx.FirstValid)
+	require.Zero(t, tx.Amount)
+	require.Equal(t, receiver, tx.CloseRemainderTo)
+
+	p.Window = 101
+	require.ErrorIs(t, p.Validate(), errWindowTooLong)
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/gob"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"math/rand"
@@ -1657,7 +1658,7 @@ func tealCompile(filename string) (err error) {
 		tealCompleResult.response = result
 		return
 	}
-	if _, ok := err.(commonV2.BadRequest); ok {
+	if errors.Is(err, commonV2.BadRequest{}) {
 		tealCompleResult.status = 400
 		tealCompleResult.response.Hash = ""
 		tealCompleResult.response.Result = ""
@@ -0,0 +1,375 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/indexer"
+	"github.com/algorand/go-algorand-sdk/v2/logic"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// AppDeployNoteDappName is the ARC-2 dapp name of the notes recording the
+// metadata of deployed applications, shared with AlgoKit so that both find the
+// applications deployed by the other.
+const AppDeployNoteDappName = "ALGOKIT_DEPLOYER"
+
+// appDeployNotePrefix starts every deployment note, whose data is JSON.
+var appDeployNotePrefix = []byte(AppDeployNoteDappName + ":j")
+
+// AppDeployMetadata identifies a deployment of an application and is recorded
+// in the note of the transactions creating and updating it.
+type AppDeployMetadata struct {
+	// Name identifies the application among those of its creator.
+	Name    string `json:"name"`
+	Version string `json:"version"`
+
+	// Updatable and Deletable tell whether the application may be updated or
+	// deleted, nil meaning unknown.
+	Updatable *bool `json:"updatable,omitempty"`
+	Deletable *bool `json:"deletable,omitempty"`
+}
+
+// AppOnUpdate is what AppDeployer.Deploy does when the programs of an
+// application changed.
+type AppOnUpdate int
+
+const (
+	// AppOnUpdateFail returns an error.
+	AppOnUpdateFail AppOnUpdate = iota
+	// AppOnUpdateUpdate updates the programs of the existing application.
+	AppOnUpdateUpdate
+	// AppOnUpdateReplace creates a new application and deletes the existing one.
+	AppOnUpdateReplace
+	// AppOnUpdateAppend creates a new application and leaves the existing one.
+	AppOnUpdateAppend
+)
+
+// AppOnSchemaBreak is what AppDeployer.Deploy does when the state schema or
+// extra pages of an application grew, which an update cannot change.
+type AppOnSchemaBreak int
+
+const (
+	// AppOnSchemaBreakFail returns an error.
+	AppOnSchemaBreakFail AppOnSchemaBreak = iota
+	// AppOnSchemaBreakReplace creates a new application and deletes the
+	// existing one.
+	AppOnSchemaBreakReplace
+	// AppOnSchemaBreakAppend creates a new application and leaves the existing
+	// one.
+	AppOnSchemaBreakAppend
+)
+
+// AppDeployOperation is what AppDeployer.Deploy did.
+type AppDeployOperation int
+
+const (
+	// AppDeployNothing means the deployed application was up to date.
+	AppDeployNothing AppDeployOperation = iota
+	// AppDeployCreate means a new application was created.
+	AppDeployCreate
+	// AppDeployUpdate means the programs of the application were updated.
+	AppDeployUpdate
+	// AppDeployReplace means a new application was created and the previous
+	// one deleted.
+	AppDeployReplace
+)
+
+// AppDeployParams describe the application to deploy.
+type AppDeployParams struct {
+	Metadata AppDeployMetadata
+
+	// ApprovalTEAL and ClearTEAL are the TEAL templates of the programs, see
+	// logic.SubstituteTemplate. TMPL_UPDATABLE and TMPL_DELETABLE are replaced
+	// by 1 or 0 according to the metadata.
+	ApprovalTEAL string
+	ClearTEAL    string
+
+	// TemplateValues are the values of the other placeholders.
+	TemplateValues map[string]interface{}
+
+	GlobalSchema types.StateSchema
+	LocalSchema  types.StateSchema
+	ExtraPages   uint32
+
+	OnUpdate      AppOnUpdate
+	OnSchemaBreak AppOnSchemaBreak
+
+	// WaitRounds is how many rounds to wait for the transactions to be
+	// confirmed.
+	WaitRounds uint64
+}
+
+// DeployedApp is an application found by AppDeployer.
+type DeployedApp struct {
+	AppID uint64
+
+	// CreatedRound and UpdatedRound are the rounds of the first and latest
+	// transactions recording the metadata of the application.
+	CreatedRound uint64
+	UpdatedRound uint64
+
+	// Metadata is the metadata of the latest deployment.
+	Metadata AppDeployMetadata
+}
+
+// AppDeployResult is the outcome of AppDeployer.Deploy.
+type AppDeployResult struct {
+	Operation AppDeployOperation
+
+	// AppID is the application deployed.
+	AppID uint64
+
+	// DeletedAppID is the application deleted by a replacement.
+	DeletedAppID uint64
+
+	// TxIDs of the submitted transactions, if any.
+	TxIDs []string
+}
+
+// AppDeployer deploys applications idempotently, like AlgoKit does: the
+// metadata of every deployment is recorded in a transaction note, and a
+// deployment looks for the application of the same name already deployed by
+// the creator to create, update or replace it as needed.
+type AppDeployer struct {
+	Algod   *algod.Client
+	Indexer *indexer.Client
+
+	// Creator creates the applications and signs all the transactions, with
+	// Signer.
+	Creator types.Address
+	Signer  TransactionSigner
+}
+
+// DeployedApps returns the applications of the creator recorded by deployment
+// notes, by name. Deleted applications are ignored, and of two applications
+// with the same name the latest created is kept.
+func (d AppDeployer) DeployedApps(ctx context.Context) (map[string]DeployedApp, error) {
+	apps := make(map[string]DeployedApp)
+	appIt := d.Indexer.SearchForApplications().Creator(d.Creator.String()).Iterator(ctx)
+	for appIt.Next() {
+		app := appIt.Item()
+		if app.Deleted {
+			continue
+		}
+
+		deployed := DeployedApp{AppID: app.Id}
+		found := false
+		// the indexer returns transactions from the oldest
+		txIt := d.Indexer.SearchForTransactions().
+			ApplicationId(app.Id).
+			AddressString(d.Creator.String()).
+			AddressRole("sender").
+			NotePrefix(appDeployNotePrefix).
+			Iterator(ctx)
+		for txIt.Next() {
+			txn := txIt.Item()
+			metadata, err := parseAppDeployNote(txn.Note)
+			if err != nil {
+				continue
+			}
+			if !found {
+				deployed.CreatedRound = txn.ConfirmedRound
+				found = true
+			}
+			deployed.UpdatedRound = txn.ConfirmedRound
+			deployed.Metadata = metadata
+		}
+		if err := txIt.Err(); err != nil {
+			return nil, err
+		}
+
+		if !found {
+			continue
+		}
+		if previous, ok := apps[deployed.Metadata.Name]; ok && previous.CreatedRound > deployed.CreatedRound {
+			continue
+		}
+		apps[deployed.Metadata.Name] = deployed
+	}
+	if err := appIt.Err(); err != nil {
+		return nil, err
+	}
+	return apps, nil
+}
+
+// Deploy deploys the application described by params. If the creator has no
+// application of that name, it is created. Otherwise it is left as is if its
+// programs and schema are unchanged, and else updated, replaced or appended
+// to according to params.OnUpdate and params.OnSchemaBreak.
+func (d AppDeployer) Deploy(ctx context.Context, params AppDeployParams) (AppDeployResult, error) {
+	if params.Metadata.Name == "" {
+		return AppDeployResult{}, fmt.Errorf("an application to deploy must have a name")
+	}
+	note, err := MakeJSONNote(AppDeployNoteDappName, params.Metadata)
+	if err != nil {
+		return AppDeployResult{}, err
+	}
+
+	values := deployTemplateValues(params)
+	approval, err := d.compile(ctx, params.ApprovalTEAL, values)
+	if err != nil {
+		return AppDeployResult{}, fmt.Errorf("compiling approval program: %w", err)
+	}
+	clear, err := d.compile(ctx, params.ClearTEAL, values)
+	if err != nil {
+		return AppDeployResult{}, fmt.Errorf("compiling clear program: %w", err)
+	}
+
+	apps, err := d.DeployedApps(ctx)
+	if err != nil {
+		return AppDeployResult{}, err
+	}
+	existing, found := apps[params.Metadata.Name]
+	var current models.Application
+	if found {
+		if current, err = d.Algod.GetApplicationByID(existing.AppID).Do(ctx); err != nil {
+			return AppDeployResult{}, err
+		}
+	}
+
+	operation, err := decideAppDeploy(found, existing.Metadata, current.Params, approval, clear, params)
+	if err != nil {
+		return AppDeployResult{}, err
+	}
+	if operation == AppDeployNothing {
+		return AppDeployResult{Operation: operation, AppID: existing.AppID}, nil
+	}
+
+	sp, err := d.Algod.SuggestedParams().Do(ctx)
+	if err != nil {
+		return AppDeployResult{}, err
+	}
+
+	var atc AtomicTransactionComposer
+	add := func(tx types.Transaction, err error) error {
+		if err != nil {
+			return err
+		}
+		return atc.AddTransaction(TransactionWithSigner{Txn: tx, Signer: d.Signer})
+	}
+	if operation == AppDeployUpdate {
+		err = add(MakeApplicationUpdateTx(existing.AppID, nil, nil, nil, nil, approval, clear, sp, d.Creator, note, types.Digest{}, [32]byte{}, types.ZeroAddress))
+	} else {
+		err = add(MakeApplicationCreateTxWithExtraPages(false, approval, clear, params.GlobalSchema, params.LocalSchema, nil, nil, nil, nil, sp, d.Creator, note, types.Digest{}, [32]byte{}, types.ZeroAddress, params.ExtraPages))
+	}
+	if err == nil && operation == AppDeployReplace {
+		err = add(MakeApplicationDeleteTx(existing.AppID, nil, nil, nil, nil, sp, d.Creator, nil, types.Digest{}, [32]byte{}, types.ZeroAddress))
+	}
+	if err != nil {
+		return AppDeployResult{}, err
+	}
+
+	executed, err := atc.Execute(d.Algod, ctx, params.WaitRounds)
+	if err != nil {
+		return AppDeployResult{}, err
+	}
+	result := AppDeployResult{Operation: operation, AppID: existing.AppID, TxIDs: executed.TxIDs}
+	if operation == AppDeployUpdate {
+		return result, nil
+	}
+
+	info, _, err := d.Algod.PendingTransactionInformation(executed.TxIDs[0]).Do(ctx)
+	if err != nil {
+		return AppDeployResult{}, err
+	}
+	result.AppID = info.ApplicationIndex
+	if operation == AppDeployReplace {
+		result.DeletedAppID = existing.AppID
+	}
+	return result, nil
+}
+
+// compile substitutes values in a TEAL template and compiles it with algod.
+func (d AppDeployer) compile(ctx context.Context, template string, values map[string]interface{}) ([]byte, error) {
+	source, err := logic.SubstituteTemplate(template, values)
+	if err != nil {
+		return nil, err
+	}
+	response, err := d.Algod.TealCompile([]byte(source)).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(response.Result)
+}
+
+// decideAppDeploy returns the operation deploying the compiled programs and
+// params, given the existing application if found.
+func decideAppDeploy(found bool, existing AppDeployMetadata, current models.ApplicationParams, approval, clear []byte, params AppDeployParams) (AppDeployOperation, error) {
+	if !found {
+		return AppDeployCreate, nil
+	}
+
+	schemaBreak := params.GlobalSchema.NumUint > current.GlobalStateSchema.NumUint ||
+		params.GlobalSchema.NumByteSlice > current.GlobalStateSchema.NumByteSlice ||
+		params.LocalSchema.NumUint > current.LocalStateSchema.NumUint ||
+		params.LocalSchema.NumByteSlice > current.LocalStateSchema.NumByteSlice ||
+		uint64(params.ExtraPages) > current.ExtraProgramPages
+	if schemaBreak {
+		switch params.OnSchemaBreak {
+		case AppOnSchemaBreakAppend:
+			return AppDeployCreate, nil
+		case AppOnSchemaBreakReplace:
+			return replaceApp(existing)
+		default:
+			return AppDeployNothing, fmt.Errorf("the schema of application %s grew, which requires replacing it", existing.Name)
+		}
+	}
+
+	if bytes.Equal(approval, current.ApprovalProgram) && bytes.Equal(clear, current.ClearStateProgram) {
+		return AppDeployNothing, nil
+	}
+	switch params.OnUpdate {
+	case AppOnUpdateAppend:
+		return AppDeployCreate, nil
+	case AppOnUpdateReplace:
+		return replaceApp(existing)
+	case AppOnUpdateUpdate:
+		if existing.Updatable != nil && !*existing.Updatable {
+			return AppDeployNothing, fmt.Errorf("application %s was deployed as not updatable", existing.Name)
+		}
+		return AppDeployUpdate, nil
+	default:
+		return AppDeployNothing, fmt.Errorf("the programs of application %s changed, which requires updating it", existing.Name)
+	}
+}
+
+func replaceApp(existing AppDeployMetadata) (AppDeployOperation, error) {
+	if existing.Deletable != nil && !*existing.Deletable {
+		return AppDeployNothing, fmt.Errorf("application %s was deployed as not deletable and cannot be replaced", existing.Name)
+	}
+	return AppDeployReplace, nil
+}
+
+// deployTemplateValues returns the template values of params, with the
+// deploy-time controls set according to the metadata, unknown counting as
+// false.
+func deployTemplateValues(params AppDeployParams) map[string]interface{} {
+	values := make(map[string]interface{}, len(params.TemplateValues)+2)
+	for name, value := range params.TemplateValues {
+		values[name] = value
+	}
+	values[logic.TemplatePrefix+"UPDATABLE"] = params.Metadata.Updatable != nil && *params.Metadata.Updatable
+	values[logic.TemplatePrefix+"DELETABLE"] = params.Metadata.Deletable != nil && *params.Metadata.Deletable
+	return values
+}
+
+// parseAppDeployNote decodes the metadata of a deployment note.
+func parseAppDeployNote(note []byte) (AppDeployMetadata, error) {
+	n, err := ParseNote(note)
+	if err != nil {
+		return AppDeployMetadata{}, err
+	}
+	if n.DappName != AppDeployNoteDappName || n.Format != NoteFormatJSON {
+		return AppDeployMetadata{}, fmt.Errorf("note is not an application deployment note")
+	}
+	var metadata AppDeployMetadata
+	if err := n.Decode(&metadata); err != nil {
+		return AppDeployMetadata{}, err
+	}
+	return metadata, nil
+}
@@ -0,0 +1,139 @@
+package transaction
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/indexer"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestAppDeployNote(t *testing.T) {
+	updatable := true
+	metadata := AppDeployMetadata{Name: "app", Version: "1.0", Updatable: &updatable}
+	note, err := MakeJSONNote(AppDeployNoteDappName, metadata)
+	require.NoError(t, err)
+	require.Equal(t, `ALGOKIT_DEPLOYER:j{"name":"app","updatable":true,"version":"1.0"}`, string(note))
+
+	parsed, err := parseAppDeployNote(note)
+	require.NoError(t, err)
+	require.Equal(t, metadata, parsed)
+
+	_, err = parseAppDeployNote([]byte(`other-dapp:j{"name":"app"}`))
+	require.Error(t, err)
+
+	values := deployTemplateValues(AppDeployParams{Metadata: metadata, TemplateValues: map[string]interface{}{"TMPL_FEE": uint64(1000)}})
+	require.Equal(t, map[string]interface{}{"TMPL_FEE": uint64(1000), "TMPL_UPDATABLE": true, "TMPL_DELETABLE": false}, values)
+}
+
+func TestDecideAppDeploy(t *testing.T) {
+	no := false
+	current := models.ApplicationParams{
+		ApprovalProgram:   []byte{1},
+		ClearStateProgram: []byte{2},
+		GlobalStateSchema: models.ApplicationStateSchema{NumUint: 1, NumByteSlice: 1},
+		LocalStateSchema:  models.ApplicationStateSchema{NumUint: 1},
+	}
+	params := AppDeployParams{
+		GlobalSchema: types.StateSchema{NumUint: 1, NumByteSlice: 1},
+		LocalSchema:  types.StateSchema{NumUint: 1},
+	}
+
+	op, err := decideAppDeploy(false, AppDeployMetadata{}, models.ApplicationParams{}, []byte{1}, []byte{2}, params)
+	require.NoError(t, err)
+	require.Equal(t, AppDeployCreate, op)
+
+	op, err = decideAppDeploy(true, AppDeployMetadata{}, current, []byte{1}, []byte{2}, params)
+	require.NoError(t, err)
+	require.Equal(t, AppDeployNothing, op)
+
+	// changed programs
+	_, err = decideAppDeploy(true, AppDeployMetadata{}, current, []byte{3}, []byte{2}, params)
+	require.Error(t, err)
+	for onUpdate, expected := range map[AppOnUpdate]AppDeployOperation{
+		AppOnUpdateUpdate:  AppDeployUpdate,
+		AppOnUpdateReplace: AppDeployReplace,
+		AppOnUpdateAppend:  AppDeployCreate,
+	} {
+		params.OnUpdate = onUpdate
+		op, err = decideAppDeploy(true, AppDeployMetadata{}, current, []byte{3}, []byte{2}, params)
+		require.NoError(t, err)
+		require.Equal(t, expected, op)
+	}
+	params.OnUpdate = AppOnUpdateUpdate
+	_, err = decideAppDeploy(true, AppDeployMetadata{Updatable: &no}, current, []byte{3}, []byte{2}, params)
+	require.Error(t, err)
+
+	// a smaller schema is fine, a larger one breaks
+	params.LocalSchema = types.StateSchema{}
+	op, err = decideAppDeploy(true, AppDeployMetadata{}, current, []byte{1}, []byte{2}, params)
+	require.NoError(t, err)
+	require.Equal(t, AppDeployNothing, op)
+
+	params.ExtraPages = 1
+	_, err = decideAppDeploy(true, AppDeployMetadata{}, current, []byte{1}, []byte{2}, params)
+	require.Error(t, err)
+	params.OnSchemaBreak = AppOnSchemaBreakReplace
+	op, err = decideAppDeploy(true, AppDeployMetadata{}, current, []byte{1}, []byte{2}, params)
+	require.NoError(t, err)
+	require.Equal(t, AppDeployReplace, op)
+	_, err = decideAppDeploy(true, AppDeployMetadata{Deletable: &no}, current, []byte{1}, []byte{2}, params)
+	require.Error(t, err)
+	params.OnSchemaBreak = AppOnSchemaBreakAppend
+	op, err = decideAppDeploy(true, AppDeployMetadata{}, current, []byte{1}, []byte{2}, params)
+	require.NoError(t, err)
+	require.Equal(t, AppDeployCreate, op)
+}
+
+func TestDeployedApps(t *testing.T) {
+	creator := crypto.GenerateAccount().Address
+	note := func(name, version string) []byte {
+		n, err := MakeJSONNote(AppDeployNoteDappName, AppDeployMetadata{Name: name, Version: version})
+		require.NoError(t, err)
+		return n
+	}
+	txns := map[uint64][]models.Transaction{
+		1: {{ConfirmedRound: 10, Note: note("app", "1")}, {ConfirmedRound: 20, Note: note("app", "2")}},
+		2: {{ConfirmedRound: 30, Note: note("app", "3")}},
+		3: {{ConfirmedRound: 40, Note: note("deleted", "1")}},
+		4: {{ConfirmedRound: 50, Note: note("other", "1")}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/applications":
+			require.Equal(t, creator.String(), r.URL.Query().Get("creator"))
+			w.Write(json.Encode(models.ApplicationsResponse{Applications: []models.Application{
+				{Id: 1}, {Id: 2}, {Id: 3, Deleted: true}, {Id: 4},
+			}}))
+		case "/v2/transactions":
+			require.Equal(t, creator.String(), r.URL.Query().Get("address"))
+			require.Equal(t, "sender", r.URL.Query().Get("address-role"))
+			id, err := strconv.ParseUint(r.URL.Query().Get("application-id"), 10, 64)
+			require.NoError(t, err)
+			w.Write(json.Encode(models.TransactionsResponse{Transactions: txns[id]}))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	}))
+	defer server.Close()
+
+	indexerClient, err := indexer.MakeClient(server.URL, "")
+	require.NoError(t, err)
+	deployer := AppDeployer{Indexer: indexerClient, Creator: creator}
+
+	apps, err := deployer.DeployedApps(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]DeployedApp{
+		"app":   {AppID: 2, CreatedRound: 30, UpdatedRound: 30, Metadata: AppDeployMetadata{Name: "app", Version: "3"}},
+		"other": {AppID: 4, CreatedRound: 50, UpdatedRound: 50, Metadata: AppDeployMetadata{Name: "other", Version: "1"}},
+	}, apps)
+}
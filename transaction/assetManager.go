@@ -0,0 +1,164 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// AssetManager makes the transactions of the roles of an asset, checking
+// against the current parameters of the asset that Sender holds the role each
+// needs. Transactions are returned unsigned.
+type AssetManager struct {
+	Algod *algod.Client
+
+	// Sender sends the transactions.
+	Sender types.Address
+}
+
+// AssetRoles are the role addresses of an asset to change. Nil addresses keep
+// their current value, and the zero address clears a role for good, which
+// AllowClear must then be set for.
+type AssetRoles struct {
+	Manager, Reserve, Freeze, Clawback *types.Address
+
+	AllowClear bool
+}
+
+// Freeze makes the transaction freezing the holding of assetID of target,
+// which must be sent by the freeze address.
+func (m AssetManager) Freeze(ctx context.Context, assetID uint64, target types.Address) (types.Transaction, error) {
+	return m.freeze(ctx, assetID, target, true)
+}
+
+// Unfreeze makes the transaction unfreezing the holding of assetID of
+// target, which must be sent by the freeze address.
+func (m AssetManager) Unfreeze(ctx context.Context, assetID uint64, target types.Address) (types.Transaction, error) {
+	return m.freeze(ctx, assetID, target, false)
+}
+
+func (m AssetManager) freeze(ctx context.Context, assetID uint64, target types.Address, frozen bool) (types.Transaction, error) {
+	asset, params, err := m.fetch(ctx, assetID)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	if err := m.checkRole(assetID, "freeze", asset.Freeze); err != nil {
+		return types.Transaction{}, err
+	}
+	return MakeAssetFreezeTxn(m.Sender.String(), nil, params, assetID, target.String(), frozen)
+}
+
+// Clawback makes the transaction moving amount of assetID from the holding of
+// from to the one of to, which must be sent by the clawback address.
+func (m AssetManager) Clawback(ctx context.Context, assetID uint64, from, to types.Address, amount uint64) (types.Transaction, error) {
+	asset, params, err := m.fetch(ctx, assetID)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	if err := m.checkRole(assetID, "clawback", asset.Clawback); err != nil {
+		return types.Transaction{}, err
+	}
+	return MakeAssetRevocationTxn(m.Sender.String(), from.String(), amount, to.String(), nil, params, assetID)
+}
+
+// Destroy makes the transaction destroying assetID, which must be sent by the
+// manager address, once the creator holds all of its units.
+func (m AssetManager) Destroy(ctx context.Context, assetID uint64) (types.Transaction, error) {
+	asset, params, err := m.fetch(ctx, assetID)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	if err := m.checkRole(assetID, "manager", asset.Manager); err != nil {
+		return types.Transaction{}, err
+	}
+	holding, err := m.Algod.AccountAssetInformation(asset.Creator, assetID).Do(ctx)
+	if err != nil {
+		return types.Transaction{}, fmt.Errorf("could not fetch the holding of asset %d of its creator: %w", assetID, err)
+	}
+	if holding.AssetHolding.Amount != asset.Total {
+		return types.Transaction{}, fmt.Errorf("asset %d cannot be destroyed: its creator holds %d of its %d units", assetID, holding.AssetHolding.Amount, asset.Total)
+	}
+	return MakeAssetDestroyTxn(m.Sender.String(), nil, params, assetID)
+}
+
+// Reconfigure makes the transaction changing the roles of assetID set in
+// roles, which must be sent by the manager address. Configuration
+// transactions set all the roles at once, so the others are set to their
+// current value rather than cleared.
+func (m AssetManager) Reconfigure(ctx context.Context, assetID uint64, roles AssetRoles) (types.Transaction, error) {
+	asset, params, err := m.fetch(ctx, assetID)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	if err := m.checkRole(assetID, "manager", asset.Manager); err != nil {
+		return types.Transaction{}, err
+	}
+
+	return MakeAssetReconfigureTxn(m.Sender.String(), nil, params, assetID, asset, roles)
+}
+
+// MakeAssetReconfigureTxn makes the transaction changing the roles of
+// assetID set in roles, starting from current, its current parameters as
+// returned by algod or the indexer. Configuration transactions set all the
+// roles at once, so the others are set to their current value rather than
+// cleared. Clearing a role that is set is irreversible, and an error unless
+// roles.AllowClear is set.
+func MakeAssetReconfigureTxn(account string, note []byte, params types.SuggestedParams, assetID uint64, current models.AssetParams, roles AssetRoles) (types.Transaction, error) {
+	var cleared []string
+	role := func(name, current string, changed *types.Address) string {
+		if changed == nil {
+			return current
+		}
+		if changed.IsZero() {
+			if current != "" {
+				cleared = append(cleared, name)
+			}
+			return ""
+		}
+		return changed.String()
+	}
+	manager := role("manager", current.Manager, roles.Manager)
+	reserve := role("reserve", current.Reserve, roles.Reserve)
+	freeze := role("freeze", current.Freeze, roles.Freeze)
+	clawback := role("clawback", current.Clawback, roles.Clawback)
+	if manager == "" && reserve == "" && freeze == "" && clawback == "" {
+		// a configuration clearing every role would destroy the asset
+		return types.Transaction{}, fmt.Errorf("asset %d cannot have all of its roles cleared, destroy it instead", assetID)
+	}
+	if len(cleared) > 0 && !roles.AllowClear {
+		addresses := "address"
+		if len(cleared) > 1 {
+			addresses += "es"
+		}
+		return types.Transaction{}, fmt.Errorf("reconfiguring asset %d would clear its %s %s for good, which requires AllowClear", assetID, strings.Join(cleared, " and "), addresses)
+	}
+	return MakeAssetConfigTxn(account, note, params, assetID, manager, reserve, freeze, clawback, false)
+}
+
+// fetch returns the current parameters of assetID and suggested parameters.
+func (m AssetManager) fetch(ctx context.Context, assetID uint64) (models.AssetParams, types.SuggestedParams, error) {
+	asset, err := m.Algod.GetAssetByID(assetID).Do(ctx)
+	if err != nil {
+		return models.AssetParams{}, types.SuggestedParams{}, fmt.Errorf("could not fetch asset %d: %w", assetID, err)
+	}
+	params, err := m.Algod.SuggestedParams().Do(ctx)
+	if err != nil {
+		return models.AssetParams{}, types.SuggestedParams{}, err
+	}
+	return asset.Params, params, nil
+}
+
+// checkRole returns an error if Sender is not address, the role of assetID.
+func (m AssetManager) checkRole(assetID uint64, role, address string) error {
+	if address == "" {
+		return fmt.Errorf("asset %d has no %s address", assetID, role)
+	}
+	if address != m.Sender.String() {
+		return fmt.Errorf("%s is not the %s address %s of asset %d", m.Sender, role, address, assetID)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package transaction
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/mockserver"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestAssetManager(t *testing.T) {
+	ctx := context.Background()
+	manager := crypto.TestAccount("manager", 0).Address
+	freeze := crypto.TestAccount("freeze", 0).Address
+	holder := crypto.TestAccount("holder", 0).Address
+
+	s := mockserver.New(t)
+	asset := models.Asset{Index: 5, Params: models.AssetParams{
+		Creator: manager.String(), Manager: manager.String(), Reserve: manager.String(), Freeze: freeze.String(), Total: 100,
+	}}
+	s.Expect(http.MethodGet, "/v2/assets/5").ReturnJSON(asset).AnyTimes()
+	s.ExpectSuggestedParams(models.TransactionParametersResponse{MinFee: 1000, LastRound: 9, GenesisHash: make([]byte, 32)}).AnyTimes()
+
+	m := AssetManager{Algod: s.Algod(), Sender: freeze}
+	tx, err := m.Freeze(ctx, 5, holder)
+	require.NoError(t, err)
+	require.Equal(t, types.AssetFreezeTx, tx.Type)
+	require.Equal(t, holder, tx.FreezeAccount)
+	require.True(t, tx.AssetFrozen)
+	tx, err = m.Unfreeze(ctx, 5, holder)
+	require.NoError(t, err)
+	require.False(t, tx.AssetFrozen)
+
+	// the freeze address is not the manager, and there is no clawback
+	_, err = m.Destroy(ctx, 5)
+	require.ErrorContains(t, err, "is not the manager address")
+	_, err = m.Clawback(ctx, 5, holder, freeze, 1)
+	require.EqualError(t, err, "asset 5 has no clawback address")
+
+	// reconfiguring keeps the roles not changed
+	m.Sender = manager
+	_, err = m.Reconfigure(ctx, 5, AssetRoles{Clawback: &manager, Freeze: &types.ZeroAddress})
+	require.EqualError(t, err, "reconfiguring asset 5 would clear its freeze address for good, which requires AllowClear")
+	tx, err = m.Reconfigure(ctx, 5, AssetRoles{Clawback: &manager, Freeze: &types.ZeroAddress, AllowClear: true})
+	require.NoError(t, err)
+	require.Equal(t, types.AssetConfigTx, tx.Type)
+	require.Equal(t, manager, tx.AssetParams.Manager)
+	require.Equal(t, manager, tx.AssetParams.Reserve)
+	require.Equal(t, manager, tx.AssetParams.Clawback)
+	require.True(t, tx.AssetParams.Freeze.IsZero())
+	_, err = m.Reconfigure(ctx, 5, AssetRoles{Manager: &types.ZeroAddress, Reserve: &types.ZeroAddress, Freeze: &types.ZeroAddress, AllowClear: true})
+	require.ErrorContains(t, err, "cannot have all of its roles cleared")
+
+	// destroying requires the creator to hold every unit
+	path := "/v2/accounts/" + manager.String() + "/assets/5"
+	s.Expect(http.MethodGet, path).ReturnJSON(models.AccountAssetResponse{AssetHolding: models.AssetHolding{Amount: 90}})
+	_, err = m.Destroy(ctx, 5)
+	require.EqualError(t, err, "asset 5 cannot be destroyed: its creator holds 90 of its 100 units")
+	s.Expect(http.MethodGet, path).ReturnJSON(models.AccountAssetResponse{AssetHolding: models.AssetHolding{Amount: 100}})
+	tx, err = m.Destroy(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, types.AssetIndex(5), tx.ConfigAsset)
+	require.Equal(t, types.AssetParams{}, tx.AssetParams)
+
+	asset.Index, asset.Params.Clawback = 6, manager.String()
+	s.Expect(http.MethodGet, "/v2/assets/6").ReturnJSON(asset)
+	tx, err = m.Clawback(ctx, 6, holder, manager, 3)
+	require.NoError(t, err)
+	require.Equal(t, holder, tx.AssetSender)
+	require.Equal(t, manager, tx.AssetReceiver)
+	require.Equal(t, uint64(3), tx.AssetAmount)
+}
+
+func TestMakeAssetReconfigureTxn(t *testing.T) {
+	a := crypto.TestAccount("reconfigure", 0).Address
+	b := crypto.TestAccount("reconfigure", 1).Address
+	params := types.SuggestedParams{Fee: 1000, FirstRoundValid: 1, LastRoundValid: 1001, GenesisHash: make([]byte, 32), FlatFee: true}
+	current := models.AssetParams{Manager: a.String(), Reserve: a.String()}
+
+	// the reserve is kept, and roles already cleared stay cleared
+	tx, err := MakeAssetReconfigureTxn(a.String(), nil, params, 5, current, AssetRoles{Manager: &b, Clawback: &types.ZeroAddress})
+	require.NoError(t, err)
+	require.Equal(t, types.AssetParams{Manager: b, Reserve: a}, tx.AssetParams)
+
+	_, err = MakeAssetReconfigureTxn(a.String(), nil, params, 5, current, AssetRoles{Manager: &types.ZeroAddress, Reserve: &types.ZeroAddress, Freeze: &b})
+	require.EqualError(t, err, "reconfiguring asset 5 would clear its manager and reserve addresses for good, which requires AllowClear")
+}
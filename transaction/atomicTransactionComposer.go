@@ -176,6 +176,8 @@ func (txContext *transactionContext) isMethodCallTx() bool {
 // MaxAtomicGroupSize is the maximum size of an atomic transaction group.
 const MaxAtomicGroupSize = 16
 
+var errMissingSigner = errors.New("a TransactionSigner must be provided for every transaction")
+
 // AtomicTransactionComposer is a helper class used to construct and execute atomic transaction groups
 type AtomicTransactionComposer struct {
 	// The current status of the composer. The status increases monotonically.
@@ -241,6 +243,10 @@ func (atc *AtomicTransactionComposer) AddTransaction(txnAndSigner TransactionWit
 		return fmt.Errorf("reached max group size: %d", MaxAtomicGroupSize)
 	}
 
+	if txnAndSigner.Signer == nil {
+		return errMissingSigner
+	}
+
 	err := atc.validateTransaction(txnAndSigner.Txn, abi.AnyTransactionType)
 	if err != nil {
 		return err
@@ -272,6 +278,10 @@ func (atc *AtomicTransactionComposer) AddMethodCall(params AddMethodCallParams)
 		return fmt.Errorf("reached max group size: %d", MaxAtomicGroupSize)
 	}
 
+	if params.Signer == nil {
+		return errMissingSigner
+	}
+
 	if params.AppID == 0 {
 		if len(params.ApprovalProgram) == 0 || len(params.ClearProgram) == 0 {
 			return fmt.Errorf("ApprovalProgram and ClearProgram must be provided for an application creation call")
@@ -301,6 +311,9 @@ func (atc *AtomicTransactionComposer) AddMethodCall(params AddMethodCallParams)
 			if !ok {
 				return fmt.Errorf("invalid arg type, expected transaction")
 			}
+			if txnAndSigner.Signer == nil {
+				return errMissingSigner
+			}
 
 			err := atc.validateTransaction(txnAndSigner.Txn, arg.Type)
 			if err != nil {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/algorand/go-algorand-sdk/v2/abi"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
 	"github.com/algorand/go-algorand-sdk/v2/crypto"
 	"github.com/algorand/go-algorand-sdk/v2/types"
 	"github.com/stretchr/testify/require"
@@ -134,6 +135,43 @@ func TestAddTransactionWithMaxTransactions(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestAddTransactionWithoutSigner(t *testing.T) {
+	var atc AtomicTransactionComposer
+
+	addr, err := types.DecodeAddress("DN7MBMCL5JQ3PFUQS7TMX5AH4EEKOBJVDUF4TCV6WERATKFLQF4MQUPZTA")
+	require.NoError(t, err)
+
+	tx := types.Transaction{
+		Type: types.PaymentTx,
+		Header: types.Header{
+			Sender:     addr,
+			Fee:        1000,
+			FirstValid: 972508,
+			LastValid:  973508,
+		},
+		PaymentTxnFields: types.PaymentTxnFields{
+			Receiver: addr,
+			Amount:   5000,
+		},
+	}
+
+	err = atc.AddTransaction(TransactionWithSigner{Txn: tx})
+	require.ErrorIs(t, err, errMissingSigner)
+	require.Equal(t, atc.Count(), 0)
+
+	method, err := abi.MethodFromSignature("add()uint32")
+	require.NoError(t, err)
+
+	err = atc.AddMethodCall(
+		AddMethodCallParams{
+			AppID:  4,
+			Method: method,
+			Sender: addr,
+		})
+	require.ErrorIs(t, err, errMissingSigner)
+	require.Equal(t, atc.Count(), 0)
+}
+
 func TestAddMethodCall(t *testing.T) {
 	var atc AtomicTransactionComposer
 	account := crypto.GenerateAccount()
@@ -251,3 +289,76 @@ func TestGatherSignatures(t *testing.T) {
 	require.Equal(t, len(sigs[0]), len(expectedSig))
 	require.Equal(t, sigs[0], expectedSig)
 }
+
+func TestAddMethodCallPacksExtraArgsIntoTuple(t *testing.T) {
+	var atc AtomicTransactionComposer
+	account := crypto.GenerateAccount()
+	txSigner := BasicAccountTransactionSigner{Account: account}
+
+	methodSig := "many(uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64,uint64)void"
+	method, err := abi.MethodFromSignature(methodSig)
+	require.NoError(t, err)
+
+	args := make([]interface{}, len(method.Args))
+	for i := range args {
+		args[i] = uint64(i)
+	}
+
+	err = atc.AddMethodCall(
+		AddMethodCallParams{
+			AppID:      4,
+			Method:     method,
+			MethodArgs: args,
+			Sender:     account.Address,
+			Signer:     txSigner,
+		})
+	require.NoError(t, err)
+
+	txns, err := atc.BuildGroup()
+	require.NoError(t, err)
+	require.Len(t, txns, 1)
+
+	appArgs := txns[0].Txn.ApplicationArgs
+	require.Len(t, appArgs, maxAppArgs)
+	require.Equal(t, method.GetSelector(), appArgs[0])
+
+	uint64Type, err := abi.TypeOf("uint64")
+	require.NoError(t, err)
+	for i := 0; i < methodArgsTupleThreshold; i++ {
+		decoded, err := uint64Type.Decode(appArgs[i+1])
+		require.NoError(t, err)
+		require.Equal(t, uint64(i), decoded)
+	}
+
+	tupleType, err := abi.TypeOf("(uint64,uint64,uint64)")
+	require.NoError(t, err)
+	decoded, err := tupleType.Decode(appArgs[maxAppArgs-1])
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{uint64(14), uint64(15), uint64(16)}, decoded)
+}
+
+func TestPrepareMethodResult(t *testing.T) {
+	method, err := abi.MethodFromSignature("add(uint64,uint64)uint64")
+	require.NoError(t, err)
+
+	returnValue := []byte{0, 0, 0, 0, 0, 0, 0, 3}
+	info := models.PendingTransactionInfoResponse{
+		Logs: [][]byte{[]byte("debug"), append(append([]byte{}, abiReturnHash...), returnValue...)},
+	}
+
+	result := prepareMethodResult(ABIMethodResult{Method: method}, info)
+	require.NoError(t, result.DecodeError)
+	require.Equal(t, returnValue, result.RawReturnValue)
+	require.Equal(t, uint64(3), result.ReturnValue)
+
+	info.Logs = [][]byte{[]byte("debug")}
+	result = prepareMethodResult(ABIMethodResult{Method: method}, info)
+	require.Error(t, result.DecodeError)
+
+	voidMethod, err := abi.MethodFromSignature("reset()void")
+	require.NoError(t, err)
+	result = prepareMethodResult(ABIMethodResult{Method: voidMethod}, info)
+	require.NoError(t, result.DecodeError)
+	require.Empty(t, result.RawReturnValue)
+	require.Nil(t, result.ReturnValue)
+}
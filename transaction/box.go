@@ -0,0 +1,77 @@
+package transaction
+
+import (
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// Consensus parameters of boxes, from the current consensus version
+var (
+	// BoxFlatMinBalance is the increase of the minimum balance of an
+	// application account for each of its boxes, in microAlgos.
+	BoxFlatMinBalance = consensusParams.BoxFlatMinBalance
+
+	// BoxByteMinBalance is the increase of the minimum balance of an
+	// application account for each byte of the name and value of its boxes, in
+	// microAlgos.
+	BoxByteMinBalance = consensusParams.BoxByteMinBalance
+
+	// MaxBoxNameLen is the longest name of a box, the longest application
+	// state key.
+	MaxBoxNameLen = consensusParams.MaxAppKeyLen
+
+	// MaxBoxSize is the largest size of the value of a box.
+	MaxBoxSize = consensusParams.MaxBoxSize
+)
+
+// MakeBoxReferences returns references to the boxes of appID with the given
+// names, 0 meaning the application called.
+func MakeBoxReferences(appID uint64, names ...[]byte) []types.AppBoxReference {
+	refs := make([]types.AppBoxReference, len(names))
+	for i, name := range names {
+		refs[i] = types.AppBoxReference{AppID: appID, Name: name}
+	}
+	return refs
+}
+
+// ParseBoxReferences returns references to the boxes of appID whose names are
+// in the goal form 'encoding:value', see common.DecodeBoxName.
+func ParseBoxReferences(appID uint64, encodedNames ...string) ([]types.AppBoxReference, error) {
+	names := make([][]byte, len(encodedNames))
+	for i, encoded := range encodedNames {
+		var err error
+		if names[i], err = common.DecodeBoxName(encoded); err != nil {
+			return nil, err
+		}
+	}
+	return MakeBoxReferences(appID, names...), nil
+}
+
+// BoxMinBalance returns the increase of the minimum balance of an application
+// account holding a box with the given name and size.
+func BoxMinBalance(name []byte, size uint64) (types.MicroAlgos, error) {
+	if len(name) == 0 || len(name) > MaxBoxNameLen {
+		return 0, fmt.Errorf("box name must be 1 to %d bytes, got %d", MaxBoxNameLen, len(name))
+	}
+	if size > MaxBoxSize {
+		return 0, fmt.Errorf("box size %d is more than the maximum %d", size, MaxBoxSize)
+	}
+	return types.MicroAlgos(BoxFlatMinBalance + BoxByteMinBalance*(uint64(len(name))+size)), nil
+}
+
+// BoxesMinBalance returns the increase of the minimum balance of an
+// application account holding boxes of the given sizes, keyed by the raw name
+// of the box.
+func BoxesMinBalance(sizes map[string]uint64) (types.MicroAlgos, error) {
+	var total types.MicroAlgos
+	for name, size := range sizes {
+		mbr, err := BoxMinBalance([]byte(name), size)
+		if err != nil {
+			return 0, err
+		}
+		total += mbr
+	}
+	return total, nil
+}
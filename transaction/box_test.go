@@ -0,0 +1,42 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestBoxReferences(t *testing.T) {
+	refs := MakeBoxReferences(7, []byte("a"), []byte{0, 1})
+	require.Equal(t, []types.AppBoxReference{{AppID: 7, Name: []byte("a")}, {AppID: 7, Name: []byte{0, 1}}}, refs)
+
+	refs, err := ParseBoxReferences(0, "str:a", "int:1", "b64:AAE=")
+	require.NoError(t, err)
+	require.Equal(t, []types.AppBoxReference{
+		{Name: []byte("a")},
+		{Name: []byte{0, 0, 0, 0, 0, 0, 0, 1}},
+		{Name: []byte{0, 1}},
+	}, refs)
+
+	_, err = ParseBoxReferences(0, "a")
+	require.Error(t, err)
+}
+
+func TestBoxMinBalance(t *testing.T) {
+	mbr, err := BoxMinBalance([]byte("name"), 100)
+	require.NoError(t, err)
+	require.Equal(t, types.MicroAlgos(2500+400*104), mbr)
+
+	total, err := BoxesMinBalance(map[string]uint64{"name": 100, "x": 0})
+	require.NoError(t, err)
+	require.Equal(t, mbr+2500+400, total)
+
+	_, err = BoxMinBalance(nil, 1)
+	require.Error(t, err)
+	_, err = BoxMinBalance(make([]byte, 65), 1)
+	require.Error(t, err)
+	_, err = BoxMinBalance([]byte("name"), MaxBoxSize+1)
+	require.Error(t, err)
+}
@@ -0,0 +1,262 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/base64"
+	stdjson "encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// description is a list of labelled lines, aligned once rendered.
+type description struct {
+	labels []string
+	values []string
+}
+
+func (d *description) add(label string, format string, args ...interface{}) {
+	d.labels = append(d.labels, label)
+	d.values = append(d.values, fmt.Sprintf(format, args...))
+}
+
+func (d *description) addAddress(label string, address types.Address) {
+	if !address.IsZero() {
+		d.add(label, "%s", address)
+	}
+}
+
+func (d *description) String() string {
+	width := 0
+	for _, label := range d.labels {
+		if len(label) > width {
+			width = len(label)
+		}
+	}
+	var b strings.Builder
+	for i, label := range d.labels {
+		fmt.Fprintf(&b, "%-*s  %s\n", width+1, label+":", d.values[i])
+	}
+	return b.String()
+}
+
+// Describe returns a human-readable summary of tx, one field per line, for
+// command-line tools and logs. Amounts of algos are shown in algos, notes as
+// text, ARC-2 notes or base64, and only the fields used by the type of tx.
+func Describe(tx types.Transaction) string {
+	var d description
+	describeTransaction(&d, tx)
+	return d.String()
+}
+
+// DescribeSigned returns the summary of stx as Describe does, followed by
+// how it is signed.
+func DescribeSigned(stx types.SignedTxn) string {
+	var d description
+	describeTransaction(&d, stx.Txn)
+	switch {
+	case stx.Sig != (types.Signature{}):
+		d.add("Signature", "single")
+	case !stx.Msig.Blank():
+		signed := 0
+		for _, subsig := range stx.Msig.Subsigs {
+			if subsig.Sig != (types.Signature{}) {
+				signed++
+			}
+		}
+		d.add("Signature", "multisig %d of %d, %d signed", stx.Msig.Threshold, len(stx.Msig.Subsigs), signed)
+	case len(stx.Lsig.Logic) > 0:
+		kind := "escrow"
+		if stx.Lsig.Sig != (types.Signature{}) || !stx.Lsig.Msig.Blank() {
+			kind = "delegated"
+		}
+		d.add("Signature", "logicsig, %s, %d bytes of program, %d arguments", kind, len(stx.Lsig.Logic), len(stx.Lsig.Args))
+	default:
+		d.add("Signature", "none")
+	}
+	d.addAddress("Auth address", stx.AuthAddr)
+	return d.String()
+}
+
+// DescribeJSON returns the indented JSON of a Transaction or SignedTxn in the
+// form algod uses, with addresses as strings, for tools that output JSON.
+func DescribeJSON(v interface{}) ([]byte, error) {
+	var out bytes.Buffer
+	if err := stdjson.Indent(&out, json.EncodeAlgod(v), "", "  "); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+func describeTransaction(d *description, tx types.Transaction) {
+	d.add("Type", "%s", tx.Type)
+	d.add("ID", "%s", crypto.GetTxID(tx))
+	d.addAddress("Sender", tx.Sender)
+	d.add("Fee", "%s", formatAlgos(uint64(tx.Fee)))
+	d.add("Valid", "rounds %d to %d", tx.FirstValid, tx.LastValid)
+	genesis := base64.StdEncoding.EncodeToString(tx.GenesisHash[:])
+	if network, ok := types.NetworkByGenesisHash(tx.GenesisHash); ok {
+		genesis = network.Name
+	}
+	if tx.GenesisID != "" {
+		genesis = tx.GenesisID + " (" + genesis + ")"
+	}
+	d.add("Genesis", "%s", genesis)
+	if tx.Group != (types.Digest{}) {
+		d.add("Group", "%s", base64.StdEncoding.EncodeToString(tx.Group[:]))
+	}
+	if tx.Lease != ([32]byte{}) {
+		d.add("Lease", "%s", base64.StdEncoding.EncodeToString(tx.Lease[:]))
+	}
+	if len(tx.Note) > 0 {
+		d.add("Note", "%s", describeNote(tx.Note))
+	}
+	d.addAddress("Rekey to", tx.RekeyTo)
+
+	switch tx.Type {
+	case types.PaymentTx:
+		d.addAddress("Receiver", tx.Receiver)
+		d.add("Amount", "%s", formatAlgos(uint64(tx.Amount)))
+		d.addAddress("Close to", tx.CloseRemainderTo)
+	case types.KeyRegistrationTx:
+		switch {
+		case tx.Nonparticipation:
+			d.add("Status", "nonparticipating")
+		case tx.VotePK == (types.VotePK{}):
+			d.add("Status", "offline")
+		default:
+			d.add("Status", "online")
+			d.add("Vote key", "%s", base64.StdEncoding.EncodeToString(tx.VotePK[:]))
+			d.add("Selection key", "%s", base64.StdEncoding.EncodeToString(tx.SelectionPK[:]))
+			if tx.StateProofPK != (types.MerkleVerifier{}) {
+				d.add("State proof key", "%s", base64.StdEncoding.EncodeToString(tx.StateProofPK[:]))
+			}
+			d.add("Vote rounds", "%d to %d", tx.VoteFirst, tx.VoteLast)
+			d.add("Key dilution", "%d", tx.VoteKeyDilution)
+		}
+	case types.AssetConfigTx:
+		params := tx.AssetParams
+		switch {
+		case tx.ConfigAsset == 0:
+			d.add("Action", "create")
+			d.add("Total", "%d", params.Total)
+			d.add("Decimals", "%d", params.Decimals)
+			d.add("Unit name", "%q", params.UnitName)
+			d.add("Asset name", "%q", params.AssetName)
+			if params.URL != "" {
+				d.add("URL", "%s", params.URL)
+			}
+			if params.MetadataHash != ([32]byte{}) {
+				d.add("Metadata hash", "%s", base64.StdEncoding.EncodeToString(params.MetadataHash[:]))
+			}
+			if params.DefaultFrozen {
+				d.add("Default frozen", "true")
+			}
+		case params == (types.AssetParams{}):
+			d.add("Action", "destroy")
+			d.add("Asset", "%d", tx.ConfigAsset)
+		default:
+			d.add("Action", "reconfigure")
+			d.add("Asset", "%d", tx.ConfigAsset)
+		}
+		d.addAddress("Manager", params.Manager)
+		d.addAddress("Reserve", params.Reserve)
+		d.addAddress("Freeze", params.Freeze)
+		d.addAddress("Clawback", params.Clawback)
+	case types.AssetTransferTx:
+		d.add("Asset", "%d", tx.XferAsset)
+		if tx.AssetSender.IsZero() && tx.AssetReceiver == tx.Sender && tx.AssetAmount == 0 {
+			d.add("Action", "opt-in")
+			break
+		}
+		d.addAddress("Clawback from", tx.AssetSender)
+		d.addAddress("Receiver", tx.AssetReceiver)
+		d.add("Amount", "%d", tx.AssetAmount)
+		d.addAddress("Close to", tx.AssetCloseTo)
+	case types.AssetFreezeTx:
+		d.add("Asset", "%d", tx.FreezeAsset)
+		d.addAddress("Account", tx.FreezeAccount)
+		d.add("Frozen", "%t", tx.AssetFrozen)
+	case types.ApplicationCallTx:
+		if tx.ApplicationID == 0 {
+			d.add("Application", "create")
+		} else {
+			d.add("Application", "%d", tx.ApplicationID)
+		}
+		d.add("On completion", "%s", tx.OnCompletion.Name())
+		for i, arg := range tx.ApplicationArgs {
+			d.add(fmt.Sprintf("Argument %d", i), "%s", describeBytes(arg))
+		}
+		for i, account := range tx.Accounts {
+			d.add(fmt.Sprintf("Account %d", i+1), "%s", account)
+		}
+		for i, app := range tx.ForeignApps {
+			d.add(fmt.Sprintf("Foreign app %d", i+1), "%d", app)
+		}
+		for i, asset := range tx.ForeignAssets {
+			d.add(fmt.Sprintf("Foreign asset %d", i), "%d", asset)
+		}
+		for _, box := range tx.BoxReferences {
+			d.add("Box", "app %d, name %s", box.ForeignAppIdx, describeBytes(box.Name))
+		}
+		if len(tx.ApprovalProgram) > 0 {
+			d.add("Approval program", "%d bytes", len(tx.ApprovalProgram))
+		}
+		if len(tx.ClearStateProgram) > 0 {
+			d.add("Clear program", "%d bytes", len(tx.ClearStateProgram))
+		}
+		if tx.GlobalStateSchema != (types.StateSchema{}) {
+			d.add("Global schema", "%d uints, %d byte slices", tx.GlobalStateSchema.NumUint, tx.GlobalStateSchema.NumByteSlice)
+		}
+		if tx.LocalStateSchema != (types.StateSchema{}) {
+			d.add("Local schema", "%d uints, %d byte slices", tx.LocalStateSchema.NumUint, tx.LocalStateSchema.NumByteSlice)
+		}
+		if tx.ExtraProgramPages > 0 {
+			d.add("Extra pages", "%d", tx.ExtraProgramPages)
+		}
+	}
+}
+
+// formatAlgos formats an amount of microAlgos in algos, without trailing
+// zeros.
+func formatAlgos(microAlgos uint64) string {
+	algos := fmt.Sprintf("%d.%06d", microAlgos/1000000, microAlgos%1000000)
+	return strings.TrimSuffix(strings.TrimRight(algos, "0"), ".") + " Algos"
+}
+
+// describeNote shows an ARC-2 note with its dapp name and data, and other
+// notes as describeBytes does.
+func describeNote(note []byte) string {
+	if parsed, err := ParseNote(note); err == nil {
+		switch parsed.Format {
+		case NoteFormatJSON, NoteFormatUTF8:
+			return fmt.Sprintf("ARC-2 %s: %s", parsed.DappName, parsed.Data)
+		default:
+			return fmt.Sprintf("ARC-2 %s (%c): %s", parsed.DappName, parsed.Format, base64.StdEncoding.EncodeToString(parsed.Data))
+		}
+	}
+	return describeBytes(note)
+}
+
+// describeBytes shows printable text quoted, and other bytes in base64.
+func describeBytes(b []byte) string {
+	if utf8.Valid(b) && len(b) > 0 {
+		printable := true
+		for _, r := range string(b) {
+			if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+				printable = false
+				break
+			}
+		}
+		if printable {
+			return strconv.Quote(string(b))
+		}
+	}
+	return "base64:" + base64.StdEncoding.EncodeToString(b)
+}
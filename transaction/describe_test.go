@@ -0,0 +1,68 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestDescribe(t *testing.T) {
+	sender := crypto.TestAccount("sender", 0)
+	receiver := crypto.TestAccount("receiver", 0)
+	params := types.SuggestedParams{
+		Fee:             1000,
+		FlatFee:         true,
+		FirstRoundValid: 100,
+		LastRoundValid:  1100,
+		GenesisID:       types.TestNetGenesisID,
+		GenesisHash:     types.TestNet.GenesisHash[:],
+	}
+	note, err := MakeJSONNote("mydapp", map[string]int{"n": 1})
+	require.NoError(t, err)
+	tx, err := MakePaymentTxn(sender.Address.String(), receiver.Address.String(), 1500000, note, "", params)
+	require.NoError(t, err)
+
+	description := Describe(tx)
+	require.Contains(t, description, "Type:      pay\n")
+	require.Contains(t, description, "ID:        "+crypto.GetTxID(tx)+"\n")
+	require.Contains(t, description, "Fee:       0.001 Algos\n")
+	require.Contains(t, description, "Valid:     rounds 100 to 1100\n")
+	require.Contains(t, description, "Genesis:   testnet-v1.0 (testnet)\n")
+	require.Contains(t, description, `Note:      ARC-2 mydapp: {"n":1}`+"\n")
+	require.Contains(t, description, "Receiver:  "+receiver.Address.String()+"\n")
+	require.Contains(t, description, "Amount:    1.5 Algos\n")
+	require.NotContains(t, description, "Close to")
+
+	stx := types.SignedTxn{Txn: tx, Sig: types.Signature{1}, AuthAddr: receiver.Address}
+	description = DescribeSigned(stx)
+	require.Contains(t, description, "Signature:     single\n")
+	require.Contains(t, description, "Auth address:  "+receiver.Address.String()+"\n")
+
+	encoded, err := DescribeJSON(stx)
+	require.NoError(t, err)
+	require.Contains(t, string(encoded), `"snd": "`+sender.Address.String()+`"`)
+
+	call, err := MakeApplicationNoOpTx(7, [][]byte{[]byte("add"), {0, 1}}, nil, nil, []uint64{9}, params, sender.Address, nil, types.Digest{}, [32]byte{}, types.ZeroAddress)
+	require.NoError(t, err)
+	description = Describe(call)
+	require.Contains(t, description, "Application:      7\n")
+	require.Contains(t, description, "On completion:    NoOp\n")
+	require.Contains(t, description, "Argument 0:       \"add\"\n")
+	require.Contains(t, description, "Argument 1:       base64:AAE=\n")
+	require.Contains(t, description, "Foreign asset 0:  9\n")
+
+	optIn, err := MakeAssetAcceptanceTxn(sender.Address.String(), nil, params, 5)
+	require.NoError(t, err)
+	description = Describe(optIn)
+	require.Contains(t, description, "Action:   opt-in\n")
+}
+
+func TestFormatAlgos(t *testing.T) {
+	require.Equal(t, "0 Algos", formatAlgos(0))
+	require.Equal(t, "0.000001 Algos", formatAlgos(1))
+	require.Equal(t, "12 Algos", formatAlgos(12000000))
+	require.Equal(t, "12.34 Algos", formatAlgos(12340000))
+}
@@ -23,12 +23,17 @@ const (
 )
 
 // CreateDryrun creates a DryrunRequest object from a client and slice of SignedTxn objects and a default configuration
-// Passed in as a pointer to a DryrunRequest object to use for extra parameters
+// Passed in as a pointer to a DryrunRequest object to use for extra parameters. Any Apps and Accounts
+// set on it are included as-is and are not fetched from algod, except for the creator of an App
+// that does not set it, and the account of each App's creator.
 func CreateDryrun(client *algod.Client, txns []types.SignedTxn, dr *models.DryrunRequest, ctx context.Context) (drr models.DryrunRequest, err error) { //nolint:revive // Ignore Context order for backwards compatibility
 	var (
 		apps   []types.AppIndex
 		assets []types.AssetIndex
 		accts  []types.Address
+
+		seenApps  = map[types.AppIndex]bool{}
+		seenAccts = map[types.Address]bool{}
 	)
 
 	drr.Txns = txns
@@ -38,6 +43,35 @@ func CreateDryrun(client *algod.Client, txns []types.SignedTxn, dr *models.Dryru
 		drr.Round = dr.Round
 		drr.ProtocolVersion = dr.ProtocolVersion
 		drr.Sources = dr.Sources
+
+		for _, app := range dr.Apps {
+			// app_params_get AppCreator reads the creator, which callers
+			// supplying only the programs of an app leave out
+			if app.Params.Creator == "" {
+				appInfo, err := client.GetApplicationByID(app.Id).Do(ctx)
+				if err != nil {
+					return drr, fmt.Errorf("failed to get creator of application %d: %+v", app.Id, err)
+				}
+				app.Params.Creator = appInfo.Params.Creator
+			}
+			creator, err := types.DecodeAddress(app.Params.Creator)
+			if err != nil {
+				return drr, fmt.Errorf("failed to decode creator address %s: %+v", app.Params.Creator, err)
+			}
+			accts = append(accts, creator)
+
+			drr.Apps = append(drr.Apps, app)
+			seenApps[types.AppIndex(app.Id)] = true
+		}
+
+		for _, acct := range dr.Accounts {
+			addr, err := types.DecodeAddress(acct.Address)
+			if err != nil {
+				return drr, fmt.Errorf("failed to decode account address %s: %+v", acct.Address, err)
+			}
+			drr.Accounts = append(drr.Accounts, acct)
+			seenAccts[addr] = true
+		}
 	}
 
 	for _, t := range txns {
@@ -99,7 +133,6 @@ func CreateDryrun(client *algod.Client, txns []types.SignedTxn, dr *models.Dryru
 		seenAssets[assetID] = true
 	}
 
-	seenApps := map[types.AppIndex]bool{}
 	for _, appID := range apps {
 		if _, ok := seenApps[appID]; ok {
 			continue
@@ -120,14 +153,13 @@ func CreateDryrun(client *algod.Client, txns []types.SignedTxn, dr *models.Dryru
 		seenApps[appID] = true
 	}
 
-	seenAccts := map[types.Address]bool{}
 	for _, acct := range accts {
 		if _, ok := seenAccts[acct]; ok {
 			continue
 		}
 		acctInfo, err := client.AccountInformation(acct.String()).Do(ctx)
 		if err != nil {
-			return drr, fmt.Errorf("failed to get application %s: %+v", acct, err)
+			return drr, fmt.Errorf("failed to get account %s: %+v", acct, err)
 		}
 		drr.Accounts = append(drr.Accounts, acctInfo)
 		seenAccts[acct] = true
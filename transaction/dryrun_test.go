@@ -0,0 +1,115 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDryrun(t *testing.T) {
+	sender := crypto.GenerateAccount().Address
+	creator := crypto.GenerateAccount().Address
+	const appID = 10
+
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v2/applications/"):
+			w.Write(json.Encode(models.Application{Id: appID, Params: models.ApplicationParams{Creator: creator.String()}}))
+		case strings.HasPrefix(r.URL.Path, "/v2/accounts/"):
+			w.Write(json.Encode(models.Account{Address: strings.TrimPrefix(r.URL.Path, "/v2/accounts/")}))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	stxn := types.SignedTxn{Txn: types.Transaction{
+		Type:   types.ApplicationCallTx,
+		Header: types.Header{Sender: sender},
+		ApplicationFields: types.ApplicationFields{
+			ApplicationCallTxnFields: types.ApplicationCallTxnFields{ApplicationID: appID},
+		},
+	}}
+
+	// The sender's account is supplied by the caller and must not be fetched.
+	extra := models.DryrunRequest{
+		Round:    5,
+		Accounts: []models.Account{{Address: sender.String(), Amount: 1000}},
+	}
+	drr, err := CreateDryrun(client, []types.SignedTxn{stxn}, &extra, context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(5), drr.Round)
+	require.Len(t, drr.Apps, 1)
+	require.Equal(t, uint64(appID), drr.Apps[0].Id)
+
+	var addresses []string
+	for _, acct := range drr.Accounts {
+		addresses = append(addresses, acct.Address)
+	}
+	require.ElementsMatch(t, []string{sender.String(), crypto.GetApplicationAddress(appID).String(), creator.String()}, addresses)
+	require.Equal(t, uint64(1000), drr.Accounts[0].Amount)
+	require.NotContains(t, requested, "/v2/accounts/"+sender.String())
+}
+
+func TestCreateDryrunSuppliedAppCreator(t *testing.T) {
+	sender := crypto.GenerateAccount().Address
+	creator := crypto.GenerateAccount().Address
+	const appID = 10
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == fmt.Sprintf("/v2/applications/%d", appID):
+			w.Write(json.Encode(models.Application{Id: appID, Params: models.ApplicationParams{Creator: creator.String()}}))
+		case strings.HasPrefix(r.URL.Path, "/v2/accounts/"):
+			w.Write(json.Encode(models.Account{Address: strings.TrimPrefix(r.URL.Path, "/v2/accounts/")}))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	stxn := types.SignedTxn{Txn: types.Transaction{
+		Type:   types.ApplicationCallTx,
+		Header: types.Header{Sender: sender},
+		ApplicationFields: types.ApplicationFields{
+			ApplicationCallTxnFields: types.ApplicationCallTxnFields{ApplicationID: appID},
+		},
+	}}
+
+	// The caller supplies the programs of the app but not its creator, which
+	// must be fetched along with the creator's account.
+	program := []byte{0x08, 0x81, 0x01}
+	extra := models.DryrunRequest{
+		Apps: []models.Application{{Id: appID, Params: models.ApplicationParams{ApprovalProgram: program}}},
+	}
+	drr, err := CreateDryrun(client, []types.SignedTxn{stxn}, &extra, context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, drr.Apps, 1)
+	require.Equal(t, creator.String(), drr.Apps[0].Params.Creator)
+	require.Equal(t, program, drr.Apps[0].Params.ApprovalProgram)
+	var addresses []string
+	for _, acct := range drr.Accounts {
+		addresses = append(addresses, acct.Address)
+	}
+	require.Contains(t, addresses, creator.String())
+}
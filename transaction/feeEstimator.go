@@ -0,0 +1,308 @@
+package transaction
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/protocol"
+	"github.com/algorand/go-algorand-sdk/v2/protocol/config"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// DefaultFeeSampleBlocks is the number of recent blocks a FeeEstimator samples
+// by default.
+const DefaultFeeSampleBlocks = 10
+
+// FeeSample is the recent fee activity of the network a FeeStrategy suggests
+// a fee from.
+type FeeSample struct {
+	// MinFee is the minimum fee of a transaction.
+	MinFee types.MicroAlgos
+	// Multipliers are, for each group of transactions of the sampled blocks
+	// and pool, the fees it paid relative to the minimum fees of its
+	// transactions, inner transactions included, sorted. Taking the group as
+	// a whole, fees pooled in one of its transactions count as paid for all
+	// of them.
+	Multipliers []float64
+	// Fullness is, for each sampled block from the oldest, the length of its
+	// encoded transactions relative to the most a block may hold.
+	Fullness []float64
+}
+
+// FeeStrategy suggests a fee from the recent fee activity of the network.
+type FeeStrategy interface {
+	// Multiplier returns the multiple of the minimum fee to pay, at least 1.
+	Multiplier(sample FeeSample) float64
+}
+
+// MinFeeStrategy always pays the minimum fee.
+type MinFeeStrategy struct{}
+
+// Multiplier returns 1.
+func (MinFeeStrategy) Multiplier(FeeSample) float64 {
+	return 1
+}
+
+// PercentileFeeStrategy pays as much as a percentile of the recent fees.
+type PercentileFeeStrategy struct {
+	// Percentile of the fees to pay, from 0 to 100.
+	Percentile float64
+}
+
+// Multiplier returns the Percentile-th percentile of the multipliers of the
+// sample, or 1 if that is lower or the sample has none.
+func (s PercentileFeeStrategy) Multiplier(sample FeeSample) float64 {
+	if len(sample.Multipliers) == 0 {
+		return 1
+	}
+	return math.Max(1, sample.Multipliers[percentileIndex(len(sample.Multipliers), s.Percentile)])
+}
+
+// RampFeeStrategy raises the fee while blocks are fuller than a target, and
+// lowers it back while they are emptier, as EIP-1559 does the base fee: after
+// each sampled block, the multiplier changes by MaxChange times how far the
+// block is from the target, relative to the target.
+type RampFeeStrategy struct {
+	// Target fullness of blocks, 0.5 if zero.
+	Target float64
+	// MaxChange is the largest relative change of the multiplier after a
+	// block, 0.125 if zero.
+	MaxChange float64
+	// Max is the largest multiplier, unlimited if zero.
+	Max float64
+}
+
+// Multiplier returns the multiplier after ramping up and down from 1 over the
+// sampled blocks.
+func (s RampFeeStrategy) Multiplier(sample FeeSample) float64 {
+	target := s.Target
+	if target <= 0 {
+		target = 0.5
+	}
+	maxChange := s.MaxChange
+	if maxChange <= 0 {
+		maxChange = 0.125
+	}
+
+	multiplier := 1.0
+	for _, fullness := range sample.Fullness {
+		multiplier *= 1 + maxChange*(fullness-target)/target
+		multiplier = math.Max(1, multiplier)
+		if s.Max > 0 {
+			multiplier = math.Min(s.Max, multiplier)
+		}
+	}
+	return multiplier
+}
+
+// FeeEstimator suggests fees by sampling the recent blocks, and optionally
+// the transaction pool, of a node. It keeps the fees of the blocks it sampled,
+// so that each call only fetches the blocks made since the previous one.
+type FeeEstimator struct {
+	Client *algod.Client
+	// Strategy suggests the fee from the sample, MinFeeStrategy if nil.
+	Strategy FeeStrategy
+	// Blocks is the number of recent blocks to sample,
+	// DefaultFeeSampleBlocks if zero.
+	Blocks uint64
+	// Pool, if true, also samples the fees of the transaction pool.
+	Pool bool
+
+	mu sync.Mutex
+	// blocks are the fees of the sampled blocks, by round.
+	blocks map[uint64]blockFees
+}
+
+// NewFeeEstimator returns a FeeEstimator sampling the node of c, suggesting
+// fees with strategy.
+func NewFeeEstimator(c *algod.Client, strategy FeeStrategy) *FeeEstimator {
+	return &FeeEstimator{Client: c, Strategy: strategy}
+}
+
+// blockFees are the fees of a block.
+type blockFees struct {
+	groups   []groupFee
+	fullness float64
+	// hasFullness is false if the consensus version of the block is unknown.
+	hasFullness bool
+}
+
+// groupFee is the fee paid by a group of transactions, and the number of
+// transactions, inner transactions included, it paid for.
+type groupFee struct {
+	fee  uint64
+	txns int
+}
+
+// Sample returns the recent fee activity of the network.
+func (e *FeeEstimator) Sample(ctx context.Context, headers ...*common.Header) (FeeSample, error) {
+	_, sample, err := e.sample(ctx, headers)
+	return sample, err
+}
+
+// sample returns the suggested params of the node with the recent fee
+// activity of the network.
+func (e *FeeEstimator) sample(ctx context.Context, headers []*common.Header) (types.SuggestedParams, FeeSample, error) {
+	params, err := e.Client.SuggestedParams().Do(ctx, headers...)
+	if err != nil {
+		return types.SuggestedParams{}, FeeSample{}, err
+	}
+	sample := FeeSample{MinFee: types.MicroAlgos(params.MinFee)}
+	if sample.MinFee == 0 {
+		sample.MinFee = MinTxnFee
+	}
+
+	blocks := e.Blocks
+	if blocks == 0 {
+		blocks = DefaultFeeSampleBlocks
+	}
+	last := uint64(params.FirstRoundValid)
+	first := uint64(1)
+	if last >= blocks {
+		first = last - blocks + 1
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.blocks == nil {
+		e.blocks = make(map[uint64]blockFees)
+	}
+	for round := range e.blocks {
+		if round < first || round > last {
+			delete(e.blocks, round)
+		}
+	}
+	for round := first; round <= last; round++ {
+		fees, ok := e.blocks[round]
+		if !ok {
+			block, err := e.Client.Block(round).Do(ctx, headers...)
+			if err != nil {
+				return types.SuggestedParams{}, FeeSample{}, err
+			}
+			fees = makeBlockFees(block)
+			e.blocks[round] = fees
+		}
+		sample.addGroups(fees.groups)
+		if fees.hasFullness {
+			sample.Fullness = append(sample.Fullness, fees.fullness)
+		}
+	}
+
+	if e.Pool {
+		_, txns, err := e.Client.PendingTransactions().Do(ctx, headers...)
+		if err != nil {
+			return types.SuggestedParams{}, FeeSample{}, err
+		}
+		stxns := make([]types.SignedTxnWithAD, len(txns))
+		for i, stx := range txns {
+			stxns[i].SignedTxn = stx
+		}
+		sample.addGroups(groupFees(stxns))
+	}
+	sort.Float64s(sample.Multipliers)
+	return params, sample, nil
+}
+
+// makeBlockFees returns the fees of the groups of a block, and its fullness.
+func makeBlockFees(block types.Block) blockFees {
+	stxns := make([]types.SignedTxnWithAD, len(block.Payset))
+	for i, stib := range block.Payset {
+		stxns[i] = stib.SignedTxnWithAD
+	}
+	fees := blockFees{groups: groupFees(stxns)}
+	maxBytes := config.MaxTxnBytesPerBlock
+	if consensus, ok := config.Consensus[protocol.ConsensusVersion(block.CurrentProtocol)]; ok {
+		maxBytes = consensus.MaxTxnBytesPerBlock
+	}
+	if maxBytes > 0 {
+		fees.fullness = float64(len(msgpack.Encode(block.Payset))) / float64(maxBytes)
+		fees.hasFullness = true
+	}
+	return fees
+}
+
+// groupFees returns the fees paid by the groups of stxns, in the order the
+// groups start. Transactions outside a group are groups of their own.
+func groupFees(stxns []types.SignedTxnWithAD) []groupFee {
+	var groups []groupFee
+	index := make(map[types.Digest]int)
+	for _, stxn := range stxns {
+		grouped := stxn.Txn.Group != types.Digest{}
+		i, ok := index[stxn.Txn.Group]
+		if !ok || !grouped {
+			i = len(groups)
+			groups = append(groups, groupFee{})
+			if grouped {
+				index[stxn.Txn.Group] = i
+			}
+		}
+		groups[i].fee += uint64(stxn.Txn.Fee)
+		groups[i].txns += 1 + countInnerTxns(stxn.EvalDelta.InnerTxns)
+	}
+	return groups
+}
+
+// countInnerTxns returns the number of inner transactions, theirs included.
+func countInnerTxns(inners []types.SignedTxnWithAD) int {
+	count := len(inners)
+	for _, inner := range inners {
+		count += countInnerTxns(inner.EvalDelta.InnerTxns)
+	}
+	return count
+}
+
+// addGroups adds the multipliers of the fees of groups, leaving out groups
+// that paid no fee.
+func (s *FeeSample) addGroups(groups []groupFee) {
+	for _, g := range groups {
+		if g.fee > 0 {
+			s.Multipliers = append(s.Multipliers, float64(g.fee)/(float64(s.MinFee)*float64(g.txns)))
+		}
+	}
+}
+
+// Multiplier returns the multiple of the minimum fee the strategy suggests
+// paying.
+func (e *FeeEstimator) Multiplier(ctx context.Context, headers ...*common.Header) (float64, error) {
+	_, sample, err := e.sample(ctx, headers)
+	if err != nil {
+		return 0, err
+	}
+	return e.strategy().Multiplier(sample), nil
+}
+
+// SuggestedParams returns the suggested params of the node with the flat fee
+// the strategy suggests, to be passed to the Make*Txn functions.
+func (e *FeeEstimator) SuggestedParams(ctx context.Context, headers ...*common.Header) (types.SuggestedParams, error) {
+	params, sample, err := e.sample(ctx, headers)
+	if err != nil {
+		return types.SuggestedParams{}, err
+	}
+	params.MinFee = uint64(sample.MinFee)
+	return WithFeeMultiplier(params, e.strategy().Multiplier(sample)), nil
+}
+
+func (e *FeeEstimator) strategy() FeeStrategy {
+	if e.Strategy == nil {
+		return MinFeeStrategy{}
+	}
+	return e.Strategy
+}
+
+// WithFeeMultiplier returns params with a flat fee of multiplier times the
+// minimum fee, rounded up, so that transactions built with them pay it.
+// The minimum fee is params.MinFee, or MinTxnFee if unset.
+func WithFeeMultiplier(params types.SuggestedParams, multiplier float64) types.SuggestedParams {
+	minFee := params.MinFee
+	if minFee == 0 {
+		minFee = MinTxnFee
+	}
+	params.FlatFee = true
+	params.Fee = types.MicroAlgos(math.Ceil(math.Max(1, multiplier) * float64(minFee)))
+	return params
+}
@@ -0,0 +1,143 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestFeeStrategies(t *testing.T) {
+	sample := FeeSample{
+		MinFee:      1000,
+		Multipliers: []float64{1, 1, 2, 4},
+		Fullness:    []float64{1, 1, 0},
+	}
+
+	require.Equal(t, 1.0, MinFeeStrategy{}.Multiplier(sample))
+
+	require.Equal(t, 1.0, PercentileFeeStrategy{Percentile: 50}.Multiplier(sample))
+	require.Equal(t, 2.0, PercentileFeeStrategy{Percentile: 75}.Multiplier(sample))
+	require.Equal(t, 4.0, PercentileFeeStrategy{Percentile: 100}.Multiplier(sample))
+	require.Equal(t, 1.0, PercentileFeeStrategy{Percentile: 100}.Multiplier(FeeSample{MinFee: 1000}))
+
+	// Full blocks raise the multiplier by 12.5% each, an empty one lowers it
+	// by 12.5%.
+	require.InDelta(t, 1.125*1.125*0.875, RampFeeStrategy{}.Multiplier(sample), 1e-9)
+	require.Equal(t, 1.2, RampFeeStrategy{Max: 1.2}.Multiplier(FeeSample{Fullness: []float64{1, 1, 1}}))
+	require.Equal(t, 1.0, RampFeeStrategy{}.Multiplier(FeeSample{Fullness: []float64{0, 0}}))
+	require.InDelta(t, 1.5, RampFeeStrategy{Target: 0.5, MaxChange: 0.5}.Multiplier(FeeSample{Fullness: []float64{1}}), 1e-9)
+}
+
+func TestWithFeeMultiplier(t *testing.T) {
+	params := WithFeeMultiplier(types.SuggestedParams{Fee: 10, MinFee: 1000}, 1.2345)
+	require.True(t, params.FlatFee)
+	require.Equal(t, types.MicroAlgos(1235), params.Fee)
+
+	params = WithFeeMultiplier(types.SuggestedParams{}, 0.5)
+	require.Equal(t, types.MicroAlgos(MinTxnFee), params.Fee)
+
+	sender := "47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU"
+	tx, err := MakePaymentTxn(sender, sender, 1, nil, "", WithFeeMultiplier(types.SuggestedParams{MinFee: 1000, LastRoundValid: 1000, GenesisHash: make([]byte, 32)}, 3))
+	require.NoError(t, err)
+	require.Equal(t, types.MicroAlgos(3000), tx.Fee)
+}
+
+func TestFeeEstimator(t *testing.T) {
+	group := types.Digest{1}
+	blocks := map[uint64][]types.SignedTxnInBlock{8: make([]types.SignedTxnInBlock, 1), 9: make([]types.SignedTxnInBlock, 3), 10: make([]types.SignedTxnInBlock, 1), 11: make([]types.SignedTxnInBlock, 1)}
+	blocks[8][0].Txn.Fee = 1000
+	blocks[9][0].Txn.Fee = 1000
+	// a group paying 6000 in one of its two transactions, one of which makes
+	// an inner transaction, pays twice the minimum fee of each
+	blocks[9][1].Txn.Group = group
+	blocks[9][1].Txn.Fee = 6000
+	blocks[9][2].Txn.Group = group
+	blocks[9][2].EvalDelta.InnerTxns = []types.SignedTxnWithAD{{}}
+	blocks[10][0].Txn.Fee = 5000
+	blocks[11][0].Txn.Fee = 1000
+
+	var lastRound atomic.Uint64
+	lastRound.Store(10)
+	fetched := make(map[uint64]int)
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/transactions/params":
+			w.Write(json.Encode(models.TransactionParametersResponse{Fee: 0, LastRound: lastRound.Load(), MinFee: 1000}))
+		case "/v2/transactions/pending":
+			w.Write(msgpack.Encode(models.PendingTransactionsResponse{
+				TopTransactions:   []types.SignedTxn{{Txn: types.Transaction{Header: types.Header{Fee: 8000}}}},
+				TotalTransactions: 1,
+			}))
+		default:
+			var round uint64
+			if _, err := fmt.Sscanf(r.URL.Path, "/v2/blocks/%d", &round); err != nil || blocks[round] == nil {
+				t.Errorf("unexpected request %s", r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			mu.Lock()
+			fetched[round]++
+			mu.Unlock()
+			w.Write(msgpack.Encode(models.BlockResponse{Block: types.Block{Payset: blocks[round]}}))
+		}
+	}))
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	estimator := NewFeeEstimator(client, nil)
+	estimator.Blocks = 3
+	sample, err := estimator.Sample(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, types.MicroAlgos(1000), sample.MinFee)
+	require.Equal(t, []float64{1, 1, 2, 5}, sample.Multipliers)
+	require.Len(t, sample.Fullness, 3)
+	for _, fullness := range sample.Fullness {
+		require.Greater(t, fullness, 0.0)
+		require.Less(t, fullness, 0.01)
+	}
+
+	params, err := estimator.SuggestedParams(context.Background())
+	require.NoError(t, err)
+	require.True(t, params.FlatFee)
+	require.Equal(t, types.MicroAlgos(1000), params.Fee)
+	require.Equal(t, types.Round(10), params.FirstRoundValid)
+
+	estimator.Strategy = PercentileFeeStrategy{Percentile: 100}
+	multiplier, err := estimator.Multiplier(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 5.0, multiplier)
+
+	estimator.Pool = true
+	params, err = estimator.SuggestedParams(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, types.MicroAlgos(8000), params.Fee)
+
+	// each block is fetched once: at the next round, the window moves to
+	// rounds 9 to 11 and only fetches the new block
+	lastRound.Store(11)
+	sample, err = estimator.Sample(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 1, 2, 5, 8}, sample.Multipliers)
+	require.Equal(t, map[uint64]int{8: 1, 9: 1, 10: 1, 11: 1}, fetched)
+
+	cache := NewSuggestedParamsCacheWithFees(estimator, 0, time.Hour)
+	params, err = cache.Get(context.Background())
+	require.NoError(t, err)
+	require.True(t, params.FlatFee)
+	require.Equal(t, types.MicroAlgos(8000), params.Fee)
+}
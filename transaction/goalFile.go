@@ -0,0 +1,67 @@
+package transaction
+
+import (
+	"bytes"
+	"io"
+	"os"
+
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// ReadSignedTxns reads the concatenated msgpack signed transactions of r, as
+// in the .txn and .stxn files of goal clerk. The transactions of an unsigned
+// file have no signature.
+func ReadSignedTxns(r io.Reader) ([]types.SignedTxn, error) {
+	var stxns []types.SignedTxn
+	err := msgpack.DecodeStream(r, func(stxn types.SignedTxn) error {
+		stxns = append(stxns, stxn)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stxns, nil
+}
+
+// WriteSignedTxns writes stxns to w as concatenated msgpack, as goal clerk
+// does.
+func WriteSignedTxns(w io.Writer, stxns []types.SignedTxn) error {
+	var buf bytes.Buffer
+	for _, stxn := range stxns {
+		buf.Write(msgpack.Encode(stxn))
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// WriteTransactions writes txns to w the way goal clerk send -o does, each as
+// a signed transaction without signature, for goal clerk sign to sign them.
+func WriteTransactions(w io.Writer, txns []types.Transaction) error {
+	stxns := make([]types.SignedTxn, len(txns))
+	for i, txn := range txns {
+		stxns[i].Txn = txn
+	}
+	return WriteSignedTxns(w, stxns)
+}
+
+// ReadTxnFile reads the signed, or unsigned, transactions of the goal clerk
+// transaction file at path.
+func ReadTxnFile(path string) ([]types.SignedTxn, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadSignedTxns(f)
+}
+
+// WriteTxnFile writes stxns to a goal clerk transaction file at path,
+// readable by its owner only as goal does.
+func WriteTxnFile(path string, stxns []types.SignedTxn) error {
+	var buf bytes.Buffer
+	if err := WriteSignedTxns(&buf, stxns); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0600)
+}
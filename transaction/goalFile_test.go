@@ -0,0 +1,64 @@
+package transaction
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestGoalFiles(t *testing.T) {
+	sender := crypto.TestAccount("sender", 0)
+	params := types.SuggestedParams{Fee: 1000, FlatFee: true, FirstRoundValid: 1, LastRoundValid: 1001, GenesisHash: types.TestNet.GenesisHash[:]}
+	var txns []types.Transaction
+	for i := uint64(1); i <= 3; i++ {
+		tx, err := MakePaymentTxn(sender.Address.String(), sender.Address.String(), i, nil, "", params)
+		require.NoError(t, err)
+		txns = append(txns, tx)
+	}
+
+	// An unsigned file, as written by goal clerk send -o.
+	var unsigned bytes.Buffer
+	require.NoError(t, WriteTransactions(&unsigned, txns))
+	var expected []byte
+	for _, tx := range txns {
+		expected = append(expected, msgpack.Encode(types.SignedTxn{Txn: tx})...)
+	}
+	require.Equal(t, expected, unsigned.Bytes())
+	read, err := ReadSignedTxns(&unsigned)
+	require.NoError(t, err)
+	require.Len(t, read, 3)
+	for i, stxn := range read {
+		require.Equal(t, txns[i], stxn.Txn)
+	}
+
+	// A signed file, as written by goal clerk sign.
+	var stxns []types.SignedTxn
+	for _, tx := range txns {
+		_, signed, err := crypto.SignTransaction(sender.PrivateKey, tx)
+		require.NoError(t, err)
+		var stxn types.SignedTxn
+		require.NoError(t, msgpack.Decode(signed, &stxn))
+		stxns = append(stxns, stxn)
+	}
+	path := filepath.Join(t.TempDir(), "payments.stxn")
+	require.NoError(t, WriteTxnFile(path, stxns))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	read, err = ReadTxnFile(path)
+	require.NoError(t, err)
+	require.Equal(t, stxns, read)
+
+	// A truncated file is an error.
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	_, err = ReadSignedTxns(bytes.NewReader(data[:len(data)-1]))
+	require.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package transaction
+
+import (
+	"errors"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+)
+
+// SkipInnerTransactions is returned by a WalkInnerTransactions callback to
+// skip the inner transactions of the transaction it was called with.
+var SkipInnerTransactions = errors.New("skip inner transactions") //nolint:revive // Sentinel like filepath.SkipDir
+
+// InnerTransaction is an inner transaction of a transaction result.
+type InnerTransaction struct {
+	// Path has the index of the inner transaction among those issued by its
+	// parent, preceded by the indexes of its ancestors, from the top-level
+	// transaction down.
+	Path []int
+
+	// ID is the ID of the inner transaction and ParentID the ID of the
+	// transaction that issued it.
+	ID       string
+	ParentID string
+
+	// Result is the result of the inner transaction, its own inner
+	// transactions included.
+	Result models.PendingTransactionResponse
+}
+
+// Depth returns 1 for the inner transactions issued by a top-level
+// transaction, 2 for those they issued, and so on.
+func (i InnerTransaction) Depth() int {
+	return len(i.Path)
+}
+
+// WalkInnerTransactions calls fn for every inner transaction of the result of
+// a top-level transaction, as returned by algod for a pending transaction or a
+// simulation, depth-first and in the order they were issued. If fn returns
+// SkipInnerTransactions the inner transactions of the one it was called with
+// are skipped, and any other error stops the walk and is returned.
+func WalkInnerTransactions(result models.PendingTransactionResponse, fn func(InnerTransaction) error) error {
+	topID := crypto.GetTxID(result.Transaction.Txn)
+	err := walkInnerTransactions(topID, topID, nil, result.InnerTxns, fn)
+	if err == SkipInnerTransactions {
+		return nil
+	}
+	return err
+}
+
+// walkInnerTransactions walks inners, issued by the transaction whose ID is
+// parentID. Their IDs derive from callerID, the ID GetTxID returns for that
+// transaction, which differs from parentID when it is an inner transaction.
+func walkInnerTransactions(parentID, callerID string, parentPath []int, inners []models.PendingTransactionResponse, fn func(InnerTransaction) error) error {
+	for i, inner := range inners {
+		id, err := crypto.InnerTransactionID(callerID, i, inner.Transaction.Txn)
+		if err != nil {
+			return err
+		}
+		path := append(append(make([]int, 0, len(parentPath)+1), parentPath...), i)
+		err = fn(InnerTransaction{Path: path, ID: id, ParentID: parentID, Result: inner})
+		if err == SkipInnerTransactions {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := walkInnerTransactions(id, crypto.GetTxID(inner.Transaction.Txn), path, inner.InnerTxns, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FlattenInnerTransactions returns every inner transaction of the result of a
+// top-level transaction, in the order of WalkInnerTransactions.
+func FlattenInnerTransactions(result models.PendingTransactionResponse) ([]InnerTransaction, error) {
+	var inners []InnerTransaction
+	err := WalkInnerTransactions(result, func(inner InnerTransaction) error {
+		inners = append(inners, inner)
+		return nil
+	})
+	return inners, err
+}
+
+// FlattenSimulateInnerTransactions returns the inner transactions of every
+// transaction simulated, by group and by transaction within the group.
+func FlattenSimulateInnerTransactions(response models.SimulateResponse) ([][][]InnerTransaction, error) {
+	groups := make([][][]InnerTransaction, len(response.TxnGroups))
+	for g, group := range response.TxnGroups {
+		groups[g] = make([][]InnerTransaction, len(group.TxnResults))
+		for t, result := range group.TxnResults {
+			inners, err := FlattenInnerTransactions(result.TxnResult)
+			if err != nil {
+				return nil, err
+			}
+			groups[g][t] = inners
+		}
+	}
+	return groups, nil
+}
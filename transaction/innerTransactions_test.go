@@ -0,0 +1,83 @@
+package transaction
+
+import (
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestWalkInnerTransactions(t *testing.T) {
+	txn := func(amount uint64) models.PendingTransactionResponse {
+		var r models.PendingTransactionResponse
+		r.Transaction.Txn = types.Transaction{Type: types.PaymentTx, PaymentTxnFields: types.PaymentTxnFields{Amount: types.MicroAlgos(amount)}}
+		return r
+	}
+	top := txn(0)
+	first, second := txn(1), txn(2)
+	second.InnerTxns = []models.PendingTransactionResponse{txn(3)}
+	top.InnerTxns = []models.PendingTransactionResponse{first, second}
+
+	inners, err := FlattenInnerTransactions(top)
+	require.NoError(t, err)
+	require.Len(t, inners, 3)
+
+	// the IDs are computed here from their definition, the hash of "TX", the
+	// raw ID of the caller, the big-endian index and the transaction, rather
+	// than with crypto.InnerTransactionID
+	innerID := func(callerID string, index uint64, tx types.Transaction) string {
+		caller, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(callerID)
+		require.NoError(t, err)
+		input := append([]byte("TX"), caller...)
+		input = binary.BigEndian.AppendUint64(input, index)
+		input = append(input, msgpack.Encode(tx)...)
+		id := sha512.Sum512_256(input)
+		return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(id[:])
+	}
+	topID := crypto.GetTxID(top.Transaction.Txn)
+	firstID := innerID(topID, 0, first.Transaction.Txn)
+	secondID := innerID(topID, 1, second.Transaction.Txn)
+	// a nested inner transaction derives from the plain ID of its caller, not
+	// from the inner transaction ID of its caller
+	nestedID := innerID(crypto.GetTxID(second.Transaction.Txn), 0, second.InnerTxns[0].Transaction.Txn)
+	require.NotEqual(t, innerID(secondID, 0, second.InnerTxns[0].Transaction.Txn), nestedID)
+
+	require.Equal(t, []int{0}, inners[0].Path)
+	require.Equal(t, firstID, inners[0].ID)
+	require.Equal(t, topID, inners[0].ParentID)
+	require.Equal(t, []int{1}, inners[1].Path)
+	require.Equal(t, secondID, inners[1].ID)
+	require.Equal(t, []int{1, 0}, inners[2].Path)
+	require.Equal(t, 2, inners[2].Depth())
+	require.Equal(t, nestedID, inners[2].ID)
+	require.Equal(t, secondID, inners[2].ParentID)
+	require.Equal(t, types.MicroAlgos(3), inners[2].Result.Transaction.Txn.Amount)
+
+	var visited [][]int
+	err = WalkInnerTransactions(top, func(inner InnerTransaction) error {
+		visited = append(visited, inner.Path)
+		return SkipInnerTransactions
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]int{{0}, {1}}, visited)
+
+	stop := errors.New("stop")
+	err = WalkInnerTransactions(top, func(inner InnerTransaction) error { return stop })
+	require.ErrorIs(t, err, stop)
+
+	simulated, err := FlattenSimulateInnerTransactions(models.SimulateResponse{TxnGroups: []models.SimulateTransactionGroupResult{
+		{TxnResults: []models.SimulateTransactionResult{{TxnResult: txn(9)}, {TxnResult: top}}},
+	}})
+	require.NoError(t, err)
+	require.Len(t, simulated, 1)
+	require.Empty(t, simulated[0][0])
+	require.Equal(t, inners, simulated[0][1])
+}
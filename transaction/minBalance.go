@@ -0,0 +1,98 @@
+package transaction
+
+import (
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// Consensus parameters of the minimum balance, in microAlgos, from the current
+// consensus version
+var (
+	// MinBalance is the minimum balance of an account, and the increase for
+	// each asset it holds.
+	MinBalance = consensusParams.MinBalance
+
+	// AppFlatParamsMinBalance is the increase of the minimum balance for each
+	// application created, and for each of their extra program pages.
+	AppFlatParamsMinBalance = consensusParams.AppFlatParamsMinBalance
+
+	// AppFlatOptInMinBalance is the increase of the minimum balance for each
+	// application opted in to.
+	AppFlatOptInMinBalance = consensusParams.AppFlatOptInMinBalance
+
+	// SchemaMinBalancePerEntry is the increase of the minimum balance for
+	// each entry of the state schemas of the applications created and opted in
+	// to, on top of SchemaUintMinBalance or SchemaBytesMinBalance.
+	SchemaMinBalancePerEntry = consensusParams.SchemaMinBalancePerEntry
+
+	// SchemaUintMinBalance is the increase of the minimum balance for each
+	// integer entry of a state schema.
+	SchemaUintMinBalance = consensusParams.SchemaUintMinBalance
+
+	// SchemaBytesMinBalance is the increase of the minimum balance for each
+	// byte slice entry of a state schema.
+	SchemaBytesMinBalance = consensusParams.SchemaBytesMinBalance
+)
+
+// AccountHoldings are what the minimum balance of an account depends on.
+type AccountHoldings struct {
+	// Assets is the number of assets held, created assets included.
+	Assets uint64
+
+	// CreatedApps and OptedInApps are the numbers of applications created
+	// and opted in to.
+	CreatedApps uint64
+	OptedInApps uint64
+
+	// TotalSchema is the sum of the global schemas of the applications created
+	// and of the local schemas of the applications opted in to.
+	TotalSchema types.StateSchema
+
+	// ExtraPages is the total of the extra program pages of the applications
+	// created.
+	ExtraPages uint64
+
+	// Boxes and BoxBytes are the number of boxes of the account, when it is
+	// an application account, and the total length of their names and
+	// values.
+	Boxes    uint64
+	BoxBytes uint64
+}
+
+// AccountHoldingsFromModel returns the holdings of an account returned by
+// algod.
+func AccountHoldingsFromModel(account models.Account) AccountHoldings {
+	return AccountHoldings{
+		Assets:      account.TotalAssetsOptedIn,
+		CreatedApps: account.TotalCreatedApps,
+		OptedInApps: account.TotalAppsOptedIn,
+		TotalSchema: types.StateSchema{
+			NumUint:      account.AppsTotalSchema.NumUint,
+			NumByteSlice: account.AppsTotalSchema.NumByteSlice,
+		},
+		ExtraPages: account.AppsTotalExtraPages,
+		Boxes:      account.TotalBoxes,
+		BoxBytes:   account.TotalBoxBytes,
+	}
+}
+
+// MinBalance returns the minimum balance of an account with holdings h. A
+// transaction adding to the holdings of an account, like an asset opt-in, is
+// rejected if the account would then be below its minimum balance:
+//
+//	holdings.Assets++
+//	if balance < holdings.MinBalance() {
+//		// the opt-in would fail
+//	}
+func (h AccountHoldings) MinBalance() types.MicroAlgos {
+	schema := SchemaMinBalancePerEntry*(h.TotalSchema.NumUint+h.TotalSchema.NumByteSlice) +
+		SchemaUintMinBalance*h.TotalSchema.NumUint +
+		SchemaBytesMinBalance*h.TotalSchema.NumByteSlice
+	return types.MicroAlgos(MinBalance +
+		MinBalance*h.Assets +
+		AppFlatParamsMinBalance*(h.CreatedApps+h.ExtraPages) +
+		AppFlatOptInMinBalance*h.OptedInApps +
+		schema +
+		BoxFlatMinBalance*h.Boxes +
+		BoxByteMinBalance*h.BoxBytes)
+}
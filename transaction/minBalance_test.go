@@ -0,0 +1,39 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestMinBalance(t *testing.T) {
+	require.Equal(t, types.MicroAlgos(100000), AccountHoldings{}.MinBalance())
+	require.Equal(t, types.MicroAlgos(300000), AccountHoldings{Assets: 2}.MinBalance())
+
+	// an application with 1 global int, 1 global byte slice and 1 extra page,
+	// opted in to an application with 2 local ints, holding a 10 byte box
+	h := AccountHoldings{
+		CreatedApps: 1,
+		OptedInApps: 1,
+		TotalSchema: types.StateSchema{NumUint: 3, NumByteSlice: 1},
+		ExtraPages:  1,
+		Boxes:       1,
+		BoxBytes:    10,
+	}
+	expected := 100000 + 2*100000 + 100000 + 3*(25000+3500) + (25000 + 25000) + 2500 + 400*10
+	require.Equal(t, types.MicroAlgos(expected), h.MinBalance())
+
+	account := models.Account{
+		TotalAssetsOptedIn:  0,
+		TotalCreatedApps:    1,
+		TotalAppsOptedIn:    1,
+		AppsTotalSchema:     models.ApplicationStateSchema{NumUint: 3, NumByteSlice: 1},
+		AppsTotalExtraPages: 1,
+		TotalBoxes:          1,
+		TotalBoxBytes:       10,
+	}
+	require.Equal(t, h, AccountHoldingsFromModel(account))
+}
@@ -0,0 +1,125 @@
+package transaction
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+)
+
+// NoteFormat is the data format of an ARC-2 note, which follows the dapp name
+// and separator.
+type NoteFormat byte
+
+const (
+	// NoteFormatMsgpack marks note data encoded as msgpack
+	NoteFormatMsgpack NoteFormat = 'm'
+	// NoteFormatJSON marks note data encoded as JSON
+	NoteFormatJSON NoteFormat = 'j'
+	// NoteFormatBytes marks arbitrary note data
+	NoteFormatBytes NoteFormat = 'b'
+	// NoteFormatUTF8 marks note data that is a UTF-8 string
+	NoteFormatUTF8 NoteFormat = 'u'
+)
+
+// maxNoteLength is the largest note the network accepts on a transaction.
+const maxNoteLength = 1024
+
+// dappNameRegexp matches the dapp names allowed by ARC-2.
+var dappNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_/@.-]{4,31}$`)
+
+// Note is a decoded ARC-2 note of the form <dapp-name>:<format><data>.
+type Note struct {
+	DappName string
+	Format   NoteFormat
+	Data     []byte
+}
+
+// Encode returns the bytes of the note, ready to be set as a transaction Note.
+func (n Note) Encode() ([]byte, error) {
+	if !dappNameRegexp.MatchString(n.DappName) {
+		return nil, fmt.Errorf("invalid ARC-2 dapp name %q", n.DappName)
+	}
+
+	switch n.Format {
+	case NoteFormatMsgpack, NoteFormatJSON, NoteFormatBytes:
+	case NoteFormatUTF8:
+		if !utf8.Valid(n.Data) {
+			return nil, fmt.Errorf("ARC-2 note data is not valid UTF-8")
+		}
+	default:
+		return nil, fmt.Errorf("unknown ARC-2 note format %q", n.Format)
+	}
+
+	note := make([]byte, 0, len(n.DappName)+2+len(n.Data))
+	note = append(note, n.DappName...)
+	note = append(note, ':', byte(n.Format))
+	note = append(note, n.Data...)
+	if len(note) > maxNoteLength {
+		return nil, fmt.Errorf("ARC-2 note is %d bytes, at most %d are allowed", len(note), maxNoteLength)
+	}
+	return note, nil
+}
+
+// Decode unmarshals the data of a msgpack or JSON note into v.
+func (n Note) Decode(v interface{}) error {
+	switch n.Format {
+	case NoteFormatMsgpack:
+		return msgpack.Decode(n.Data, v)
+	case NoteFormatJSON:
+		return json.LenientDecode(n.Data, v)
+	default:
+		return fmt.Errorf("cannot decode ARC-2 note data of format %q", n.Format)
+	}
+}
+
+// MakeMsgpackNote builds an ARC-2 note for dappName holding v encoded as msgpack.
+func MakeMsgpackNote(dappName string, v interface{}) ([]byte, error) {
+	return Note{DappName: dappName, Format: NoteFormatMsgpack, Data: msgpack.Encode(v)}.Encode()
+}
+
+// MakeJSONNote builds an ARC-2 note for dappName holding v encoded as compact
+// JSON.
+func MakeJSONNote(dappName string, v interface{}) ([]byte, error) {
+	var data bytes.Buffer
+	if err := stdjson.Compact(&data, json.Encode(v)); err != nil {
+		return nil, err
+	}
+	return Note{DappName: dappName, Format: NoteFormatJSON, Data: data.Bytes()}.Encode()
+}
+
+// MakeUTF8Note builds an ARC-2 note for dappName holding a UTF-8 string.
+func MakeUTF8Note(dappName string, s string) ([]byte, error) {
+	return Note{DappName: dappName, Format: NoteFormatUTF8, Data: []byte(s)}.Encode()
+}
+
+// MakeBytesNote builds an ARC-2 note for dappName holding arbitrary bytes.
+func MakeBytesNote(dappName string, data []byte) ([]byte, error) {
+	return Note{DappName: dappName, Format: NoteFormatBytes, Data: data}.Encode()
+}
+
+// ParseNote decodes an ARC-2 note. It returns an error if the note does not
+// follow the ARC-2 convention, which makes it usable as a filter.
+func ParseNote(note []byte) (n Note, err error) {
+	sep := bytes.IndexByte(note, ':')
+	if sep < 0 || sep+1 >= len(note) {
+		err = fmt.Errorf("note is not of the form <dapp-name>:<format><data>")
+		return
+	}
+
+	n = Note{
+		DappName: string(note[:sep]),
+		Format:   NoteFormat(note[sep+1]),
+		Data:     note[sep+2:],
+	}
+
+	// Encode validates the dapp name, format and data.
+	if _, err = n.Encode(); err != nil {
+		n = Note{}
+	}
+	return
+}
@@ -0,0 +1,67 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testNotePayload struct {
+	Action string `codec:"action"`
+	Count  uint64 `codec:"count"`
+}
+
+func TestNoteRoundTrip(t *testing.T) {
+	payload := testNotePayload{Action: "mint", Count: 3}
+
+	note, err := MakeJSONNote("my-dapp", payload)
+	require.NoError(t, err)
+	require.Equal(t, `my-dapp:j{"action":"mint","count":3}`, string(note))
+
+	parsed, err := ParseNote(note)
+	require.NoError(t, err)
+	require.Equal(t, "my-dapp", parsed.DappName)
+	require.Equal(t, NoteFormatJSON, parsed.Format)
+	var decoded testNotePayload
+	require.NoError(t, parsed.Decode(&decoded))
+	require.Equal(t, payload, decoded)
+
+	note, err = MakeMsgpackNote("my-dapp", payload)
+	require.NoError(t, err)
+	parsed, err = ParseNote(note)
+	require.NoError(t, err)
+	require.Equal(t, NoteFormatMsgpack, parsed.Format)
+	decoded = testNotePayload{}
+	require.NoError(t, parsed.Decode(&decoded))
+	require.Equal(t, payload, decoded)
+
+	note, err = MakeUTF8Note("algo.market", "hello, world")
+	require.NoError(t, err)
+	require.Equal(t, "algo.market:uhello, world", string(note))
+
+	note, err = MakeBytesNote("algo.market", []byte{0xff, 0x00})
+	require.NoError(t, err)
+	parsed, err = ParseNote(note)
+	require.NoError(t, err)
+	require.Equal(t, Note{DappName: "algo.market", Format: NoteFormatBytes, Data: []byte{0xff, 0x00}}, parsed)
+	require.Error(t, parsed.Decode(&decoded))
+}
+
+func TestNoteErrors(t *testing.T) {
+	_, err := MakeUTF8Note("abc", "dapp name too short")
+	require.ErrorContains(t, err, "dapp name")
+
+	_, err = MakeUTF8Note("-leading-dash", "hi")
+	require.ErrorContains(t, err, "dapp name")
+
+	_, err = MakeUTF8Note("my-dapp", "\xff")
+	require.ErrorContains(t, err, "UTF-8")
+
+	_, err = MakeBytesNote("my-dapp", make([]byte, maxNoteLength))
+	require.ErrorContains(t, err, "at most")
+
+	for _, note := range []string{"no separator", "my-dapp:", "my-dapp:xdata", "a b c d:uhello"} {
+		_, err = ParseNote([]byte(note))
+		require.Error(t, err, note)
+	}
+}
@@ -0,0 +1,105 @@
+package transaction
+
+import (
+	"context"
+	"sort"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// PendingPoolSummary describes the pressure on a node's transaction pool, for
+// choosing fees. Fees are those of the summarized transactions, which may be
+// only the top of the pool as returned by the node.
+type PendingPoolSummary struct {
+	// Total is the number of transactions in the pool, as reported by the node.
+	Total uint64
+	// Count is the number of transactions summarized.
+	Count int
+	// Size is the total length of the encoded transactions summarized.
+	Size uint64
+
+	// Fees of the summarized transactions, zero if there are none.
+	MinFee    types.MicroAlgos
+	MedianFee types.MicroAlgos
+	MaxFee    types.MicroAlgos
+
+	// Fees per byte of encoded signed transaction, rounded down.
+	MinFeePerByte    uint64
+	MedianFeePerByte uint64
+	MaxFeePerByte    uint64
+
+	// feesPerByte are the sorted fees per byte, for FeePercentile.
+	feesPerByte []uint64
+}
+
+// FeePercentile returns the fee per byte that p percent of the summarized
+// transactions pay at most. It is zero if no transactions were summarized.
+func (s PendingPoolSummary) FeePercentile(p float64) uint64 {
+	return percentile(s.feesPerByte, p)
+}
+
+// SummarizePendingTransactions summarizes txns, the transactions of a pool of
+// total transactions as returned by algod.Client.PendingTransactions.
+func SummarizePendingTransactions(total uint64, txns []types.SignedTxn) PendingPoolSummary {
+	summary := PendingPoolSummary{Total: total, Count: len(txns)}
+	if len(txns) == 0 {
+		return summary
+	}
+
+	fees := make([]uint64, len(txns))
+	feesPerByte := make([]uint64, len(txns))
+	for i, stx := range txns {
+		size := uint64(len(msgpack.Encode(stx)))
+		summary.Size += size
+		fees[i] = uint64(stx.Txn.Fee)
+		feesPerByte[i] = fees[i] / size
+	}
+	sort.Slice(fees, func(i, j int) bool { return fees[i] < fees[j] })
+	sort.Slice(feesPerByte, func(i, j int) bool { return feesPerByte[i] < feesPerByte[j] })
+
+	summary.MinFee = types.MicroAlgos(fees[0])
+	summary.MedianFee = types.MicroAlgos(percentile(fees, 50))
+	summary.MaxFee = types.MicroAlgos(fees[len(fees)-1])
+	summary.MinFeePerByte = feesPerByte[0]
+	summary.MedianFeePerByte = percentile(feesPerByte, 50)
+	summary.MaxFeePerByte = feesPerByte[len(feesPerByte)-1]
+	summary.feesPerByte = feesPerByte
+	return summary
+}
+
+// SummarizePendingPool fetches up to max transactions of the pool of the node,
+// all of them if max is zero, and summarizes them.
+func SummarizePendingPool(ctx context.Context, c *algod.Client, max uint64, headers ...*common.Header) (PendingPoolSummary, error) {
+	total, txns, err := c.PendingTransactions().Max(max).Do(ctx, headers...)
+	if err != nil {
+		return PendingPoolSummary{}, err
+	}
+	return SummarizePendingTransactions(total, txns), nil
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted values.
+func percentile(sorted []uint64, p float64) uint64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	return sorted[percentileIndex(len(sorted), p)]
+}
+
+// percentileIndex returns the index of the nearest-rank p-th percentile of n
+// sorted values, n being positive.
+func percentileIndex(n int, p float64) int {
+	rank := int(p / 100 * float64(n))
+	if float64(rank) < p/100*float64(n) {
+		rank++
+	}
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > n {
+		rank = n
+	}
+	return rank - 1
+}
@@ -0,0 +1,47 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestSummarizePendingTransactions(t *testing.T) {
+	empty := SummarizePendingTransactions(7, nil)
+	require.Equal(t, uint64(7), empty.Total)
+	require.Zero(t, empty.Count)
+	require.Zero(t, empty.MedianFee)
+	require.Zero(t, empty.FeePercentile(90))
+
+	var txns []types.SignedTxn
+	var size uint64
+	for _, fee := range []types.MicroAlgos{3000, 1000, 2000, 100000} {
+		stx := types.SignedTxn{Txn: types.Transaction{
+			Type:   types.PaymentTx,
+			Header: types.Header{Fee: fee, FirstValid: 1, LastValid: 1000},
+		}}
+		size += uint64(len(msgpack.Encode(stx)))
+		txns = append(txns, stx)
+	}
+
+	summary := SummarizePendingTransactions(10, txns)
+	require.Equal(t, uint64(10), summary.Total)
+	require.Equal(t, 4, summary.Count)
+	require.Equal(t, size, summary.Size)
+	require.Equal(t, types.MicroAlgos(1000), summary.MinFee)
+	require.Equal(t, types.MicroAlgos(2000), summary.MedianFee)
+	require.Equal(t, types.MicroAlgos(100000), summary.MaxFee)
+
+	perByte := func(i int) uint64 {
+		return uint64(txns[i].Txn.Fee) / uint64(len(msgpack.Encode(txns[i])))
+	}
+	require.Equal(t, perByte(1), summary.MinFeePerByte)
+	require.Equal(t, perByte(2), summary.MedianFeePerByte)
+	require.Equal(t, perByte(3), summary.MaxFeePerByte)
+	require.Equal(t, perByte(1), summary.FeePercentile(0))
+	require.Equal(t, perByte(0), summary.FeePercentile(75))
+	require.Equal(t, perByte(3), summary.FeePercentile(100))
+}
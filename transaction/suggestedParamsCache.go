@@ -0,0 +1,151 @@
+package transaction
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// SuggestedParamsCache reuses the suggested params of a node for several
+// transactions, saving a request for each of them. Params are refreshed once
+// older than a number of rounds or a duration.
+//
+// Rounds are only counted while Run follows the blocks of the node, which also
+// refreshes the params in the background. Otherwise Get refreshes them when
+// called after they expire, with DefaultSuggestedParamsMaxAge standing in for
+// a zero maxAge, as their rounds cannot be counted.
+type SuggestedParamsCache struct {
+	client *algod.Client
+	// estimator, if not nil, suggests the fee of the params.
+	estimator *FeeEstimator
+	maxRounds uint64
+	maxAge    time.Duration
+
+	mu      sync.Mutex
+	params  types.SuggestedParams
+	fetched time.Time
+	valid   bool
+	// round is the last round of the node as followed by Run, and following
+	// whether Run is following it.
+	round     uint64
+	following bool
+}
+
+// DefaultSuggestedParamsMaxAge is the age at which a SuggestedParamsCache
+// given no limit refreshes its params.
+const DefaultSuggestedParamsMaxAge = 30 * time.Second
+
+// NewSuggestedParamsCache returns a cache of the suggested params of the node
+// of c, refreshing them once they are maxRounds rounds or maxAge old. Either
+// limit is ignored if zero; if both are, maxAge is
+// DefaultSuggestedParamsMaxAge.
+func NewSuggestedParamsCache(c *algod.Client, maxRounds uint64, maxAge time.Duration) *SuggestedParamsCache {
+	if maxRounds == 0 && maxAge == 0 {
+		maxAge = DefaultSuggestedParamsMaxAge
+	}
+	return &SuggestedParamsCache{client: c, maxRounds: maxRounds, maxAge: maxAge}
+}
+
+// NewSuggestedParamsCacheWithFees returns a cache of the suggested params of
+// estimator, with the flat fee its strategy suggests, see
+// NewSuggestedParamsCache.
+func NewSuggestedParamsCacheWithFees(estimator *FeeEstimator, maxRounds uint64, maxAge time.Duration) *SuggestedParamsCache {
+	s := NewSuggestedParamsCache(estimator.Client, maxRounds, maxAge)
+	s.estimator = estimator
+	return s
+}
+
+// Get returns the cached params, refreshing them first if expired.
+func (s *SuggestedParamsCache) Get(ctx context.Context, headers ...*common.Header) (types.SuggestedParams, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fresh() {
+		return s.params, nil
+	}
+	return s.refresh(ctx, headers)
+}
+
+// Refresh fetches the params again, and returns them.
+func (s *SuggestedParamsCache) Refresh(ctx context.Context, headers ...*common.Header) (types.SuggestedParams, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.refresh(ctx, headers)
+}
+
+// Invalidate drops the cached params, for the next Get to fetch them again.
+func (s *SuggestedParamsCache) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.valid = false
+}
+
+// Run follows the blocks of the node, refreshing the params as they expire,
+// until ctx is cancelled or a request fails. It is meant to be run in its
+// own goroutine, and returns the error that stopped it.
+func (s *SuggestedParamsCache) Run(ctx context.Context, headers ...*common.Header) error {
+	defer func() {
+		s.mu.Lock()
+		s.following = false
+		s.mu.Unlock()
+	}()
+	status, err := s.client.Status().Do(ctx, headers...)
+	for {
+		if err != nil {
+			return err
+		}
+		s.mu.Lock()
+		s.round = status.LastRound
+		s.following = true
+		if !s.fresh() {
+			_, err = s.refresh(ctx, headers)
+		}
+		s.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		status, err = s.client.StatusAfterBlock(status.LastRound).Do(ctx, headers...)
+	}
+}
+
+// fresh returns whether the cached params have not expired, nor has the last
+// round transactions built with them are valid for passed. s.mu must be held.
+func (s *SuggestedParamsCache) fresh() bool {
+	if !s.valid || s.round > uint64(s.params.LastRoundValid) {
+		return false
+	}
+	maxAge := s.maxAge
+	if maxAge == 0 && !s.following {
+		// without Run, s.round does not advance
+		maxAge = DefaultSuggestedParamsMaxAge
+	}
+	if maxAge > 0 && time.Since(s.fetched) >= maxAge {
+		return false
+	}
+	fetchedRound := uint64(s.params.FirstRoundValid)
+	return s.maxRounds == 0 || s.round < fetchedRound || s.round-fetchedRound < s.maxRounds
+}
+
+// refresh fetches the params. s.mu must be held.
+func (s *SuggestedParamsCache) refresh(ctx context.Context, headers []*common.Header) (types.SuggestedParams, error) {
+	var params types.SuggestedParams
+	var err error
+	if s.estimator != nil {
+		params, err = s.estimator.SuggestedParams(ctx, headers...)
+	} else {
+		params, err = s.client.SuggestedParams().Do(ctx, headers...)
+	}
+	if err != nil {
+		return types.SuggestedParams{}, err
+	}
+	s.params = params
+	s.fetched = time.Now()
+	s.valid = true
+	return params, nil
+}
@@ -0,0 +1,131 @@
+package transaction
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// makeSuggestedParamsServer returns an algod mock at round 10, advancing a
+// round on each wait for a block, and calling onRound with the new round.
+func makeSuggestedParamsServer(t *testing.T, fetches *int32, onRound func(uint64)) *httptest.Server {
+	var round atomic.Uint64
+	round.Store(10)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/transactions/params":
+			atomic.AddInt32(fetches, 1)
+			w.Write(json.Encode(models.TransactionParametersResponse{LastRound: round.Load(), MinFee: 1000}))
+		case r.URL.Path == "/v2/status":
+			w.Write(json.Encode(models.NodeStatus{LastRound: round.Load()}))
+		case strings.HasPrefix(r.URL.Path, "/v2/status/wait-for-block-after/"):
+			next := round.Add(1)
+			onRound(next)
+			w.Write(json.Encode(models.NodeStatus{LastRound: next}))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestSuggestedParamsCacheGet(t *testing.T) {
+	var fetches int32
+	server := makeSuggestedParamsServer(t, &fetches, func(uint64) {})
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	cache := NewSuggestedParamsCache(client, 0, time.Hour)
+	for i := 0; i < 3; i++ {
+		params, err := cache.Get(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, types.Round(10), params.FirstRoundValid)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+
+	cache.Invalidate()
+	_, err = cache.Get(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+
+	_, err = cache.Refresh(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int32(3), atomic.LoadInt32(&fetches))
+
+	cache = NewSuggestedParamsCache(client, 0, time.Nanosecond)
+	_, err = cache.Get(context.Background())
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	_, err = cache.Get(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int32(5), atomic.LoadInt32(&fetches))
+}
+
+func TestSuggestedParamsCacheRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var fetches int32
+	server := makeSuggestedParamsServer(t, &fetches, func(round uint64) {
+		if round == 15 {
+			cancel()
+		}
+	})
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	// Params are fetched at rounds 10, 12 and 14.
+	cache := NewSuggestedParamsCache(client, 2, 0)
+	require.Error(t, cache.Run(ctx))
+	require.Equal(t, int32(3), atomic.LoadInt32(&fetches))
+
+	params, err := cache.Get(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, types.Round(14), params.FirstRoundValid)
+	require.Equal(t, int32(3), atomic.LoadInt32(&fetches))
+}
+
+func TestSuggestedParamsCacheExpiry(t *testing.T) {
+	// without limits, params are refreshed after DefaultSuggestedParamsMaxAge
+	cache := NewSuggestedParamsCache(nil, 0, 0)
+	cache.params = types.SuggestedParams{FirstRoundValid: 10, LastRoundValid: 1010}
+	cache.valid = true
+	cache.fetched = time.Now()
+	require.True(t, cache.fresh())
+	cache.fetched = time.Now().Add(-DefaultSuggestedParamsMaxAge)
+	require.False(t, cache.fresh())
+
+	// params are refreshed once their last valid round has passed
+	cache = NewSuggestedParamsCache(nil, 2000, 0)
+	cache.params = types.SuggestedParams{FirstRoundValid: 10, LastRoundValid: 1010}
+	cache.valid = true
+	cache.following = true
+	cache.round = 1010
+	require.True(t, cache.fresh())
+	cache.round = 1011
+	require.False(t, cache.fresh())
+
+	// without Run, rounds do not advance, so a limit in rounds alone falls
+	// back on DefaultSuggestedParamsMaxAge
+	cache = NewSuggestedParamsCache(nil, 2, 0)
+	cache.params = types.SuggestedParams{FirstRoundValid: 10, LastRoundValid: 1010}
+	cache.valid = true
+	cache.fetched = time.Now()
+	require.True(t, cache.fresh())
+	cache.fetched = time.Now().Add(-DefaultSuggestedParamsMaxAge)
+	require.False(t, cache.fresh())
+	cache.following = true
+	require.True(t, cache.fresh())
+}
@@ -3,10 +3,13 @@ package transaction
 import (
 	"bytes"
 	"encoding/base64"
+	"errors"
 	"fmt"
 
 	"github.com/algorand/go-algorand-sdk/v2/crypto"
 	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/protocol"
+	"github.com/algorand/go-algorand-sdk/v2/protocol/config"
 	"github.com/algorand/go-algorand-sdk/v2/types"
 )
 
@@ -219,6 +222,60 @@ func MakeKeyRegTxnWithStateProofKey(account string, note []byte, params types.Su
 	return setFee(tx, params)
 }
 
+// MakeKeyRegOnlineTxn constructs a keyreg transaction that registers
+// participation keys, bringing the account online, and checks them first.
+// - account is a checksummed, human-readable address for which we register the given participation key.
+// - note is a byte array
+// - params is typically received from algod, it defines common-to-all-txns arguments like fee and validity period
+// KeyReg parameters:
+// - votePK is a base64-encoded string corresponding to the root participation public key
+// - selectionKey is a base64-encoded string corresponding to the vrf public key
+// - stateProofPK is a base64-encoded string corresponding to the block proof public key
+// - voteFirst is the first round this participation key is valid
+// - voteLast is the last round this participation key is valid, it must not be before voteFirst or the first valid round of the transaction
+// - voteKeyDilution is the dilution for the 2-level participation key, it must not be zero
+func MakeKeyRegOnlineTxn(account string, note []byte, params types.SuggestedParams, voteKey, selectionKey, stateProofPK string, voteFirst, voteLast, voteKeyDilution uint64) (types.Transaction, error) {
+	if _, err := byte32FromBase64(voteKey); err != nil {
+		return types.Transaction{}, fmt.Errorf("invalid vote key: %w", err)
+	}
+	if _, err := byte32FromBase64(selectionKey); err != nil {
+		return types.Transaction{}, fmt.Errorf("invalid selection key: %w", err)
+	}
+	if _, err := byte64FromBase64(stateProofPK); err != nil {
+		return types.Transaction{}, fmt.Errorf("invalid state proof key: %w", err)
+	}
+	if voteKeyDilution == 0 {
+		return types.Transaction{}, fmt.Errorf("vote key dilution must be positive to go online")
+	}
+	if voteFirst > voteLast {
+		return types.Transaction{}, fmt.Errorf("vote first round %d is after vote last round %d", voteFirst, voteLast)
+	}
+	if types.Round(voteLast) < params.FirstRoundValid {
+		return types.Transaction{}, fmt.Errorf("participation key expires at round %d, before the transaction first valid round %d", voteLast, params.FirstRoundValid)
+	}
+
+	return MakeKeyRegTxnWithStateProofKey(account, note, params, voteKey, selectionKey, stateProofPK, voteFirst, voteLast, voteKeyDilution, false)
+}
+
+// MakeKeyRegOfflineTxn constructs a keyreg transaction with no participation
+// keys, taking the account offline. The account can come back online later.
+// - account is a checksummed, human-readable address to take offline.
+// - note is a byte array
+// - params is typically received from algod, it defines common-to-all-txns arguments like fee and validity period
+func MakeKeyRegOfflineTxn(account string, note []byte, params types.SuggestedParams) (types.Transaction, error) {
+	return MakeKeyRegTxnWithStateProofKey(account, note, params, "", "", "", 0, 0, 0, false)
+}
+
+// MakeKeyRegNonparticipatingTxn constructs a keyreg transaction marking the
+// account as nonparticipating. This cannot be undone: the account will never
+// be able to go online again, and stops earning any participation rewards.
+// - account is a checksummed, human-readable address to mark nonparticipating.
+// - note is a byte array
+// - params is typically received from algod, it defines common-to-all-txns arguments like fee and validity period
+func MakeKeyRegNonparticipatingTxn(account string, note []byte, params types.SuggestedParams) (types.Transaction, error) {
+	return MakeKeyRegTxnWithStateProofKey(account, note, params, "", "", "", 0, 0, 0, true)
+}
+
 // MakeAssetCreateTxn constructs an asset creation transaction using the passed parameters.
 // - account is a checksummed, human-readable address which will send the transaction.
 // - note is a byte array
@@ -473,6 +530,32 @@ func MakeAssetAcceptanceTxn(account string, note []byte, params types.SuggestedP
 	return MakeAssetTransferTxn(account, account, 0, note, params, "", index)
 }
 
+// MakeAssetOptInTxn creates a tx opting account in to the given asset, a zero amount transfer of
+// the asset to itself
+// - account is a checksummed, human-readable address that will send the transaction and begin accepting the asset
+// - assetID is the asset index
+// - params is typically received from algod, it defines common-to-all-txns arguments like fee and validity period
+func MakeAssetOptInTxn(account string, assetID uint64, params types.SuggestedParams) (types.Transaction, error) {
+	return MakeAssetAcceptanceTxn(account, nil, params, assetID)
+}
+
+// MakeAssetOptOutTxn creates a tx opting account out of the given asset, sending all of its holding
+// of the asset to closeTo and removing the holding from the account
+// - account is a checksummed, human-readable address that will send the transaction and stop accepting the asset
+// - assetID is the asset index
+// - closeTo is a checksummed, human-readable address receiving the remaining assets; it must be opted in
+// to the asset, or be its creator, and cannot be account itself
+// - params is typically received from algod, it defines common-to-all-txns arguments like fee and validity period
+func MakeAssetOptOutTxn(account string, assetID uint64, closeTo string, params types.SuggestedParams) (types.Transaction, error) {
+	if closeTo == "" {
+		return types.Transaction{}, fmt.Errorf("opting out of an asset requires an address to close the holding to")
+	}
+	if closeTo == account {
+		return types.Transaction{}, fmt.Errorf("an asset holding cannot be closed to its own account")
+	}
+	return MakeAssetTransferTxn(account, closeTo, 0, nil, params, closeTo, assetID)
+}
+
 // MakeAssetRevocationTxn creates a tx for revoking an asset from an account and sending it to another
 // - account is a checksummed, human-readable address; it must be the revocation manager / clawback address from the asset's parameters
 // - target is a checksummed, human-readable address; it is the account whose assets will be revoked
@@ -796,6 +879,12 @@ func MakeApplicationUpdateTxWithBoxes(
 	group types.Digest,
 	lease [32]byte,
 	rekeyTo types.Address) (tx types.Transaction, err error) {
+	if appIdx == 0 {
+		return tx, errNoAppID
+	}
+	if len(approvalProg) == 0 || len(clearProg) == 0 {
+		return tx, fmt.Errorf("an application update requires both an approval and a clear program")
+	}
 	return MakeApplicationCallTxWithBoxes(appIdx,
 		appArgs,
 		accounts,
@@ -861,6 +950,9 @@ func MakeApplicationDeleteTxWithBoxes(
 	group types.Digest,
 	lease [32]byte,
 	rekeyTo types.Address) (tx types.Transaction, err error) {
+	if appIdx == 0 {
+		return tx, errNoAppID
+	}
 	return MakeApplicationCallTxWithBoxes(appIdx,
 		appArgs,
 		accounts,
@@ -928,6 +1020,9 @@ func MakeApplicationOptInTxWithBoxes(
 	group types.Digest,
 	lease [32]byte,
 	rekeyTo types.Address) (tx types.Transaction, err error) {
+	if appIdx == 0 {
+		return tx, errNoAppID
+	}
 	return MakeApplicationCallTxWithBoxes(appIdx,
 		appArgs,
 		accounts,
@@ -996,6 +1091,9 @@ func MakeApplicationCloseOutTxWithBoxes(
 	group types.Digest,
 	lease [32]byte,
 	rekeyTo types.Address) (tx types.Transaction, err error) {
+	if appIdx == 0 {
+		return tx, errNoAppID
+	}
 	return MakeApplicationCallTxWithBoxes(appIdx,
 		appArgs,
 		accounts,
@@ -1066,6 +1164,9 @@ func MakeApplicationClearStateTxWithBoxes(
 	group types.Digest,
 	lease [32]byte,
 	rekeyTo types.Address) (tx types.Transaction, err error) {
+	if appIdx == 0 {
+		return tx, errNoAppID
+	}
 	return MakeApplicationCallTxWithBoxes(appIdx,
 		appArgs,
 		accounts,
@@ -1259,6 +1360,10 @@ func MakeApplicationCallTxWithBoxes(
 	tx.GlobalStateSchema = globalSchema
 	tx.ExtraProgramPages = extraPages
 
+	if err = validateApplicationCall(tx); err != nil {
+		return tx, err
+	}
+
 	var gh types.Digest
 	copy(gh[:], sp.GenesisHash)
 
@@ -1279,6 +1384,67 @@ func MakeApplicationCallTxWithBoxes(
 	return setFee(tx, sp)
 }
 
+// consensusParams holds the consensus parameters the package checks and
+// computes with, such as the bounds of application call transactions. They
+// are taken from ConsensusCurrentVersion (v40 at the time of writing), so
+// they follow the SDK's protocol/config table.
+var consensusParams = config.Consensus[protocol.ConsensusCurrentVersion]
+
+var errNoAppID = errors.New("an application call to an existing application requires its application ID")
+
+// validateApplicationCall checks an application call against the consensus
+// limits, so that an invalid transaction fails when built rather than when
+// submitted.
+func validateApplicationCall(tx types.Transaction) error {
+	if len(tx.ApplicationArgs) > consensusParams.MaxAppArgs {
+		return fmt.Errorf("too many application args: %d > %d", len(tx.ApplicationArgs), consensusParams.MaxAppArgs)
+	}
+	argLen := 0
+	for _, arg := range tx.ApplicationArgs {
+		argLen += len(arg)
+	}
+	if argLen > consensusParams.MaxAppTotalArgLen {
+		return fmt.Errorf("application args too long: %d > %d bytes", argLen, consensusParams.MaxAppTotalArgLen)
+	}
+
+	if len(tx.Accounts) > consensusParams.MaxAppTxnAccounts {
+		return fmt.Errorf("too many accounts: %d > %d", len(tx.Accounts), consensusParams.MaxAppTxnAccounts)
+	}
+	if len(tx.ForeignApps) > consensusParams.MaxAppTxnForeignApps {
+		return fmt.Errorf("too many foreign apps: %d > %d", len(tx.ForeignApps), consensusParams.MaxAppTxnForeignApps)
+	}
+	if len(tx.ForeignAssets) > consensusParams.MaxAppTxnForeignAssets {
+		return fmt.Errorf("too many foreign assets: %d > %d", len(tx.ForeignAssets), consensusParams.MaxAppTxnForeignAssets)
+	}
+	if len(tx.BoxReferences) > consensusParams.MaxAppBoxReferences {
+		return fmt.Errorf("too many box references: %d > %d", len(tx.BoxReferences), consensusParams.MaxAppBoxReferences)
+	}
+	if refs := len(tx.Accounts) + len(tx.ForeignApps) + len(tx.ForeignAssets) + len(tx.BoxReferences); refs > consensusParams.MaxAppTotalTxnReferences {
+		return fmt.Errorf("too many references: %d > %d", refs, consensusParams.MaxAppTotalTxnReferences)
+	}
+
+	if int(tx.ExtraProgramPages) > consensusParams.MaxExtraAppProgramPages {
+		return fmt.Errorf("too many extra program pages: %d > %d", tx.ExtraProgramPages, consensusParams.MaxExtraAppProgramPages)
+	}
+	progLen := len(tx.ApprovalProgram) + len(tx.ClearStateProgram)
+	if maxLen := consensusParams.MaxAppTotalProgramLen * (1 + int(tx.ExtraProgramPages)); tx.ApplicationID == 0 && progLen > maxLen {
+		return fmt.Errorf("programs too long: %d > %d bytes with %d extra program pages", progLen, maxLen, tx.ExtraProgramPages)
+	}
+	// the programs of an update may use the extra pages the application was
+	// created with, which the transaction does not tell, but no application
+	// has more than the maximum
+	if maxLen := consensusParams.MaxAppTotalProgramLen * (1 + consensusParams.MaxExtraAppProgramPages); progLen > maxLen {
+		return fmt.Errorf("programs too long: %d > %d bytes, even with %d extra program pages", progLen, maxLen, consensusParams.MaxExtraAppProgramPages)
+	}
+	if entries := tx.GlobalStateSchema.NumUint + tx.GlobalStateSchema.NumByteSlice; entries > consensusParams.MaxGlobalSchemaEntries {
+		return fmt.Errorf("global schema too large: %d > %d entries", entries, consensusParams.MaxGlobalSchemaEntries)
+	}
+	if entries := tx.LocalStateSchema.NumUint + tx.LocalStateSchema.NumByteSlice; entries > consensusParams.MaxLocalSchemaEntries {
+		return fmt.Errorf("local schema too large: %d > %d entries", entries, consensusParams.MaxLocalSchemaEntries)
+	}
+	return nil
+}
+
 // AssignGroupID computes and return list of transactions with Group field set.
 // - txns is a list of transactions to process
 // - account specifies a sender field of transaction to return. Set to empty string to return all of them
@@ -1303,6 +1469,53 @@ func AssignGroupID(txns []types.Transaction, account string) (result []types.Tra
 	return result, nil
 }
 
+// PoolGroupFees returns a copy of txns where the transaction at payerIndex pays
+// the fees of the whole group, and every other transaction has a fee of zero.
+// - txns is the group, which must not have a group ID assigned yet since changing fees invalidates it
+// - payerIndex is the index of the transaction that pays the pooled fee
+// - innerTxns is the number of inner transactions the group's app calls will issue, each of which needs a minimum fee
+// The minimum fee per transaction is params.MinFee, or MinTxnFee if unset. If
+// params.FlatFee is false, a transaction's share is raised to params.Fee per
+// byte whenever that is more than the minimum.
+func PoolGroupFees(txns []types.Transaction, payerIndex int, innerTxns uint64, params types.SuggestedParams) ([]types.Transaction, error) {
+	if len(txns) > types.MaxTxGroupSize {
+		return nil, fmt.Errorf("txgroup too large, %v > max size %v", len(txns), types.MaxTxGroupSize)
+	}
+	if payerIndex < 0 || payerIndex >= len(txns) {
+		return nil, fmt.Errorf("fee payer index %d is out of range for a group of %d transactions", payerIndex, len(txns))
+	}
+
+	minFee := types.MicroAlgos(params.MinFee)
+	if minFee == 0 {
+		minFee = MinTxnFee
+	}
+
+	result := make([]types.Transaction, len(txns))
+	total := minFee * types.MicroAlgos(innerTxns)
+	for i, tx := range txns {
+		if tx.Group != (types.Digest{}) {
+			return nil, fmt.Errorf("transaction %d already has a group %v", i, tx.Group)
+		}
+
+		tx.Fee = 0
+		required := minFee
+		if !params.FlatFee {
+			eSize, err := EstimateSize(tx)
+			if err != nil {
+				return nil, err
+			}
+			if byteFee := types.MicroAlgos(eSize * uint64(params.Fee)); byteFee > required {
+				required = byteFee
+			}
+		}
+		total += required
+		result[i] = tx
+	}
+
+	result[payerIndex].Fee = total
+	return result, nil
+}
+
 // EstimateSize returns the estimated length of the encoded transaction
 func EstimateSize(txn types.Transaction) (uint64, error) {
 	return uint64(len(msgpack.Encode(txn))) + NumOfAdditionalBytesAfterSigning, nil
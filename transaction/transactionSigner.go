@@ -2,7 +2,10 @@ package transaction
 
 import (
 	"encoding/json"
+	"fmt"
+	"sync"
 
+	"github.com/algorand/go-algorand-sdk/v2/client/kmd"
 	"github.com/algorand/go-algorand-sdk/v2/crypto"
 	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
 	"github.com/algorand/go-algorand-sdk/v2/types"
@@ -20,6 +23,17 @@ type TransactionSigner interface { //nolint:revive // Ignore stuttering for back
 	Equals(other TransactionSigner) bool
 }
 
+// checkIndexesToSign makes sure every index passed to a TransactionSigner refers to a
+// transaction in the group, so a bad index produces an error rather than a panic.
+func checkIndexesToSign(txGroup []types.Transaction, indexesToSign []int) error {
+	for _, pos := range indexesToSign {
+		if pos < 0 || pos >= len(txGroup) {
+			return fmt.Errorf("index to sign %d is out of range for a group of %d transactions", pos, len(txGroup))
+		}
+	}
+	return nil
+}
+
 // BasicAccountTransactionSigner that can sign transactions for the provided basic Account.
 type BasicAccountTransactionSigner struct {
 	Account crypto.Account
@@ -27,6 +41,10 @@ type BasicAccountTransactionSigner struct {
 
 // SignTransactions signs the provided transactions with the private key of the account.
 func (txSigner BasicAccountTransactionSigner) SignTransactions(txGroup []types.Transaction, indexesToSign []int) ([][]byte, error) {
+	if err := checkIndexesToSign(txGroup, indexesToSign); err != nil {
+		return nil, err
+	}
+
 	stxs := make([][]byte, len(indexesToSign))
 	for i, pos := range indexesToSign {
 		_, stxBytes, err := crypto.SignTransaction(txSigner.Account.PrivateKey, txGroup[pos])
@@ -66,6 +84,10 @@ type LogicSigAccountTransactionSigner struct {
 
 // SignTransactions signs the provided transactions with the private key of the account.
 func (txSigner LogicSigAccountTransactionSigner) SignTransactions(txGroup []types.Transaction, indexesToSign []int) ([][]byte, error) {
+	if err := checkIndexesToSign(txGroup, indexesToSign); err != nil {
+		return nil, err
+	}
+
 	stxs := make([][]byte, len(indexesToSign))
 	for i, pos := range indexesToSign {
 		_, stxBytes, err := crypto.SignLogicSigAccountTransaction(txSigner.LogicSigAccount, txGroup[pos])
@@ -106,6 +128,14 @@ type MultiSigAccountTransactionSigner struct {
 
 // SignTransactions signs the provided transactions with the private keys of the account.
 func (txSigner MultiSigAccountTransactionSigner) SignTransactions(txGroup []types.Transaction, indexesToSign []int) ([][]byte, error) {
+	if err := checkIndexesToSign(txGroup, indexesToSign); err != nil {
+		return nil, err
+	}
+
+	if len(txSigner.Sks) == 0 {
+		return nil, fmt.Errorf("at least one private key is required to sign for a multisig account")
+	}
+
 	stxs := make([][]byte, len(indexesToSign))
 	for i, pos := range indexesToSign {
 		var unmergedStxs [][]byte
@@ -157,6 +187,10 @@ type EmptyTransactionSigner struct{}
 
 // SignTransactions returns SignedTxn bytes but does not sign them.
 func (txSigner EmptyTransactionSigner) SignTransactions(txGroup []types.Transaction, indexesToSign []int) ([][]byte, error) {
+	if err := checkIndexesToSign(txGroup, indexesToSign); err != nil {
+		return nil, err
+	}
+
 	stxs := make([][]byte, len(indexesToSign))
 	for i, pos := range indexesToSign {
 		stx := types.SignedTxn{
@@ -172,3 +206,143 @@ func (txSigner EmptyTransactionSigner) Equals(other TransactionSigner) bool {
 	_, ok := other.(EmptyTransactionSigner)
 	return ok
 }
+
+// KMDTransactionSigner is a TransactionSigner that signs transactions with keys
+// held in a kmd wallet. Use MakeKMDTransactionSigner to create one.
+type KMDTransactionSigner struct {
+	Client         kmd.Client
+	WalletID       string
+	WalletPassword string
+
+	// AuthAddr is the address of the wallet key to sign with. If it is zero,
+	// each transaction is signed with the key of its sender.
+	AuthAddr types.Address
+
+	handle *kmdWalletHandle
+}
+
+// kmdWalletHandle holds the wallet handle token shared by copies of a
+// KMDTransactionSigner. The mutex only guards the token, not the kmd requests
+// made with it, so concurrent signers do not wait on each other.
+type kmdWalletHandle struct {
+	mu    sync.Mutex
+	token string
+}
+
+// MakeKMDTransactionSigner creates a KMDTransactionSigner for the given wallet.
+// The signer keeps a wallet handle open between calls, renewing it before each
+// use and opening a new one once it has expired. Call Close to release it.
+func MakeKMDTransactionSigner(client kmd.Client, walletID, walletPassword string) KMDTransactionSigner {
+	return KMDTransactionSigner{
+		Client:         client,
+		WalletID:       walletID,
+		WalletPassword: walletPassword,
+		handle:         &kmdWalletHandle{},
+	}
+}
+
+// walletHandle returns a live wallet handle token, and a function to call once
+// the caller is done with it.
+func (txSigner KMDTransactionSigner) walletHandle() (string, func(), error) {
+	if txSigner.handle == nil {
+		// Not created by MakeKMDTransactionSigner, so there is nowhere to keep
+		// the handle between calls.
+		resp, err := txSigner.Client.InitWalletHandle(txSigner.WalletID, txSigner.WalletPassword)
+		if err != nil {
+			return "", nil, err
+		}
+		release := func() { txSigner.Client.ReleaseWalletHandle(resp.WalletHandleToken) }
+		return resp.WalletHandleToken, release, nil
+	}
+
+	txSigner.handle.mu.Lock()
+	token := txSigner.handle.token
+	txSigner.handle.mu.Unlock()
+	if token != "" {
+		if _, err := txSigner.Client.RenewWalletHandle(token); err == nil {
+			return token, func() {}, nil
+		}
+	}
+
+	resp, err := txSigner.Client.InitWalletHandle(txSigner.WalletID, txSigner.WalletPassword)
+	if err != nil {
+		return "", nil, err
+	}
+	txSigner.handle.mu.Lock()
+	current := txSigner.handle.token
+	if current == token {
+		txSigner.handle.token = resp.WalletHandleToken
+	}
+	txSigner.handle.mu.Unlock()
+	switch {
+	case current == token:
+		return resp.WalletHandleToken, func() {}, nil
+	case current != "":
+		// Another call replaced the expired handle first, keep its handle.
+		txSigner.Client.ReleaseWalletHandle(resp.WalletHandleToken)
+		return current, func() {}, nil
+	default:
+		// Close ran meanwhile, so the new handle is not kept: release it once
+		// the caller is done with it.
+		release := func() { txSigner.Client.ReleaseWalletHandle(resp.WalletHandleToken) }
+		return resp.WalletHandleToken, release, nil
+	}
+}
+
+// Close releases the wallet handle kept by the signer. Signing calls still in
+// progress may fail, and later calls open a new handle.
+func (txSigner KMDTransactionSigner) Close() error {
+	if txSigner.handle == nil {
+		return nil
+	}
+	txSigner.handle.mu.Lock()
+	token := txSigner.handle.token
+	txSigner.handle.token = ""
+	txSigner.handle.mu.Unlock()
+	if token == "" {
+		return nil
+	}
+	_, err := txSigner.Client.ReleaseWalletHandle(token)
+	return err
+}
+
+// SignTransactions signs the provided transactions with keys from the kmd wallet.
+func (txSigner KMDTransactionSigner) SignTransactions(txGroup []types.Transaction, indexesToSign []int) ([][]byte, error) {
+	if err := checkIndexesToSign(txGroup, indexesToSign); err != nil {
+		return nil, err
+	}
+
+	token, done, err := txSigner.walletHandle()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	stxs := make([][]byte, len(indexesToSign))
+	for i, pos := range indexesToSign {
+		var resp kmd.SignTransactionResponse
+		if txSigner.AuthAddr.IsZero() {
+			resp, err = txSigner.Client.SignTransaction(token, txSigner.WalletPassword, txGroup[pos])
+		} else {
+			resp, err = txSigner.Client.SignTransactionWithSpecificPublicKey(token, txSigner.WalletPassword, txGroup[pos], txSigner.AuthAddr[:])
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		stxs[i] = resp.SignedTransaction
+	}
+
+	return stxs, nil
+}
+
+// Equals returns true if the other TransactionSigner signs with the same wallet
+// key as this one.
+func (txSigner KMDTransactionSigner) Equals(other TransactionSigner) bool {
+	if castedSigner, ok := other.(KMDTransactionSigner); ok {
+		return castedSigner.WalletID == txSigner.WalletID &&
+			castedSigner.WalletPassword == txSigner.WalletPassword &&
+			castedSigner.AuthAddr == txSigner.AuthAddr
+	}
+	return false
+}
@@ -2,9 +2,16 @@ package transaction
 
 import (
 	"crypto/ed25519"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/algorand/go-algorand-sdk/v2/client/kmd"
 	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
 	"github.com/algorand/go-algorand-sdk/v2/mnemonic"
 	"github.com/algorand/go-algorand-sdk/v2/types"
 	"github.com/stretchr/testify/require"
@@ -137,3 +144,138 @@ func TestMakeMultiSigAccountTransactionSigner(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, sigs[0], expectedSig)
 }
+
+func TestTransactionSignersRejectInvalidIndexes(t *testing.T) {
+	ma, sk1, _, _ := makeTestMultisigAccount(t)
+	lsig, err := crypto.MakeLogicSigAccountEscrowChecked([]byte{1, 32, 1, 1, 34}, nil)
+	require.NoError(t, err)
+
+	signers := []TransactionSigner{
+		BasicAccountTransactionSigner{Account: crypto.GenerateAccount()},
+		LogicSigAccountTransactionSigner{LogicSigAccount: lsig},
+		MultiSigAccountTransactionSigner{Msig: ma, Sks: [][]byte{sk1}},
+		EmptyTransactionSigner{},
+	}
+
+	txGroup := []types.Transaction{{Type: types.PaymentTx}}
+	for _, signer := range signers {
+		_, err := signer.SignTransactions(txGroup, []int{1})
+		require.Error(t, err)
+
+		_, err = signer.SignTransactions(txGroup, []int{-1})
+		require.Error(t, err)
+	}
+
+	noKeys := MultiSigAccountTransactionSigner{Msig: ma}
+	_, err = noKeys.SignTransactions(txGroup, []int{0})
+	require.Error(t, err)
+}
+
+func TestKMDTransactionSigner(t *testing.T) {
+	account := crypto.GenerateAccount()
+	tx := types.Transaction{
+		Type: types.PaymentTx,
+		Header: types.Header{
+			Sender:     account.Address,
+			Fee:        1000,
+			FirstValid: 972508,
+			LastValid:  973508,
+		},
+	}
+
+	var (
+		inits      int
+		expired    = map[string]bool{}
+		released   []string
+		lastHandle string
+		onInit     func()
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		switch r.URL.Path {
+		case "/v1/wallet/init":
+			inits++
+			lastHandle = fmt.Sprintf("handle-%d", inits)
+			if onInit != nil {
+				onInit()
+			}
+			w.Write(json.Encode(kmd.InitWalletHandleResponse{WalletHandleToken: lastHandle}))
+		case "/v1/wallet/renew":
+			var req kmd.RenewWalletHandleRequest
+			require.NoError(t, json.Decode(body, &req))
+			if expired[req.WalletHandleToken] {
+				w.Write(json.Encode(kmd.RenewWalletHandleResponse{APIV1ResponseEnvelope: kmd.APIV1ResponseEnvelope{Error: true, Message: "handle expired"}}))
+				return
+			}
+			w.Write(json.Encode(kmd.RenewWalletHandleResponse{}))
+		case "/v1/wallet/release":
+			var req kmd.ReleaseWalletHandleRequest
+			require.NoError(t, json.Decode(body, &req))
+			released = append(released, req.WalletHandleToken)
+			w.Write(json.Encode(kmd.ReleaseWalletHandleResponse{}))
+		case "/v1/transaction/sign":
+			var req kmd.SignTransactionRequest
+			require.NoError(t, json.Decode(body, &req))
+			require.Equal(t, lastHandle, req.WalletHandleToken)
+			var txn types.Transaction
+			require.NoError(t, msgpack.Decode(req.Transaction, &txn))
+			_, stx, err := crypto.SignTransaction(account.PrivateKey, txn)
+			require.NoError(t, err)
+			w.Write(json.Encode(kmd.SignTransactionResponse{SignedTransaction: stx}))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := kmd.MakeClient(server.URL, "")
+	require.NoError(t, err)
+	txSigner := MakeKMDTransactionSigner(client, "wallet-id", "password")
+
+	_, expected, err := crypto.SignTransaction(account.PrivateKey, tx)
+	require.NoError(t, err)
+
+	sigs, err := txSigner.SignTransactions([]types.Transaction{tx}, []int{0})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{expected}, sigs)
+
+	// the handle is renewed and reused
+	_, err = txSigner.SignTransactions([]types.Transaction{tx}, []int{0})
+	require.NoError(t, err)
+	require.Equal(t, 1, inits)
+
+	// an expired handle is replaced
+	expired[lastHandle] = true
+	sigs, err = txSigner.SignTransactions([]types.Transaction{tx}, []int{0})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{expected}, sigs)
+	require.Equal(t, 2, inits)
+
+	// closing releases the handle, and the next call opens a new one
+	require.NoError(t, txSigner.Close())
+	require.Equal(t, []string{"handle-2"}, released)
+	_, err = txSigner.SignTransactions([]types.Transaction{tx}, []int{0})
+	require.NoError(t, err)
+	require.Equal(t, 3, inits)
+	require.NoError(t, txSigner.Close())
+	require.NoError(t, txSigner.Close())
+	require.Equal(t, []string{"handle-2", "handle-3"}, released)
+
+	// a handle opened while Close runs is released after use, not kept
+	_, err = txSigner.SignTransactions([]types.Transaction{tx}, []int{0})
+	require.NoError(t, err)
+	expired[lastHandle] = true
+	onInit = func() { require.NoError(t, txSigner.Close()) }
+	_, err = txSigner.SignTransactions([]types.Transaction{tx}, []int{0})
+	require.NoError(t, err)
+	require.Equal(t, 5, inits)
+	require.Equal(t, []string{"handle-2", "handle-3", "handle-4", "handle-5"}, released)
+	onInit = nil
+	require.NoError(t, txSigner.Close())
+	require.Len(t, released, 4)
+
+	require.True(t, txSigner.Equals(MakeKMDTransactionSigner(client, "wallet-id", "password")))
+	require.False(t, txSigner.Equals(MakeKMDTransactionSigner(client, "other-wallet", "password")))
+}
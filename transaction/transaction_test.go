@@ -241,6 +241,50 @@ func TestMakeKeyRegTxnWithStateProofKey(t *testing.T) {
 	require.Equal(t, expKeyRegTxn, tx)
 }
 
+func TestMakeKeyRegOnlineOfflineTxns(t *testing.T) {
+	const addr = "BH55E5RMBD4GYWXGX5W5PJ5JAHPGM5OXKDQH5DC4O2MGI7NW4H6VOE4CP4"
+	const voteKey = "Kv7QI7chi1y6axoy+t7wzAVpePqRq/rkjzWh/RMYyLo="
+	const selectionKey = "bPgrv4YogPcdaUAxrt1QysYZTVyRAuUMD4zQmCu9llc="
+	const stateProof = "mYR0GVEObMTSNdsKM6RwYywHYPqVDqg3E4JFzxZOreH9NU8B+tKzUanyY8AQ144hETgSMX7fXWwjBdHz6AWk9w=="
+	ghAsArray := byte32ArrayFromBase64("SGO1GKSzyE7IEPItTxCByw9x8FmnrCDexi9/cOUJOiI=")
+	params := types.SuggestedParams{
+		Fee:             10,
+		FirstRoundValid: 322575,
+		LastRoundValid:  323575,
+		GenesisHash:     ghAsArray[:],
+	}
+
+	tx, err := MakeKeyRegOnlineTxn(addr, nil, params, voteKey, selectionKey, stateProof, 322000, 3322000, 1733)
+	require.NoError(t, err)
+	expected, err := MakeKeyRegTxnWithStateProofKey(addr, nil, params, voteKey, selectionKey, stateProof, 322000, 3322000, 1733, false)
+	require.NoError(t, err)
+	require.Equal(t, expected, tx)
+
+	invalid := []struct {
+		voteKey, selectionKey, stateProof string
+		voteFirst, voteLast, dilution     uint64
+	}{
+		{"", selectionKey, stateProof, 322000, 3322000, 1733},
+		{voteKey, stateProof, stateProof, 322000, 3322000, 1733},
+		{voteKey, selectionKey, voteKey, 322000, 3322000, 1733},
+		{voteKey, selectionKey, stateProof, 322000, 3322000, 0},
+		{voteKey, selectionKey, stateProof, 3322000, 322000, 1733},
+		{voteKey, selectionKey, stateProof, 10000, 10111, 11},
+	}
+	for _, tc := range invalid {
+		_, err := MakeKeyRegOnlineTxn(addr, nil, params, tc.voteKey, tc.selectionKey, tc.stateProof, tc.voteFirst, tc.voteLast, tc.dilution)
+		require.Error(t, err, tc)
+	}
+
+	tx, err = MakeKeyRegOfflineTxn(addr, nil, params)
+	require.NoError(t, err)
+	require.Equal(t, types.KeyregTxnFields{}, tx.KeyregTxnFields)
+
+	tx, err = MakeKeyRegNonparticipatingTxn(addr, nil, params)
+	require.NoError(t, err)
+	require.Equal(t, types.KeyregTxnFields{Nonparticipation: true}, tx.KeyregTxnFields)
+}
+
 func TestMakeAssetCreateTxn(t *testing.T) {
 	const addr = "BH55E5RMBD4GYWXGX5W5PJ5JAHPGM5OXKDQH5DC4O2MGI7NW4H6VOE4CP4"
 	const defaultFrozen = false
@@ -1055,3 +1099,114 @@ func TestParseBoxReferences(t *testing.T) {
 			brs)
 	})
 }
+
+func TestPoolGroupFees(t *testing.T) {
+	const address = "UPYAFLHSIPMJOHVXU2MPLQ46GXJKSDCEMZ6RLCQ7GWB5PRDKJUWKKXECXI"
+	params := types.SuggestedParams{
+		Fee:             0,
+		FirstRoundValid: 100,
+		LastRoundValid:  1100,
+		GenesisHash:     byteFromBase64("sC3P7e2SdbqKJK0tbiCdK9tdSpbe6XeCGKdoNzmlj0E="),
+		MinFee:          1000,
+	}
+	tx1, err := MakePaymentTxn(address, address, 1, nil, "", params)
+	require.NoError(t, err)
+	tx2, err := MakePaymentTxn(address, address, 2, nil, "", params)
+	require.NoError(t, err)
+
+	pooled, err := PoolGroupFees([]types.Transaction{tx1, tx2}, 1, 3, params)
+	require.NoError(t, err)
+	require.Equal(t, types.MicroAlgos(0), pooled[0].Fee)
+	require.Equal(t, types.MicroAlgos(5*1000), pooled[1].Fee)
+	// the input is left untouched
+	require.Equal(t, types.MicroAlgos(1000), tx1.Fee)
+
+	// a congested per-byte fee raises each transaction's share
+	params.Fee = 100
+	pooled, err = PoolGroupFees([]types.Transaction{tx1, tx2}, 0, 0, params)
+	require.NoError(t, err)
+	require.Greater(t, uint64(pooled[0].Fee), uint64(2*1000))
+	require.Equal(t, types.MicroAlgos(0), pooled[1].Fee)
+
+	_, err = PoolGroupFees([]types.Transaction{tx1, tx2}, 2, 0, params)
+	require.Error(t, err)
+
+	grouped, err := AssignGroupID([]types.Transaction{tx1, tx2}, "")
+	require.NoError(t, err)
+	_, err = PoolGroupFees(grouped, 0, 0, params)
+	require.Error(t, err)
+}
+
+func TestMakeAssetOptInOutTxn(t *testing.T) {
+	account := crypto.GenerateAccount().Address
+	creator := crypto.GenerateAccount().Address
+	params := types.SuggestedParams{Fee: 1000, FlatFee: true, FirstRoundValid: 1, LastRoundValid: 1001, GenesisHash: make([]byte, 32)}
+
+	optIn, err := MakeAssetOptInTxn(account.String(), 7, params)
+	require.NoError(t, err)
+	expected, err := MakeAssetAcceptanceTxn(account.String(), nil, params, 7)
+	require.NoError(t, err)
+	require.Equal(t, expected, optIn)
+
+	optOut, err := MakeAssetOptOutTxn(account.String(), 7, creator.String(), params)
+	require.NoError(t, err)
+	require.Equal(t, types.AssetTransferTx, optOut.Type)
+	require.Equal(t, account, optOut.Sender)
+	require.Equal(t, types.AssetIndex(7), optOut.XferAsset)
+	require.Zero(t, optOut.AssetAmount)
+	require.Equal(t, creator, optOut.AssetReceiver)
+	require.Equal(t, creator, optOut.AssetCloseTo)
+
+	_, err = MakeAssetOptOutTxn(account.String(), 7, "", params)
+	require.Error(t, err)
+	_, err = MakeAssetOptOutTxn(account.String(), 7, account.String(), params)
+	require.Error(t, err)
+}
+
+func TestApplicationCallValidation(t *testing.T) {
+	sender := crypto.GenerateAccount().Address
+	params := types.SuggestedParams{Fee: 1000, FlatFee: true, FirstRoundValid: 1, LastRoundValid: 1001, GenesisHash: make([]byte, 32)}
+	prog := []byte{0x08, 0x81, 0x01}
+
+	tx, err := MakeApplicationOptInTx(7, nil, nil, nil, nil, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress)
+	require.NoError(t, err)
+	require.Equal(t, types.OptInOC, tx.OnCompletion)
+
+	_, err = MakeApplicationOptInTx(0, nil, nil, nil, nil, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress)
+	require.ErrorIs(t, err, errNoAppID)
+	_, err = MakeApplicationCloseOutTx(0, nil, nil, nil, nil, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress)
+	require.ErrorIs(t, err, errNoAppID)
+	_, err = MakeApplicationClearStateTx(0, nil, nil, nil, nil, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress)
+	require.ErrorIs(t, err, errNoAppID)
+	_, err = MakeApplicationDeleteTx(0, nil, nil, nil, nil, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress)
+	require.ErrorIs(t, err, errNoAppID)
+	_, err = MakeApplicationUpdateTx(0, nil, nil, nil, nil, prog, prog, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress)
+	require.ErrorIs(t, err, errNoAppID)
+	_, err = MakeApplicationUpdateTx(7, nil, nil, nil, nil, prog, nil, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress)
+	require.Error(t, err)
+
+	// an update may use the extra pages of the application
+	_, err = MakeApplicationUpdateTx(7, nil, nil, nil, nil, make([]byte, 3000), prog, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress)
+	require.NoError(t, err)
+	// but no application has more than three
+	_, err = MakeApplicationUpdateTx(7, nil, nil, nil, nil, make([]byte, 8190), prog, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress)
+	require.ErrorContains(t, err, "even with 3 extra program pages")
+
+	tooMany := func(n int) [][]byte { return make([][]byte, n) }
+	_, err = MakeApplicationNoOpTx(7, tooMany(17), nil, nil, nil, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress)
+	require.ErrorContains(t, err, "application args")
+	_, err = MakeApplicationNoOpTx(7, [][]byte{make([]byte, 2049)}, nil, nil, nil, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress)
+	require.ErrorContains(t, err, "application args too long")
+	_, err = MakeApplicationNoOpTx(7, nil, nil, []uint64{1, 2, 3, 4, 5}, []uint64{1, 2, 3, 4}, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress)
+	require.ErrorContains(t, err, "too many references")
+	_, err = MakeApplicationCreateTxWithExtraPages(false, make([]byte, 4093), prog, types.StateSchema{}, types.StateSchema{}, nil, nil, nil, nil, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress, 1)
+	require.NoError(t, err)
+	_, err = MakeApplicationCreateTxWithExtraPages(false, make([]byte, 4096), prog, types.StateSchema{}, types.StateSchema{}, nil, nil, nil, nil, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress, 1)
+	require.ErrorContains(t, err, "programs too long: 4099 > 4096 bytes with 1 extra program pages")
+	_, err = MakeApplicationCreateTxWithExtraPages(false, prog, prog, types.StateSchema{}, types.StateSchema{}, nil, nil, nil, nil, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress, 4)
+	require.ErrorContains(t, err, "too many extra program pages")
+	_, err = MakeApplicationCreateTx(false, prog, prog, types.StateSchema{}, types.StateSchema{NumUint: 8, NumByteSlice: 9}, nil, nil, nil, nil, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress)
+	require.ErrorContains(t, err, "local schema")
+	_, err = MakeApplicationCreateTx(false, prog, prog, types.StateSchema{NumUint: 60, NumByteSlice: 5}, types.StateSchema{}, nil, nil, nil, nil, params, sender, nil, types.Digest{}, [32]byte{}, types.ZeroAddress)
+	require.ErrorContains(t, err, "global schema")
+}
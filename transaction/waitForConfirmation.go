@@ -12,6 +12,10 @@ import (
 // WaitForConfirmation waits for a pending transaction to be accepted by the network
 // txid: The ID of the pending transaction to wait for
 // waitRounds: The number of rounds to block before exiting with an error.
+//
+// If the transaction is removed from the pool, the returned txInfo holds the
+// pool error alongside the returned error. Cancelling ctx stops the wait and
+// returns ctx.Err().
 func WaitForConfirmation(c *algod.Client, txid string, waitRounds uint64, ctx context.Context, headers ...*common.Header) (txInfo models.PendingTransactionInfoResponse, err error) { //nolint:revive // Ignore Context order for backwards compatibility
 	response, err := c.Status().Do(ctx, headers...)
 	if err != nil {
@@ -22,6 +26,11 @@ func WaitForConfirmation(c *algod.Client, txid string, waitRounds uint64, ctx co
 	currentRound := lastRound + 1
 
 	for {
+		// Stop waiting if the caller gave up
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
 		// Check that the `waitRounds` has not passed
 		if currentRound > lastRound+waitRounds {
 			err = fmt.Errorf("Wait for transaction id %s timed out", txid)
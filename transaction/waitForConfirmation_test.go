@@ -0,0 +1,84 @@
+package transaction
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/stretchr/testify/require"
+)
+
+// makeWaitForConfirmationServer returns an algod mock which reports the
+// transaction as pending until confirmedRound, then as confirmed.
+func makeWaitForConfirmationServer(t *testing.T, confirmedRound uint64, poolError string) *httptest.Server {
+	round := uint64(10)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/status":
+			w.Write(json.Encode(models.NodeStatus{LastRound: round}))
+		case strings.HasPrefix(r.URL.Path, "/v2/status/wait-for-block-after/"):
+			round++
+			w.Write(json.Encode(models.NodeStatus{LastRound: round}))
+		case strings.HasPrefix(r.URL.Path, "/v2/transactions/pending/"):
+			var info models.PendingTransactionInfoResponse
+			info.PoolError = poolError
+			if confirmedRound != 0 && round >= confirmedRound {
+				info.ConfirmedRound = confirmedRound
+			}
+			w.Write(msgpack.Encode(info))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestWaitForConfirmation(t *testing.T) {
+	server := makeWaitForConfirmationServer(t, 12, "")
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	info, err := WaitForConfirmation(client, "TXID", 5, context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), info.ConfirmedRound)
+}
+
+func TestWaitForConfirmationTimeout(t *testing.T) {
+	server := makeWaitForConfirmationServer(t, 0, "")
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	_, err = WaitForConfirmation(client, "TXID", 3, context.Background())
+	require.ErrorContains(t, err, "timed out")
+}
+
+func TestWaitForConfirmationPoolError(t *testing.T) {
+	server := makeWaitForConfirmationServer(t, 0, "overspend")
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	info, err := WaitForConfirmation(client, "TXID", 3, context.Background())
+	require.ErrorContains(t, err, "overspend")
+	require.Equal(t, "overspend", info.PoolError)
+}
+
+func TestWaitForConfirmationCancelled(t *testing.T) {
+	server := makeWaitForConfirmationServer(t, 0, "")
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = WaitForConfirmation(client, "TXID", 3, ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,36 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+)
+
+// WaitForSync waits until the node is caught up with the network, its status
+// reporting no catchup time nor catchpoint being caught up to, and returns
+// its status then. The status is checked again after every new block.
+//
+// It returns an error if the node stopped at a round whose protocol it does
+// not support. Cancelling ctx stops the wait and returns ctx.Err(). Once
+// synced, algod also answers its /ready endpoint, see algod.Client.GetReady.
+func WaitForSync(ctx context.Context, c *algod.Client, headers ...*common.Header) (models.NodeStatus, error) {
+	status, err := c.Status().Do(ctx, headers...)
+	for {
+		if err != nil {
+			return models.NodeStatus{}, err
+		}
+		if status.StoppedAtUnsupportedRound {
+			return status, fmt.Errorf("node stopped at round %d, whose protocol it does not support", status.LastRound)
+		}
+		if status.CatchupTime == 0 && status.Catchpoint == "" {
+			return status, nil
+		}
+		if err = ctx.Err(); err != nil {
+			return models.NodeStatus{}, err
+		}
+		status, err = c.StatusAfterBlock(status.LastRound).Do(ctx, headers...)
+	}
+}
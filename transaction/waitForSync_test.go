@@ -0,0 +1,77 @@
+package transaction
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/v2/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+)
+
+// makeWaitForSyncServer returns an algod mock catching up until syncedRound,
+// or stopping there if unsupported.
+func makeWaitForSyncServer(t *testing.T, syncedRound uint64, unsupported bool) *httptest.Server {
+	round := uint64(10)
+	status := func() models.NodeStatus {
+		s := models.NodeStatus{LastRound: round}
+		if round < syncedRound {
+			s.CatchupTime = 1000
+		} else if unsupported {
+			s.StoppedAtUnsupportedRound = true
+		}
+		return s
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/status":
+			w.Write(json.Encode(status()))
+		case strings.HasPrefix(r.URL.Path, "/v2/status/wait-for-block-after/"):
+			round++
+			w.Write(json.Encode(status()))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestWaitForSync(t *testing.T) {
+	server := makeWaitForSyncServer(t, 13, false)
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	status, err := WaitForSync(context.Background(), client)
+	require.NoError(t, err)
+	require.Equal(t, uint64(13), status.LastRound)
+	require.Zero(t, status.CatchupTime)
+}
+
+func TestWaitForSyncUnsupported(t *testing.T) {
+	server := makeWaitForSyncServer(t, 11, true)
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	status, err := WaitForSync(context.Background(), client)
+	require.ErrorContains(t, err, "does not support")
+	require.Equal(t, uint64(11), status.LastRound)
+}
+
+func TestWaitForSyncCancelled(t *testing.T) {
+	server := makeWaitForSyncServer(t, 1000, false)
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = WaitForSync(ctx, client)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,216 @@
+package transaction
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// MultisigMetadata describes the multisig account a WalletTransaction is
+// signed by, as defined by ARC-1.
+type MultisigMetadata struct {
+	Version   uint8    `json:"version"`
+	Threshold uint8    `json:"threshold"`
+	Addrs     []string `json:"addrs"`
+}
+
+// Account returns the multisig account described by m.
+func (m MultisigMetadata) Account() (crypto.MultisigAccount, error) {
+	addrs := make([]types.Address, len(m.Addrs))
+	for i, a := range m.Addrs {
+		var err error
+		if addrs[i], err = types.DecodeAddress(a); err != nil {
+			return crypto.MultisigAccount{}, fmt.Errorf("invalid multisig address %d: %w", i, err)
+		}
+	}
+	return crypto.MultisigAccountWithParams(m.Version, m.Threshold, addrs)
+}
+
+// WalletTransaction is a transaction to be signed by a wallet, as exchanged by
+// the ARC-1 signTxns protocol, which encodes it in JSON.
+type WalletTransaction struct {
+	// Txn is the base64 msgpack encoding of the unsigned transaction.
+	Txn string `json:"txn"`
+
+	// AuthAddr is the address the transaction is signed by, if it is not its
+	// sender.
+	AuthAddr string `json:"authAddr,omitempty"`
+
+	// Msig describes the multisig account signing the transaction, whose
+	// address is AuthAddr or the sender.
+	Msig *MultisigMetadata `json:"msig,omitempty"`
+
+	// Signers lists the addresses the wallet must sign with. If nil, the
+	// wallet signs for AuthAddr or the sender. If empty but not nil, the
+	// wallet must not sign the transaction, which is part of a group for
+	// context only.
+	Signers []string `json:"signers"`
+
+	// Stxn is the base64 msgpack encoding of the transaction already signed,
+	// which may only be given with an empty Signers.
+	Stxn string `json:"stxn,omitempty"`
+
+	// Message is a description of the transaction shown to the user.
+	Message string `json:"message,omitempty"`
+
+	// GroupMessage is a description of the group of the transaction, set on
+	// the first transaction of the group only.
+	GroupMessage string `json:"groupMessage,omitempty"`
+}
+
+// NewWalletTransaction returns a WalletTransaction asking the wallet to sign tx
+// for its sender.
+func NewWalletTransaction(tx types.Transaction) WalletTransaction {
+	return WalletTransaction{Txn: types.EncodeTxnToBase64(tx)}
+}
+
+// MarshalJSON omits Signers when it is nil but keeps it when it is empty, the
+// two meaning different things.
+func (w WalletTransaction) MarshalJSON() ([]byte, error) {
+	type walletTransaction WalletTransaction
+	encoded := struct {
+		walletTransaction
+		Signers *[]string `json:"signers,omitempty"`
+	}{walletTransaction: walletTransaction(w)}
+	if w.Signers != nil {
+		encoded.Signers = &w.Signers
+	}
+	return json.Marshal(encoded)
+}
+
+// Transaction decodes the transaction of w.
+func (w WalletTransaction) Transaction() (types.Transaction, error) {
+	return types.DecodeTxnFromBase64(w.Txn)
+}
+
+// MustSign reports whether the wallet is asked to sign the transaction.
+func (w WalletTransaction) MustSign() bool {
+	return w.Signers == nil || len(w.Signers) > 0
+}
+
+// Validate checks that w follows the rules of ARC-1: the transaction decodes,
+// the multisig metadata matches the address signing it, signers are
+// consistent with it, and a signed transaction is only given, for the same
+// transaction, when the wallet must not sign.
+func (w WalletTransaction) Validate() error {
+	tx, err := w.Transaction()
+	if err != nil {
+		return fmt.Errorf("invalid txn: %w", err)
+	}
+
+	authAddr := tx.Sender
+	if w.AuthAddr != "" {
+		if authAddr, err = types.DecodeAddress(w.AuthAddr); err != nil {
+			return fmt.Errorf("invalid authAddr: %w", err)
+		}
+	}
+
+	var msigAddrs map[string]bool
+	if w.Msig != nil {
+		ma, err := w.Msig.Account()
+		if err != nil {
+			return fmt.Errorf("invalid msig: %w", err)
+		}
+		addr, err := ma.Address()
+		if err != nil {
+			return fmt.Errorf("invalid msig: %w", err)
+		}
+		if addr != authAddr {
+			return fmt.Errorf("msig address %s is not the address %s signing the transaction", addr, authAddr)
+		}
+		msigAddrs = make(map[string]bool, len(w.Msig.Addrs))
+		for _, a := range w.Msig.Addrs {
+			msigAddrs[a] = true
+		}
+	}
+
+	switch {
+	case w.Signers == nil:
+	case len(w.Signers) == 0:
+		if w.Stxn != "" {
+			stx, err := types.DecodeSignedTxnFromBase64(w.Stxn)
+			if err != nil {
+				return fmt.Errorf("invalid stxn: %w", err)
+			}
+			if crypto.GetTxID(stx.Txn) != crypto.GetTxID(tx) {
+				return fmt.Errorf("stxn does not sign txn")
+			}
+		}
+	case w.Msig == nil:
+		if len(w.Signers) != 1 || w.Signers[0] != authAddr.String() {
+			return fmt.Errorf("signers must be the single address %s signing the transaction", authAddr)
+		}
+	default:
+		seen := make(map[string]bool, len(w.Signers))
+		for _, s := range w.Signers {
+			if !msigAddrs[s] {
+				return fmt.Errorf("signer %s is not part of the multisig account", s)
+			}
+			if seen[s] {
+				return fmt.Errorf("signer %s is listed twice", s)
+			}
+			seen[s] = true
+		}
+	}
+
+	if w.Stxn != "" && w.MustSign() {
+		return fmt.Errorf("stxn can only be given when signers is empty")
+	}
+	return nil
+}
+
+// ValidateWalletTransactions checks that wtxns is a valid ARC-1 signTxns
+// request: every transaction is valid, the wallet is asked to sign at least
+// one, the transactions of a group are all present, in order, and match their
+// group ID, and a group message is only set on the first transaction of a
+// group.
+func ValidateWalletTransactions(wtxns []WalletTransaction) error {
+	if len(wtxns) == 0 {
+		return fmt.Errorf("no transactions to sign")
+	}
+
+	txns := make([]types.Transaction, len(wtxns))
+	mustSign := false
+	for i, w := range wtxns {
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("transaction %d: %w", i, err)
+		}
+		txns[i], _ = w.Transaction()
+		mustSign = mustSign || w.MustSign()
+	}
+	if !mustSign {
+		return fmt.Errorf("none of the transactions is to be signed by the wallet")
+	}
+
+	for start := 0; start < len(txns); {
+		group := txns[start].Group
+		end := start + 1
+		for end < len(txns) && group != (types.Digest{}) && txns[end].Group == group {
+			end++
+		}
+		if group != (types.Digest{}) {
+			// the group ID is computed over the transactions without it
+			members := make([]types.Transaction, end-start)
+			for i, tx := range txns[start:end] {
+				tx.Group = types.Digest{}
+				members[i] = tx
+			}
+			gid, err := crypto.ComputeGroupID(members)
+			if err != nil {
+				return fmt.Errorf("transaction %d: %w", start, err)
+			}
+			if gid != group {
+				return fmt.Errorf("transactions %d to %d do not form their whole group", start, end-1)
+			}
+		}
+		for i := start + 1; i < end; i++ {
+			if wtxns[i].GroupMessage != "" {
+				return fmt.Errorf("transaction %d: groupMessage must be set on the first transaction of the group", i)
+			}
+		}
+		start = end
+	}
+	return nil
+}
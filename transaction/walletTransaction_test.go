@@ -0,0 +1,106 @@
+package transaction
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestWalletTransaction(t *testing.T) {
+	sender, other := crypto.GenerateAccount(), crypto.GenerateAccount()
+	params := types.SuggestedParams{Fee: 1000, FirstRoundValid: 1, LastRoundValid: 100, GenesisHash: make([]byte, 32), FlatFee: true}
+	tx, err := MakePaymentTxn(sender.Address.String(), other.Address.String(), 1, nil, "", params)
+	require.NoError(t, err)
+
+	w := NewWalletTransaction(tx)
+	require.NoError(t, w.Validate())
+	require.True(t, w.MustSign())
+
+	encoded, err := json.Marshal(w)
+	require.NoError(t, err)
+	require.NotContains(t, string(encoded), "signers")
+
+	// an empty signers list means the wallet must not sign, and is kept
+	w.Signers = []string{}
+	require.False(t, w.MustSign())
+	encoded, err = json.Marshal(w)
+	require.NoError(t, err)
+	require.Contains(t, string(encoded), `"signers":[]`)
+	var decoded WalletTransaction
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, w, decoded)
+
+	// stxn must sign txn, and is only allowed when the wallet does not sign
+	_, stxBytes, err := crypto.SignTransaction(sender.PrivateKey, tx)
+	require.NoError(t, err)
+	w.Stxn = base64.StdEncoding.EncodeToString(stxBytes)
+	require.NoError(t, w.Validate())
+	w.Signers = nil
+	require.ErrorContains(t, w.Validate(), "stxn")
+	w.Stxn = ""
+
+	// a single signer must be the address signing the transaction
+	w.Signers = []string{sender.Address.String()}
+	require.NoError(t, w.Validate())
+	w.Signers = []string{other.Address.String()}
+	require.Error(t, w.Validate())
+	w.AuthAddr = other.Address.String()
+	require.NoError(t, w.Validate())
+
+	// msig must match the signing address, and signers be part of it
+	ma, err := crypto.MultisigAccountWithParams(1, 1, []types.Address{sender.Address, other.Address})
+	require.NoError(t, err)
+	msigAddr, err := ma.Address()
+	require.NoError(t, err)
+	w.Msig = &MultisigMetadata{Version: 1, Threshold: 1, Addrs: []string{sender.Address.String(), other.Address.String()}}
+	w.Signers = []string{sender.Address.String(), other.Address.String()}
+	require.ErrorContains(t, w.Validate(), "msig address")
+	w.AuthAddr = msigAddr.String()
+	require.NoError(t, w.Validate())
+	w.Signers = []string{crypto.GenerateAccount().Address.String()}
+	require.ErrorContains(t, w.Validate(), "not part of the multisig")
+
+	w = WalletTransaction{Txn: "not a transaction"}
+	require.ErrorContains(t, w.Validate(), "invalid txn")
+}
+
+func TestValidateWalletTransactions(t *testing.T) {
+	sender := crypto.GenerateAccount()
+	params := types.SuggestedParams{Fee: 1000, FirstRoundValid: 1, LastRoundValid: 100, GenesisHash: make([]byte, 32), FlatFee: true}
+	var txns []types.Transaction
+	for i := uint64(0); i < 3; i++ {
+		tx, err := MakePaymentTxn(sender.Address.String(), sender.Address.String(), i, nil, "", params)
+		require.NoError(t, err)
+		txns = append(txns, tx)
+	}
+	grouped, err := AssignGroupID(txns[:2], "")
+	require.NoError(t, err)
+
+	wtxns := []WalletTransaction{
+		NewWalletTransaction(grouped[0]),
+		NewWalletTransaction(grouped[1]),
+		NewWalletTransaction(txns[2]),
+	}
+	wtxns[0].GroupMessage = "swap"
+	require.NoError(t, ValidateWalletTransactions(wtxns))
+
+	// a partial group is rejected
+	require.ErrorContains(t, ValidateWalletTransactions(wtxns[1:]), "whole group")
+
+	// the group message belongs to the first transaction
+	wtxns[1].GroupMessage = "swap"
+	require.ErrorContains(t, ValidateWalletTransactions(wtxns), "groupMessage")
+	wtxns[1].GroupMessage = ""
+
+	// at least one transaction must be signed
+	for i := range wtxns {
+		wtxns[i].Signers = []string{}
+	}
+	require.ErrorContains(t, ValidateWalletTransactions(wtxns), "none of the transactions")
+	require.Error(t, ValidateWalletTransactions(nil))
+}
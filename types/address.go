@@ -6,26 +6,70 @@ import (
 	"encoding/base32"
 	"encoding/base64"
 	"fmt"
+	"sync/atomic"
 )
 
 const (
 	checksumLenBytes = 4
 	hashLenBytes     = sha512.Size256
+
+	// encodedAddressLen is the length of the base32 form of an address and
+	// its checksum.
+	encodedAddressLen = (8*(hashLenBytes+checksumLenBytes) + 4) / 5
 )
 
+var addressEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// addressCacheSize is the number of entries of the cache of address strings.
+const addressCacheSize = 1 << 12
+
+type cachedAddress struct {
+	address Address
+	encoded string
+}
+
+// addressCache caches the string form of addresses, in the entry of their
+// first bytes: addresses being hashes, they spread evenly over the entries,
+// and a few thousand of the most recent ones are kept.
+var addressCache [addressCacheSize]atomic.Pointer[cachedAddress]
+
+// base32Values maps the characters of base32 to their value, and the others
+// to 0xff.
+var base32Values = func() (values [256]byte) {
+	for i := range values {
+		values[i] = 0xff
+	}
+	for i, c := range "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567" {
+		values[c] = byte(i)
+	}
+	return
+}()
+
 // Address represents an Algorand address.
 type Address [hashLenBytes]byte
 
 // String grabs a human-readable representation of the address. This
-// representation includes a 4-byte checksum.
+// representation includes a 4-byte checksum. The strings of recently
+// formatted addresses are cached.
 func (a Address) String() string {
+	entry := &addressCache[(int(a[0])<<8|int(a[1]))%addressCacheSize]
+	if cached := entry.Load(); cached != nil && cached.address == a {
+		return cached.encoded
+	}
+
 	// Compute the checksum
 	checksumHash := sha512.Sum512_256(a[:])
-	checksumLenBytes := checksumHash[hashLenBytes-checksumLenBytes:]
 
 	// Append the checksum and encode as base32
-	checksumAddress := append(a[:], checksumLenBytes...)
-	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(checksumAddress)
+	var checksumAddress [hashLenBytes + checksumLenBytes]byte
+	copy(checksumAddress[:], a[:])
+	copy(checksumAddress[hashLenBytes:], checksumHash[hashLenBytes-checksumLenBytes:])
+	var encoded [encodedAddressLen]byte
+	addressEncoding.Encode(encoded[:], checksumAddress[:])
+
+	s := string(encoded[:])
+	entry.Store(&cachedAddress{address: a, encoded: s})
+	return s
 }
 
 // ZeroAddress is Address with all zero bytes. For handy == != comparisons.
@@ -68,8 +112,12 @@ func (a *Address) UnmarshalText(text []byte) error {
 // checks that the checksum is correct and whether the address is canonical,
 // and returns an error if it's not.
 func DecodeAddress(addr string) (a Address, err error) {
+	if a, ok, err := decodeAddressFast(addr); ok {
+		return a, err
+	}
+
 	// Interpret the address as base32
-	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(addr)
+	decoded, err := addressEncoding.DecodeString(addr)
 	if err != nil {
 		return
 	}
@@ -106,6 +154,44 @@ func DecodeAddress(addr string) (a Address, err error) {
 	return a, nil
 }
 
+// decodeAddressFast decodes addr without allocating if it is made of the
+// right number of base32 characters, and returns false otherwise, for
+// DecodeAddress to report the error.
+func decodeAddressFast(addr string) (a Address, ok bool, err error) {
+	if len(addr) != encodedAddressLen {
+		return a, false, nil
+	}
+	var decoded [hashLenBytes + checksumLenBytes]byte
+	var acc uint64
+	var accLen uint
+	n := 0
+	for i := 0; i < len(addr); i++ {
+		v := base32Values[addr[i]]
+		if v == 0xff {
+			return a, false, nil
+		}
+		acc = acc<<5 | uint64(v)
+		accLen += 5
+		if accLen >= 8 {
+			accLen -= 8
+			decoded[n] = byte(acc >> accLen)
+			n++
+		}
+	}
+
+	checksumHash := sha512.Sum512_256(decoded[:hashLenBytes])
+	if !bytes.Equal(checksumHash[hashLenBytes-checksumLenBytes:], decoded[hashLenBytes:]) {
+		return a, true, errWrongChecksum
+	}
+	copy(a[:], decoded[:hashLenBytes])
+
+	// the bits past the last byte must be zero for the address to be canonical
+	if acc&(1<<accLen-1) != 0 {
+		return a, true, fmt.Errorf("address %s is non-canonical", addr)
+	}
+	return a, true, nil
+}
+
 // EncodeAddress turns a byte slice into the human readable representation of the address.
 // This representation includes a 4-byte checksum
 func EncodeAddress(addr []byte) (a string, err error) {
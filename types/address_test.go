@@ -1,7 +1,10 @@
 package types
 
 import (
+	"bytes"
 	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base32"
 	"fmt"
 	"testing"
 
@@ -107,3 +110,99 @@ func TestDecodeNonCanonicalAddress(t *testing.T) {
 		require.ErrorContains(t, err, fmt.Sprintf("address %s is non-canonical", addr))
 	}
 }
+
+// referenceAddressString and referenceDecodeAddress encode and decode
+// addresses as String and DecodeAddress did before their fast paths.
+func referenceAddressString(a Address) string {
+	checksumHash := sha512.Sum512_256(a[:])
+	checksumAddress := append(a[:], checksumHash[hashLenBytes-checksumLenBytes:]...)
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(checksumAddress)
+}
+
+func referenceDecodeAddress(addr string) (a Address, err error) {
+	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(addr)
+	if err != nil {
+		return
+	}
+	if len(decoded) != len(a)+checksumLenBytes {
+		return a, errWrongAddressLen
+	}
+	checksumHash := sha512.Sum512_256(decoded[:len(a)])
+	if !bytes.Equal(checksumHash[hashLenBytes-checksumLenBytes:], decoded[len(a):]) {
+		return a, errWrongChecksum
+	}
+	copy(a[:], decoded)
+	if referenceAddressString(a) != addr {
+		return a, fmt.Errorf("address %s is non-canonical", addr)
+	}
+	return a, nil
+}
+
+func FuzzAddressString(f *testing.F) {
+	f.Add(make([]byte, 32))
+	f.Add(bytes.Repeat([]byte{0xff}, 32))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var a Address
+		copy(a[:], b)
+		s := a.String()
+		require.Equal(t, referenceAddressString(a), s)
+		// cached
+		require.Equal(t, s, a.String())
+		decoded, err := DecodeAddress(s)
+		require.NoError(t, err)
+		require.Equal(t, a, decoded)
+	})
+}
+
+func FuzzDecodeAddress(f *testing.F) {
+	f.Add("7777777777777777777777777777777777777777777777777774MSJUVU")
+	f.Add("7777777777777777777777777777777777777777777777777774MSJUVV")
+	f.Add("7777777777777777777777777777777777777777777777777774MSJUVA")
+	f.Add("7HJBGRIWI7GDL42SOJNIAZ7LJ7EBEGKGE5S52QZXAWDXOHDKMDFR6AUXDF")
+	f.Add("7777777777777777777777777777777777777777777777777774MSJUV")
+	f.Add("77777777777777777777777777777777777777777777777777741SJUVU")
+	f.Fuzz(func(t *testing.T, addr string) {
+		a, err := DecodeAddress(addr)
+		expected, expectedErr := referenceDecodeAddress(addr)
+		require.Equal(t, expectedErr, err)
+		require.Equal(t, expected, a)
+		if err == nil {
+			require.Equal(t, addr, a.String())
+		}
+	})
+}
+
+func BenchmarkAddressString(b *testing.B) {
+	var a Address
+	randomBytes(a[:])
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = a.String()
+		}
+	})
+	b.Run("reference", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = referenceAddressString(a)
+		}
+	})
+}
+
+func BenchmarkDecodeAddress(b *testing.B) {
+	var a Address
+	randomBytes(a[:])
+	s := a.String()
+	b.Run("fast", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = DecodeAddress(s)
+		}
+	})
+	b.Run("reference", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = referenceDecodeAddress(s)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"encoding/base32"
+	"reflect"
+
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+)
+
+// init registers the algod JSON form of the types that algod does not write as
+// base64 byte arrays.
+func init() {
+	json.RegisterAlgodText(reflect.TypeOf(Address{}), func(v interface{}) string {
+		return v.(*Address).String()
+	})
+	json.RegisterAlgodText(reflect.TypeOf(BlockHash{}), func(v interface{}) string {
+		b := v.(*BlockHash)
+		return "blk-" + base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b[:])
+	})
+}
+
+// init lets lenient JSON decoding keep the enum values a newer protocol adds,
+// which strict decoding rejects.
+func init() {
+	json.RegisterLenient(reflect.TypeOf(TxType("")))
+	json.RegisterLenient(reflect.TypeOf(OnCompletion(0)))
+}
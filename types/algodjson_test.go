@@ -0,0 +1,61 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+)
+
+func TestAlgodJSON(t *testing.T) {
+	addr, err := DecodeAddress("7ZUECA7HFLZTXENRV24SHLU4AVPUTMTTDUFUBNBD64C73F3UHRTHAIOF6Q")
+	require.NoError(t, err)
+
+	stxn := SignedTxn{
+		Txn: Transaction{
+			Type: PaymentTx,
+			Header: Header{
+				Sender:      addr,
+				Fee:         1000,
+				FirstValid:  1,
+				LastValid:   100,
+				GenesisHash: Digest{1},
+				Note:        []byte("hello"),
+			},
+			PaymentTxnFields: PaymentTxnFields{Receiver: addr, Amount: 5},
+		},
+		Sig: Signature{3},
+	}
+
+	encoded := json.EncodeAlgod(stxn)
+	require.JSONEq(t, `{
+		"sig": "AwAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA==",
+		"txn": {
+			"amt": 5,
+			"fee": 1000,
+			"fv": 1,
+			"gh": "AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=",
+			"lv": 100,
+			"note": "aGVsbG8=",
+			"rcv": "7ZUECA7HFLZTXENRV24SHLU4AVPUTMTTDUFUBNBD64C73F3UHRTHAIOF6Q",
+			"snd": "7ZUECA7HFLZTXENRV24SHLU4AVPUTMTTDUFUBNBD64C73F3UHRTHAIOF6Q",
+			"type": "pay"
+		}
+	}`, string(encoded))
+
+	var decoded SignedTxn
+	require.NoError(t, json.DecodeAlgod(encoded, &decoded))
+	require.Equal(t, stxn, decoded)
+	require.Equal(t, encoded, json.EncodeAlgod(decoded))
+
+	// the default encoding is unchanged
+	require.Contains(t, string(json.Encode(stxn)), `"snd": "/mhBA+cq8zuRsa65I66cBV9JsnMdC0C0I/cF/Zd0PGY="`)
+
+	block := Block{BlockHeader: BlockHeader{Round: 5, Branch: BlockHash{1}}}
+	encoded = json.EncodeAlgod(block)
+	require.Contains(t, string(encoded), `"prev": "blk-AEAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"`)
+	var decodedBlock Block
+	require.NoError(t, json.DecodeAlgod(encoded, &decodedBlock))
+	require.Equal(t, block, decodedBlock)
+}
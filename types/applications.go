@@ -1,5 +1,11 @@
 package types
 
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
 // This file has the applications specific structures
 
 // ApplicationFields are the fields that are common to all application
@@ -98,6 +104,89 @@ const (
 	DeleteApplicationOC OnCompletion = 5
 )
 
+// onCompletionNames are the names of the on-completions, as ARC-32 and
+// ARC-56 application specifications spell them.
+var onCompletionNames = [...]string{
+	NoOpOC:              "NoOp",
+	OptInOC:             "OptIn",
+	CloseOutOC:          "CloseOut",
+	ClearStateOC:        "ClearState",
+	UpdateApplicationOC: "UpdateApplication",
+	DeleteApplicationOC: "DeleteApplication",
+}
+
+// Valid returns whether oc is one of the on-completions algod accepts.
+func (oc OnCompletion) Valid() bool {
+	return oc < OnCompletion(len(onCompletionNames))
+}
+
+// Name returns the name of oc as ARC-32 and ARC-56 spell it, such as
+// "OptIn", or its number if oc is unknown.
+func (oc OnCompletion) Name() string {
+	if !oc.Valid() {
+		return strconv.FormatUint(uint64(oc), 10)
+	}
+	return onCompletionNames[oc]
+}
+
+// ParseOnCompletion returns the on-completion named s, as ARC-32 and ARC-56
+// spell it, such as "OptIn".
+func ParseOnCompletion(s string) (OnCompletion, error) {
+	for oc, name := range onCompletionNames {
+		if s == name {
+			return OnCompletion(oc), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown on-completion %q", s)
+}
+
+// MarshalText encodes oc by name, such as "OptIn".
+func (oc OnCompletion) MarshalText() ([]byte, error) {
+	if !oc.Valid() {
+		return nil, fmt.Errorf("unknown on-completion %d", uint64(oc))
+	}
+	return []byte(onCompletionNames[oc]), nil
+}
+
+// UnmarshalText decodes an on-completion by name, such as "OptIn".
+func (oc *OnCompletion) UnmarshalText(text []byte) error {
+	parsed, err := ParseOnCompletion(string(text))
+	if err != nil {
+		return err
+	}
+	*oc = parsed
+	return nil
+}
+
+// MarshalJSON encodes oc as a number, as algod does. It takes precedence
+// over MarshalText, which is meant for application specifications.
+func (oc OnCompletion) MarshalJSON() ([]byte, error) {
+	return strconv.AppendUint(nil, uint64(oc), 10), nil
+}
+
+// UnmarshalJSON decodes an on-completion from a number or a name, rejecting
+// the ones algod does not accept. Strict JSON decoding uses it; msgpack and
+// lenient JSON decoding keep unknown on-completions so that transactions from
+// a newer protocol still decode, and Transaction.Validate reports them.
+func (oc *OnCompletion) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var name string
+		if err := json.Unmarshal(data, &name); err != nil {
+			return err
+		}
+		return oc.UnmarshalText([]byte(name))
+	}
+	n, err := strconv.ParseUint(string(data), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid on-completion %s: %w", data, err)
+	}
+	if !OnCompletion(n).Valid() {
+		return fmt.Errorf("unknown on-completion %d", n)
+	}
+	*oc = OnCompletion(n)
+	return nil
+}
+
 // ApplicationCallTxnFields captures the transaction fields used for all
 // interactions with applications
 type ApplicationCallTxnFields struct {
@@ -1,11 +1,13 @@
 package types
 
 import (
+	"bytes"
 	"encoding/base32"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"math"
+	"strings"
 
 	"golang.org/x/crypto/ed25519"
 
@@ -34,6 +36,61 @@ const (
 	HeartbeatTx TxType = "hb"
 )
 
+// txTypes are the transaction types algod accepts.
+var txTypes = [...]TxType{
+	PaymentTx,
+	KeyRegistrationTx,
+	AssetConfigTx,
+	AssetTransferTx,
+	AssetFreezeTx,
+	ApplicationCallTx,
+	StateProofTx,
+	HeartbeatTx,
+}
+
+// Valid returns whether t is one of the transaction types algod accepts.
+func (t TxType) Valid() bool {
+	for _, known := range txTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns t as it appears on the wire, such as "pay".
+func (t TxType) String() string {
+	return string(t)
+}
+
+// ParseTxType returns the transaction type named s, such as "pay", or an
+// error if algod does not accept it.
+func ParseTxType(s string) (TxType, error) {
+	t := TxType(s)
+	if !t.Valid() {
+		return "", fmt.Errorf("unknown transaction type %q", s)
+	}
+	return t, nil
+}
+
+// MarshalText encodes t as it appears on the wire.
+func (t TxType) MarshalText() ([]byte, error) {
+	return []byte(t), nil
+}
+
+// UnmarshalText decodes a transaction type, rejecting the ones algod does
+// not accept. Strict JSON decoding uses it; msgpack and lenient JSON decoding
+// keep unknown types so that transactions from a newer protocol still decode,
+// and Transaction.Validate reports them.
+func (t *TxType) UnmarshalText(text []byte) error {
+	parsed, err := ParseTxType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
 const masterDerivationKeyLenBytes = 32
 
 // MaxTxGroupSize is max number of transactions in a single group
@@ -101,6 +158,95 @@ func (block *Block) FromBase64String(b64string string) error {
 	return nil
 }
 
+// EncodeTxnToBase64 returns the base64 msgpack encoding of tx, the form
+// wallet protocols such as ARC-1 exchange unsigned transactions in.
+func EncodeTxnToBase64(tx Transaction) string {
+	return base64.StdEncoding.EncodeToString(msgpack.Encode(tx))
+}
+
+// DecodeTxnFromBase64 decodes a transaction encoded by EncodeTxnToBase64.
+func DecodeTxnFromBase64(b64string string) (tx Transaction, err error) {
+	err = decodeBase64Msgpack(b64string, &tx)
+	return
+}
+
+// EncodeSignedTxnToBase64 returns the base64 msgpack encoding of stx.
+func EncodeSignedTxnToBase64(stx SignedTxn) string {
+	return base64.StdEncoding.EncodeToString(msgpack.Encode(stx))
+}
+
+// DecodeSignedTxnFromBase64 decodes a signed transaction encoded by
+// EncodeSignedTxnToBase64.
+func DecodeSignedTxnFromBase64(b64string string) (stx SignedTxn, err error) {
+	err = decodeBase64Msgpack(b64string, &stx)
+	return
+}
+
+// EncodeTxnsToBase64 returns the base64 msgpack encoding of each of txns.
+func EncodeTxnsToBase64(txns []Transaction) []string {
+	encoded := make([]string, len(txns))
+	for i, tx := range txns {
+		encoded[i] = EncodeTxnToBase64(tx)
+	}
+	return encoded
+}
+
+// DecodeTxnsFromBase64 decodes transactions encoded by EncodeTxnsToBase64. The
+// error names the first one that could not be decoded.
+func DecodeTxnsFromBase64(b64strings []string) ([]Transaction, error) {
+	txns := make([]Transaction, len(b64strings))
+	for i, s := range b64strings {
+		var err error
+		if txns[i], err = DecodeTxnFromBase64(s); err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+	}
+	return txns, nil
+}
+
+// EncodeSignedTxnsToBase64 returns the base64 msgpack encoding of each of
+// stxns.
+func EncodeSignedTxnsToBase64(stxns []SignedTxn) []string {
+	encoded := make([]string, len(stxns))
+	for i, stx := range stxns {
+		encoded[i] = EncodeSignedTxnToBase64(stx)
+	}
+	return encoded
+}
+
+// DecodeSignedTxnsFromBase64 decodes signed transactions encoded by
+// EncodeSignedTxnsToBase64. The error names the first one that could not be
+// decoded.
+func DecodeSignedTxnsFromBase64(b64strings []string) ([]SignedTxn, error) {
+	stxns := make([]SignedTxn, len(b64strings))
+	for i, s := range b64strings {
+		var err error
+		if stxns[i], err = DecodeSignedTxnFromBase64(s); err != nil {
+			return nil, fmt.Errorf("signed transaction %d: %w", i, err)
+		}
+	}
+	return stxns, nil
+}
+
+// decodeBase64Msgpack decodes the base64 msgpack object b64string into objptr.
+// The whole string must be a single object: trailing data usually means that
+// several transactions were concatenated, and would otherwise be dropped
+// silently.
+func decodeBase64Msgpack(b64string string, objptr interface{}) error {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(b64string))
+	if err != nil {
+		return err
+	}
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	if err = dec.Decode(objptr); err != nil {
+		return err
+	}
+	if n := dec.NumBytesRead(); n != len(data) {
+		return fmt.Errorf("%d bytes of trailing data after the encoded object", len(data)-n)
+	}
+	return nil
+}
+
 // DigestFromString converts a string to a Digest
 func DigestFromString(str string) (d Digest, err error) {
 	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(str)
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"encoding/base64"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
 	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
 
 	"github.com/stretchr/testify/require"
@@ -38,3 +39,108 @@ func TestBlockFromBase64String(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, vbl.CurrentProtocol, protocol)
 }
+
+func TestBase64TxnCodecs(t *testing.T) {
+	tx := Transaction{Type: PaymentTx, Header: Header{Fee: 1000, FirstValid: 1, LastValid: 100, Note: []byte("note")}}
+	stx := SignedTxn{Txn: tx, Sig: Signature{1}}
+
+	decodedTxn, err := DecodeTxnFromBase64(EncodeTxnToBase64(tx))
+	require.NoError(t, err)
+	require.Equal(t, tx, decodedTxn)
+
+	decodedStx, err := DecodeSignedTxnFromBase64(" " + EncodeSignedTxnToBase64(stx) + "\n")
+	require.NoError(t, err)
+	require.Equal(t, stx, decodedStx)
+
+	txns, err := DecodeTxnsFromBase64(EncodeTxnsToBase64([]Transaction{tx, tx}))
+	require.NoError(t, err)
+	require.Equal(t, []Transaction{tx, tx}, txns)
+
+	stxns, err := DecodeSignedTxnsFromBase64(EncodeSignedTxnsToBase64([]SignedTxn{stx, stx}))
+	require.NoError(t, err)
+	require.Equal(t, []SignedTxn{stx, stx}, stxns)
+
+	// concatenated transactions are not silently truncated to the first one
+	concatenated := base64.StdEncoding.EncodeToString(append(msgpack.Encode(stx), msgpack.Encode(stx)...))
+	_, err = DecodeSignedTxnFromBase64(concatenated)
+	require.ErrorContains(t, err, "trailing data")
+
+	_, err = DecodeSignedTxnsFromBase64([]string{EncodeSignedTxnToBase64(stx), "not base64!"})
+	require.ErrorContains(t, err, "signed transaction 1")
+}
+
+func TestTxTypeEncoding(t *testing.T) {
+	for _, txType := range txTypes {
+		parsed, err := ParseTxType(txType.String())
+		require.NoError(t, err)
+		require.Equal(t, txType, parsed)
+	}
+	_, err := ParseTxType("")
+	require.Error(t, err)
+
+	tx := Transaction{Type: PaymentTx}
+	require.Contains(t, string(json.Encode(tx)), `"type": "pay"`)
+	var decoded Transaction
+	require.NoError(t, json.Decode(json.Encode(tx), &decoded))
+	require.Equal(t, tx, decoded)
+
+	// Strict JSON decoding rejects unknown types, while msgpack and lenient
+	// JSON decoding keep them for Validate to report.
+	tx.Type = "xfer"
+	require.Error(t, json.Decode(json.Encode(tx), &decoded))
+	decoded = Transaction{}
+	require.NoError(t, json.LenientDecode(json.Encode(tx), &decoded))
+	require.Equal(t, tx, decoded)
+	decoded = Transaction{}
+	require.NoError(t, msgpack.Decode(msgpack.Encode(tx), &decoded))
+	require.Equal(t, tx, decoded)
+	require.ErrorContains(t, decoded.Validate(), `unknown transaction type "xfer"`)
+}
+
+func TestOnCompletionEncoding(t *testing.T) {
+	require.Equal(t, "OptInOC", OptInOC.String())
+	require.Equal(t, "OnCompletion(9)", OnCompletion(9).String())
+	require.Equal(t, "DeleteApplication", DeleteApplicationOC.Name())
+	require.Equal(t, "9", OnCompletion(9).Name())
+
+	for oc := NoOpOC; oc <= DeleteApplicationOC; oc++ {
+		require.True(t, oc.Valid())
+		text, err := oc.MarshalText()
+		require.NoError(t, err)
+		parsed, err := ParseOnCompletion(string(text))
+		require.NoError(t, err)
+		require.Equal(t, oc, parsed)
+	}
+	require.False(t, OnCompletion(9).Valid())
+	_, err := OnCompletion(9).MarshalText()
+	require.Error(t, err)
+	_, err = ParseOnCompletion("OptInOC")
+	require.Error(t, err)
+
+	// JSON keeps algod's numeric form, but also accepts names.
+	tx := Transaction{Type: ApplicationCallTx}
+	tx.OnCompletion = CloseOutOC
+	require.Contains(t, string(json.Encode(tx)), `"apan": 2`)
+	var decoded Transaction
+	require.NoError(t, json.Decode(json.Encode(tx), &decoded))
+	require.Equal(t, tx, decoded)
+	decoded = Transaction{}
+	require.NoError(t, json.Decode([]byte(`{"type": "appl", "apan": "CloseOut"}`), &decoded))
+	require.Equal(t, tx, decoded)
+	require.Error(t, json.Decode([]byte(`{"type": "appl", "apan": "Close"}`), &decoded))
+
+	// Strict JSON decoding rejects unknown on-completions, while msgpack and
+	// lenient JSON decoding keep them for Validate to report.
+	tx.OnCompletion = 9
+	require.Error(t, json.Decode(json.Encode(tx), &decoded))
+	decoded = Transaction{}
+	require.NoError(t, json.LenientDecode(json.Encode(tx), &decoded))
+	require.Equal(t, tx, decoded)
+	decoded = Transaction{}
+	require.NoError(t, json.LenientDecode([]byte(`{"type": "appl", "apan": "CloseOut"}`), &decoded))
+	require.Equal(t, CloseOutOC, decoded.OnCompletion)
+	decoded = Transaction{}
+	require.NoError(t, msgpack.Decode(msgpack.Encode(tx), &decoded))
+	require.Equal(t, tx, decoded)
+	require.ErrorContains(t, decoded.Validate(), "unknown on-completion 9")
+}
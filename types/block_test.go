@@ -0,0 +1,70 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+)
+
+func TestBlockWithApplyDataRoundTrip(t *testing.T) {
+	inner := SignedTxnWithAD{}
+	inner.Txn.Type = PaymentTx
+	inner.Txn.Sender = Address{0x02}
+	inner.Txn.Receiver = Address{0x03}
+	inner.Txn.Amount = 5000
+	inner.SenderRewards = 7
+
+	appCall := SignedTxnInBlock{HasGenesisID: true, HasGenesisHash: true}
+	appCall.Sig = Signature{0x04}
+	appCall.Txn.Type = ApplicationCallTx
+	appCall.Txn.Sender = Address{0x01}
+	appCall.Txn.Fee = 2000
+	appCall.Txn.ApplicationID = 42
+	appCall.EvalDelta = EvalDelta{
+		GlobalDelta: StateDelta{
+			"counter": {Action: SetUintAction, Uint: 3},
+			"name":    {Action: SetBytesAction, Bytes: "algo"},
+		},
+		LocalDeltas: map[uint64]StateDelta{
+			0: {"gone": {Action: DeleteAction}},
+		},
+		SharedAccts: []Address{{0x05}},
+		Logs:        []string{"hello", "\x00\x01"},
+		InnerTxns:   []SignedTxnWithAD{inner},
+	}
+
+	closeTxn := SignedTxnInBlock{}
+	closeTxn.Sig = Signature{0x06}
+	closeTxn.Txn.Type = PaymentTx
+	closeTxn.Txn.Sender = Address{0x07}
+	closeTxn.Txn.CloseRemainderTo = Address{0x08}
+	closeTxn.ClosingAmount = 123456
+	closeTxn.CloseRewards = 9
+
+	var block Block
+	block.Round = 1000
+	block.Branch = BlockHash{0x09}
+	block.GenesisID = "testnet-v1.0"
+	block.GenesisHash = Digest{0x0a}
+	block.TimeStamp = 1700000000
+	block.TxnCounter = 77
+	block.FeesCollected = 3000
+	block.Proposer = Address{0x0b}
+	block.CurrentProtocol = "future"
+	block.Payset = Payset{appCall, closeTxn}
+
+	encoded := msgpack.Encode(block)
+
+	var decoded Block
+	require.NoError(t, msgpack.Decode(encoded, &decoded))
+	require.Equal(t, block.BlockHeader, decoded.BlockHeader)
+	require.Equal(t, block.Payset, decoded.Payset)
+	require.Equal(t, encoded, msgpack.Encode(decoded))
+
+	itx := decoded.Payset[0].EvalDelta.InnerTxns
+	require.Len(t, itx, 1)
+	require.Equal(t, uint64(5000), uint64(itx[0].Txn.Amount))
+	require.Equal(t, MicroAlgos(123456), decoded.Payset[1].ClosingAmount)
+}
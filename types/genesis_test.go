@@ -153,3 +153,30 @@ func TestGenesis_Balances(t *testing.T) {
 		})
 	}
 }
+
+func TestKnownNetworks(t *testing.T) {
+	genesisStr, err := ioutil.ReadFile("test_resource/mainnet_genesis.json")
+	require.NoError(t, err)
+	genesis, err := ParseGenesis(genesisStr)
+	require.NoError(t, err)
+	require.Equal(t, MainNetGenesisID, genesis.ID())
+	network, ok := genesis.KnownNetwork()
+	require.True(t, ok)
+	require.Equal(t, MainNet, network)
+
+	_, err = ParseGenesis([]byte("not json"))
+	require.Error(t, err)
+	_, ok = NetworkByGenesisHash(Digest{1})
+	require.False(t, ok)
+
+	var tx Transaction
+	tx.GenesisHash = TestNet.GenesisHash
+	require.NoError(t, TestNet.CheckTransaction(tx))
+	tx.GenesisID = TestNetGenesisID
+	require.NoError(t, TestNet.CheckTransaction(tx))
+	require.ErrorContains(t, MainNet.CheckTransaction(tx), "for testnet, not mainnet")
+	tx.GenesisID = BetaNetGenesisID
+	require.ErrorContains(t, TestNet.CheckTransaction(tx), "genesis ID")
+	tx.GenesisHash = Digest{1}
+	require.ErrorContains(t, BetaNet.CheckTransaction(tx), "genesis hash")
+}
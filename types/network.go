@@ -0,0 +1,88 @@
+package types
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/v2/encoding/json"
+)
+
+// Genesis IDs and base64 genesis hashes of the public networks.
+const (
+	MainNetGenesisID   = "mainnet-v1.0"
+	MainNetGenesisHash = "wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8="
+	TestNetGenesisID   = "testnet-v1.0"
+	TestNetGenesisHash = "SGO1GKSzyE7IEPItTxCByw9x8FmnrCDexi9/cOUJOiI="
+	BetaNetGenesisID   = "betanet-v1.0"
+	BetaNetGenesisHash = "mFgazF+2uRS1tMiL9dsj01hJGySEmPN28B/TjjvpVW0="
+)
+
+// KnownNetwork is a public Algorand network.
+type KnownNetwork struct {
+	Name        string
+	GenesisID   string
+	GenesisHash Digest
+}
+
+// The public networks.
+var (
+	MainNet = KnownNetwork{Name: "mainnet", GenesisID: MainNetGenesisID, GenesisHash: mustDecodeDigest(MainNetGenesisHash)}
+	TestNet = KnownNetwork{Name: "testnet", GenesisID: TestNetGenesisID, GenesisHash: mustDecodeDigest(TestNetGenesisHash)}
+	BetaNet = KnownNetwork{Name: "betanet", GenesisID: BetaNetGenesisID, GenesisHash: mustDecodeDigest(BetaNetGenesisHash)}
+)
+
+// KnownNetworks returns the public networks.
+func KnownNetworks() []KnownNetwork {
+	return []KnownNetwork{MainNet, TestNet, BetaNet}
+}
+
+func mustDecodeDigest(b64 string) Digest {
+	var d Digest
+	decoded, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil || len(decoded) != len(d) {
+		panic(fmt.Sprintf("invalid digest %s", b64))
+	}
+	copy(d[:], decoded)
+	return d
+}
+
+// NetworkByGenesisHash returns the public network of genesis hash hash, if
+// any.
+func NetworkByGenesisHash(hash Digest) (KnownNetwork, bool) {
+	for _, network := range KnownNetworks() {
+		if network.GenesisHash == hash {
+			return network, true
+		}
+	}
+	return KnownNetwork{}, false
+}
+
+// CheckTransaction returns an error if tx is not valid on the network: its
+// genesis hash must be the one of the network, and its genesis ID, if set,
+// the one of the network.
+func (n KnownNetwork) CheckTransaction(tx Transaction) error {
+	if tx.GenesisHash != n.GenesisHash {
+		if other, ok := NetworkByGenesisHash(tx.GenesisHash); ok {
+			return fmt.Errorf("transaction is for %s, not %s", other.Name, n.Name)
+		}
+		return fmt.Errorf("transaction genesis hash %s is not the one of %s", base64.StdEncoding.EncodeToString(tx.GenesisHash[:]), n.Name)
+	}
+	if tx.GenesisID != "" && tx.GenesisID != n.GenesisID {
+		return fmt.Errorf("transaction genesis ID %s is not the one of %s", tx.GenesisID, n.Name)
+	}
+	return nil
+}
+
+// ParseGenesis decodes a genesis.json file, ignoring unknown fields.
+func ParseGenesis(data []byte) (Genesis, error) {
+	var genesis Genesis
+	if err := json.LenientDecode(data, &genesis); err != nil {
+		return Genesis{}, fmt.Errorf("could not decode genesis: %w", err)
+	}
+	return genesis, nil
+}
+
+// KnownNetwork returns the public network of the genesis, if any.
+func (genesis Genesis) KnownNetwork() (KnownNetwork, bool) {
+	return NetworkByGenesisHash(genesis.Hash())
+}
@@ -331,3 +331,39 @@ type LedgerStateDelta struct {
 	// The account totals reflecting the changes in this StateDelta object.
 	Totals AccountTotals
 }
+
+// GetData returns the modified AccountData of the given address, and whether
+// the account was modified at all in these deltas.
+//
+// The lookup caches are not populated when deltas are decoded from algod, so
+// this scans Accts.
+func (ad AccountDeltas) GetData(addr Address) (AccountData, bool) {
+	for _, record := range ad.Accts {
+		if record.Addr == addr {
+			return record.AccountData, true
+		}
+	}
+	return AccountData{}, false
+}
+
+// GetAppResource returns the application params and local state deltas of the
+// given address and application, and whether either was modified.
+func (ad AccountDeltas) GetAppResource(addr Address, aidx AppIndex) (AppResourceRecord, bool) {
+	for _, record := range ad.AppResources {
+		if record.Addr == addr && record.Aidx == aidx {
+			return record, true
+		}
+	}
+	return AppResourceRecord{}, false
+}
+
+// GetAssetResource returns the asset params and holding deltas of the given
+// address and asset, and whether either was modified.
+func (ad AccountDeltas) GetAssetResource(addr Address, aidx AssetIndex) (AssetResourceRecord, bool) {
+	for _, record := range ad.AssetResources {
+		if record.Addr == addr && record.Aidx == aidx {
+			return record, true
+		}
+	}
+	return AssetResourceRecord{}, false
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+)
+
+func TestLedgerStateDeltaLookups(t *testing.T) {
+	holder := Address{0x01}
+	creator := Address{0x02}
+
+	var delta LedgerStateDelta
+	delta.Accts.Accts = []BalanceRecord{
+		{Addr: holder, AccountData: AccountData{AccountBaseData: AccountBaseData{MicroAlgos: 100}}},
+		{Addr: creator, AccountData: AccountData{AccountBaseData: AccountBaseData{MicroAlgos: 200}}},
+	}
+	delta.Accts.AppResources = []AppResourceRecord{
+		{Aidx: 7, Addr: creator, Params: AppParamsDelta{Deleted: true}},
+	}
+	delta.Accts.AssetResources = []AssetResourceRecord{
+		{Aidx: 9, Addr: holder, Holding: AssetHoldingDelta{Holding: &AssetHolding{Amount: 5}}},
+	}
+	delta.KvMods = map[string]KvValueDelta{"box": {Data: []byte("value")}}
+
+	var decoded LedgerStateDelta
+	require.NoError(t, msgpack.Decode(msgpack.Encode(delta), &decoded))
+
+	data, ok := decoded.Accts.GetData(creator)
+	require.True(t, ok)
+	require.Equal(t, MicroAlgos(200), data.MicroAlgos)
+	_, ok = decoded.Accts.GetData(Address{0x03})
+	require.False(t, ok)
+
+	app, ok := decoded.Accts.GetAppResource(creator, 7)
+	require.True(t, ok)
+	require.True(t, app.Params.Deleted)
+	_, ok = decoded.Accts.GetAppResource(holder, 7)
+	require.False(t, ok)
+
+	asset, ok := decoded.Accts.GetAssetResource(holder, 9)
+	require.True(t, ok)
+	require.Equal(t, uint64(5), asset.Holding.Holding.Amount)
+
+	require.Equal(t, []byte("value"), decoded.KvMods["box"].Data)
+}
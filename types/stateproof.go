@@ -97,6 +97,10 @@ type Proof struct {
 	TreeDepth uint8 `codec:"td"`
 }
 
+func (p *Proof) empty() bool {
+	return len(p.Path) == 0 && p.HashFactory.HashType == 0 && p.TreeDepth == 0
+}
+
 // MerkleSignatureSchemeRootSize is the size of the root of the merkle tree.
 const MerkleSignatureSchemeRootSize = SumhashDigestSize
 
@@ -211,6 +215,18 @@ type StateProof struct {
 	PositionsToReveal []uint64          `codec:"pr,allocbound=MaxReveals"`
 }
 
+// Empty returns true if the StateProof carries no data, i.e. it would be
+// omitted entirely from a msgpack encoding.
+func (sp *StateProof) Empty() bool {
+	return len(sp.SigCommit) == 0 &&
+		sp.SignedWeight == 0 &&
+		sp.SigProofs.empty() &&
+		sp.PartProofs.empty() &&
+		sp.MerkleSignatureSaltVersion == 0 &&
+		len(sp.Reveals) == 0 &&
+		len(sp.PositionsToReveal) == 0
+}
+
 // Message represents the message that the state proofs are attesting to. This message can be
 // used by lightweight client and gives it the ability to verify proofs on the Algorand's state.
 // In addition to that proof, this message also contains fields that
@@ -225,6 +241,15 @@ type Message struct {
 	LastAttestedRound      uint64 `codec:"l"`
 }
 
+// Empty returns true if the Message carries no data.
+func (m *Message) Empty() bool {
+	return len(m.BlockHeadersCommitment) == 0 &&
+		len(m.VotersCommitment) == 0 &&
+		m.LnProvenWeight == 0 &&
+		m.FirstAttestedRound == 0 &&
+		m.LastAttestedRound == 0
+}
+
 // StateProofTxnFields captures the fields used for stateproof transactions.
 type StateProofTxnFields struct {
 	_struct struct{} `codec:",omitempty,omitemptyarray"`
@@ -233,3 +258,9 @@ type StateProofTxnFields struct {
 	StateProof     StateProof     `codec:"sp"`
 	Message        Message        `codec:"spmsg"`
 }
+
+// Empty indicates whether or not all the fields in the
+// StateProofTxnFields are zeroed out
+func (sp *StateProofTxnFields) Empty() bool {
+	return sp.StateProofType == 0 && sp.StateProof.Empty() && sp.Message.Empty()
+}
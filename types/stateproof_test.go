@@ -0,0 +1,79 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateProofTxnFieldsEmpty(t *testing.T) {
+	var fields StateProofTxnFields
+	require.True(t, fields.Empty())
+
+	fields.Message.LastAttestedRound = 512
+	require.False(t, fields.Empty())
+
+	fields = StateProofTxnFields{}
+	fields.StateProof.SigProofs.TreeDepth = 2
+	require.False(t, fields.Empty())
+}
+
+func TestBlockWithStateProofTxnRoundTrip(t *testing.T) {
+	stpf := SignedTxnInBlock{}
+	stpf.Txn.Type = StateProofTx
+	stpf.Txn.Sender = Address{0x01}
+	stpf.Txn.FirstValid = 1024
+	stpf.Txn.LastValid = 2024
+	stpf.Txn.StateProofType = StateProofBasic
+	stpf.Txn.StateProof = StateProof{
+		SigCommit:    GenericDigest{0x01, 0x02, 0x03},
+		SignedWeight: 1_000_000,
+		SigProofs: Proof{
+			Path:        []GenericDigest{{0x04}, {0x05}},
+			HashFactory: HashFactory{HashType: Sumhash},
+			TreeDepth:   2,
+		},
+		PartProofs: Proof{
+			Path:        []GenericDigest{{0x06}},
+			HashFactory: HashFactory{HashType: Sumhash},
+			TreeDepth:   1,
+		},
+		MerkleSignatureSaltVersion: 0,
+		Reveals: map[uint64]Reveal{
+			3: {
+				SigSlot: sigslotCommit{
+					Sig: FalconSignatureStruct{
+						Signature:             MerkleSignature{0x07, 0x08},
+						VectorCommitmentIndex: 9,
+					},
+					L: 42,
+				},
+				Part: Participant{Weight: 500},
+			},
+		},
+		PositionsToReveal: []uint64{3, 3},
+	}
+	stpf.Txn.Message = Message{
+		BlockHeadersCommitment: []byte{0x0a},
+		VotersCommitment:       []byte{0x0b},
+		LnProvenWeight:         7,
+		FirstAttestedRound:     257,
+		LastAttestedRound:      512,
+	}
+
+	var block Block
+	block.Round = 1025
+	block.Payset = Payset{stpf}
+
+	encoded := msgpack.Encode(block)
+
+	var decoded Block
+	require.NoError(t, msgpack.Decode(encoded, &decoded))
+	require.Len(t, decoded.Payset, 1)
+	require.Equal(t, StateProofTx, decoded.Payset[0].Txn.Type)
+	require.Equal(t, stpf.Txn.StateProofTxnFields, decoded.Payset[0].Txn.StateProofTxnFields)
+	require.False(t, decoded.Payset[0].Txn.StateProofTxnFields.Empty())
+	require.Equal(t, encoded, msgpack.Encode(decoded))
+}
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/v2/protocol"
+	"github.com/algorand/go-algorand-sdk/v2/protocol/config"
+)
+
+// validateParams holds the consensus parameters the well-formedness of
+// transactions is checked against locally, those of the current consensus
+// version.
+var validateParams = config.Consensus[protocol.ConsensusCurrentVersion]
+
+// Validate checks that tx is well-formed, as algod requires, without
+// querying the network, and returns all the rules it breaks joined in an
+// error:
+//
+//   - its type, and the on-completion of an application call, are known;
+//   - its sender and genesis hash are set;
+//   - its fee is at least the minimum fee, unless it is grouped with
+//     transactions paying its fee;
+//   - its first valid round is not after its last valid round, which is at
+//     most MaxTxnLife rounds later;
+//   - its note is at most MaxTxnNoteBytes long;
+//   - it does not close an account or asset holding to its own sender.
+//
+// Rules depending on the ledger, such as balances, are left to algod.
+func (tx Transaction) Validate() error {
+	var violations []error
+	violation := func(format string, args ...interface{}) {
+		violations = append(violations, fmt.Errorf(format, args...))
+	}
+
+	if !tx.Type.Valid() {
+		violation("unknown transaction type %q", tx.Type)
+	}
+	if tx.Type == ApplicationCallTx && !tx.OnCompletion.Valid() {
+		violation("unknown on-completion %d", uint64(tx.OnCompletion))
+	}
+	if tx.Sender.IsZero() {
+		violation("sender is not set")
+	}
+	if tx.GenesisHash == (Digest{}) {
+		violation("genesis hash is not set")
+	}
+
+	// state proof and heartbeat transactions are issued by the network
+	networkIssued := tx.Type == StateProofTx || tx.Type == HeartbeatTx
+	if !networkIssued && uint64(tx.Fee) < validateParams.MinTxnFee && tx.Group == (Digest{}) {
+		violation("fee %d is below the minimum fee %d of a transaction outside a group", tx.Fee, validateParams.MinTxnFee)
+	}
+
+	if tx.FirstValid > tx.LastValid {
+		violation("first valid round %d is after last valid round %d", tx.FirstValid, tx.LastValid)
+	} else if uint64(tx.LastValid-tx.FirstValid) > validateParams.MaxTxnLife {
+		violation("validity window of %d rounds is longer than %d rounds", tx.LastValid-tx.FirstValid, validateParams.MaxTxnLife)
+	}
+
+	if len(tx.Note) > validateParams.MaxTxnNoteBytes {
+		violation("note of %d bytes is longer than %d bytes", len(tx.Note), validateParams.MaxTxnNoteBytes)
+	}
+
+	if !tx.CloseRemainderTo.IsZero() && tx.CloseRemainderTo == tx.Sender {
+		violation("transaction cannot close the account to its sender")
+	}
+	if !tx.AssetCloseTo.IsZero() && tx.AssetCloseTo == tx.Sender {
+		violation("transaction cannot close the asset holding to its sender")
+	}
+	return errors.Join(violations...)
+}
+
+// Validate checks that stx is well-formed, as algod requires, without
+// querying the network or verifying its signature: its transaction is
+// well-formed, exactly one of a signature, a multisig or a logic signature is
+// set, and its authorized address, if set, is not the sender. It returns all
+// the rules broken joined in an error.
+func (stx SignedTxn) Validate() error {
+	var violations []error
+	if err := stx.Txn.Validate(); err != nil {
+		violations = append(violations, err)
+	}
+
+	signatures := 0
+	if stx.Sig != (Signature{}) {
+		signatures++
+	}
+	if !stx.Msig.Blank() {
+		signatures++
+	}
+	if !stx.Lsig.Blank() {
+		signatures++
+	}
+	if signatures != 1 {
+		violations = append(violations, fmt.Errorf("exactly one of a signature, a multisig or a logic signature must be set, not %d", signatures))
+	}
+
+	if stx.AuthAddr == stx.Txn.Sender && !stx.AuthAddr.IsZero() {
+		violations = append(violations, fmt.Errorf("authorized address is the sender, and must be left unset"))
+	}
+	return errors.Join(violations...)
+}
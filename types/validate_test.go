@@ -0,0 +1,55 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	sender := Address{1}
+	tx := Transaction{
+		Type:             PaymentTx,
+		Header:           Header{Sender: sender, Fee: 1000, FirstValid: 100, LastValid: 1100, GenesisHash: Digest{2}, Note: make([]byte, 1024)},
+		PaymentTxnFields: PaymentTxnFields{Receiver: Address{3}, Amount: 5},
+	}
+	require.NoError(t, tx.Validate())
+	stx := SignedTxn{Txn: tx, Sig: Signature{4}}
+	require.NoError(t, stx.Validate())
+
+	// fees may be pooled in a group
+	grouped := tx
+	grouped.Fee = 0
+	require.Error(t, grouped.Validate())
+	grouped.Group = Digest{5}
+	require.NoError(t, grouped.Validate())
+
+	bad := tx
+	bad.Type = "xfer"
+	bad.Fee = 10
+	bad.FirstValid = 2000
+	bad.Note = make([]byte, 1025)
+	bad.CloseRemainderTo = sender
+	err := bad.Validate()
+	require.Error(t, err)
+	violations := strings.Split(err.Error(), "\n")
+	require.Equal(t, []string{
+		`unknown transaction type "xfer"`,
+		"fee 10 is below the minimum fee 1000 of a transaction outside a group",
+		"first valid round 2000 is after last valid round 1100",
+		"note of 1025 bytes is longer than 1024 bytes",
+		"transaction cannot close the account to its sender",
+	}, violations)
+
+	bad = tx
+	bad.LastValid = 1101
+	require.EqualError(t, bad.Validate(), "validity window of 1001 rounds is longer than 1000 rounds")
+
+	stx = SignedTxn{Txn: tx, AuthAddr: sender}
+	require.EqualError(t, stx.Validate(), "exactly one of a signature, a multisig or a logic signature must be set, not 0\nauthorized address is the sender, and must be left unset")
+	stx = SignedTxn{Txn: tx, Sig: Signature{4}, Lsig: LogicSig{Logic: []byte{1}}}
+	require.Error(t, stx.Validate())
+	stx.Txn.Sender = Address{}
+	require.ErrorContains(t, stx.Validate(), "sender is not set")
+}
@@ -0,0 +1,7 @@
+package uri
+
+import (
+	"errors"
+)
+
+var errNoteAndXNote = errors.New("a payment URI cannot have both a note and an xnote")
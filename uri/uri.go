@@ -0,0 +1,135 @@
+// Package uri builds and parses Algorand payment URIs as described by ARC-26.
+//
+// A payment URI has the form
+//
+//	algorand://<address>?amount=<amount>&asset=<id>&label=<label>&note=<note>
+//
+// where every query parameter is optional. Amounts are in the base unit of the
+// asset, or in microAlgos when no asset is given.
+package uri
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// Scheme is the URI scheme used by Algorand payment URIs.
+const Scheme = "algorand"
+
+const (
+	amountParam = "amount"
+	assetParam  = "asset"
+	labelParam  = "label"
+	noteParam   = "note"
+	xnoteParam  = "xnote"
+)
+
+// PaymentURI is the decoded form of an ARC-26 payment URI.
+type PaymentURI struct {
+	// Address is the account the URI refers to, the receiver of a payment.
+	Address types.Address
+
+	// Amount to pay, in the base unit of AssetID, or in microAlgos if AssetID
+	// is zero. Zero means the amount is left to the payer.
+	Amount uint64
+
+	// AssetID of the asset to transfer. Zero means a payment in Algos.
+	AssetID uint64
+
+	// Label is a display name for Address.
+	Label string
+
+	// Note is a transaction note the payer may edit.
+	Note string
+
+	// XNote is a transaction note the payer must not edit. At most one of Note
+	// and XNote may be set.
+	XNote string
+}
+
+// Encode returns the URI form of p.
+func (p PaymentURI) Encode() (string, error) {
+	if p.Note != "" && p.XNote != "" {
+		return "", errNoteAndXNote
+	}
+
+	var params []string
+	if p.Amount != 0 {
+		params = append(params, amountParam+"="+strconv.FormatUint(p.Amount, 10))
+	}
+	if p.AssetID != 0 {
+		params = append(params, assetParam+"="+strconv.FormatUint(p.AssetID, 10))
+	}
+	if p.Label != "" {
+		params = append(params, labelParam+"="+escape(p.Label))
+	}
+	if p.Note != "" {
+		params = append(params, noteParam+"="+escape(p.Note))
+	}
+	if p.XNote != "" {
+		params = append(params, xnoteParam+"="+escape(p.XNote))
+	}
+
+	encoded := Scheme + "://" + p.Address.String()
+	if len(params) > 0 {
+		encoded += "?" + strings.Join(params, "&")
+	}
+	return encoded, nil
+}
+
+// escape percent-encodes a query value, using %20 rather than + for spaces.
+func escape(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+// Parse decodes an ARC-26 payment URI. Query parameters this package does not
+// know about are ignored.
+func Parse(encoded string) (p PaymentURI, err error) {
+	u, err := url.Parse(encoded)
+	if err != nil {
+		return
+	}
+
+	if u.Scheme != Scheme {
+		err = fmt.Errorf("unexpected scheme %q, expected %q", u.Scheme, Scheme)
+		return
+	}
+
+	p.Address, err = types.DecodeAddress(u.Host)
+	if err != nil {
+		err = fmt.Errorf("invalid address %q: %w", u.Host, err)
+		return
+	}
+
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return
+	}
+
+	if amount := query.Get(amountParam); amount != "" {
+		p.Amount, err = strconv.ParseUint(amount, 10, 64)
+		if err != nil {
+			err = fmt.Errorf("invalid amount %q: %w", amount, err)
+			return
+		}
+	}
+	if asset := query.Get(assetParam); asset != "" {
+		p.AssetID, err = strconv.ParseUint(asset, 10, 64)
+		if err != nil {
+			err = fmt.Errorf("invalid asset %q: %w", asset, err)
+			return
+		}
+	}
+	p.Label = query.Get(labelParam)
+	p.Note = query.Get(noteParam)
+	p.XNote = query.Get(xnoteParam)
+
+	if p.Note != "" && p.XNote != "" {
+		err = errNoteAndXNote
+	}
+	return
+}
@@ -0,0 +1,91 @@
+package uri
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+const testAddress = "TMTAD6N22HCS2LKH7677L2KFLT3PAQWY6M4JFQFXQS32ECBFC23F57RYX4"
+
+func TestPaymentURIRoundTrip(t *testing.T) {
+	addr, err := types.DecodeAddress(testAddress)
+	require.NoError(t, err)
+
+	testcases := []struct {
+		name    string
+		encoded string
+		decoded PaymentURI
+	}{
+		{
+			name:    "contact",
+			encoded: "algorand://" + testAddress + "?label=Silvio",
+			decoded: PaymentURI{Address: addr, Label: "Silvio"},
+		},
+		{
+			name:    "payment",
+			encoded: "algorand://" + testAddress + "?amount=150500000",
+			decoded: PaymentURI{Address: addr, Amount: 150500000},
+		},
+		{
+			name:    "asset transfer",
+			encoded: "algorand://" + testAddress + "?amount=150&asset=45",
+			decoded: PaymentURI{Address: addr, Amount: 150, AssetID: 45},
+		},
+		{
+			name:    "editable note",
+			encoded: "algorand://" + testAddress + "?amount=10000&note=Coffee%20and%20cake",
+			decoded: PaymentURI{Address: addr, Amount: 10000, Note: "Coffee and cake"},
+		},
+		{
+			name:    "fixed note",
+			encoded: "algorand://" + testAddress + "?amount=10000&xnote=Invoice%20%23120%20%26%20tip%3D5%2B1",
+			decoded: PaymentURI{Address: addr, Amount: 10000, XNote: "Invoice #120 & tip=5+1"},
+		},
+		{
+			name:    "address only",
+			encoded: "algorand://" + testAddress,
+			decoded: PaymentURI{Address: addr},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded, err := Parse(tc.encoded)
+			require.NoError(t, err)
+			require.Equal(t, tc.decoded, decoded)
+
+			encoded, err := tc.decoded.Encode()
+			require.NoError(t, err)
+			require.Equal(t, tc.encoded, encoded)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	testcases := []struct {
+		name    string
+		encoded string
+		err     string
+	}{
+		{name: "scheme", encoded: "bitcoin://" + testAddress, err: "unexpected scheme"},
+		{name: "address", encoded: "algorand://NOTANADDRESS", err: "invalid address"},
+		{name: "amount", encoded: "algorand://" + testAddress + "?amount=1.5", err: "invalid amount"},
+		{name: "asset", encoded: "algorand://" + testAddress + "?asset=-1", err: "invalid asset"},
+		{name: "notes", encoded: "algorand://" + testAddress + "?note=a&xnote=b", err: errNoteAndXNote.Error()},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.encoded)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestEncodeNoteAndXNote(t *testing.T) {
+	_, err := PaymentURI{Note: "a", XNote: "b"}.Encode()
+	require.ErrorIs(t, err, errNoteAndXNote)
+}
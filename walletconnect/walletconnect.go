@@ -0,0 +1,278 @@
+// Package walletconnect builds and parses the algo_signTxn JSON-RPC payloads
+// that dapps exchange with wallets over WalletConnect, as described by ARC-25,
+// and turns the signatures wallets return into a group ready to submit.
+//
+// A request carries the ARC-1 wallet transactions to sign:
+//
+//	{"id": 1, "jsonrpc": "2.0", "method": "algo_signTxn", "params": [[{"txn": "..."}]]}
+//
+// and the response has, for each of them, the base64 signed transaction, or
+// null for the transactions the wallet was not asked to sign:
+//
+//	{"id": 1, "jsonrpc": "2.0", "result": ["...", null]}
+package walletconnect
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+// SignTxnMethod is the JSON-RPC method wallets sign transactions with.
+const SignTxnMethod = "algo_signTxn"
+
+// jsonRPCVersion is the version of JSON-RPC used by WalletConnect.
+const jsonRPCVersion = "2.0"
+
+// SignTxnOpts are the options of an algo_signTxn request.
+type SignTxnOpts struct {
+	// Message is a description of the whole request shown to the user.
+	Message string `json:"message,omitempty"`
+}
+
+// Request is an algo_signTxn JSON-RPC request.
+type Request struct {
+	ID      uint64            `json:"id"`
+	JSONRPC string            `json:"jsonrpc"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+// RPCError is the error of a JSON-RPC response, returned when the wallet
+// rejected the request.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("wallet error %d: %s", e.Code, e.Message)
+}
+
+// Response is an algo_signTxn JSON-RPC response.
+type Response struct {
+	ID      uint64    `json:"id"`
+	JSONRPC string    `json:"jsonrpc"`
+	Result  []*string `json:"result,omitempty"`
+	Error   *RPCError `json:"error,omitempty"`
+}
+
+// NewSignTxnRequest returns the request asking a wallet to sign wtxns, which
+// must be a valid ARC-1 request. opts may be nil.
+func NewSignTxnRequest(id uint64, wtxns []transaction.WalletTransaction, opts *SignTxnOpts) (Request, error) {
+	if err := transaction.ValidateWalletTransactions(wtxns); err != nil {
+		return Request{}, err
+	}
+
+	params := make([]json.RawMessage, 0, 2)
+	txnsParam, err := json.Marshal(wtxns)
+	if err != nil {
+		return Request{}, err
+	}
+	params = append(params, txnsParam)
+	if opts != nil {
+		optsParam, err := json.Marshal(opts)
+		if err != nil {
+			return Request{}, err
+		}
+		params = append(params, optsParam)
+	}
+	return Request{ID: id, JSONRPC: jsonRPCVersion, Method: SignTxnMethod, Params: params}, nil
+}
+
+// ParseSignTxnRequest parses and validates an algo_signTxn request, as a wallet
+// receives it. The returned options are nil if the request has none.
+func ParseSignTxnRequest(data []byte) (Request, []transaction.WalletTransaction, *SignTxnOpts, error) {
+	var req Request
+	if err := json.Unmarshal(data, &req); err != nil {
+		return Request{}, nil, nil, err
+	}
+	if req.Method != SignTxnMethod {
+		return Request{}, nil, nil, fmt.Errorf("unexpected method %q, expected %s", req.Method, SignTxnMethod)
+	}
+	if len(req.Params) == 0 || len(req.Params) > 2 {
+		return Request{}, nil, nil, fmt.Errorf("%s takes 1 or 2 params, got %d", SignTxnMethod, len(req.Params))
+	}
+
+	var wtxns []transaction.WalletTransaction
+	if err := json.Unmarshal(req.Params[0], &wtxns); err != nil {
+		return Request{}, nil, nil, fmt.Errorf("invalid transactions: %w", err)
+	}
+	if err := transaction.ValidateWalletTransactions(wtxns); err != nil {
+		return Request{}, nil, nil, err
+	}
+
+	var opts *SignTxnOpts
+	if len(req.Params) == 2 {
+		opts = &SignTxnOpts{}
+		if err := json.Unmarshal(req.Params[1], opts); err != nil {
+			return Request{}, nil, nil, fmt.Errorf("invalid options: %w", err)
+		}
+	}
+	return req, wtxns, opts, nil
+}
+
+// NewSignTxnResponse returns the response of a wallet to the request with the
+// given id, stxns having for each requested transaction its encoded signed
+// transaction, or nil if the wallet was not asked to sign it.
+func NewSignTxnResponse(id uint64, stxns [][]byte) Response {
+	result := make([]*string, len(stxns))
+	for i, stx := range stxns {
+		if stx != nil {
+			encoded := base64.StdEncoding.EncodeToString(stx)
+			result[i] = &encoded
+		}
+	}
+	return Response{ID: id, JSONRPC: jsonRPCVersion, Result: result}
+}
+
+// ParseSignTxnResponse parses the response of a wallet to the request for
+// wtxns, returning an *RPCError if the wallet rejected it. The result must
+// have a signed transaction for each transaction the wallet was asked to sign,
+// and nothing for the others. Each signed transaction must be for the requested
+// transaction and authorized by the requested address.
+func ParseSignTxnResponse(data []byte, wtxns []transaction.WalletTransaction) ([]*types.SignedTxn, error) {
+	var resp Response
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	if len(resp.Result) != len(wtxns) {
+		return nil, fmt.Errorf("wallet returned %d results for %d transactions", len(resp.Result), len(wtxns))
+	}
+
+	stxns := make([]*types.SignedTxn, len(wtxns))
+	for i, result := range resp.Result {
+		if result == nil {
+			if wtxns[i].MustSign() {
+				return nil, fmt.Errorf("transaction %d: wallet did not sign it", i)
+			}
+			continue
+		}
+		if !wtxns[i].MustSign() {
+			return nil, fmt.Errorf("transaction %d: wallet signed a transaction it was not asked to", i)
+		}
+		stx, err := types.DecodeSignedTxnFromBase64(*result)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		if err := checkSignedTxn(wtxns[i], stx); err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		stxns[i] = &stx
+	}
+	return stxns, nil
+}
+
+// checkSignedTxn checks that stx is a signature of the transaction of w by the
+// address w asks for.
+func checkSignedTxn(w transaction.WalletTransaction, stx types.SignedTxn) error {
+	tx, err := w.Transaction()
+	if err != nil {
+		return err
+	}
+	if crypto.GetTxID(stx.Txn) != crypto.GetTxID(tx) {
+		return fmt.Errorf("signed transaction does not match the requested one")
+	}
+
+	authAddr := tx.Sender
+	if w.AuthAddr != "" {
+		if authAddr, err = types.DecodeAddress(w.AuthAddr); err != nil {
+			return err
+		}
+	}
+	signer := stx.AuthAddr
+	if signer == (types.Address{}) {
+		signer = tx.Sender
+	}
+	if signer != authAddr {
+		return fmt.Errorf("signed transaction is authorized by %s rather than %s", signer, authAddr)
+	}
+	// a multisig may only have part of its signatures, MergeSignedGroup checks
+	// it once complete
+	if stx.Msig.Blank() && !crypto.VerifySignedTxn(stx) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// MergeSignedGroup returns the group of encoded signed transactions to submit
+// for the request for wtxns, given the results of one or more wallets, each
+// parsed by ParseSignTxnResponse. A transaction is taken from the stxn of the
+// request if it has one, or else from the results, merging the partial
+// signatures of a multisig transaction signed by several wallets. Every
+// transaction must end up validly signed, and every group match its group ID.
+// As the request may hold several groups and ungrouped transactions, each of
+// them is to be submitted on its own.
+func MergeSignedGroup(wtxns []transaction.WalletTransaction, results ...[]*types.SignedTxn) ([][]byte, error) {
+	for i, result := range results {
+		if len(result) != len(wtxns) {
+			return nil, fmt.Errorf("result %d has %d transactions, expected %d", i, len(result), len(wtxns))
+		}
+	}
+
+	group := make([][]byte, len(wtxns))
+	for i, w := range wtxns {
+		if w.Stxn != "" {
+			stx, err := types.DecodeSignedTxnFromBase64(w.Stxn)
+			if err != nil {
+				return nil, fmt.Errorf("transaction %d: %w", i, err)
+			}
+			group[i] = msgpack.Encode(stx)
+			continue
+		}
+
+		var parts [][]byte
+		for _, result := range results {
+			if result[i] != nil {
+				parts = append(parts, msgpack.Encode(*result[i]))
+			}
+		}
+		switch {
+		case len(parts) == 0:
+			return nil, fmt.Errorf("transaction %d: not signed", i)
+		case len(parts) == 1:
+			group[i] = parts[0]
+		default:
+			var err error
+			if _, group[i], err = crypto.MergeMultisigTransactions(parts...); err != nil {
+				return nil, fmt.Errorf("transaction %d: %w", i, err)
+			}
+		}
+	}
+
+	txns := make([]types.Transaction, len(group))
+	for i, stxBytes := range group {
+		var stx types.SignedTxn
+		if err := msgpack.Decode(stxBytes, &stx); err != nil {
+			return nil, err
+		}
+		if !crypto.VerifySignedTxn(stx) {
+			return nil, fmt.Errorf("transaction %d: invalid or incomplete signature", i)
+		}
+		txns[i] = stx.Txn
+	}
+	// as in transaction.ValidateWalletTransactions, the request may hold
+	// several groups and ungrouped transactions
+	for start := 0; start < len(txns); {
+		gid := txns[start].Group
+		end := start + 1
+		for end < len(txns) && gid != (types.Digest{}) && txns[end].Group == gid {
+			end++
+		}
+		if gid != (types.Digest{}) {
+			if err := crypto.VerifyGroupID(txns[start:end]); err != nil {
+				return nil, fmt.Errorf("transactions %d to %d: %w", start, end-1, err)
+			}
+		}
+		start = end
+	}
+	return group, nil
+}
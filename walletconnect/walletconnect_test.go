@@ -0,0 +1,150 @@
+package walletconnect
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/v2/crypto"
+	"github.com/algorand/go-algorand-sdk/v2/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/v2/transaction"
+	"github.com/algorand/go-algorand-sdk/v2/types"
+)
+
+func TestSignTxnRoundTrip(t *testing.T) {
+	alice, bob := crypto.GenerateAccount(), crypto.GenerateAccount()
+	ma, err := crypto.MultisigAccountWithParams(1, 2, []types.Address{alice.Address, bob.Address})
+	require.NoError(t, err)
+	msigAddr, err := ma.Address()
+	require.NoError(t, err)
+
+	params := types.SuggestedParams{Fee: 1000, FirstRoundValid: 1, LastRoundValid: 100, GenesisHash: make([]byte, 32), FlatFee: true}
+	pay, err := transaction.MakePaymentTxn(alice.Address.String(), bob.Address.String(), 1, nil, "", params)
+	require.NoError(t, err)
+	msigPay, err := transaction.MakePaymentTxn(msigAddr.String(), alice.Address.String(), 2, nil, "", params)
+	require.NoError(t, err)
+	group, err := transaction.AssignGroupID([]types.Transaction{pay, msigPay}, "")
+	require.NoError(t, err)
+
+	msigWtxn := transaction.NewWalletTransaction(group[1])
+	msigWtxn.Msig = &transaction.MultisigMetadata{Version: 1, Threshold: 2, Addrs: []string{alice.Address.String(), bob.Address.String()}}
+	wtxns := []transaction.WalletTransaction{transaction.NewWalletTransaction(group[0]), msigWtxn}
+
+	// the dapp sends the request, the wallet parses it
+	req, err := NewSignTxnRequest(7, wtxns, &SignTxnOpts{Message: "pay twice"})
+	require.NoError(t, err)
+	data, err := json.Marshal(req)
+	require.NoError(t, err)
+	_, parsed, opts, err := ParseSignTxnRequest(data)
+	require.NoError(t, err)
+	require.Equal(t, wtxns, parsed)
+	require.Equal(t, "pay twice", opts.Message)
+
+	// two wallets each sign part of the group
+	_, aliceStx, err := crypto.SignTransaction(alice.PrivateKey, group[0])
+	require.NoError(t, err)
+	_, aliceMsig, err := crypto.SignMultisigTransaction(alice.PrivateKey, ma, group[1])
+	require.NoError(t, err)
+	_, bobMsig, err := crypto.SignMultisigTransaction(bob.PrivateKey, ma, group[1])
+	require.NoError(t, err)
+
+	aliceResp, err := json.Marshal(NewSignTxnResponse(7, [][]byte{aliceStx, aliceMsig}))
+	require.NoError(t, err)
+	aliceResult, err := ParseSignTxnResponse(aliceResp, wtxns)
+	require.NoError(t, err)
+
+	bobWtxns := []transaction.WalletTransaction{wtxns[0], wtxns[1]}
+	bobWtxns[0].Signers = []string{}
+	bobResp, err := json.Marshal(NewSignTxnResponse(7, [][]byte{nil, bobMsig}))
+	require.NoError(t, err)
+	bobResult, err := ParseSignTxnResponse(bobResp, bobWtxns)
+	require.NoError(t, err)
+
+	signed, err := MergeSignedGroup(wtxns, aliceResult, bobResult)
+	require.NoError(t, err)
+	require.Equal(t, aliceStx, signed[0])
+	var merged types.SignedTxn
+	require.NoError(t, msgpack.Decode(signed[1], &merged))
+	for _, subsig := range merged.Msig.Subsigs {
+		require.NotEqual(t, types.Signature{}, subsig.Sig)
+	}
+
+	// a missing signature leaves the group incomplete
+	_, err = MergeSignedGroup(wtxns, bobResult)
+	require.ErrorContains(t, err, "not signed")
+	_, err = MergeSignedGroup(wtxns, aliceResult)
+	require.ErrorContains(t, err, "incomplete signature")
+}
+
+func TestMergeSignedGroups(t *testing.T) {
+	alice, bob := crypto.GenerateAccount(), crypto.GenerateAccount()
+	params := types.SuggestedParams{Fee: 1000, FirstRoundValid: 1, LastRoundValid: 100, GenesisHash: make([]byte, 32), FlatFee: true}
+	var txns []types.Transaction
+	for i := uint64(0); i < 4; i++ {
+		pay, err := transaction.MakePaymentTxn(alice.Address.String(), bob.Address.String(), i, nil, "", params)
+		require.NoError(t, err)
+		txns = append(txns, pay)
+	}
+	// two ungrouped transactions, then a group of two
+	group, err := transaction.AssignGroupID(txns[2:], "")
+	require.NoError(t, err)
+	txns = append(txns[:2], group...)
+
+	wtxns := make([]transaction.WalletTransaction, len(txns))
+	stxns := make([][]byte, len(txns))
+	for i, txn := range txns {
+		wtxns[i] = transaction.NewWalletTransaction(txn)
+		_, stxns[i], err = crypto.SignTransaction(alice.PrivateKey, txn)
+		require.NoError(t, err)
+	}
+	require.NoError(t, transaction.ValidateWalletTransactions(wtxns))
+	resp, err := json.Marshal(NewSignTxnResponse(1, stxns))
+	require.NoError(t, err)
+	result, err := ParseSignTxnResponse(resp, wtxns)
+	require.NoError(t, err)
+
+	signed, err := MergeSignedGroup(wtxns, result)
+	require.NoError(t, err)
+	require.Equal(t, stxns, signed)
+
+	// a group missing one of its transactions is rejected
+	_, err = MergeSignedGroup(wtxns[:3], result[:3])
+	require.ErrorContains(t, err, "transactions 2 to 2")
+}
+
+func TestParseSignTxnResponseErrors(t *testing.T) {
+	alice, bob := crypto.GenerateAccount(), crypto.GenerateAccount()
+	params := types.SuggestedParams{Fee: 1000, FirstRoundValid: 1, LastRoundValid: 100, GenesisHash: make([]byte, 32), FlatFee: true}
+	pay, err := transaction.MakePaymentTxn(alice.Address.String(), bob.Address.String(), 1, nil, "", params)
+	require.NoError(t, err)
+	other, err := transaction.MakePaymentTxn(alice.Address.String(), bob.Address.String(), 2, nil, "", params)
+	require.NoError(t, err)
+	wtxns := []transaction.WalletTransaction{transaction.NewWalletTransaction(pay)}
+
+	_, err = ParseSignTxnResponse([]byte(`{"id":1,"jsonrpc":"2.0","error":{"code":4001,"message":"User rejected"}}`), wtxns)
+	var rpcErr *RPCError
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, 4001, rpcErr.Code)
+
+	_, err = ParseSignTxnResponse([]byte(`{"id":1,"jsonrpc":"2.0","result":[null]}`), wtxns)
+	require.ErrorContains(t, err, "did not sign")
+
+	_, otherStx, err := crypto.SignTransaction(alice.PrivateKey, other)
+	require.NoError(t, err)
+	resp, err := json.Marshal(NewSignTxnResponse(1, [][]byte{otherStx}))
+	require.NoError(t, err)
+	_, err = ParseSignTxnResponse(resp, wtxns)
+	require.ErrorContains(t, err, "does not match")
+
+	// signed by bob, while the request expects alice
+	_, bobStx, err := crypto.SignTransaction(bob.PrivateKey, pay)
+	require.NoError(t, err)
+	resp, err = json.Marshal(NewSignTxnResponse(1, [][]byte{bobStx}))
+	require.NoError(t, err)
+	_, err = ParseSignTxnResponse(resp, wtxns)
+	require.ErrorContains(t, err, "authorized by")
+
+	_, _, _, err = ParseSignTxnRequest([]byte(`{"id":1,"jsonrpc":"2.0","method":"algo_signData","params":[[]]}`))
+	require.ErrorContains(t, err, "unexpected method")
+}